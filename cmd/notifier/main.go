@@ -77,6 +77,24 @@ func processMessage(msg *message.Message, emailNotifier *notifications.EmailNoti
 	switch eventType {
 	case notifications.UserLoggedIn:
 		return handleUserLoggedIn(msg, emailNotifier)
+	case notifications.UserRegistered, notifications.EmailVerificationRequested:
+		return handleEmailVerification(msg, emailNotifier)
+	case notifications.PasswordResetRequested:
+		return handlePasswordReset(msg, emailNotifier)
+	case notifications.EmailChangeRequested:
+		return handleEmailChangeRequested(msg, emailNotifier)
+	case notifications.EmailChanged:
+		return handleEmailChanged(msg, emailNotifier)
+	case notifications.SecurityTokenReuse:
+		return handleTokenReuse(msg, emailNotifier)
+	case notifications.UserLockedOut:
+		return handleUserLockedOut(msg, emailNotifier)
+	case notifications.QuestionAnswered:
+		return handleQuestionAnswered(msg, emailNotifier)
+	case notifications.CartAbandoned:
+		return handleCartAbandoned(msg, emailNotifier)
+	case notifications.SubscriptionOrderFailed:
+		return handleSubscriptionOrderFailed(msg, emailNotifier)
 	default:
 		log.Printf("Unknown event type: %s", eventType)
 		return nil
@@ -98,3 +116,147 @@ func handleUserLoggedIn(msg *message.Message, emailNotifier *notifications.Email
 
 	return emailNotifier.SendLoginNotification(user.Email, userName)
 }
+
+func handleQuestionAnswered(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.QuestionAnsweredPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	userName := payload.Name
+	if userName == "" {
+		userName = "User"
+	}
+
+	log.Printf("Sending answer notification to %s", payload.Email)
+
+	return emailNotifier.SendQuestionAnsweredNotification(payload.Email, userName, payload.ProductName, payload.Question, payload.Answer)
+}
+
+func handleCartAbandoned(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.CartAbandonedPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	userName := payload.Name
+	if userName == "" {
+		userName = "User"
+	}
+
+	log.Printf("Sending cart reminder to %s", payload.Email)
+
+	return emailNotifier.SendCartReminder(payload.Email, userName, payload.Items, payload.Total, payload.RestoreURL)
+}
+
+func handleSubscriptionOrderFailed(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.SubscriptionOrderFailedPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	userName := payload.Name
+	if userName == "" {
+		userName = "User"
+	}
+
+	log.Printf("Sending subscription failure notification to %s", payload.Email)
+
+	return emailNotifier.SendSubscriptionOrderFailed(payload.Email, userName, payload.SubscriptionID, payload.Reason, payload.WillRetry, payload.NextAttemptAt)
+}
+
+func handleEmailVerification(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.EmailVerificationPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	userName := payload.Name
+	if userName == "" {
+		userName = "User"
+	}
+
+	log.Printf("Sending email verification to %s", payload.Email)
+
+	return emailNotifier.SendEmailVerification(payload.Email, userName, payload.VerificationURL, payload.ExpiresAt)
+}
+
+func handlePasswordReset(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.PasswordResetPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	userName := payload.Name
+	if userName == "" {
+		userName = "User"
+	}
+
+	log.Printf("Sending password reset to %s", payload.Email)
+
+	return emailNotifier.SendPasswordReset(payload.Email, userName, payload.ResetURL, payload.ExpiresAt)
+}
+
+func handleEmailChangeRequested(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.EmailVerificationPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	userName := payload.Name
+	if userName == "" {
+		userName = "User"
+	}
+
+	log.Printf("Sending email change confirmation to %s", payload.Email)
+
+	return emailNotifier.SendEmailChangeConfirmation(payload.Email, userName, payload.VerificationURL, payload.ExpiresAt)
+}
+
+func handleEmailChanged(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.EmailChangedPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	userName := payload.Name
+	if userName == "" {
+		userName = "User"
+	}
+
+	log.Printf("Sending email change notice to %s", payload.Email)
+
+	return emailNotifier.SendEmailChanged(payload.Email, userName, payload.NewEmail)
+}
+
+func handleTokenReuse(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.TokenReusePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	userName := payload.Name
+	if userName == "" {
+		userName = "User"
+	}
+
+	log.Printf("Sending token reuse alert to %s", payload.Email)
+
+	return emailNotifier.SendTokenReuseAlert(payload.Email, userName, payload.DetectedAt)
+}
+
+func handleUserLockedOut(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.UserLockedOutPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	userName := payload.Name
+	if userName == "" {
+		userName = "User"
+	}
+
+	log.Printf("Sending lockout notice to %s", payload.Email)
+
+	return emailNotifier.SendAccountLockedOut(payload.Email, userName, payload.IPAddress, payload.LockedUntil)
+}
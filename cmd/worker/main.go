@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/database"
+	"github.com/JihadRinaldi/go-shop/internal/events"
+	"github.com/JihadRinaldi/go-shop/internal/logger"
+	"github.com/JihadRinaldi/go-shop/internal/services"
+)
+
+func main() {
+	log := logger.New()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load configuration")
+	}
+
+	db, err := database.New(&cfg.Database)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to connect to database")
+	}
+
+	mainDB, err := db.DB()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to get database instance")
+	}
+	defer mainDB.Close()
+
+	eventPublisher, err := events.NewEventPublisher(context.Background(), cfg.AWS)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create event publisher")
+	}
+
+	cartService := services.NewCartService(db, cfg)
+	cartReminderService := services.NewCartReminderService(db, cfg, eventPublisher, cartService)
+	orderService := services.NewOrderService(db, cfg, cartService)
+	subscriptionService := services.NewSubscriptionService(db, cfg, eventPublisher, orderService)
+
+	sendReminders := func() {
+		sent, err := cartReminderService.SendAbandonedCartReminders(time.Now())
+		if err != nil {
+			log.Error().Err(err).Msg("failed to send abandoned cart reminders")
+			return
+		}
+		if sent > 0 {
+			log.Info().Int("sent", sent).Msg("abandoned cart reminders sent")
+		}
+	}
+
+	processSubscriptions := func() {
+		placed, err := subscriptionService.ProcessDueSubscriptions(time.Now())
+		if err != nil {
+			log.Error().Err(err).Msg("failed to process subscriptions")
+			return
+		}
+		if placed > 0 {
+			log.Info().Int("placed", placed).Msg("subscription orders placed")
+		}
+	}
+
+	releaseReservations := func() {
+		released, err := cartService.ReleaseExpiredReservations(time.Now())
+		if err != nil {
+			log.Error().Err(err).Msg("failed to release expired cart reservations")
+			return
+		}
+		if released > 0 {
+			log.Info().Int("released", released).Msg("expired cart reservations released")
+		}
+	}
+
+	reminderTicker := time.NewTicker(cfg.Cart.ReminderCheckInterval)
+	defer reminderTicker.Stop()
+	subscriptionTicker := time.NewTicker(cfg.Subscription.CheckInterval)
+	defer subscriptionTicker.Stop()
+	reservationTicker := time.NewTicker(cfg.Cart.ReservationCheckInterval)
+	defer reservationTicker.Stop()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	log.Info().
+		Dur("reminder_interval", cfg.Cart.ReminderCheckInterval).
+		Dur("subscription_interval", cfg.Subscription.CheckInterval).
+		Dur("reservation_interval", cfg.Cart.ReservationCheckInterval).
+		Msg("worker started")
+	sendReminders()
+	processSubscriptions()
+	releaseReservations()
+
+	for {
+		select {
+		case <-reminderTicker.C:
+			sendReminders()
+		case <-subscriptionTicker.C:
+			processSubscriptions()
+		case <-reservationTicker.C:
+			releaseReservations()
+		case <-quit:
+			log.Info().Msg("shutting down worker...")
+			return
+		}
+	}
+}
@@ -0,0 +1,24 @@
+CREATE TYPE attribute_type AS ENUM ('text', 'number', 'boolean', 'enum');
+
+CREATE TABLE category_attributes (
+    id SERIAL PRIMARY KEY,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    code VARCHAR(100) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    type attribute_type NOT NULL,
+    unit VARCHAR(50),
+    options JSONB NOT NULL DEFAULT '[]',
+    is_required BOOLEAN DEFAULT false,
+    sort_order INTEGER DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX uniq_active_category_attribute_code
+ON category_attributes (category_id, code)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_category_attributes_category_id ON category_attributes(category_id);
+CREATE INDEX idx_category_attributes_code ON category_attributes(code);
+CREATE INDEX idx_category_attributes_deleted_at ON category_attributes(deleted_at);
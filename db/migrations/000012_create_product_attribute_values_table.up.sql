@@ -0,0 +1,20 @@
+CREATE TABLE product_attribute_values (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    category_attribute_id INTEGER NOT NULL REFERENCES category_attributes(id) ON DELETE CASCADE,
+    text_value TEXT,
+    number_value DECIMAL(14,4),
+    boolean_value BOOLEAN,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX uniq_active_product_attribute
+ON product_attribute_values (product_id, category_attribute_id)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_product_attribute_values_product_id ON product_attribute_values(product_id);
+CREATE INDEX idx_product_attribute_values_attribute_id ON product_attribute_values(category_attribute_id);
+CREATE INDEX idx_product_attribute_values_number_value ON product_attribute_values(number_value);
+CREATE INDEX idx_product_attribute_values_deleted_at ON product_attribute_values(deleted_at);
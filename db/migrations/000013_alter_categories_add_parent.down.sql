@@ -0,0 +1,12 @@
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_category_id_fkey;
+ALTER TABLE products
+    ADD CONSTRAINT products_category_id_fkey
+    FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS idx_categories_sort_order;
+DROP INDEX IF EXISTS idx_categories_parent_id;
+
+ALTER TABLE categories
+    DROP CONSTRAINT IF EXISTS chk_categories_not_own_parent,
+    DROP COLUMN IF EXISTS sort_order,
+    DROP COLUMN IF EXISTS parent_id;
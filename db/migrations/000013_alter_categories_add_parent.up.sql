@@ -0,0 +1,13 @@
+ALTER TABLE categories
+    ADD COLUMN parent_id INTEGER REFERENCES categories(id) ON DELETE RESTRICT,
+    ADD COLUMN sort_order INTEGER DEFAULT 0,
+    ADD CONSTRAINT chk_categories_not_own_parent CHECK (parent_id IS NULL OR parent_id <> id);
+
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
+CREATE INDEX idx_categories_sort_order ON categories(parent_id, sort_order);
+
+-- Deleting a category must never wipe its products
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_category_id_fkey;
+ALTER TABLE products
+    ADD CONSTRAINT products_category_id_fkey
+    FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE RESTRICT;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS uniq_active_product_slug;
+DROP INDEX IF EXISTS uniq_active_category_slug;
+
+ALTER TABLE products DROP COLUMN IF EXISTS slug;
+ALTER TABLE categories DROP COLUMN IF EXISTS slug;
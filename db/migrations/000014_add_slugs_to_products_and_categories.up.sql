@@ -0,0 +1,15 @@
+ALTER TABLE categories ADD COLUMN slug VARCHAR(255);
+ALTER TABLE products ADD COLUMN slug VARCHAR(255);
+
+-- Existing rows get the name based slug suffixed with the id to stay unique
+UPDATE categories
+SET slug = COALESCE(NULLIF(TRIM(BOTH '-' FROM LOWER(REGEXP_REPLACE(name, '[^a-zA-Z0-9]+', '-', 'g'))), ''), 'category') || '-' || id;
+
+UPDATE products
+SET slug = COALESCE(NULLIF(TRIM(BOTH '-' FROM LOWER(REGEXP_REPLACE(name, '[^a-zA-Z0-9]+', '-', 'g'))), ''), 'product') || '-' || id;
+
+ALTER TABLE categories ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE products ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX uniq_active_category_slug ON categories (slug) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX uniq_active_product_slug ON products (slug) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS slug_redirects;
+DROP TYPE IF EXISTS slug_entity_type;
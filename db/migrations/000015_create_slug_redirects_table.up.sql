@@ -0,0 +1,12 @@
+CREATE TYPE slug_entity_type AS ENUM ('product', 'category');
+
+CREATE TABLE slug_redirects (
+    id SERIAL PRIMARY KEY,
+    entity_type slug_entity_type NOT NULL,
+    entity_id INTEGER NOT NULL,
+    old_slug VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uniq_slug_redirects_entity_slug ON slug_redirects (entity_type, old_slug);
+CREATE INDEX idx_slug_redirects_entity ON slug_redirects (entity_type, entity_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_products_rating;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS rating_average,
+    DROP COLUMN IF EXISTS rating_count,
+    DROP COLUMN IF EXISTS rating_sum;
@@ -0,0 +1,6 @@
+ALTER TABLE products
+    ADD COLUMN rating_sum INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN rating_average DECIMAL(3,2) NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_products_rating ON products(rating_average DESC, rating_count DESC);
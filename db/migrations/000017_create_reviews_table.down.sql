@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS reviews;
+DROP TYPE IF EXISTS review_status;
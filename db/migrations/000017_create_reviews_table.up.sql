@@ -0,0 +1,24 @@
+CREATE TYPE review_status AS ENUM ('pending', 'approved', 'rejected');
+
+CREATE TABLE reviews (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    order_item_id INTEGER NOT NULL REFERENCES order_items(id),
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    title VARCHAR(255),
+    body TEXT NOT NULL,
+    status review_status DEFAULT 'pending',
+    moderation_note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX uniq_active_review_user_product
+ON reviews (user_id, product_id)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_reviews_product_id ON reviews(product_id);
+CREATE INDEX idx_reviews_status ON reviews(status);
+CREATE INDEX idx_reviews_deleted_at ON reviews(deleted_at);
@@ -0,0 +1,10 @@
+CREATE TABLE review_images (
+    id SERIAL PRIMARY KEY,
+    review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    url VARCHAR(500) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_review_images_review_id ON review_images(review_id);
+CREATE INDEX idx_review_images_deleted_at ON review_images(deleted_at);
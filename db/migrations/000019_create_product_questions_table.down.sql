@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_questions;
+DROP TYPE IF EXISTS qa_status;
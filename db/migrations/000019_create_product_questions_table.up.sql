@@ -0,0 +1,17 @@
+CREATE TYPE qa_status AS ENUM ('pending', 'published', 'rejected');
+
+CREATE TABLE product_questions (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    status qa_status DEFAULT 'pending',
+    upvote_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_product_questions_product_id ON product_questions(product_id);
+CREATE INDEX idx_product_questions_status ON product_questions(status);
+CREATE INDEX idx_product_questions_deleted_at ON product_questions(deleted_at);
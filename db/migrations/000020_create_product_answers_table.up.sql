@@ -0,0 +1,17 @@
+CREATE TABLE product_answers (
+    id SERIAL PRIMARY KEY,
+    question_id INTEGER NOT NULL REFERENCES product_questions(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    status qa_status DEFAULT 'pending',
+    is_staff BOOLEAN DEFAULT FALSE,
+    is_verified_buyer BOOLEAN DEFAULT FALSE,
+    upvote_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_product_answers_question_id ON product_answers(question_id);
+CREATE INDEX idx_product_answers_status ON product_answers(status);
+CREATE INDEX idx_product_answers_deleted_at ON product_answers(deleted_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS qa_votes;
+DROP TYPE IF EXISTS qa_vote_target;
@@ -0,0 +1,11 @@
+CREATE TYPE qa_vote_target AS ENUM ('question', 'answer');
+
+CREATE TABLE qa_votes (
+    id SERIAL PRIMARY KEY,
+    target_type qa_vote_target NOT NULL,
+    target_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uniq_qa_vote_target_user ON qa_votes (target_type, target_id, user_id);
@@ -0,0 +1,20 @@
+CREATE TABLE wishlists (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    share_token VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX uniq_active_wishlist_user_name
+ON wishlists (user_id, name)
+WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX uniq_wishlist_share_token
+ON wishlists (share_token)
+WHERE share_token IS NOT NULL;
+
+CREATE INDEX idx_wishlists_user_id ON wishlists(user_id);
+CREATE INDEX idx_wishlists_deleted_at ON wishlists(deleted_at);
@@ -0,0 +1,14 @@
+CREATE TABLE wishlist_items (
+    id SERIAL PRIMARY KEY,
+    wishlist_id INTEGER NOT NULL REFERENCES wishlists(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX uniq_active_wishlist_item_product
+ON wishlist_items (wishlist_id, product_id)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_wishlist_items_wishlist_id ON wishlist_items(wishlist_id);
+CREATE INDEX idx_wishlist_items_deleted_at ON wishlist_items(deleted_at);
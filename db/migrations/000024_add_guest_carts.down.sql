@@ -0,0 +1,6 @@
+DELETE FROM carts WHERE user_id IS NULL;
+
+DROP INDEX IF EXISTS uniq_carts_guest_id;
+ALTER TABLE carts DROP CONSTRAINT IF EXISTS chk_carts_owner;
+ALTER TABLE carts DROP COLUMN IF EXISTS guest_id;
+ALTER TABLE carts ALTER COLUMN user_id SET NOT NULL;
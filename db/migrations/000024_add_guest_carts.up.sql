@@ -0,0 +1,5 @@
+ALTER TABLE carts ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE carts ADD COLUMN guest_id VARCHAR(36);
+ALTER TABLE carts ADD CONSTRAINT chk_carts_owner CHECK (user_id IS NOT NULL OR guest_id IS NOT NULL);
+
+CREATE UNIQUE INDEX uniq_carts_guest_id ON carts (guest_id) WHERE guest_id IS NOT NULL;
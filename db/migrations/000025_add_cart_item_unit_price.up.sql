@@ -0,0 +1,6 @@
+ALTER TABLE cart_items ADD COLUMN unit_price DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+UPDATE cart_items
+SET unit_price = products.price
+FROM products
+WHERE products.id = cart_items.product_id;
@@ -0,0 +1,14 @@
+CREATE TABLE cart_reminders (
+    id SERIAL PRIMARY KEY,
+    cart_id INTEGER NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL,
+    items JSONB NOT NULL DEFAULT '[]',
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    restored_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uniq_cart_reminder_token ON cart_reminders(token);
+CREATE INDEX idx_cart_reminders_user_sent_at ON cart_reminders(user_id, sent_at);
+CREATE INDEX idx_cart_reminders_cart_sent_at ON cart_reminders(cart_id, sent_at);
@@ -0,0 +1,6 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS gift_message;
+ALTER TABLE order_items DROP COLUMN IF EXISTS note;
+
+ALTER TABLE cart_items DROP COLUMN IF EXISTS gift_message;
+ALTER TABLE cart_items DROP COLUMN IF EXISTS note;
+ALTER TABLE cart_items DROP COLUMN IF EXISTS saved_for_later;
@@ -0,0 +1,6 @@
+ALTER TABLE cart_items ADD COLUMN saved_for_later BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE cart_items ADD COLUMN note VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE cart_items ADD COLUMN gift_message VARCHAR(500) NOT NULL DEFAULT '';
+
+ALTER TABLE order_items ADD COLUMN note VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE order_items ADD COLUMN gift_message VARCHAR(500) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_amount;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_amount;
+ALTER TABLE orders DROP COLUMN IF EXISTS subtotal;
@@ -0,0 +1,22 @@
+CREATE TYPE subscription_status AS ENUM ('active', 'paused', 'cancelled');
+CREATE TYPE subscription_frequency AS ENUM ('weekly', 'biweekly', 'monthly');
+
+CREATE TABLE subscriptions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status subscription_status DEFAULT 'active',
+    frequency subscription_frequency NOT NULL,
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    retry_at TIMESTAMP WITH TIME ZONE,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    last_order_id INTEGER REFERENCES orders(id) ON DELETE SET NULL,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_subscriptions_user_id ON subscriptions(user_id);
+CREATE INDEX idx_subscriptions_due ON subscriptions(status, next_run_at);
+CREATE INDEX idx_subscriptions_deleted_at ON subscriptions(deleted_at);
@@ -0,0 +1,14 @@
+CREATE TABLE subscription_items (
+    id SERIAL PRIMARY KEY,
+    subscription_id INTEGER NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_subscription_items_subscription_id ON subscription_items(subscription_id);
+CREATE INDEX idx_subscription_items_deleted_at ON subscription_items(deleted_at);
+CREATE UNIQUE INDEX uniq_active_subscription_product
+ON subscription_items (subscription_id, product_id)
+WHERE deleted_at IS NULL;
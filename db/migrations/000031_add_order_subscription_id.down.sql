@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_orders_subscription_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS subscription_id;
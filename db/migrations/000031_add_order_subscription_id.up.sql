@@ -0,0 +1,3 @@
+ALTER TABLE orders ADD COLUMN subscription_id INTEGER REFERENCES subscriptions(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_orders_subscription_id ON orders(subscription_id);
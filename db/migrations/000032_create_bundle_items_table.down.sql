@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bundle_items;
+
+ALTER TABLE products DROP COLUMN IF EXISTS is_bundle;
@@ -0,0 +1,18 @@
+ALTER TABLE products ADD COLUMN is_bundle BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE bundle_items (
+    id SERIAL PRIMARY KEY,
+    bundle_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    component_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CHECK (bundle_id <> component_id)
+);
+
+CREATE INDEX idx_bundle_items_bundle_id ON bundle_items(bundle_id);
+CREATE INDEX idx_bundle_items_component_id ON bundle_items(component_id);
+CREATE INDEX idx_bundle_items_deleted_at ON bundle_items(deleted_at);
+CREATE UNIQUE INDEX uniq_active_bundle_component
+ON bundle_items (bundle_id, component_id)
+WHERE deleted_at IS NULL;
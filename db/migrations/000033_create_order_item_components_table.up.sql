@@ -0,0 +1,11 @@
+CREATE TABLE order_item_components (
+    id SERIAL PRIMARY KEY,
+    order_item_id INTEGER NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_order_item_components_order_item_id ON order_item_components(order_item_id);
+CREATE INDEX idx_order_item_components_deleted_at ON order_item_components(deleted_at);
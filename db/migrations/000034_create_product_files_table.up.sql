@@ -0,0 +1,15 @@
+ALTER TABLE products ADD COLUMN is_digital BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE product_files (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    path VARCHAR(500) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255),
+    size BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_product_files_product_id ON product_files(product_id);
+CREATE INDEX idx_product_files_deleted_at ON product_files(deleted_at);
@@ -0,0 +1 @@
+ALTER TABLE order_items ADD COLUMN download_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,19 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- Accounts created before verification existed are trusted as they are
+UPDATE users SET email_verified_at = created_at;
+
+CREATE TYPE user_token_purpose AS ENUM ('email_verification');
+
+CREATE TABLE user_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose user_token_purpose NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_tokens_user_id_purpose ON user_tokens(user_id, purpose, created_at);
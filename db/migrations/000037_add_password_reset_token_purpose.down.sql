@@ -0,0 +1,7 @@
+DELETE FROM user_tokens WHERE purpose = 'password_reset';
+
+-- Enum values cannot be dropped, so the type is rebuilt without it
+ALTER TYPE user_token_purpose RENAME TO user_token_purpose_old;
+CREATE TYPE user_token_purpose AS ENUM ('email_verification');
+ALTER TABLE user_tokens ALTER COLUMN purpose TYPE user_token_purpose USING purpose::text::user_token_purpose;
+DROP TYPE user_token_purpose_old;
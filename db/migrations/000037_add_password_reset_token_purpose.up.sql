@@ -0,0 +1 @@
+ALTER TYPE user_token_purpose ADD VALUE IF NOT EXISTS 'password_reset';
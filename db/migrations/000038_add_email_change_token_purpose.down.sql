@@ -0,0 +1,6 @@
+DELETE FROM user_tokens WHERE purpose = 'email_change';
+
+ALTER TYPE user_token_purpose RENAME TO user_token_purpose_old;
+CREATE TYPE user_token_purpose AS ENUM ('email_verification', 'password_reset');
+ALTER TABLE user_tokens ALTER COLUMN purpose TYPE user_token_purpose USING purpose::text::user_token_purpose;
+DROP TYPE user_token_purpose_old;
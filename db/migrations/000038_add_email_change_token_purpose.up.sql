@@ -0,0 +1 @@
+ALTER TYPE user_token_purpose ADD VALUE IF NOT EXISTS 'email_change';
@@ -0,0 +1,10 @@
+-- Raw tokens cannot be recovered from their hashes, so every session ends
+DELETE FROM refresh_tokens;
+
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS rotated_at;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS family_id;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS token_hash;
+ALTER TABLE refresh_tokens ADD COLUMN token VARCHAR(500) UNIQUE NOT NULL;
+
+CREATE INDEX idx_refresh_tokens_token ON refresh_tokens(token);
@@ -0,0 +1,14 @@
+-- Refresh tokens are only kept as SHA-256 hashes from now on
+ALTER TABLE refresh_tokens ADD COLUMN token_hash VARCHAR(64);
+UPDATE refresh_tokens SET token_hash = encode(sha256(convert_to(token, 'UTF8')), 'hex');
+DROP INDEX IF EXISTS idx_refresh_tokens_token;
+ALTER TABLE refresh_tokens DROP COLUMN token;
+ALTER TABLE refresh_tokens ALTER COLUMN token_hash SET NOT NULL;
+ALTER TABLE refresh_tokens ADD CONSTRAINT refresh_tokens_token_hash_key UNIQUE (token_hash);
+
+-- Every existing token starts a family of its own
+ALTER TABLE refresh_tokens ADD COLUMN family_id UUID NOT NULL DEFAULT gen_random_uuid();
+ALTER TABLE refresh_tokens ALTER COLUMN family_id DROP DEFAULT;
+ALTER TABLE refresh_tokens ADD COLUMN rotated_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
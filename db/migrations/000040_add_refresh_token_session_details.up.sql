@@ -0,0 +1,8 @@
+ALTER TABLE refresh_tokens ADD COLUMN user_agent VARCHAR(512) NOT NULL DEFAULT '';
+ALTER TABLE refresh_tokens ADD COLUMN ip_address VARCHAR(45) NOT NULL DEFAULT '';
+ALTER TABLE refresh_tokens ADD COLUMN last_used_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE refresh_tokens SET last_used_at = created_at;
+
+ALTER TABLE refresh_tokens ALTER COLUMN last_used_at SET NOT NULL;
+ALTER TABLE refresh_tokens ALTER COLUMN last_used_at SET DEFAULT CURRENT_TIMESTAMP;
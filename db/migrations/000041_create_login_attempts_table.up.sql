@@ -0,0 +1,8 @@
+CREATE TABLE login_attempts (
+    throttle_key VARCHAR(320) PRIMARY KEY,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    blocked_until TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_login_attempts_last_failed_at ON login_attempts(last_failed_at);
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS recovery_codes;
+
+ALTER TABLE users DROP COLUMN IF EXISTS totp_last_step;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_enabled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_secret;
+
+DELETE FROM user_tokens WHERE purpose = 'two_factor_login';
+
+ALTER TYPE user_token_purpose RENAME TO user_token_purpose_old;
+CREATE TYPE user_token_purpose AS ENUM ('email_verification', 'password_reset', 'email_change');
+ALTER TABLE user_tokens ALTER COLUMN purpose TYPE user_token_purpose USING purpose::text::user_token_purpose;
+DROP TYPE user_token_purpose_old;
@@ -0,0 +1,12 @@
+CREATE TYPE user_role AS ENUM ('customer', 'admin');
+ALTER TABLE users ADD COLUMN role user_role DEFAULT 'customer';
+
+-- Only the admin role maps back, holders of other roles become customers
+UPDATE users SET role = 'admin'
+WHERE id IN (SELECT ur.user_id FROM user_roles ur JOIN roles r ON r.id = ur.role_id WHERE r.name = 'admin');
+
+ALTER TABLE users DROP COLUMN IF EXISTS roles_changed_at;
+
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS roles;
@@ -0,0 +1,48 @@
+CREATE TABLE roles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) UNIQUE NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE role_permissions (
+    role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission VARCHAR(100) NOT NULL,
+    PRIMARY KEY (role_id, permission)
+);
+
+CREATE TABLE user_roles (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX idx_user_roles_role_id ON user_roles(role_id);
+
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Full access to the shop'),
+    ('catalog_manager', 'Maintains categories and products'),
+    ('fulfilment', 'Processes and ships orders'),
+    ('support', 'Helps customers and moderates their content');
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+JOIN (VALUES
+    ('admin', '*'),
+    ('catalog_manager', 'catalog:manage'),
+    ('fulfilment', 'orders:update'),
+    ('support', 'reviews:moderate'),
+    ('support', 'questions:moderate'),
+    ('support', 'questions:answer'),
+    ('support', 'users:unlock')
+) AS p(role, permission) ON p.role = r.name;
+
+-- Admins keep their access through the admin role
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.id, r.id FROM users u JOIN roles r ON r.name = 'admin' WHERE u.role = 'admin';
+
+ALTER TABLE users ADD COLUMN roles_changed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users DROP COLUMN role;
+DROP TYPE user_role;
@@ -0,0 +1,5 @@
+-- Monthly deliveries keep the day of the month they started on, falling back
+-- to the last day in shorter months
+ALTER TABLE subscriptions ADD COLUMN anchor_day SMALLINT NOT NULL DEFAULT 0;
+
+UPDATE subscriptions SET anchor_day = EXTRACT(DAY FROM next_run_at);
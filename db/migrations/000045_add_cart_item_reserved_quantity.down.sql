@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_cart_items_reserved;
+ALTER TABLE cart_items DROP COLUMN IF EXISTS reserved_quantity;
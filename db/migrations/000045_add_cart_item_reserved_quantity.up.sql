@@ -0,0 +1,5 @@
+-- Bundle lines in the active cart hold their components' stock. This is the
+-- number of bundles a line currently holds.
+ALTER TABLE cart_items ADD COLUMN reserved_quantity INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_cart_items_reserved ON cart_items(updated_at) WHERE reserved_quantity > 0;
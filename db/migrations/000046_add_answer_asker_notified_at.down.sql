@@ -0,0 +1 @@
+ALTER TABLE product_answers DROP COLUMN IF EXISTS asker_notified_at;
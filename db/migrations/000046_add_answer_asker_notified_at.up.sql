@@ -0,0 +1,5 @@
+-- The asker hears about an answer once, the first time it is published.
+-- Answers published so far have already been announced.
+ALTER TABLE product_answers ADD COLUMN asker_notified_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE product_answers SET asker_notified_at = updated_at WHERE status = 'published';
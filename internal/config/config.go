@@ -1,25 +1,38 @@
 package config
 
 import (
+	"crypto"
+	"errors"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	AWS      AWSConfig
-	Upload   UploadConfig
-	SMTP     SMTPConfig
+	Server       ServerConfig
+	Database     DatabaseConfig
+	JWT          JWTConfig
+	Auth         AuthConfig
+	AWS          AWSConfig
+	Upload       UploadConfig
+	SMTP         SMTPConfig
+	Cart         CartConfig
+	Checkout     CheckoutConfig
+	Subscription SubscriptionConfig
+	Download     DownloadConfig
 }
 
 type ServerConfig struct {
-	Port    string
-	GinMode string
+	Port        string
+	GinMode     string
+	PublicURL   string
+	FrontendURL string
+	// TrustedProxies lists the addresses allowed to set X-Forwarded-For;
+	// when empty the client IP is always the connection's remote address.
+	TrustedProxies []string
 }
 
 type DatabaseConfig struct {
@@ -31,10 +44,87 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+// defaultJWTSecret is only good enough for development, release mode refuses
+// to start with it
+const defaultJWTSecret = "your-super-secret-jwt-key"
+
+// JWTConfig signs access and refresh tokens with SigningKey, an RSA (RS256)
+// or Ed25519 (EdDSA) key, and accepts tokens of VerificationKeys as well; all
+// of them are published in the JWKS. To rotate, add the new key to the
+// verification keys first, then make it the signing key and keep the old one
+// as a verification key until RefreshTokenExpires has passed.
+//
+// Without a signing key tokens are signed with SecretKey (HS256). Such tokens
+// keep being accepted after switching to a key only with AcceptLegacyTokens.
+// SecretKey also signs the other tokens of the shop, like emailed links.
+//
+// Issuer and Audience are put in every token and required when validating,
+// services verifying our tokens through the JWKS should check them as well.
 type JWTConfig struct {
 	SecretKey           string
+	Issuer              string
+	Audience            string
 	ExpireIn            time.Duration
 	RefreshTokenExpires time.Duration
+	SigningKey          crypto.Signer
+	VerificationKeys    []crypto.PublicKey
+	AcceptLegacyTokens  bool
+}
+
+// Values of AuthConfig.RequireVerifiedEmail
+const (
+	RequireVerifiedEmailForCheckout = "checkout"
+	RequireVerifiedEmailForLogin    = "login"
+)
+
+// AuthConfig covers account verification, recovery, login throttling and
+// two-factor authentication. RequireVerifiedEmail blocks unverified accounts
+// from checking out or from signing in altogether; when empty they are not
+// restricted.
+type AuthConfig struct {
+	EmailVerificationTTL       time.Duration
+	VerificationResendInterval time.Duration
+	RequireVerifiedEmail       string
+	PasswordResetTTL           time.Duration
+	PasswordResetInterval      time.Duration
+	LoginThrottle              LoginThrottleConfig
+	TwoFactor                  TwoFactorConfig
+}
+
+// TwoFactorConfig covers TOTP sign in. Issuer is the account name shown in
+// authenticator apps, and ChallengeTTL is how long a login that passed the
+// password may take to enter the code. With RequireForStaff, routes that need
+// a permission refuse accounts that have not enabled two-factor authentication.
+type TwoFactorConfig struct {
+	Issuer            string
+	ChallengeTTL      time.Duration
+	RecoveryCodeCount int
+	RequireForStaff   bool
+}
+
+// LoginThrottleConfig slows down password guessing. Failed logins are counted
+// per account and per client IP within Window. Past the free attempts every
+// failure doubles the wait before the next try, starting at BackoffBase and
+// capped at BackoffMax. An account reaching LockoutThreshold failures is
+// locked for LockoutDuration and its owner is emailed.
+type LoginThrottleConfig struct {
+	Window           time.Duration
+	FreeAttempts     int
+	IPFreeAttempts   int
+	BackoffBase      time.Duration
+	BackoffMax       time.Duration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+}
+
+// VerifiedEmailRequiredForCheckout reports whether unverified accounts may not
+// place orders. Blocking sign in implies blocking checkout.
+func (c AuthConfig) VerifiedEmailRequiredForCheckout() bool {
+	return c.RequireVerifiedEmail == RequireVerifiedEmailForCheckout || c.RequireVerifiedEmail == RequireVerifiedEmailForLogin
+}
+
+func (c AuthConfig) VerifiedEmailRequiredForLogin() bool {
+	return c.RequireVerifiedEmail == RequireVerifiedEmailForLogin
 }
 
 type AWSConfig struct {
@@ -60,18 +150,103 @@ type SMTPConfig struct {
 	From     string
 }
 
+// CartConfig drives abandoned cart reminders and the stock bundle lines hold.
+// A bundle line gives its component stock back once left unchanged for
+// ReservationTTL.
+type CartConfig struct {
+	AbandonedAfter           time.Duration
+	ReminderWindow           time.Duration
+	ReminderCheckInterval    time.Duration
+	ReminderBatchSize        int
+	ReservationTTL           time.Duration
+	ReservationCheckInterval time.Duration
+}
+
+// CheckoutConfig prices orders. TaxRate is a fraction of the subtotal, and
+// shipping is free from FreeShippingThreshold on when it is above zero.
+type CheckoutConfig struct {
+	TaxRate               float64
+	ShippingFee           float64
+	FreeShippingThreshold float64
+}
+
+// SubscriptionConfig drives the subscription scheduler. A failed delivery is
+// retried every RetryDelay until MaxAttempts is reached, after which it is
+// skipped.
+type SubscriptionConfig struct {
+	CheckInterval time.Duration
+	RetryDelay    time.Duration
+	MaxAttempts   int
+	BatchSize     int
+}
+
+// DownloadConfig limits access to the files of digital products. Links expire
+// after LinkTTL and each purchase can be downloaded MaxPerPurchase times.
+type DownloadConfig struct {
+	LinkTTL        time.Duration
+	MaxPerPurchase int
+}
+
 func Load() (*Config, error) {
 	_ = godotenv.Load()
 
 	jwtExpiresIn, _ := time.ParseDuration(getEnv("JWT_EXPIRES_IN", "24h"))
 	refreshTokenExpires, _ := time.ParseDuration(getEnv("REFRESH_TOKEN_EXPIRES_IN", "720h"))
+	emailVerificationTTL, _ := time.ParseDuration(getEnv("EMAIL_VERIFICATION_TTL", "24h"))
+	verificationResendInterval, _ := time.ParseDuration(getEnv("EMAIL_VERIFICATION_RESEND_INTERVAL", "1m"))
+	passwordResetTTL, _ := time.ParseDuration(getEnv("PASSWORD_RESET_TTL", "30m"))
+	passwordResetInterval, _ := time.ParseDuration(getEnv("PASSWORD_RESET_INTERVAL", "1m"))
+	loginAttemptWindow, _ := time.ParseDuration(getEnv("LOGIN_ATTEMPT_WINDOW", "1h"))
+	loginFreeAttempts, _ := strconv.Atoi(getEnv("LOGIN_FREE_ATTEMPTS", "3"))
+	loginIPFreeAttempts, _ := strconv.Atoi(getEnv("LOGIN_IP_FREE_ATTEMPTS", "20"))
+	loginBackoffBase, _ := time.ParseDuration(getEnv("LOGIN_BACKOFF_BASE", "1s"))
+	loginBackoffMax, _ := time.ParseDuration(getEnv("LOGIN_BACKOFF_MAX", "5m"))
+	loginLockoutThreshold, _ := strconv.Atoi(getEnv("LOGIN_LOCKOUT_THRESHOLD", "10"))
+	loginLockoutDuration, _ := time.ParseDuration(getEnv("LOGIN_LOCKOUT_DURATION", "30m"))
+	twoFactorChallengeTTL, _ := time.ParseDuration(getEnv("TWO_FACTOR_CHALLENGE_TTL", "5m"))
+	twoFactorRecoveryCodes, _ := strconv.Atoi(getEnv("TWO_FACTOR_RECOVERY_CODES", "10"))
+	requireStaffTwoFactor, _ := strconv.ParseBool(getEnv("REQUIRE_STAFF_TWO_FACTOR", "false"))
+	jwtAcceptLegacyTokens, _ := strconv.ParseBool(getEnv("JWT_ACCEPT_LEGACY_TOKENS", "false"))
 	maxUploadSize, _ := strconv.ParseInt(getEnv("MAX_UPLOAD_SIZE", "10485760"), 10, 64)
 	smtpPort, _ := strconv.Atoi(getEnv("SMTP_PORT", "1025"))
+	cartAbandonedAfter, _ := time.ParseDuration(getEnv("CART_ABANDONED_AFTER", "24h"))
+	cartReminderWindow, _ := time.ParseDuration(getEnv("CART_REMINDER_WINDOW", "168h"))
+	cartReminderCheckInterval, _ := time.ParseDuration(getEnv("CART_REMINDER_CHECK_INTERVAL", "1h"))
+	cartReminderBatchSize, _ := strconv.Atoi(getEnv("CART_REMINDER_BATCH_SIZE", "100"))
+	cartReservationTTL, _ := time.ParseDuration(getEnv("CART_RESERVATION_TTL", "30m"))
+	cartReservationCheckInterval, _ := time.ParseDuration(getEnv("CART_RESERVATION_CHECK_INTERVAL", "5m"))
+	taxRate, _ := strconv.ParseFloat(getEnv("TAX_RATE", "0"), 64)
+	shippingFee, _ := strconv.ParseFloat(getEnv("SHIPPING_FEE", "0"), 64)
+	freeShippingThreshold, _ := strconv.ParseFloat(getEnv("FREE_SHIPPING_THRESHOLD", "0"), 64)
+	subscriptionCheckInterval, _ := time.ParseDuration(getEnv("SUBSCRIPTION_CHECK_INTERVAL", "15m"))
+	subscriptionRetryDelay, _ := time.ParseDuration(getEnv("SUBSCRIPTION_RETRY_DELAY", "6h"))
+	subscriptionMaxAttempts, _ := strconv.Atoi(getEnv("SUBSCRIPTION_MAX_ATTEMPTS", "3"))
+	subscriptionBatchSize, _ := strconv.Atoi(getEnv("SUBSCRIPTION_BATCH_SIZE", "100"))
+	downloadLinkTTL, _ := time.ParseDuration(getEnv("DOWNLOAD_LINK_TTL", "15m"))
+	downloadMaxPerPurchase, _ := strconv.Atoi(getEnv("DOWNLOAD_MAX_PER_PURCHASE", "5"))
+
+	jwtSigningKey, err := loadSigningKey(getEnv("JWT_SIGNING_KEY_FILE", ""))
+	if err != nil {
+		return nil, err
+	}
+	jwtVerificationKeys, err := loadVerificationKeys(getEnv("JWT_VERIFICATION_KEY_FILES", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	ginMode := getEnv("GIN_MODE", "debug")
+	jwtSecret := getEnv("JWT_SECRET", defaultJWTSecret)
+	if ginMode == "release" && jwtSecret == defaultJWTSecret {
+		return nil, errors.New("JWT_SECRET must be set in release mode")
+	}
 
 	return &Config{
 		Server: ServerConfig{
-			Port:    getEnv("PORT", "8080"),
-			GinMode: getEnv("GIN_MODE", "debug"),
+			Port:           getEnv("PORT", "8080"),
+			GinMode:        ginMode,
+			PublicURL:      getEnv("PUBLIC_URL", "http://localhost:8080"),
+			FrontendURL:    getEnv("FRONTEND_URL", "http://localhost:3000"),
+			TrustedProxies: getEnvList("TRUSTED_PROXIES"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -82,9 +257,36 @@ func Load() (*Config, error) {
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
 		},
 		JWT: JWTConfig{
-			SecretKey:           getEnv("JWT_SECRET", "your-super-secret-jwt-key"),
+			SecretKey:           jwtSecret,
+			Issuer:              getEnv("JWT_ISSUER", "go-shop"),
+			Audience:            getEnv("JWT_AUDIENCE", "go-shop-api"),
 			ExpireIn:            jwtExpiresIn,
 			RefreshTokenExpires: refreshTokenExpires,
+			SigningKey:          jwtSigningKey,
+			VerificationKeys:    jwtVerificationKeys,
+			AcceptLegacyTokens:  jwtAcceptLegacyTokens,
+		},
+		Auth: AuthConfig{
+			EmailVerificationTTL:       emailVerificationTTL,
+			VerificationResendInterval: verificationResendInterval,
+			RequireVerifiedEmail:       getEnv("REQUIRE_VERIFIED_EMAIL", ""),
+			PasswordResetTTL:           passwordResetTTL,
+			PasswordResetInterval:      passwordResetInterval,
+			LoginThrottle: LoginThrottleConfig{
+				Window:           loginAttemptWindow,
+				FreeAttempts:     loginFreeAttempts,
+				IPFreeAttempts:   loginIPFreeAttempts,
+				BackoffBase:      loginBackoffBase,
+				BackoffMax:       loginBackoffMax,
+				LockoutThreshold: loginLockoutThreshold,
+				LockoutDuration:  loginLockoutDuration,
+			},
+			TwoFactor: TwoFactorConfig{
+				Issuer:            getEnv("TWO_FACTOR_ISSUER", "Go Shop"),
+				ChallengeTTL:      twoFactorChallengeTTL,
+				RecoveryCodeCount: twoFactorRecoveryCodes,
+				RequireForStaff:   requireStaffTwoFactor,
+			},
 		},
 		AWS: AWSConfig{
 			Region:          getEnv("AWS_REGION", "us-east-1"),
@@ -106,6 +308,29 @@ func Load() (*Config, error) {
 			Password: getEnv("SMTP_PASSWORD", ""),
 			From:     getEnv("SMTP_FROM", "noreply@shop.com"),
 		},
+		Cart: CartConfig{
+			AbandonedAfter:           cartAbandonedAfter,
+			ReminderWindow:           cartReminderWindow,
+			ReminderCheckInterval:    cartReminderCheckInterval,
+			ReminderBatchSize:        cartReminderBatchSize,
+			ReservationTTL:           cartReservationTTL,
+			ReservationCheckInterval: cartReservationCheckInterval,
+		},
+		Checkout: CheckoutConfig{
+			TaxRate:               taxRate,
+			ShippingFee:           shippingFee,
+			FreeShippingThreshold: freeShippingThreshold,
+		},
+		Subscription: SubscriptionConfig{
+			CheckInterval: subscriptionCheckInterval,
+			RetryDelay:    subscriptionRetryDelay,
+			MaxAttempts:   subscriptionMaxAttempts,
+			BatchSize:     subscriptionBatchSize,
+		},
+		Download: DownloadConfig{
+			LinkTTL:        downloadLinkTTL,
+			MaxPerPurchase: downloadMaxPerPurchase,
+		},
 	}, nil
 
 }
@@ -117,3 +342,14 @@ func getEnv(key, defaultValue string) string {
 
 	return defaultValue
 }
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// minRSAKeyBits is the smallest RSA key accepted for signing tokens
+const minRSAKeyBits = 2048
+
+// loadSigningKey reads the PEM private key that signs tokens. An empty path
+// means tokens are signed with the shared secret instead.
+func loadSigningKey(path string) (crypto.Signer, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// loadVerificationKeys reads the comma separated PEM files of the keys that
+// are accepted besides the signing key. Each file holds a public key, or a
+// private key whose public half is used.
+func loadVerificationKeys(paths string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for _, path := range strings.Split(paths, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+
+		block, err := readPEM(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if block.Type != "PUBLIC KEY" {
+			private, err := parsePrivateKey(block)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			keys = append(keys, private.Public())
+			continue
+		}
+
+		public, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		switch key := public.(type) {
+		case *rsa.PublicKey:
+			if key.N.BitLen() < minRSAKeyBits {
+				return nil, fmt.Errorf("%s: RSA keys need at least %d bits", path, minRSAKeyBits)
+			}
+		case ed25519.PublicKey:
+		default:
+			return nil, fmt.Errorf("%s: only RSA and Ed25519 keys are supported", path)
+		}
+		keys = append(keys, public)
+	}
+	return keys, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+	return block, nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	var key interface{}
+	var err error
+	if block.Type == "RSA PRIVATE KEY" {
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA keys need at least %d bits", minRSAKeyBits)
+		}
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, errors.New("only RSA and Ed25519 keys are supported")
+	}
+}
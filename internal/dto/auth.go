@@ -8,33 +8,130 @@ type RegisterRequest struct {
 	FirstName string `json:"first_name" binding:"required"`
 	LastName  string `json:"last_name" binding:"required"`
 	Phone     string `json:"phone"`
+	CartToken string `json:"cart_token"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email     string `json:"email" binding:"required,email"`
+	Password  string `json:"password" binding:"required"`
+	CartToken string `json:"cart_token"`
 }
 
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
+// ClientInfo describes the device a session is signed in from
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// AuthResponse leaves out the tokens when the account has to verify its email
+// address before it can sign in, or when the login still has to pass the
+// second factor; TwoFactor then holds the challenge to answer.
 type AuthResponse struct {
-	User         UserResponse `json:"user"`
-	AccessToken  string       `json:"access_token"`
-	RefreshToken string       `json:"refresh_token"`
+	User         UserResponse        `json:"user"`
+	AccessToken  string              `json:"access_token,omitempty"`
+	RefreshToken string              `json:"refresh_token,omitempty"`
+	TwoFactor    *TwoFactorChallenge `json:"two_factor,omitempty"`
+	CartMerge    *CartMergeResult    `json:"cart_merge,omitempty"`
+}
+
+type TwoFactorChallenge struct {
+	ChallengeToken string    `json:"challenge_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// TwoFactorLoginRequest answers a login challenge with an authenticator code
+// or one of the recovery codes
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+	CartToken      string `json:"cart_token"`
 }
 
 type UserResponse struct {
-	ID        uint      `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	Phone     string    `json:"phone"`
-	Role      string    `json:"role"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               uint      `json:"id"`
+	Email            string    `json:"email"`
+	FirstName        string    `json:"first_name"`
+	LastName         string    `json:"last_name"`
+	Phone            string    `json:"phone"`
+	Roles            []string  `json:"roles"`
+	IsActive         bool      `json:"is_active"`
+	EmailVerified    bool      `json:"email_verified"`
+	TwoFactorEnabled bool      `json:"two_factor_enabled"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+}
+
+type ChangeEmailRequest struct {
+	NewEmail        string `json:"new_email" binding:"required,email"`
+	CurrentPassword string `json:"current_password" binding:"required"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type EnrollTwoFactorRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+}
+
+// TwoFactorEnrollmentResponse is shown once to set up the authenticator app,
+// usually as a QR code of the URI
+type TwoFactorEnrollmentResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	Code            string `json:"code" binding:"required"`
+}
+
+type RegenerateRecoveryCodesRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// RecoveryCodesResponse is the only time the recovery codes are readable
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
 
 type UpdateProfileRequest struct {
@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type ProductFileResponse struct {
+	ID          uint      `json:"id"`
+	ProductID   uint      `json:"product_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DownloadLinkResponse is one file the customer bought. URL is left out once
+// the purchase used up its downloads.
+type DownloadLinkResponse struct {
+	OrderItemID        uint       `json:"order_item_id"`
+	ProductID          uint       `json:"product_id"`
+	ProductName        string     `json:"product_name"`
+	FileID             uint       `json:"file_id"`
+	FileName           string     `json:"file_name"`
+	Size               int64      `json:"size"`
+	URL                string     `json:"url,omitempty"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+	DownloadsRemaining int        `json:"downloads_remaining"`
+}
+
+type DownloadQuery struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
@@ -3,46 +3,138 @@ package dto
 import "time"
 
 type AddToCartRequest struct {
-	ProductID uint `json:"product_id" binding:"required"`
-	Quantity  int  `json:"quantity" binding:"required,min=1"`
+	ProductID   uint   `json:"product_id" binding:"required"`
+	Quantity    int    `json:"quantity" binding:"required,min=1"`
+	Note        string `json:"note" binding:"max=500"`
+	GiftMessage string `json:"gift_message" binding:"max=500"`
 }
 
 type UpdateCartItemRequest struct {
 	Quantity int `json:"quantity" binding:"required,min=1"`
 }
 
+type UpdateCartItemNoteRequest struct {
+	Note        string `json:"note" binding:"max=500"`
+	GiftMessage string `json:"gift_message" binding:"max=500"`
+}
+
 type CartResponse struct {
-	ID        uint               `json:"id"`
-	UserID    uint               `json:"user_id"`
-	CartItems []CartItemResponse `json:"cart_items"`
-	Total     float64            `json:"total"`
-	CreatedAt time.Time          `json:"created_at"`
-	UpdatedAt time.Time          `json:"updated_at"`
+	ID          uint               `json:"id"`
+	UserID      uint               `json:"user_id"`
+	CartItems   []CartItemResponse `json:"cart_items"`
+	SavedItems  []CartItemResponse `json:"saved_items"`
+	Total       float64            `json:"total"`
+	HasWarnings bool               `json:"has_warnings"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
 }
 
 type CartItemResponse struct {
-	ID        uint            `json:"id"`
-	Product   ProductResponse `json:"product"`
-	Quantity  int             `json:"quantity"`
-	Subtotal  float64         `json:"subtotal"`
-	CreatedAt time.Time       `json:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at"`
+	ID          uint              `json:"id"`
+	Product     ProductResponse   `json:"product"`
+	Quantity    int               `json:"quantity"`
+	UnitPrice   float64           `json:"unit_price"`
+	Subtotal    float64           `json:"subtotal"`
+	Note        string            `json:"note,omitempty"`
+	GiftMessage string            `json:"gift_message,omitempty"`
+	Warnings    []CartItemWarning `json:"warnings,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// CartItemWarning describes a change to a cart line since it was added
+type CartItemWarning struct {
+	Code           string  `json:"code"`
+	Message        string  `json:"message"`
+	PreviousPrice  float64 `json:"previous_price,omitempty"`
+	CurrentPrice   float64 `json:"current_price,omitempty"`
+	AvailableStock *int    `json:"available_stock,omitempty"`
+}
+
+type CartItemIssue struct {
+	CartItemID  uint              `json:"cart_item_id"`
+	ProductID   uint              `json:"product_id"`
+	ProductName string            `json:"product_name"`
+	Warnings    []CartItemWarning `json:"warnings"`
+}
+
+type CartMergeResult struct {
+	MergedItems int                   `json:"merged_items"`
+	Adjustments []CartMergeAdjustment `json:"adjustments"`
+}
+
+// CartMergeAdjustment describes a guest cart line that could not be merged as is
+type CartMergeAdjustment struct {
+	ProductID         uint   `json:"product_id"`
+	ProductName       string `json:"product_name"`
+	RequestedQuantity int    `json:"requested_quantity"`
+	Quantity          int    `json:"quantity"`
+	Reason            string `json:"reason"`
+}
+
+// CreateOrderRequest checks out the given cart items. Without any IDs the whole
+// cart, apart from items saved for later, is checked out.
+type CreateOrderRequest struct {
+	CartItemIDs []uint `json:"cart_item_ids"`
+}
+
+type BuyNowRequest struct {
+	ProductID   uint   `json:"product_id" binding:"required"`
+	Quantity    int    `json:"quantity" binding:"required,min=1"`
+	Note        string `json:"note" binding:"max=500"`
+	GiftMessage string `json:"gift_message" binding:"max=500"`
+}
+
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending confirmed shipped delivered cancelled"`
+}
+
+// ReorderResponse reports the cart after copying a past order into it. Lines
+// that were skipped, reduced or repriced are listed as issues.
+type ReorderResponse struct {
+	Cart       *CartResponse  `json:"cart"`
+	AddedItems int            `json:"added_items"`
+	Issues     []ReorderIssue `json:"issues"`
+}
+
+type ReorderIssue struct {
+	ProductID         uint    `json:"product_id"`
+	ProductName       string  `json:"product_name"`
+	RequestedQuantity int     `json:"requested_quantity"`
+	Quantity          int     `json:"quantity"`
+	Reason            string  `json:"reason"`
+	PreviousPrice     float64 `json:"previous_price,omitempty"`
+	CurrentPrice      float64 `json:"current_price,omitempty"`
 }
 
 type OrderResponse struct {
-	ID          uint                `json:"id"`
-	UserID      uint                `json:"user_id"`
-	Status      string              `json:"status"`
-	TotalAmount float64             `json:"total_amount"`
-	OrderItems  []OrderItemResponse `json:"order_items"`
-	CreatedAt   time.Time           `json:"created_at"`
-	UpdatedAt   time.Time           `json:"updated_at"`
+	ID             uint                `json:"id"`
+	UserID         uint                `json:"user_id"`
+	SubscriptionID *uint               `json:"subscription_id,omitempty"`
+	Status         string              `json:"status"`
+	Subtotal       float64             `json:"subtotal"`
+	TaxAmount      float64             `json:"tax_amount"`
+	ShippingAmount float64             `json:"shipping_amount"`
+	TotalAmount    float64             `json:"total_amount"`
+	OrderItems     []OrderItemResponse `json:"order_items"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
 }
 
 type OrderItemResponse struct {
-	ID        uint            `json:"id"`
-	Product   ProductResponse `json:"product"`
-	Quantity  int             `json:"quantity"`
-	Price     float64         `json:"price"`
-	CreatedAt time.Time       `json:"created_at"`
+	ID          uint                         `json:"id"`
+	Product     ProductResponse              `json:"product"`
+	Quantity    int                          `json:"quantity"`
+	Price       float64                      `json:"price"`
+	Note        string                       `json:"note,omitempty"`
+	GiftMessage string                       `json:"gift_message,omitempty"`
+	Components  []OrderItemComponentResponse `json:"components,omitempty"`
+	CreatedAt   time.Time                    `json:"created_at"`
+}
+
+type OrderItemComponentResponse struct {
+	ProductID uint   `json:"product_id"`
+	Name      string `json:"name"`
+	SKU       string `json:"sku"`
+	Quantity  int    `json:"quantity"`
 }
@@ -4,55 +4,167 @@ import "time"
 
 type CreateCategoryRequest struct {
 	Name        string `json:"name" binding:"required"`
+	Slug        string `json:"slug"`
 	Description string `json:"description"`
+	ParentID    *uint  `json:"parent_id"`
+	SortOrder   int    `json:"sort_order"`
 }
 
+// UpdateCategoryRequest keeps the parent of the category unless ParentID is
+// given, or DetachParent moves it to the top level
 type UpdateCategoryRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description"`
-	IsActive    *bool  `json:"is_active"`
+	Name         string `json:"name" binding:"required"`
+	Slug         string `json:"slug"`
+	Description  string `json:"description"`
+	IsActive     *bool  `json:"is_active"`
+	ParentID     *uint  `json:"parent_id"`
+	DetachParent bool   `json:"detach_parent"`
+	SortOrder    int    `json:"sort_order"`
 }
 
 type CategoryResponse struct {
-	ID          uint      `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	IsActive    bool      `json:"is_active"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uint                 `json:"id"`
+	ParentID    *uint                `json:"parent_id"`
+	Name        string               `json:"name"`
+	Slug        string               `json:"slug"`
+	Description string               `json:"description"`
+	IsActive    bool                 `json:"is_active"`
+	SortOrder   int                  `json:"sort_order"`
+	Breadcrumbs []CategoryBreadcrumb `json:"breadcrumbs,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+type CategoryBreadcrumb struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type CategoryTreeResponse struct {
+	CategoryResponse
+	Children []CategoryTreeResponse `json:"children"`
+}
+
+type CreateCategoryAttributeRequest struct {
+	Code       string   `json:"code" binding:"required"`
+	Name       string   `json:"name" binding:"required"`
+	Type       string   `json:"type" binding:"required,oneof=text number boolean enum"`
+	Unit       string   `json:"unit"`
+	Options    []string `json:"options"`
+	IsRequired bool     `json:"is_required"`
+	SortOrder  int      `json:"sort_order"`
+}
+
+type UpdateCategoryAttributeRequest struct {
+	Name       string   `json:"name" binding:"required"`
+	Unit       string   `json:"unit"`
+	Options    []string `json:"options"`
+	IsRequired bool     `json:"is_required"`
+	SortOrder  int      `json:"sort_order"`
+}
+
+type CategoryAttributeResponse struct {
+	ID         uint      `json:"id"`
+	CategoryID uint      `json:"category_id"`
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	Unit       string    `json:"unit"`
+	Options    []string  `json:"options"`
+	IsRequired bool      `json:"is_required"`
+	SortOrder  int       `json:"sort_order"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 type CreateProductRequest struct {
-	CategoryID  uint    `json:"category_id" binding:"required"`
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
-	Stock       int     `json:"stock" binding:"min=0"`
-	SKU         string  `json:"sku" binding:"required"`
+	CategoryID  uint                   `json:"category_id" binding:"required"`
+	Name        string                 `json:"name" binding:"required"`
+	Slug        string                 `json:"slug"`
+	Description string                 `json:"description"`
+	Price       float64                `json:"price" binding:"required,gt=0"`
+	Stock       int                    `json:"stock" binding:"min=0"`
+	SKU         string                 `json:"sku" binding:"required"`
+	IsDigital   bool                   `json:"is_digital"`
+	Attributes  map[string]interface{} `json:"attributes"`
 }
 
 type UpdateProductRequest struct {
-	CategoryID  uint    `json:"category_id" binding:"required"`
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
-	Stock       int     `json:"stock" binding:"min=0"`
-	IsActive    *bool   `json:"is_active"`
+	CategoryID  uint                   `json:"category_id" binding:"required"`
+	Name        string                 `json:"name" binding:"required"`
+	Slug        string                 `json:"slug"`
+	Description string                 `json:"description"`
+	Price       float64                `json:"price" binding:"required,gt=0"`
+	Stock       int                    `json:"stock" binding:"min=0"`
+	IsActive    *bool                  `json:"is_active"`
+	IsDigital   *bool                  `json:"is_digital"`
+	Attributes  map[string]interface{} `json:"attributes"`
+}
+
+// SetBundleItemsRequest defines the components of a bundle. The bundle keeps
+// its own price; its stock follows from the components.
+type SetBundleItemsRequest struct {
+	Items []BundleItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type BundleItemRequest struct {
+	ProductID uint `json:"product_id" binding:"required"`
+	Quantity  int  `json:"quantity" binding:"required,min=1"`
+}
+
+type ProductListQuery struct {
+	CategoryID uint              `form:"category_id"`
+	Sort       string            `form:"sort" binding:"omitempty,oneof=rating price_asc price_desc newest"`
+	Attributes []AttributeFilter `form:"-"`
+}
+
+// AttributeFilter matches products by the value of a category attribute code.
+// It is parsed from query parameters such as attr[screen_size][gte]=13.
+type AttributeFilter struct {
+	Code     string
+	Operator string
+	Value    string
 }
 
 type ProductResponse struct {
-	ID          uint                   `json:"id"`
-	CategoryID  uint                   `json:"category_id"`
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	Price       float64                `json:"price"`
-	Stock       int                    `json:"stock"`
-	SKU         string                 `json:"sku"`
-	IsActive    bool                   `json:"is_active"`
-	Category    CategoryResponse       `json:"category"`
-	Images      []ProductImageResponse `json:"images"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
+	ID            uint                       `json:"id"`
+	CategoryID    uint                       `json:"category_id"`
+	Name          string                     `json:"name"`
+	Slug          string                     `json:"slug"`
+	Description   string                     `json:"description"`
+	Price         float64                    `json:"price"`
+	Stock         int                        `json:"stock"`
+	SKU           string                     `json:"sku"`
+	IsActive      bool                       `json:"is_active"`
+	IsBundle      bool                       `json:"is_bundle"`
+	IsDigital     bool                       `json:"is_digital"`
+	RatingAverage float64                    `json:"rating_average"`
+	RatingCount   int                        `json:"rating_count"`
+	Category      CategoryResponse           `json:"category"`
+	Images        []ProductImageResponse     `json:"images"`
+	Attributes    []ProductAttributeResponse `json:"attributes"`
+	BundleItems   []BundleItemResponse       `json:"bundle_items,omitempty"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+}
+
+type BundleItemResponse struct {
+	ProductID uint   `json:"product_id"`
+	Name      string `json:"name"`
+	SKU       string `json:"sku"`
+	Quantity  int    `json:"quantity"`
+	Stock     int    `json:"stock"`
+	IsActive  bool   `json:"is_active"`
+}
+
+type ProductAttributeResponse struct {
+	AttributeID uint        `json:"attribute_id"`
+	Code        string      `json:"code"`
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Unit        string      `json:"unit,omitempty"`
+	Value       interface{} `json:"value"`
 }
 
 type ProductImageResponse struct {
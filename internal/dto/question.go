@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+type CreateQuestionRequest struct {
+	Body string `json:"body" binding:"required,max=1000"`
+}
+
+type CreateAnswerRequest struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+type ModerateQARequest struct {
+	Status string `json:"status" binding:"required,oneof=published rejected"`
+}
+
+type QuestionResponse struct {
+	ID          uint             `json:"id"`
+	ProductID   uint             `json:"product_id"`
+	UserID      uint             `json:"user_id"`
+	AuthorName  string           `json:"author_name"`
+	Body        string           `json:"body"`
+	Status      string           `json:"status"`
+	UpvoteCount int              `json:"upvote_count"`
+	Answers     []AnswerResponse `json:"answers"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+type AnswerResponse struct {
+	ID            uint      `json:"id"`
+	QuestionID    uint      `json:"question_id"`
+	UserID        uint      `json:"user_id"`
+	AuthorName    string    `json:"author_name"`
+	Body          string    `json:"body"`
+	Status        string    `json:"status"`
+	IsStaff       bool      `json:"is_staff"`
+	VerifiedBuyer bool      `json:"verified_buyer"`
+	UpvoteCount   int       `json:"upvote_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
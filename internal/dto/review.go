@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+type CreateReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5"`
+	Title  string `json:"title" binding:"max=255"`
+	Body   string `json:"body" binding:"required"`
+}
+
+type ModerateReviewRequest struct {
+	Status string `json:"status" binding:"required,oneof=approved rejected"`
+	Note   string `json:"note"`
+}
+
+type ReviewResponse struct {
+	ID               uint                  `json:"id"`
+	ProductID        uint                  `json:"product_id"`
+	UserID           uint                  `json:"user_id"`
+	AuthorName       string                `json:"author_name"`
+	Rating           int                   `json:"rating"`
+	Title            string                `json:"title"`
+	Body             string                `json:"body"`
+	Status           string                `json:"status"`
+	VerifiedPurchase bool                  `json:"verified_purchase"`
+	Images           []ReviewImageResponse `json:"images"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+}
+
+type ReviewImageResponse struct {
+	ID        uint      `json:"id"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
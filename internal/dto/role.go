@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required,max=50"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions" binding:"required,min=1"`
+}
+
+// UpdateRoleRequest replaces the description and permissions of a role, its
+// name cannot change
+type UpdateRoleRequest struct {
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions" binding:"required,min=1"`
+}
+
+type RoleResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type PermissionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+type CreateSubscriptionRequest struct {
+	Frequency string                    `json:"frequency" binding:"required,oneof=weekly biweekly monthly"`
+	StartDate *time.Time                `json:"start_date"`
+	Items     []SubscriptionItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type SubscriptionItemRequest struct {
+	ProductID uint `json:"product_id" binding:"required"`
+	Quantity  int  `json:"quantity" binding:"required,min=1"`
+}
+
+type SubscriptionResponse struct {
+	ID             uint                       `json:"id"`
+	Status         string                     `json:"status"`
+	Frequency      string                     `json:"frequency"`
+	NextRunAt      time.Time                  `json:"next_run_at"`
+	RetryAt        *time.Time                 `json:"retry_at,omitempty"`
+	FailedAttempts int                        `json:"failed_attempts"`
+	LastError      string                     `json:"last_error,omitempty"`
+	LastOrderID    *uint                      `json:"last_order_id,omitempty"`
+	CancelledAt    *time.Time                 `json:"cancelled_at,omitempty"`
+	Items          []SubscriptionItemResponse `json:"items"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	UpdatedAt      time.Time                  `json:"updated_at"`
+}
+
+type SubscriptionItemResponse struct {
+	ID       uint            `json:"id"`
+	Product  ProductResponse `json:"product"`
+	Quantity int             `json:"quantity"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+type CreateWishlistRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type UpdateWishlistRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type AddWishlistItemRequest struct {
+	ProductID uint `json:"product_id" binding:"required"`
+}
+
+type MoveToCartRequest struct {
+	Quantity int `json:"quantity" binding:"omitempty,min=1"`
+}
+
+type WishlistResponse struct {
+	ID         uint                   `json:"id"`
+	Name       string                 `json:"name"`
+	IsShared   bool                   `json:"is_shared"`
+	ShareToken string                 `json:"share_token,omitempty"`
+	Items      []WishlistItemResponse `json:"items"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+}
+
+type WishlistItemResponse struct {
+	ID        uint            `json:"id"`
+	Product   ProductResponse `json:"product"`
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -1,6 +1,10 @@
 package handler
 
 import (
+	"errors"
+	"math"
+	"strconv"
+
 	"github.com/JihadRinaldi/go-shop/internal/dto"
 	"github.com/JihadRinaldi/go-shop/internal/services"
 	"github.com/JihadRinaldi/go-shop/internal/utils"
@@ -23,11 +27,17 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		utils.BadRequestResponse(c, "Invalid request data", err)
 		return
 	}
-	response, err := h.authService.Register(&req)
+	if req.CartToken == "" {
+		req.CartToken = guestCartToken(c)
+	}
+	response, err := h.authService.Register(&req, clientInfo(c))
 	if err != nil {
 		utils.BadRequestResponse(c, "Registration failed", err)
 		return
 	}
+	if response.CartMerge != nil {
+		clearGuestCartToken(c)
+	}
 
 	utils.CreatedResponse(c, "User registered successfully", response)
 }
@@ -38,11 +48,53 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		utils.BadRequestResponse(c, "Invalid request data", err)
 		return
 	}
-	response, err := h.authService.Login(&req)
+	if req.CartToken == "" {
+		req.CartToken = guestCartToken(c)
+	}
+	response, err := h.authService.Login(&req, clientInfo(c))
+	if respondLoginThrottled(c, err) {
+		return
+	}
+	if errors.Is(err, services.ErrEmailNotVerified) {
+		utils.ForbiddenResponse(c, "Verify your email address before signing in")
+		return
+	}
 	if err != nil {
 		utils.UnauthorizedResponse(c, "Login failed")
 		return
 	}
+	if response.TwoFactor != nil {
+		utils.SuccessResponse(c, "Enter the code from your authenticator app", response)
+		return
+	}
+	if response.CartMerge != nil {
+		clearGuestCartToken(c)
+	}
+
+	utils.SuccessResponse(c, "Login successful", response)
+}
+
+// VerifyTwoFactor completes a login that answered with a two-factor challenge
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req dto.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+	if req.CartToken == "" {
+		req.CartToken = guestCartToken(c)
+	}
+	response, err := h.authService.VerifyTwoFactorLogin(&req, clientInfo(c))
+	if respondLoginThrottled(c, err) {
+		return
+	}
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Two-factor verification failed")
+		return
+	}
+	if response.CartMerge != nil {
+		clearGuestCartToken(c)
+	}
 
 	utils.SuccessResponse(c, "Login successful", response)
 }
@@ -53,7 +105,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		utils.BadRequestResponse(c, "Invalid request data", err)
 		return
 	}
-	response, err := h.authService.RefreshToken(&req)
+	response, err := h.authService.RefreshToken(&req, clientInfo(c))
 	if err != nil {
 		utils.UnauthorizedResponse(c, "Token refresh failed")
 		return
@@ -76,3 +128,106 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 
 	utils.SuccessResponse(c, "Logout successful", nil)
 }
+
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	user, err := h.authService.VerifyEmail(&req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Email address verified", user)
+}
+
+// ResendVerification answers the same way whether or not the address belongs
+// to an account
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req dto.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	if err := h.authService.ResendVerification(&req); err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to send verification email", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "If the address needs verification, a new link has been sent", nil)
+}
+
+// ForgotPassword answers the same way whether or not the address belongs to
+// an account
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	h.authService.ForgotPassword(&req)
+	utils.SuccessResponse(c, "If the address belongs to an account, a password reset link has been sent", nil)
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	if err := h.authService.ResetPassword(&req); err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Password has been reset, sign in with the new password", nil)
+}
+
+// UnlockAccount lets staff lift the lockout of an account after failed logins
+func (h *AuthHandler) UnlockAccount(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	err = h.authService.UnlockAccount(uint(userID))
+	if errors.Is(err, services.ErrUserNotFound) {
+		utils.NotFoundResponse(c, "User not found")
+		return
+	}
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to unlock account", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Account unlocked", nil)
+}
+
+// clientInfo describes the device of the request for the session it signs in
+func clientInfo(c *gin.Context) dto.ClientInfo {
+	return dto.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+// respondLoginThrottled answers with 429 and the time to wait when the login
+// was refused by the throttle
+func respondLoginThrottled(c *gin.Context, err error) bool {
+	var throttled *services.LoginThrottledError
+	if !errors.As(err, &throttled) {
+		return false
+	}
+
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	utils.TooManyRequestsResponse(c, "Too many failed login attempts, try again later")
+	return true
+}
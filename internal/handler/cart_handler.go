@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"net/http"
 	"strconv"
 
 	"github.com/JihadRinaldi/go-shop/internal/dto"
@@ -9,20 +10,30 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// CartHandler serves both signed-in users and guests. Requests without a user
+// are resolved through the guest cart token.
 type CartHandler struct {
-	cartService *services.CartService
+	cartService         *services.CartService
+	cartReminderService *services.CartReminderService
 }
 
-func NewCartHandler(cartService *services.CartService) *CartHandler {
+func NewCartHandler(cartService *services.CartService, cartReminderService *services.CartReminderService) *CartHandler {
 	return &CartHandler{
-		cartService: cartService,
+		cartService:         cartService,
+		cartReminderService: cartReminderService,
 	}
 }
 
 func (h *CartHandler) GetCart(c *gin.Context) {
 	userID := c.GetUint("user_id")
 
-	cart, err := h.cartService.GetCart(userID)
+	var cart *dto.CartResponse
+	var err error
+	if userID != 0 {
+		cart, err = h.cartService.GetCart(userID)
+	} else {
+		cart, err = h.cartService.GetGuestCart(guestCartToken(c))
+	}
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to fetch cart", err)
 		return
@@ -40,7 +51,17 @@ func (h *CartHandler) AddToCart(c *gin.Context) {
 		return
 	}
 
-	cart, err := h.cartService.AddToCart(userID, req)
+	var cart *dto.CartResponse
+	var err error
+	if userID != 0 {
+		cart, err = h.cartService.AddToCart(userID, req)
+	} else {
+		var token string
+		cart, token, err = h.cartService.AddToGuestCart(guestCartToken(c), req)
+		if err == nil {
+			setGuestCartToken(c, token)
+		}
+	}
 	if err != nil {
 		utils.BadRequestResponse(c, err.Error(), err)
 		return
@@ -64,7 +85,12 @@ func (h *CartHandler) UpdateCartItem(c *gin.Context) {
 		return
 	}
 
-	cart, err := h.cartService.UpdateCartItem(userID, uint(itemID), req)
+	var cart *dto.CartResponse
+	if userID != 0 {
+		cart, err = h.cartService.UpdateCartItem(userID, uint(itemID), req)
+	} else {
+		cart, err = h.cartService.UpdateGuestCartItem(guestCartToken(c), uint(itemID), req)
+	}
 	if err != nil {
 		utils.BadRequestResponse(c, err.Error(), err)
 		return
@@ -82,10 +108,120 @@ func (h *CartHandler) RemoveCartItem(c *gin.Context) {
 		return
 	}
 
-	if err := h.cartService.RemoveCartItem(userID, uint(itemID)); err != nil {
+	if userID != 0 {
+		err = h.cartService.RemoveCartItem(userID, uint(itemID))
+	} else {
+		err = h.cartService.RemoveGuestCartItem(guestCartToken(c), uint(itemID))
+	}
+	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to remove cart item", err)
 		return
 	}
 
 	utils.SuccessResponse(c, "Cart item removed", nil)
 }
+
+func (h *CartHandler) SaveForLater(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	itemID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid item ID", err)
+		return
+	}
+
+	var cart *dto.CartResponse
+	if userID != 0 {
+		cart, err = h.cartService.SaveForLater(userID, uint(itemID))
+	} else {
+		cart, err = h.cartService.SaveGuestItemForLater(guestCartToken(c), uint(itemID))
+	}
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Cart item saved for later", cart)
+}
+
+func (h *CartHandler) MoveToCart(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	itemID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid item ID", err)
+		return
+	}
+
+	var cart *dto.CartResponse
+	if userID != 0 {
+		cart, err = h.cartService.MoveToCart(userID, uint(itemID))
+	} else {
+		cart, err = h.cartService.MoveGuestItemToCart(guestCartToken(c), uint(itemID))
+	}
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Cart item moved to cart", cart)
+}
+
+func (h *CartHandler) UpdateCartItemNote(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	itemID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid item ID", err)
+		return
+	}
+
+	var req dto.UpdateCartItemNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	var cart *dto.CartResponse
+	if userID != 0 {
+		cart, err = h.cartService.UpdateCartItemNote(userID, uint(itemID), req)
+	} else {
+		cart, err = h.cartService.UpdateGuestCartItemNote(guestCartToken(c), uint(itemID), req)
+	}
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Cart item note updated", cart)
+}
+
+func (h *CartHandler) AcceptChanges(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var cart *dto.CartResponse
+	var err error
+	if userID != 0 {
+		cart, err = h.cartService.AcceptCartChanges(userID)
+	} else {
+		cart, err = h.cartService.AcceptGuestCartChanges(guestCartToken(c))
+	}
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Cart changes accepted", cart)
+}
+
+// RestoreCart is the target of the link in abandoned cart emails. It puts the
+// reminded items back into the cart and redirects to the storefront cart page.
+func (h *CartHandler) RestoreCart(c *gin.Context) {
+	redirectURL, err := h.cartReminderService.RestoreCart(c.Param("token"))
+	if err != nil {
+		utils.NotFoundResponse(c, "Cart reminder not found")
+		return
+	}
+
+	c.Redirect(http.StatusFound, redirectURL)
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	CartTokenHeader = "X-Cart-Token"
+	cartTokenCookie = "cart_token"
+	cartTokenMaxAge = 30 * 24 * 60 * 60
+)
+
+// guestCartToken reads the guest cart token from the request header, falling
+// back to the cookie set by earlier responses
+func guestCartToken(c *gin.Context) string {
+	if token := c.GetHeader(CartTokenHeader); token != "" {
+		return token
+	}
+
+	token, _ := c.Cookie(cartTokenCookie)
+	return token
+}
+
+func setGuestCartToken(c *gin.Context, token string) {
+	c.Header(CartTokenHeader, token)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(cartTokenCookie, token, cartTokenMaxAge, "/", "", c.Request.TLS != nil, true)
+}
+
+func clearGuestCartToken(c *gin.Context) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(cartTokenCookie, "", -1, "/", "", c.Request.TLS != nil, true)
+}
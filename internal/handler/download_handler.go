@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/services"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type DownloadHandler struct {
+	downloadService *services.DownloadService
+}
+
+func NewDownloadHandler(downloadService *services.DownloadService) *DownloadHandler {
+	return &DownloadHandler{
+		downloadService: downloadService,
+	}
+}
+
+func (h *DownloadHandler) GetOrderDownloads(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	links, err := h.downloadService.GetOrderDownloads(userID, uint(orderID))
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Downloads fetched successfully", links)
+}
+
+// Download streams a purchased file. The signed link is the only credential,
+// so it works from emails and download managers without a session.
+func (h *DownloadHandler) Download(c *gin.Context) {
+	itemID, err := strconv.ParseUint(c.Param("itemId"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order item ID", err)
+		return
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("fileId"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid file ID", err)
+		return
+	}
+
+	var query dto.DownloadQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.ForbiddenResponse(c, "Invalid download link")
+		return
+	}
+
+	file, err := h.downloadService.OpenDownload(uint(itemID), uint(fileID), query)
+	if err != nil {
+		utils.ForbiddenResponse(c, err.Error())
+		return
+	}
+	defer file.Content.Close()
+
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.DataFromReader(http.StatusOK, file.Size, contentType, file.Content, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", file.FileName),
+	})
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"strconv"
 
+	"github.com/JihadRinaldi/go-shop/internal/dto"
 	"github.com/JihadRinaldi/go-shop/internal/services"
 	"github.com/JihadRinaldi/go-shop/internal/utils"
 	"github.com/gin-gonic/gin"
@@ -18,9 +21,49 @@ func NewOrderHandler(orderService *services.OrderService) *OrderHandler {
 	}
 }
 
+// CreateOrder checks out the cart. The request body is optional; without one
+// the whole cart is ordered.
 func (h *OrderHandler) CreateOrder(c *gin.Context) {
 	userID := c.GetUint("user_id")
-	orderResponse, err := h.orderService.CreateOrder(userID)
+
+	var req dto.CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	orderResponse, err := h.orderService.CreateOrder(userID, req)
+	if errors.Is(err, services.ErrEmailNotVerified) {
+		utils.ForbiddenResponse(c, "Verify your email address before placing an order")
+		return
+	}
+	var validationErr *services.CartValidationError
+	if errors.As(err, &validationErr) {
+		utils.ConflictResponse(c, "Cart needs review before checkout", err, validationErr.Items)
+		return
+	}
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to create order", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Order created successfully", orderResponse)
+}
+
+func (h *OrderHandler) BuyNow(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.BuyNowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	orderResponse, err := h.orderService.BuyNow(userID, req)
+	if errors.Is(err, services.ErrEmailNotVerified) {
+		utils.ForbiddenResponse(c, "Verify your email address before placing an order")
+		return
+	}
 	if err != nil {
 		utils.BadRequestResponse(c, "Failed to create order", err)
 		return
@@ -60,3 +103,42 @@ func (h *OrderHandler) GetOrders(c *gin.Context) {
 
 	utils.PaginatedSuccessResponse(c, "Orders fetched successfully", orders, *meta)
 }
+
+func (h *OrderHandler) Reorder(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	response, err := h.orderService.Reorder(userID, uint(orderID))
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Order items added to cart", response)
+}
+
+func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	var req dto.UpdateOrderStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	response, err := h.orderService.UpdateOrderStatus(uint(orderID), req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Order status updated", response)
+}
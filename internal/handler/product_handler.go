@@ -1,7 +1,12 @@
 package handler
 
 import (
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/JihadRinaldi/go-shop/internal/dto"
 	"github.com/JihadRinaldi/go-shop/internal/services"
@@ -9,6 +14,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+var attributeFilterPattern = regexp.MustCompile(`^attr\[([a-z][a-z0-9_]*)\](?:\[(eq|in|gt|gte|lt|lte)\])?$`)
+
 type ProductHandler struct {
 	productService *services.ProductService
 	uploadService  *services.UploadService
@@ -30,7 +37,7 @@ func (h *ProductHandler) CreateCategory(c *gin.Context) {
 
 	category, err := h.productService.CreateCategory(&req)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to create category", err)
+		utils.BadRequestResponse(c, "Failed to create category", err)
 		return
 	}
 
@@ -47,6 +54,31 @@ func (h *ProductHandler) GetCategories(c *gin.Context) {
 	utils.SuccessResponse(c, "Categories fetched", categories)
 }
 
+func (h *ProductHandler) GetCategoryTree(c *gin.Context) {
+	tree, err := h.productService.GetCategoryTree()
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch category tree", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Category tree fetched", tree)
+}
+
+func (h *ProductHandler) GetCategoryBySlug(c *gin.Context) {
+	category, currentSlug, err := h.productService.GetCategoryBySlug(c.Param("slug"))
+	if err != nil {
+		utils.NotFoundResponse(c, "Category not found")
+		return
+	}
+
+	if currentSlug != "" {
+		c.Redirect(http.StatusMovedPermanently, "/api/v1/categories/slug/"+currentSlug)
+		return
+	}
+
+	utils.SuccessResponse(c, "Category fetched", category)
+}
+
 func (h *ProductHandler) UpdateCategory(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -62,7 +94,7 @@ func (h *ProductHandler) UpdateCategory(c *gin.Context) {
 
 	category, err := h.productService.UpdateCategory(uint(id), &req)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to update category", err)
+		utils.BadRequestResponse(c, "Failed to update category", err)
 		return
 	}
 
@@ -76,14 +108,103 @@ func (h *ProductHandler) DeleteCategory(c *gin.Context) {
 		return
 	}
 
-	if err := h.productService.DeleteCategory(uint(id)); err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to delete category", err)
+	reparent, _ := strconv.ParseBool(c.DefaultQuery("reparent", "false"))
+
+	if err := h.productService.DeleteCategory(uint(id), reparent); err != nil {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to delete category", err)
 		return
 	}
 
 	utils.SuccessResponse(c, "Category deleted", nil)
 }
 
+func (h *ProductHandler) CreateCategoryAttribute(c *gin.Context) {
+	categoryID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid category ID", err)
+		return
+	}
+
+	var req dto.CreateCategoryAttributeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	attribute, err := h.productService.CreateCategoryAttribute(uint(categoryID), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to create attribute", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Attribute created", attribute)
+}
+
+func (h *ProductHandler) GetCategoryAttributes(c *gin.Context) {
+	categoryID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid category ID", err)
+		return
+	}
+
+	attributes, err := h.productService.GetCategoryAttributes(uint(categoryID))
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch attributes", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Attributes fetched", attributes)
+}
+
+func (h *ProductHandler) UpdateCategoryAttribute(c *gin.Context) {
+	categoryID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid category ID", err)
+		return
+	}
+
+	attributeID, err := strconv.ParseUint(c.Param("attributeId"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid attribute ID", err)
+		return
+	}
+
+	var req dto.UpdateCategoryAttributeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	attribute, err := h.productService.UpdateCategoryAttribute(uint(categoryID), uint(attributeID), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to update attribute", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Attribute updated", attribute)
+}
+
+func (h *ProductHandler) DeleteCategoryAttribute(c *gin.Context) {
+	categoryID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid category ID", err)
+		return
+	}
+
+	attributeID, err := strconv.ParseUint(c.Param("attributeId"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid attribute ID", err)
+		return
+	}
+
+	if err := h.productService.DeleteCategoryAttribute(uint(categoryID), uint(attributeID)); err != nil {
+		utils.NotFoundResponse(c, "Attribute not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Attribute deleted", nil)
+}
+
 func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	var req dto.CreateProductRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -93,7 +214,7 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 
 	product, err := h.productService.CreateProduct(&req)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to create product", err)
+		utils.BadRequestResponse(c, "Failed to create product", err)
 		return
 	}
 
@@ -104,7 +225,20 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	products, meta, err := h.productService.GetProducts(page, limit)
+	var query dto.ProductListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.BadRequestResponse(c, "Invalid query parameters", err)
+		return
+	}
+
+	attributeFilters, err := parseAttributeFilters(c.Request.URL.Query())
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid attribute filter", err)
+		return
+	}
+	query.Attributes = attributeFilters
+
+	products, meta, err := h.productService.GetProducts(page, limit, &query)
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to fetch products", err)
 		return
@@ -129,6 +263,21 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 	utils.SuccessResponse(c, "Product fetched", product)
 }
 
+func (h *ProductHandler) GetProductBySlug(c *gin.Context) {
+	product, currentSlug, err := h.productService.GetProductBySlug(c.Param("slug"))
+	if err != nil {
+		utils.NotFoundResponse(c, "Product not found")
+		return
+	}
+
+	if currentSlug != "" {
+		c.Redirect(http.StatusMovedPermanently, "/api/v1/products/slug/"+currentSlug)
+		return
+	}
+
+	utils.SuccessResponse(c, "Product fetched", product)
+}
+
 func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -144,13 +293,35 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 
 	product, err := h.productService.UpdateProduct(uint(id), &req)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to update product", err)
+		utils.BadRequestResponse(c, "Failed to update product", err)
 		return
 	}
 
 	utils.SuccessResponse(c, "Product updated", product)
 }
 
+func (h *ProductHandler) SetBundleItems(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.SetBundleItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	product, err := h.productService.SetBundleItems(uint(id), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Bundle updated", product)
+}
+
 func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -192,3 +363,55 @@ func (s *ProductHandler) UploadProductImage(c *gin.Context) {
 
 	utils.SuccessResponse(c, "Image uploaded successfully", map[string]string{"url": url})
 }
+
+func (h *ProductHandler) UploadProductFile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		utils.BadRequestResponse(c, "No file uploaded", err)
+		return
+	}
+
+	productFile, err := h.uploadService.UploadProductFile(uint(id), file)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.CreatedResponse(c, "File uploaded successfully", productFile)
+}
+
+// parseAttributeFilters collects attr[code]=value and attr[code][op]=value
+// query parameters into attribute filters
+func parseAttributeFilters(query url.Values) ([]dto.AttributeFilter, error) {
+	var filters []dto.AttributeFilter
+	for key, values := range query {
+		if !strings.HasPrefix(key, "attr[") {
+			continue
+		}
+
+		matches := attributeFilterPattern.FindStringSubmatch(key)
+		if matches == nil {
+			return nil, errors.New("unsupported filter: " + key)
+		}
+
+		operator := matches[2]
+		if operator == "" {
+			operator = "eq"
+		}
+
+		for _, value := range values {
+			filters = append(filters, dto.AttributeFilter{
+				Code:     matches[1],
+				Operator: operator,
+				Value:    value,
+			})
+		}
+	}
+	return filters, nil
+}
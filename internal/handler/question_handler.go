@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/services"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type QuestionHandler struct {
+	questionService *services.QuestionService
+}
+
+func NewQuestionHandler(questionService *services.QuestionService) *QuestionHandler {
+	return &QuestionHandler{
+		questionService: questionService,
+	}
+}
+
+func (h *QuestionHandler) AskQuestion(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.CreateQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	question, err := h.questionService.AskQuestion(userID, uint(productID), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to submit question", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Question submitted for moderation", question)
+}
+
+func (h *QuestionHandler) GetProductQuestions(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	questions, meta, err := h.questionService.GetProductQuestions(uint(productID), page, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch questions", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Questions fetched", questions, *meta)
+}
+
+func (h *QuestionHandler) AnswerQuestion(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	isStaff := models.HasPermission(c.GetStringSlice("user_permissions"), models.PermissionQuestionsAnswer)
+
+	questionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid question ID", err)
+		return
+	}
+
+	var req dto.CreateAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	answer, err := h.questionService.AnswerQuestion(userID, isStaff, uint(questionID), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to submit answer", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Answer submitted", answer)
+}
+
+func (h *QuestionHandler) UpvoteQuestion(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	questionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid question ID", err)
+		return
+	}
+
+	if err := h.questionService.UpvoteQuestion(userID, uint(questionID)); err != nil {
+		utils.BadRequestResponse(c, "Failed to upvote question", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Question upvoted", nil)
+}
+
+func (h *QuestionHandler) RemoveQuestionUpvote(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	questionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid question ID", err)
+		return
+	}
+
+	if err := h.questionService.RemoveQuestionUpvote(userID, uint(questionID)); err != nil {
+		utils.BadRequestResponse(c, "Failed to remove upvote", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Upvote removed", nil)
+}
+
+func (h *QuestionHandler) UpvoteAnswer(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	answerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid answer ID", err)
+		return
+	}
+
+	if err := h.questionService.UpvoteAnswer(userID, uint(answerID)); err != nil {
+		utils.BadRequestResponse(c, "Failed to upvote answer", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Answer upvoted", nil)
+}
+
+func (h *QuestionHandler) RemoveAnswerUpvote(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	answerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid answer ID", err)
+		return
+	}
+
+	if err := h.questionService.RemoveAnswerUpvote(userID, uint(answerID)); err != nil {
+		utils.BadRequestResponse(c, "Failed to remove upvote", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Upvote removed", nil)
+}
+
+func (h *QuestionHandler) GetQuestionsForModeration(c *gin.Context) {
+	status := c.DefaultQuery("status", "pending")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	questions, meta, err := h.questionService.GetQuestionsByStatus(status, page, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch questions", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Questions fetched", questions, *meta)
+}
+
+func (h *QuestionHandler) GetAnswersForModeration(c *gin.Context) {
+	status := c.DefaultQuery("status", "pending")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	answers, meta, err := h.questionService.GetAnswersByStatus(status, page, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch answers", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Answers fetched", answers, *meta)
+}
+
+func (h *QuestionHandler) ModerateQuestion(c *gin.Context) {
+	questionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid question ID", err)
+		return
+	}
+
+	var req dto.ModerateQARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	question, err := h.questionService.ModerateQuestion(uint(questionID), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to moderate question", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Question moderated", question)
+}
+
+func (h *QuestionHandler) ModerateAnswer(c *gin.Context) {
+	answerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid answer ID", err)
+		return
+	}
+
+	var req dto.ModerateQARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	answer, err := h.questionService.ModerateAnswer(uint(answerID), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to moderate answer", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Answer moderated", answer)
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/services"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewHandler struct {
+	reviewService *services.ReviewService
+	uploadService *services.UploadService
+}
+
+func NewReviewHandler(reviewService *services.ReviewService, uploadService *services.UploadService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+		uploadService: uploadService,
+	}
+}
+
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	review, err := h.reviewService.CreateReview(userID, uint(productID), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to create review", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Review submitted for moderation", review)
+}
+
+func (h *ReviewHandler) GetProductReviews(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	reviews, meta, err := h.reviewService.GetProductReviews(uint(productID), page, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch reviews", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Reviews fetched", reviews, *meta)
+}
+
+func (h *ReviewHandler) UploadReviewImage(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	reviewID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid review ID", err)
+		return
+	}
+
+	if _, err := h.reviewService.GetOwnReview(userID, uint(reviewID)); err != nil {
+		utils.NotFoundResponse(c, "Review not found")
+		return
+	}
+
+	file, err := c.FormFile("image")
+	if err != nil {
+		utils.BadRequestResponse(c, "No file uploaded", err)
+		return
+	}
+
+	url, err := h.uploadService.UploadReviewImage(uint(reviewID), file)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to upload image", err)
+		return
+	}
+
+	review, err := h.reviewService.AddReviewImage(userID, uint(reviewID), url)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to save image record", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Image uploaded successfully", review)
+}
+
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	reviewID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid review ID", err)
+		return
+	}
+
+	if err := h.reviewService.DeleteReview(userID, uint(reviewID)); err != nil {
+		utils.NotFoundResponse(c, "Review not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Review deleted", nil)
+}
+
+func (h *ReviewHandler) GetReviewsForModeration(c *gin.Context) {
+	status := c.DefaultQuery("status", "pending")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	reviews, meta, err := h.reviewService.GetReviewsByStatus(status, page, limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch reviews", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Reviews fetched", reviews, *meta)
+}
+
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	reviewID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid review ID", err)
+		return
+	}
+
+	var req dto.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	review, err := h.reviewService.ModerateReview(uint(reviewID), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to moderate review", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Review moderated", review)
+}
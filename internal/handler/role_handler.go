@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/services"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandler struct {
+	roleService *services.RoleService
+}
+
+func NewRoleHandler(roleService *services.RoleService) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+	}
+}
+
+func (h *RoleHandler) GetPermissions(c *gin.Context) {
+	utils.SuccessResponse(c, "Permissions fetched", h.roleService.GetPermissions())
+}
+
+func (h *RoleHandler) GetRoles(c *gin.Context) {
+	roles, err := h.roleService.GetRoles()
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch roles", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Roles fetched", roles)
+}
+
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	role, err := h.roleService.CreateRole(&req, c.GetStringSlice("user_permissions"))
+	if errors.Is(err, services.ErrPrivilegedRole) {
+		utils.ForbiddenResponse(c, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrRoleExists) {
+		utils.ConflictResponse(c, "Role already exists", err, nil)
+		return
+	}
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to create role", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Role created", role)
+}
+
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	roleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid role ID", err)
+		return
+	}
+
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	role, err := h.roleService.UpdateRole(uint(roleID), &req, c.GetStringSlice("user_permissions"))
+	if errors.Is(err, services.ErrRoleNotFound) {
+		utils.NotFoundResponse(c, "Role not found")
+		return
+	}
+	if errors.Is(err, services.ErrBuiltInRole) || errors.Is(err, services.ErrPrivilegedRole) {
+		utils.ForbiddenResponse(c, err.Error())
+		return
+	}
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to update role", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Role updated", role)
+}
+
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	roleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid role ID", err)
+		return
+	}
+
+	err = h.roleService.DeleteRole(uint(roleID), c.GetStringSlice("user_permissions"))
+	if errors.Is(err, services.ErrRoleNotFound) {
+		utils.NotFoundResponse(c, "Role not found")
+		return
+	}
+	if errors.Is(err, services.ErrBuiltInRole) || errors.Is(err, services.ErrPrivilegedRole) {
+		utils.ForbiddenResponse(c, err.Error())
+		return
+	}
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to delete role", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Role deleted", nil)
+}
+
+func (h *RoleHandler) GetUserRoles(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	roles, err := h.roleService.GetUserRoles(uint(userID))
+	if errors.Is(err, services.ErrUserNotFound) {
+		utils.NotFoundResponse(c, "User not found")
+		return
+	}
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch roles", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Roles fetched", roles)
+}
+
+func (h *RoleHandler) AssignRole(c *gin.Context) {
+	userID, roleID, ok := userRoleParams(c)
+	if !ok {
+		return
+	}
+
+	err := h.roleService.AssignRole(userID, roleID, c.GetStringSlice("user_permissions"))
+	if errors.Is(err, services.ErrUserNotFound) {
+		utils.NotFoundResponse(c, "User not found")
+		return
+	}
+	if errors.Is(err, services.ErrRoleNotFound) {
+		utils.NotFoundResponse(c, "Role not found")
+		return
+	}
+	if errors.Is(err, services.ErrPrivilegedRole) {
+		utils.ForbiddenResponse(c, err.Error())
+		return
+	}
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to assign role", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Role assigned", nil)
+}
+
+func (h *RoleHandler) RemoveRole(c *gin.Context) {
+	userID, roleID, ok := userRoleParams(c)
+	if !ok {
+		return
+	}
+
+	err := h.roleService.RemoveRole(userID, roleID, c.GetStringSlice("user_permissions"))
+	if errors.Is(err, services.ErrRoleNotFound) {
+		utils.NotFoundResponse(c, "Role not found")
+		return
+	}
+	if errors.Is(err, services.ErrPrivilegedRole) {
+		utils.ForbiddenResponse(c, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrRoleNotAssigned) {
+		utils.NotFoundResponse(c, "User does not have this role")
+		return
+	}
+	if errors.Is(err, services.ErrLastAdmin) {
+		utils.ConflictResponse(c, "Failed to remove role", err, nil)
+		return
+	}
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to remove role", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Role removed", nil)
+}
+
+// userRoleParams reads the user and role IDs of the path, answering with 400
+// when either is invalid
+func userRoleParams(c *gin.Context) (uint, uint, bool) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return 0, 0, false
+	}
+
+	roleID, err := strconv.ParseUint(c.Param("roleId"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid role ID", err)
+		return 0, 0, false
+	}
+
+	return uint(userID), uint(roleID), true
+}
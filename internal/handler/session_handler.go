@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/JihadRinaldi/go-shop/internal/services"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type SessionHandler struct {
+	sessionService *services.SessionService
+}
+
+func NewSessionHandler(sessionService *services.SessionService) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+func (h *SessionHandler) GetSessions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	sessions, err := h.sessionService.GetSessions(userID, c.GetString("session_id"))
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch sessions", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Sessions fetched", sessions)
+}
+
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	err := h.sessionService.RevokeSession(userID, c.Param("id"))
+	if errors.Is(err, services.ErrSessionNotFound) {
+		utils.NotFoundResponse(c, "Session not found")
+		return
+	}
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to revoke session", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Session revoked", nil)
+}
+
+// RevokeAllSessions logs the user out everywhere, this device included
+func (h *SessionHandler) RevokeAllSessions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	if err := h.sessionService.RevokeAllSessions(userID); err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to revoke sessions", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Signed out of every session", nil)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/services"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type SubscriptionHandler struct {
+	subscriptionService *services.SubscriptionService
+}
+
+func NewSubscriptionHandler(subscriptionService *services.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subscriptionService: subscriptionService,
+	}
+}
+
+func (h *SubscriptionHandler) GetSubscriptions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	subscriptions, err := h.subscriptionService.GetSubscriptions(userID)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch subscriptions", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Subscriptions fetched", subscriptions)
+}
+
+func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.CreateSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	subscription, err := h.subscriptionService.CreateSubscription(userID, &req)
+	if errors.Is(err, services.ErrEmailNotVerified) {
+		utils.ForbiddenResponse(c, "Verify your email address before subscribing")
+		return
+	}
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to create subscription", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Subscription created", subscription)
+}
+
+func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	subscriptionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid subscription ID", err)
+		return
+	}
+
+	subscription, err := h.subscriptionService.GetSubscription(userID, uint(subscriptionID))
+	if err != nil {
+		utils.NotFoundResponse(c, "Subscription not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Subscription fetched", subscription)
+}
+
+func (h *SubscriptionHandler) SkipNextDelivery(c *gin.Context) {
+	h.changeSubscription(c, h.subscriptionService.SkipNextDelivery, "Next delivery skipped")
+}
+
+func (h *SubscriptionHandler) PauseSubscription(c *gin.Context) {
+	h.changeSubscription(c, h.subscriptionService.PauseSubscription, "Subscription paused")
+}
+
+func (h *SubscriptionHandler) ResumeSubscription(c *gin.Context) {
+	h.changeSubscription(c, h.subscriptionService.ResumeSubscription, "Subscription resumed")
+}
+
+func (h *SubscriptionHandler) CancelSubscription(c *gin.Context) {
+	h.changeSubscription(c, h.subscriptionService.CancelSubscription, "Subscription cancelled")
+}
+
+func (h *SubscriptionHandler) changeSubscription(c *gin.Context, change func(userID, subscriptionID uint) (*dto.SubscriptionResponse, error), message string) {
+	userID := c.GetUint("user_id")
+
+	subscriptionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid subscription ID", err)
+		return
+	}
+
+	subscription, err := change(userID, uint(subscriptionID))
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, message, subscription)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/services"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService *services.TwoFactorService
+}
+
+func NewTwoFactorHandler(twoFactorService *services.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorService: twoFactorService,
+	}
+}
+
+func (h *TwoFactorHandler) Enroll(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var req dto.EnrollTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	enrollment, err := h.twoFactorService.Enroll(userID, &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Add the account to your authenticator app and confirm with a code", enrollment)
+}
+
+// Confirm returns the recovery codes, the only time they can be read
+func (h *TwoFactorHandler) Confirm(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var req dto.ConfirmTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	codes, err := h.twoFactorService.Confirm(userID, c.GetString("session_id"), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Two-factor authentication enabled", codes)
+}
+
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var req dto.DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	err := h.twoFactorService.Disable(userID, &req)
+	if errors.Is(err, services.ErrTwoFactorRequired) {
+		utils.ForbiddenResponse(c, "Two-factor authentication is required for admin accounts")
+		return
+	}
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Two-factor authentication disabled", nil)
+}
+
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var req dto.RegenerateRecoveryCodesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(userID, &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Recovery codes regenerated", codes)
+}
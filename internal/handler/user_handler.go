@@ -44,3 +44,52 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	utils.SuccessResponse(c, "Profile updated", profile)
 }
+
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	if err := h.userService.ChangePassword(userID, c.GetString("session_id"), &req); err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Password changed", nil)
+}
+
+func (h *UserHandler) RequestEmailChange(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var req dto.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	if err := h.userService.RequestEmailChange(userID, &req); err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "A confirmation link has been sent to the new address", nil)
+}
+
+func (h *UserHandler) ConfirmEmailChange(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	var req dto.ConfirmEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	profile, err := h.userService.ConfirmEmailChange(userID, c.GetString("session_id"), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Email address changed", profile)
+}
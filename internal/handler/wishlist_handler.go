@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/services"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type WishlistHandler struct {
+	wishlistService *services.WishlistService
+}
+
+func NewWishlistHandler(wishlistService *services.WishlistService) *WishlistHandler {
+	return &WishlistHandler{
+		wishlistService: wishlistService,
+	}
+}
+
+func (h *WishlistHandler) GetWishlists(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	wishlists, err := h.wishlistService.GetWishlists(userID)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to fetch wishlists", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Wishlists fetched", wishlists)
+}
+
+func (h *WishlistHandler) CreateWishlist(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.CreateWishlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	wishlist, err := h.wishlistService.CreateWishlist(userID, &req)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to create wishlist", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Wishlist created", wishlist)
+}
+
+func (h *WishlistHandler) GetWishlist(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid wishlist ID", err)
+		return
+	}
+
+	wishlist, err := h.wishlistService.GetWishlist(userID, uint(wishlistID))
+	if err != nil {
+		utils.NotFoundResponse(c, "Wishlist not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Wishlist fetched", wishlist)
+}
+
+func (h *WishlistHandler) UpdateWishlist(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid wishlist ID", err)
+		return
+	}
+
+	var req dto.UpdateWishlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	wishlist, err := h.wishlistService.UpdateWishlist(userID, uint(wishlistID), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to update wishlist", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Wishlist updated", wishlist)
+}
+
+func (h *WishlistHandler) DeleteWishlist(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid wishlist ID", err)
+		return
+	}
+
+	if err := h.wishlistService.DeleteWishlist(userID, uint(wishlistID)); err != nil {
+		utils.NotFoundResponse(c, "Wishlist not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Wishlist deleted", nil)
+}
+
+func (h *WishlistHandler) AddItem(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid wishlist ID", err)
+		return
+	}
+
+	var req dto.AddWishlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	wishlist, err := h.wishlistService.AddItem(userID, uint(wishlistID), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Item added to wishlist", wishlist)
+}
+
+func (h *WishlistHandler) RemoveItem(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid wishlist ID", err)
+		return
+	}
+
+	itemID, err := strconv.ParseUint(c.Param("itemId"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid item ID", err)
+		return
+	}
+
+	if err := h.wishlistService.RemoveItem(userID, uint(wishlistID), uint(itemID)); err != nil {
+		utils.NotFoundResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Item removed from wishlist", nil)
+}
+
+func (h *WishlistHandler) MoveToCart(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid wishlist ID", err)
+		return
+	}
+
+	itemID, err := strconv.ParseUint(c.Param("itemId"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid item ID", err)
+		return
+	}
+
+	var req dto.MoveToCartRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequestResponse(c, "Invalid request data", err)
+			return
+		}
+	}
+
+	cart, err := h.wishlistService.MoveToCart(userID, uint(wishlistID), uint(itemID), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error(), err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Item moved to cart", cart)
+}
+
+func (h *WishlistHandler) ShareWishlist(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid wishlist ID", err)
+		return
+	}
+
+	wishlist, err := h.wishlistService.ShareWishlist(userID, uint(wishlistID))
+	if err != nil {
+		utils.NotFoundResponse(c, "Wishlist not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Wishlist shared", wishlist)
+}
+
+func (h *WishlistHandler) UnshareWishlist(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	wishlistID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid wishlist ID", err)
+		return
+	}
+
+	wishlist, err := h.wishlistService.UnshareWishlist(userID, uint(wishlistID))
+	if err != nil {
+		utils.NotFoundResponse(c, "Wishlist not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Wishlist sharing disabled", wishlist)
+}
+
+func (h *WishlistHandler) GetSharedWishlist(c *gin.Context) {
+	wishlist, err := h.wishlistService.GetSharedWishlist(c.Param("token"))
+	if err != nil {
+		utils.NotFoundResponse(c, "Wishlist not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Wishlist fetched", wishlist)
+}
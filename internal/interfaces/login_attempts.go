@@ -0,0 +1,16 @@
+package interfaces
+
+import "time"
+
+// LoginAttemptStore keeps failed login counters where every API instance sees
+// them. A key names what is throttled, an account or a client IP.
+type LoginAttemptStore interface {
+	// RecordFailure counts a failed attempt and returns the failures within
+	// window, this one included
+	RecordFailure(key string, now time.Time, window time.Duration) (int, error)
+	// BlockedUntil returns when the key may try again, the zero time when it
+	// is not blocked
+	BlockedUntil(key string) (time.Time, error)
+	Block(key string, until time.Time) error
+	Reset(key string) error
+}
@@ -1,8 +1,12 @@
 package interfaces
 
-import "mime/multipart"
+import (
+	"io"
+	"mime/multipart"
+)
 
 type UploadProvider interface {
 	UploadFile(file *multipart.FileHeader, path string) (string, error)
 	DeleteFile(path string) error
+	OpenFile(path string) (io.ReadCloser, error)
 }
@@ -0,0 +1,336 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/JihadRinaldi/go-shop/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAttributeRepositoryInterface is an autogenerated mock type for the AttributeRepositoryInterface type
+type MockAttributeRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockAttributeRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAttributeRepositoryInterface) EXPECT() *MockAttributeRepositoryInterface_Expecter {
+	return &MockAttributeRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreateCategoryAttribute provides a mock function with given fields: attribute
+func (_m *MockAttributeRepositoryInterface) CreateCategoryAttribute(attribute *models.CategoryAttribute) error {
+	ret := _m.Called(attribute)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCategoryAttribute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.CategoryAttribute) error); ok {
+		r0 = rf(attribute)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAttributeRepositoryInterface_CreateCategoryAttribute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCategoryAttribute'
+type MockAttributeRepositoryInterface_CreateCategoryAttribute_Call struct {
+	*mock.Call
+}
+
+// CreateCategoryAttribute is a helper method to define mock.On call
+//   - attribute *models.CategoryAttribute
+func (_e *MockAttributeRepositoryInterface_Expecter) CreateCategoryAttribute(attribute interface{}) *MockAttributeRepositoryInterface_CreateCategoryAttribute_Call {
+	return &MockAttributeRepositoryInterface_CreateCategoryAttribute_Call{Call: _e.mock.On("CreateCategoryAttribute", attribute)}
+}
+
+func (_c *MockAttributeRepositoryInterface_CreateCategoryAttribute_Call) Run(run func(attribute *models.CategoryAttribute)) *MockAttributeRepositoryInterface_CreateCategoryAttribute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.CategoryAttribute))
+	})
+	return _c
+}
+
+func (_c *MockAttributeRepositoryInterface_CreateCategoryAttribute_Call) Return(_a0 error) *MockAttributeRepositoryInterface_CreateCategoryAttribute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAttributeRepositoryInterface_CreateCategoryAttribute_Call) RunAndReturn(run func(*models.CategoryAttribute) error) *MockAttributeRepositoryInterface_CreateCategoryAttribute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCategoryAttribute provides a mock function with given fields: id
+func (_m *MockAttributeRepositoryInterface) DeleteCategoryAttribute(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategoryAttribute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAttributeRepositoryInterface_DeleteCategoryAttribute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCategoryAttribute'
+type MockAttributeRepositoryInterface_DeleteCategoryAttribute_Call struct {
+	*mock.Call
+}
+
+// DeleteCategoryAttribute is a helper method to define mock.On call
+//   - id uint
+func (_e *MockAttributeRepositoryInterface_Expecter) DeleteCategoryAttribute(id interface{}) *MockAttributeRepositoryInterface_DeleteCategoryAttribute_Call {
+	return &MockAttributeRepositoryInterface_DeleteCategoryAttribute_Call{Call: _e.mock.On("DeleteCategoryAttribute", id)}
+}
+
+func (_c *MockAttributeRepositoryInterface_DeleteCategoryAttribute_Call) Run(run func(id uint)) *MockAttributeRepositoryInterface_DeleteCategoryAttribute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockAttributeRepositoryInterface_DeleteCategoryAttribute_Call) Return(_a0 error) *MockAttributeRepositoryInterface_DeleteCategoryAttribute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAttributeRepositoryInterface_DeleteCategoryAttribute_Call) RunAndReturn(run func(uint) error) *MockAttributeRepositoryInterface_DeleteCategoryAttribute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCategoryAttributeByID provides a mock function with given fields: id
+func (_m *MockAttributeRepositoryInterface) GetCategoryAttributeByID(id uint) (*models.CategoryAttribute, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategoryAttributeByID")
+	}
+
+	var r0 *models.CategoryAttribute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*models.CategoryAttribute, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *models.CategoryAttribute); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CategoryAttribute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAttributeRepositoryInterface_GetCategoryAttributeByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCategoryAttributeByID'
+type MockAttributeRepositoryInterface_GetCategoryAttributeByID_Call struct {
+	*mock.Call
+}
+
+// GetCategoryAttributeByID is a helper method to define mock.On call
+//   - id uint
+func (_e *MockAttributeRepositoryInterface_Expecter) GetCategoryAttributeByID(id interface{}) *MockAttributeRepositoryInterface_GetCategoryAttributeByID_Call {
+	return &MockAttributeRepositoryInterface_GetCategoryAttributeByID_Call{Call: _e.mock.On("GetCategoryAttributeByID", id)}
+}
+
+func (_c *MockAttributeRepositoryInterface_GetCategoryAttributeByID_Call) Run(run func(id uint)) *MockAttributeRepositoryInterface_GetCategoryAttributeByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockAttributeRepositoryInterface_GetCategoryAttributeByID_Call) Return(_a0 *models.CategoryAttribute, _a1 error) *MockAttributeRepositoryInterface_GetCategoryAttributeByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAttributeRepositoryInterface_GetCategoryAttributeByID_Call) RunAndReturn(run func(uint) (*models.CategoryAttribute, error)) *MockAttributeRepositoryInterface_GetCategoryAttributeByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCategoryAttributes provides a mock function with given fields: categoryID
+func (_m *MockAttributeRepositoryInterface) GetCategoryAttributes(categoryID uint) ([]models.CategoryAttribute, error) {
+	ret := _m.Called(categoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategoryAttributes")
+	}
+
+	var r0 []models.CategoryAttribute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]models.CategoryAttribute, error)); ok {
+		return rf(categoryID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []models.CategoryAttribute); ok {
+		r0 = rf(categoryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CategoryAttribute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(categoryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAttributeRepositoryInterface_GetCategoryAttributes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCategoryAttributes'
+type MockAttributeRepositoryInterface_GetCategoryAttributes_Call struct {
+	*mock.Call
+}
+
+// GetCategoryAttributes is a helper method to define mock.On call
+//   - categoryID uint
+func (_e *MockAttributeRepositoryInterface_Expecter) GetCategoryAttributes(categoryID interface{}) *MockAttributeRepositoryInterface_GetCategoryAttributes_Call {
+	return &MockAttributeRepositoryInterface_GetCategoryAttributes_Call{Call: _e.mock.On("GetCategoryAttributes", categoryID)}
+}
+
+func (_c *MockAttributeRepositoryInterface_GetCategoryAttributes_Call) Run(run func(categoryID uint)) *MockAttributeRepositoryInterface_GetCategoryAttributes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockAttributeRepositoryInterface_GetCategoryAttributes_Call) Return(_a0 []models.CategoryAttribute, _a1 error) *MockAttributeRepositoryInterface_GetCategoryAttributes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAttributeRepositoryInterface_GetCategoryAttributes_Call) RunAndReturn(run func(uint) ([]models.CategoryAttribute, error)) *MockAttributeRepositoryInterface_GetCategoryAttributes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceProductAttributeValues provides a mock function with given fields: productID, values
+func (_m *MockAttributeRepositoryInterface) ReplaceProductAttributeValues(productID uint, values []models.ProductAttributeValue) error {
+	ret := _m.Called(productID, values)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceProductAttributeValues")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, []models.ProductAttributeValue) error); ok {
+		r0 = rf(productID, values)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAttributeRepositoryInterface_ReplaceProductAttributeValues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceProductAttributeValues'
+type MockAttributeRepositoryInterface_ReplaceProductAttributeValues_Call struct {
+	*mock.Call
+}
+
+// ReplaceProductAttributeValues is a helper method to define mock.On call
+//   - productID uint
+//   - values []models.ProductAttributeValue
+func (_e *MockAttributeRepositoryInterface_Expecter) ReplaceProductAttributeValues(productID interface{}, values interface{}) *MockAttributeRepositoryInterface_ReplaceProductAttributeValues_Call {
+	return &MockAttributeRepositoryInterface_ReplaceProductAttributeValues_Call{Call: _e.mock.On("ReplaceProductAttributeValues", productID, values)}
+}
+
+func (_c *MockAttributeRepositoryInterface_ReplaceProductAttributeValues_Call) Run(run func(productID uint, values []models.ProductAttributeValue)) *MockAttributeRepositoryInterface_ReplaceProductAttributeValues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].([]models.ProductAttributeValue))
+	})
+	return _c
+}
+
+func (_c *MockAttributeRepositoryInterface_ReplaceProductAttributeValues_Call) Return(_a0 error) *MockAttributeRepositoryInterface_ReplaceProductAttributeValues_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAttributeRepositoryInterface_ReplaceProductAttributeValues_Call) RunAndReturn(run func(uint, []models.ProductAttributeValue) error) *MockAttributeRepositoryInterface_ReplaceProductAttributeValues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCategoryAttribute provides a mock function with given fields: attribute
+func (_m *MockAttributeRepositoryInterface) UpdateCategoryAttribute(attribute *models.CategoryAttribute) error {
+	ret := _m.Called(attribute)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCategoryAttribute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.CategoryAttribute) error); ok {
+		r0 = rf(attribute)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAttributeRepositoryInterface_UpdateCategoryAttribute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCategoryAttribute'
+type MockAttributeRepositoryInterface_UpdateCategoryAttribute_Call struct {
+	*mock.Call
+}
+
+// UpdateCategoryAttribute is a helper method to define mock.On call
+//   - attribute *models.CategoryAttribute
+func (_e *MockAttributeRepositoryInterface_Expecter) UpdateCategoryAttribute(attribute interface{}) *MockAttributeRepositoryInterface_UpdateCategoryAttribute_Call {
+	return &MockAttributeRepositoryInterface_UpdateCategoryAttribute_Call{Call: _e.mock.On("UpdateCategoryAttribute", attribute)}
+}
+
+func (_c *MockAttributeRepositoryInterface_UpdateCategoryAttribute_Call) Run(run func(attribute *models.CategoryAttribute)) *MockAttributeRepositoryInterface_UpdateCategoryAttribute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.CategoryAttribute))
+	})
+	return _c
+}
+
+func (_c *MockAttributeRepositoryInterface_UpdateCategoryAttribute_Call) Return(_a0 error) *MockAttributeRepositoryInterface_UpdateCategoryAttribute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAttributeRepositoryInterface_UpdateCategoryAttribute_Call) RunAndReturn(run func(*models.CategoryAttribute) error) *MockAttributeRepositoryInterface_UpdateCategoryAttribute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAttributeRepositoryInterface creates a new instance of MockAttributeRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAttributeRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAttributeRepositoryInterface {
+	mock := &MockAttributeRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,294 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/JihadRinaldi/go-shop/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockCartReminderRepositoryInterface is an autogenerated mock type for the CartReminderRepositoryInterface type
+type MockCartReminderRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockCartReminderRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCartReminderRepositoryInterface) EXPECT() *MockCartReminderRepositoryInterface_Expecter {
+	return &MockCartReminderRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: reminder
+func (_m *MockCartReminderRepositoryInterface) Create(reminder *models.CartReminder) error {
+	ret := _m.Called(reminder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.CartReminder) error); ok {
+		r0 = rf(reminder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCartReminderRepositoryInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockCartReminderRepositoryInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - reminder *models.CartReminder
+func (_e *MockCartReminderRepositoryInterface_Expecter) Create(reminder interface{}) *MockCartReminderRepositoryInterface_Create_Call {
+	return &MockCartReminderRepositoryInterface_Create_Call{Call: _e.mock.On("Create", reminder)}
+}
+
+func (_c *MockCartReminderRepositoryInterface_Create_Call) Run(run func(reminder *models.CartReminder)) *MockCartReminderRepositoryInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.CartReminder))
+	})
+	return _c
+}
+
+func (_c *MockCartReminderRepositoryInterface_Create_Call) Return(_a0 error) *MockCartReminderRepositoryInterface_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCartReminderRepositoryInterface_Create_Call) RunAndReturn(run func(*models.CartReminder) error) *MockCartReminderRepositoryInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *MockCartReminderRepositoryInterface) Delete(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCartReminderRepositoryInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockCartReminderRepositoryInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uint
+func (_e *MockCartReminderRepositoryInterface_Expecter) Delete(id interface{}) *MockCartReminderRepositoryInterface_Delete_Call {
+	return &MockCartReminderRepositoryInterface_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *MockCartReminderRepositoryInterface_Delete_Call) Run(run func(id uint)) *MockCartReminderRepositoryInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockCartReminderRepositoryInterface_Delete_Call) Return(_a0 error) *MockCartReminderRepositoryInterface_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCartReminderRepositoryInterface_Delete_Call) RunAndReturn(run func(uint) error) *MockCartReminderRepositoryInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindAbandonedCarts provides a mock function with given fields: idleSince, remindedSince, limit
+func (_m *MockCartReminderRepositoryInterface) FindAbandonedCarts(idleSince time.Time, remindedSince time.Time, limit int) ([]models.Cart, error) {
+	ret := _m.Called(idleSince, remindedSince, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAbandonedCarts")
+	}
+
+	var r0 []models.Cart
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, int) ([]models.Cart, error)); ok {
+		return rf(idleSince, remindedSince, limit)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, int) []models.Cart); ok {
+		r0 = rf(idleSince, remindedSince, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Cart)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time, int) error); ok {
+		r1 = rf(idleSince, remindedSince, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCartReminderRepositoryInterface_FindAbandonedCarts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAbandonedCarts'
+type MockCartReminderRepositoryInterface_FindAbandonedCarts_Call struct {
+	*mock.Call
+}
+
+// FindAbandonedCarts is a helper method to define mock.On call
+//   - idleSince time.Time
+//   - remindedSince time.Time
+//   - limit int
+func (_e *MockCartReminderRepositoryInterface_Expecter) FindAbandonedCarts(idleSince interface{}, remindedSince interface{}, limit interface{}) *MockCartReminderRepositoryInterface_FindAbandonedCarts_Call {
+	return &MockCartReminderRepositoryInterface_FindAbandonedCarts_Call{Call: _e.mock.On("FindAbandonedCarts", idleSince, remindedSince, limit)}
+}
+
+func (_c *MockCartReminderRepositoryInterface_FindAbandonedCarts_Call) Run(run func(idleSince time.Time, remindedSince time.Time, limit int)) *MockCartReminderRepositoryInterface_FindAbandonedCarts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockCartReminderRepositoryInterface_FindAbandonedCarts_Call) Return(_a0 []models.Cart, _a1 error) *MockCartReminderRepositoryInterface_FindAbandonedCarts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCartReminderRepositoryInterface_FindAbandonedCarts_Call) RunAndReturn(run func(time.Time, time.Time, int) ([]models.Cart, error)) *MockCartReminderRepositoryInterface_FindAbandonedCarts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByToken provides a mock function with given fields: token
+func (_m *MockCartReminderRepositoryInterface) GetByToken(token string) (*models.CartReminder, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByToken")
+	}
+
+	var r0 *models.CartReminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.CartReminder, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.CartReminder); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CartReminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCartReminderRepositoryInterface_GetByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByToken'
+type MockCartReminderRepositoryInterface_GetByToken_Call struct {
+	*mock.Call
+}
+
+// GetByToken is a helper method to define mock.On call
+//   - token string
+func (_e *MockCartReminderRepositoryInterface_Expecter) GetByToken(token interface{}) *MockCartReminderRepositoryInterface_GetByToken_Call {
+	return &MockCartReminderRepositoryInterface_GetByToken_Call{Call: _e.mock.On("GetByToken", token)}
+}
+
+func (_c *MockCartReminderRepositoryInterface_GetByToken_Call) Run(run func(token string)) *MockCartReminderRepositoryInterface_GetByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockCartReminderRepositoryInterface_GetByToken_Call) Return(_a0 *models.CartReminder, _a1 error) *MockCartReminderRepositoryInterface_GetByToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCartReminderRepositoryInterface_GetByToken_Call) RunAndReturn(run func(string) (*models.CartReminder, error)) *MockCartReminderRepositoryInterface_GetByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRestored provides a mock function with given fields: id, restoredAt
+func (_m *MockCartReminderRepositoryInterface) MarkRestored(id uint, restoredAt time.Time) error {
+	ret := _m.Called(id, restoredAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRestored")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time) error); ok {
+		r0 = rf(id, restoredAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCartReminderRepositoryInterface_MarkRestored_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRestored'
+type MockCartReminderRepositoryInterface_MarkRestored_Call struct {
+	*mock.Call
+}
+
+// MarkRestored is a helper method to define mock.On call
+//   - id uint
+//   - restoredAt time.Time
+func (_e *MockCartReminderRepositoryInterface_Expecter) MarkRestored(id interface{}, restoredAt interface{}) *MockCartReminderRepositoryInterface_MarkRestored_Call {
+	return &MockCartReminderRepositoryInterface_MarkRestored_Call{Call: _e.mock.On("MarkRestored", id, restoredAt)}
+}
+
+func (_c *MockCartReminderRepositoryInterface_MarkRestored_Call) Run(run func(id uint, restoredAt time.Time)) *MockCartReminderRepositoryInterface_MarkRestored_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockCartReminderRepositoryInterface_MarkRestored_Call) Return(_a0 error) *MockCartReminderRepositoryInterface_MarkRestored_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCartReminderRepositoryInterface_MarkRestored_Call) RunAndReturn(run func(uint, time.Time) error) *MockCartReminderRepositoryInterface_MarkRestored_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCartReminderRepositoryInterface creates a new instance of MockCartReminderRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCartReminderRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCartReminderRepositoryInterface {
+	mock := &MockCartReminderRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
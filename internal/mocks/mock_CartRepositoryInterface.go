@@ -5,6 +5,8 @@ package mocks
 import (
 	models "github.com/JihadRinaldi/go-shop/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockCartRepositoryInterface is an autogenerated mock type for the CartRepositoryInterface type
@@ -66,6 +68,52 @@ func (_c *MockCartRepositoryInterface_Create_Call) RunAndReturn(run func(*models
 	return _c
 }
 
+// CreateItem provides a mock function with given fields: item
+func (_m *MockCartRepositoryInterface) CreateItem(item *models.CartItem) error {
+	ret := _m.Called(item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.CartItem) error); ok {
+		r0 = rf(item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCartRepositoryInterface_CreateItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateItem'
+type MockCartRepositoryInterface_CreateItem_Call struct {
+	*mock.Call
+}
+
+// CreateItem is a helper method to define mock.On call
+//   - item *models.CartItem
+func (_e *MockCartRepositoryInterface_Expecter) CreateItem(item interface{}) *MockCartRepositoryInterface_CreateItem_Call {
+	return &MockCartRepositoryInterface_CreateItem_Call{Call: _e.mock.On("CreateItem", item)}
+}
+
+func (_c *MockCartRepositoryInterface_CreateItem_Call) Run(run func(item *models.CartItem)) *MockCartRepositoryInterface_CreateItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.CartItem))
+	})
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_CreateItem_Call) Return(_a0 error) *MockCartRepositoryInterface_CreateItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_CreateItem_Call) RunAndReturn(run func(*models.CartItem) error) *MockCartRepositoryInterface_CreateItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: id
 func (_m *MockCartRepositoryInterface) Delete(id uint) error {
 	ret := _m.Called(id)
@@ -112,6 +160,111 @@ func (_c *MockCartRepositoryInterface_Delete_Call) RunAndReturn(run func(uint) e
 	return _c
 }
 
+// DeleteItem provides a mock function with given fields: cartID, itemID
+func (_m *MockCartRepositoryInterface) DeleteItem(cartID uint, itemID uint) error {
+	ret := _m.Called(cartID, itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(cartID, itemID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCartRepositoryInterface_DeleteItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteItem'
+type MockCartRepositoryInterface_DeleteItem_Call struct {
+	*mock.Call
+}
+
+// DeleteItem is a helper method to define mock.On call
+//   - cartID uint
+//   - itemID uint
+func (_e *MockCartRepositoryInterface_Expecter) DeleteItem(cartID interface{}, itemID interface{}) *MockCartRepositoryInterface_DeleteItem_Call {
+	return &MockCartRepositoryInterface_DeleteItem_Call{Call: _e.mock.On("DeleteItem", cartID, itemID)}
+}
+
+func (_c *MockCartRepositoryInterface_DeleteItem_Call) Run(run func(cartID uint, itemID uint)) *MockCartRepositoryInterface_DeleteItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_DeleteItem_Call) Return(_a0 error) *MockCartRepositoryInterface_DeleteItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_DeleteItem_Call) RunAndReturn(run func(uint, uint) error) *MockCartRepositoryInterface_DeleteItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByGuestID provides a mock function with given fields: guestID
+func (_m *MockCartRepositoryInterface) GetByGuestID(guestID string) (*models.Cart, error) {
+	ret := _m.Called(guestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByGuestID")
+	}
+
+	var r0 *models.Cart
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.Cart, error)); ok {
+		return rf(guestID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.Cart); ok {
+		r0 = rf(guestID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Cart)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(guestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCartRepositoryInterface_GetByGuestID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByGuestID'
+type MockCartRepositoryInterface_GetByGuestID_Call struct {
+	*mock.Call
+}
+
+// GetByGuestID is a helper method to define mock.On call
+//   - guestID string
+func (_e *MockCartRepositoryInterface_Expecter) GetByGuestID(guestID interface{}) *MockCartRepositoryInterface_GetByGuestID_Call {
+	return &MockCartRepositoryInterface_GetByGuestID_Call{Call: _e.mock.On("GetByGuestID", guestID)}
+}
+
+func (_c *MockCartRepositoryInterface_GetByGuestID_Call) Run(run func(guestID string)) *MockCartRepositoryInterface_GetByGuestID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_GetByGuestID_Call) Return(_a0 *models.Cart, _a1 error) *MockCartRepositoryInterface_GetByGuestID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_GetByGuestID_Call) RunAndReturn(run func(string) (*models.Cart, error)) *MockCartRepositoryInterface_GetByGuestID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByUserID provides a mock function with given fields: userID
 func (_m *MockCartRepositoryInterface) GetByUserID(userID uint) (*models.Cart, error) {
 	ret := _m.Called(userID)
@@ -170,6 +323,343 @@ func (_c *MockCartRepositoryInterface_GetByUserID_Call) RunAndReturn(run func(ui
 	return _c
 }
 
+// GetItem provides a mock function with given fields: cartID, itemID
+func (_m *MockCartRepositoryInterface) GetItem(cartID uint, itemID uint) (*models.CartItem, error) {
+	ret := _m.Called(cartID, itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItem")
+	}
+
+	var r0 *models.CartItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (*models.CartItem, error)); ok {
+		return rf(cartID, itemID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) *models.CartItem); ok {
+		r0 = rf(cartID, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CartItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(cartID, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCartRepositoryInterface_GetItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItem'
+type MockCartRepositoryInterface_GetItem_Call struct {
+	*mock.Call
+}
+
+// GetItem is a helper method to define mock.On call
+//   - cartID uint
+//   - itemID uint
+func (_e *MockCartRepositoryInterface_Expecter) GetItem(cartID interface{}, itemID interface{}) *MockCartRepositoryInterface_GetItem_Call {
+	return &MockCartRepositoryInterface_GetItem_Call{Call: _e.mock.On("GetItem", cartID, itemID)}
+}
+
+func (_c *MockCartRepositoryInterface_GetItem_Call) Run(run func(cartID uint, itemID uint)) *MockCartRepositoryInterface_GetItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_GetItem_Call) Return(_a0 *models.CartItem, _a1 error) *MockCartRepositoryInterface_GetItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_GetItem_Call) RunAndReturn(run func(uint, uint) (*models.CartItem, error)) *MockCartRepositoryInterface_GetItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItemByProduct provides a mock function with given fields: cartID, productID
+func (_m *MockCartRepositoryInterface) GetItemByProduct(cartID uint, productID uint) (*models.CartItem, error) {
+	ret := _m.Called(cartID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItemByProduct")
+	}
+
+	var r0 *models.CartItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (*models.CartItem, error)); ok {
+		return rf(cartID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) *models.CartItem); ok {
+		r0 = rf(cartID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CartItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(cartID, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCartRepositoryInterface_GetItemByProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItemByProduct'
+type MockCartRepositoryInterface_GetItemByProduct_Call struct {
+	*mock.Call
+}
+
+// GetItemByProduct is a helper method to define mock.On call
+//   - cartID uint
+//   - productID uint
+func (_e *MockCartRepositoryInterface_Expecter) GetItemByProduct(cartID interface{}, productID interface{}) *MockCartRepositoryInterface_GetItemByProduct_Call {
+	return &MockCartRepositoryInterface_GetItemByProduct_Call{Call: _e.mock.On("GetItemByProduct", cartID, productID)}
+}
+
+func (_c *MockCartRepositoryInterface_GetItemByProduct_Call) Run(run func(cartID uint, productID uint)) *MockCartRepositoryInterface_GetItemByProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_GetItemByProduct_Call) Return(_a0 *models.CartItem, _a1 error) *MockCartRepositoryInterface_GetItemByProduct_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_GetItemByProduct_Call) RunAndReturn(run func(uint, uint) (*models.CartItem, error)) *MockCartRepositoryInterface_GetItemByProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItems provides a mock function with given fields: cartID
+func (_m *MockCartRepositoryInterface) GetItems(cartID uint) ([]models.CartItem, error) {
+	ret := _m.Called(cartID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItems")
+	}
+
+	var r0 []models.CartItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]models.CartItem, error)); ok {
+		return rf(cartID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []models.CartItem); ok {
+		r0 = rf(cartID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CartItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(cartID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCartRepositoryInterface_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
+type MockCartRepositoryInterface_GetItems_Call struct {
+	*mock.Call
+}
+
+// GetItems is a helper method to define mock.On call
+//   - cartID uint
+func (_e *MockCartRepositoryInterface_Expecter) GetItems(cartID interface{}) *MockCartRepositoryInterface_GetItems_Call {
+	return &MockCartRepositoryInterface_GetItems_Call{Call: _e.mock.On("GetItems", cartID)}
+}
+
+func (_c *MockCartRepositoryInterface_GetItems_Call) Run(run func(cartID uint)) *MockCartRepositoryInterface_GetItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_GetItems_Call) Return(_a0 []models.CartItem, _a1 error) *MockCartRepositoryInterface_GetItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_GetItems_Call) RunAndReturn(run func(uint) ([]models.CartItem, error)) *MockCartRepositoryInterface_GetItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWithItems provides a mock function with given fields: id
+func (_m *MockCartRepositoryInterface) GetWithItems(id uint) (*models.Cart, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithItems")
+	}
+
+	var r0 *models.Cart
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*models.Cart, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *models.Cart); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Cart)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCartRepositoryInterface_GetWithItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWithItems'
+type MockCartRepositoryInterface_GetWithItems_Call struct {
+	*mock.Call
+}
+
+// GetWithItems is a helper method to define mock.On call
+//   - id uint
+func (_e *MockCartRepositoryInterface_Expecter) GetWithItems(id interface{}) *MockCartRepositoryInterface_GetWithItems_Call {
+	return &MockCartRepositoryInterface_GetWithItems_Call{Call: _e.mock.On("GetWithItems", id)}
+}
+
+func (_c *MockCartRepositoryInterface_GetWithItems_Call) Run(run func(id uint)) *MockCartRepositoryInterface_GetWithItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_GetWithItems_Call) Return(_a0 *models.Cart, _a1 error) *MockCartRepositoryInterface_GetWithItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_GetWithItems_Call) RunAndReturn(run func(uint) (*models.Cart, error)) *MockCartRepositoryInterface_GetWithItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseExpiredReservations provides a mock function with given fields: idleSince
+func (_m *MockCartRepositoryInterface) ReleaseExpiredReservations(idleSince time.Time) (int, error) {
+	ret := _m.Called(idleSince)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseExpiredReservations")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int, error)); ok {
+		return rf(idleSince)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int); ok {
+		r0 = rf(idleSince)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(idleSince)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCartRepositoryInterface_ReleaseExpiredReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseExpiredReservations'
+type MockCartRepositoryInterface_ReleaseExpiredReservations_Call struct {
+	*mock.Call
+}
+
+// ReleaseExpiredReservations is a helper method to define mock.On call
+//   - idleSince time.Time
+func (_e *MockCartRepositoryInterface_Expecter) ReleaseExpiredReservations(idleSince interface{}) *MockCartRepositoryInterface_ReleaseExpiredReservations_Call {
+	return &MockCartRepositoryInterface_ReleaseExpiredReservations_Call{Call: _e.mock.On("ReleaseExpiredReservations", idleSince)}
+}
+
+func (_c *MockCartRepositoryInterface_ReleaseExpiredReservations_Call) Run(run func(idleSince time.Time)) *MockCartRepositoryInterface_ReleaseExpiredReservations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_ReleaseExpiredReservations_Call) Return(_a0 int, _a1 error) *MockCartRepositoryInterface_ReleaseExpiredReservations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_ReleaseExpiredReservations_Call) RunAndReturn(run func(time.Time) (int, error)) *MockCartRepositoryInterface_ReleaseExpiredReservations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseReservations provides a mock function with given fields: cartID, itemIDs
+func (_m *MockCartRepositoryInterface) ReleaseReservations(cartID uint, itemIDs []uint) error {
+	ret := _m.Called(cartID, itemIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseReservations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, []uint) error); ok {
+		r0 = rf(cartID, itemIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCartRepositoryInterface_ReleaseReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseReservations'
+type MockCartRepositoryInterface_ReleaseReservations_Call struct {
+	*mock.Call
+}
+
+// ReleaseReservations is a helper method to define mock.On call
+//   - cartID uint
+//   - itemIDs []uint
+func (_e *MockCartRepositoryInterface_Expecter) ReleaseReservations(cartID interface{}, itemIDs interface{}) *MockCartRepositoryInterface_ReleaseReservations_Call {
+	return &MockCartRepositoryInterface_ReleaseReservations_Call{Call: _e.mock.On("ReleaseReservations", cartID, itemIDs)}
+}
+
+func (_c *MockCartRepositoryInterface_ReleaseReservations_Call) Run(run func(cartID uint, itemIDs []uint)) *MockCartRepositoryInterface_ReleaseReservations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].([]uint))
+	})
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_ReleaseReservations_Call) Return(_a0 error) *MockCartRepositoryInterface_ReleaseReservations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_ReleaseReservations_Call) RunAndReturn(run func(uint, []uint) error) *MockCartRepositoryInterface_ReleaseReservations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: cart
 func (_m *MockCartRepositoryInterface) Update(cart *models.Cart) error {
 	ret := _m.Called(cart)
@@ -216,6 +706,52 @@ func (_c *MockCartRepositoryInterface_Update_Call) RunAndReturn(run func(*models
 	return _c
 }
 
+// UpdateItem provides a mock function with given fields: item
+func (_m *MockCartRepositoryInterface) UpdateItem(item *models.CartItem) error {
+	ret := _m.Called(item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.CartItem) error); ok {
+		r0 = rf(item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCartRepositoryInterface_UpdateItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateItem'
+type MockCartRepositoryInterface_UpdateItem_Call struct {
+	*mock.Call
+}
+
+// UpdateItem is a helper method to define mock.On call
+//   - item *models.CartItem
+func (_e *MockCartRepositoryInterface_Expecter) UpdateItem(item interface{}) *MockCartRepositoryInterface_UpdateItem_Call {
+	return &MockCartRepositoryInterface_UpdateItem_Call{Call: _e.mock.On("UpdateItem", item)}
+}
+
+func (_c *MockCartRepositoryInterface_UpdateItem_Call) Run(run func(item *models.CartItem)) *MockCartRepositoryInterface_UpdateItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.CartItem))
+	})
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_UpdateItem_Call) Return(_a0 error) *MockCartRepositoryInterface_UpdateItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCartRepositoryInterface_UpdateItem_Call) RunAndReturn(run func(*models.CartItem) error) *MockCartRepositoryInterface_UpdateItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockCartRepositoryInterface creates a new instance of MockCartRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockCartRepositoryInterface(t interface {
@@ -0,0 +1,621 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/JihadRinaldi/go-shop/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCategoryRepositoryInterface is an autogenerated mock type for the CategoryRepositoryInterface type
+type MockCategoryRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockCategoryRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCategoryRepositoryInterface) EXPECT() *MockCategoryRepositoryInterface_Expecter {
+	return &MockCategoryRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// CountChildren provides a mock function with given fields: id
+func (_m *MockCategoryRepositoryInterface) CountChildren(id uint) (int64, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountChildren")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (int64, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) int64); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCategoryRepositoryInterface_CountChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountChildren'
+type MockCategoryRepositoryInterface_CountChildren_Call struct {
+	*mock.Call
+}
+
+// CountChildren is a helper method to define mock.On call
+//   - id uint
+func (_e *MockCategoryRepositoryInterface_Expecter) CountChildren(id interface{}) *MockCategoryRepositoryInterface_CountChildren_Call {
+	return &MockCategoryRepositoryInterface_CountChildren_Call{Call: _e.mock.On("CountChildren", id)}
+}
+
+func (_c *MockCategoryRepositoryInterface_CountChildren_Call) Run(run func(id uint)) *MockCategoryRepositoryInterface_CountChildren_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_CountChildren_Call) Return(_a0 int64, _a1 error) *MockCategoryRepositoryInterface_CountChildren_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_CountChildren_Call) RunAndReturn(run func(uint) (int64, error)) *MockCategoryRepositoryInterface_CountChildren_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountProducts provides a mock function with given fields: id
+func (_m *MockCategoryRepositoryInterface) CountProducts(id uint) (int64, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountProducts")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (int64, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) int64); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCategoryRepositoryInterface_CountProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountProducts'
+type MockCategoryRepositoryInterface_CountProducts_Call struct {
+	*mock.Call
+}
+
+// CountProducts is a helper method to define mock.On call
+//   - id uint
+func (_e *MockCategoryRepositoryInterface_Expecter) CountProducts(id interface{}) *MockCategoryRepositoryInterface_CountProducts_Call {
+	return &MockCategoryRepositoryInterface_CountProducts_Call{Call: _e.mock.On("CountProducts", id)}
+}
+
+func (_c *MockCategoryRepositoryInterface_CountProducts_Call) Run(run func(id uint)) *MockCategoryRepositoryInterface_CountProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_CountProducts_Call) Return(_a0 int64, _a1 error) *MockCategoryRepositoryInterface_CountProducts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_CountProducts_Call) RunAndReturn(run func(uint) (int64, error)) *MockCategoryRepositoryInterface_CountProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: category
+func (_m *MockCategoryRepositoryInterface) Create(category *models.Category) error {
+	ret := _m.Called(category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Category) error); ok {
+		r0 = rf(category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCategoryRepositoryInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockCategoryRepositoryInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - category *models.Category
+func (_e *MockCategoryRepositoryInterface_Expecter) Create(category interface{}) *MockCategoryRepositoryInterface_Create_Call {
+	return &MockCategoryRepositoryInterface_Create_Call{Call: _e.mock.On("Create", category)}
+}
+
+func (_c *MockCategoryRepositoryInterface_Create_Call) Run(run func(category *models.Category)) *MockCategoryRepositoryInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Category))
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_Create_Call) Return(_a0 error) *MockCategoryRepositoryInterface_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_Create_Call) RunAndReturn(run func(*models.Category) error) *MockCategoryRepositoryInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *MockCategoryRepositoryInterface) Delete(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCategoryRepositoryInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockCategoryRepositoryInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uint
+func (_e *MockCategoryRepositoryInterface_Expecter) Delete(id interface{}) *MockCategoryRepositoryInterface_Delete_Call {
+	return &MockCategoryRepositoryInterface_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *MockCategoryRepositoryInterface_Delete_Call) Run(run func(id uint)) *MockCategoryRepositoryInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_Delete_Call) Return(_a0 error) *MockCategoryRepositoryInterface_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_Delete_Call) RunAndReturn(run func(uint) error) *MockCategoryRepositoryInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAndReparent provides a mock function with given fields: category
+func (_m *MockCategoryRepositoryInterface) DeleteAndReparent(category *models.Category) error {
+	ret := _m.Called(category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAndReparent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Category) error); ok {
+		r0 = rf(category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCategoryRepositoryInterface_DeleteAndReparent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAndReparent'
+type MockCategoryRepositoryInterface_DeleteAndReparent_Call struct {
+	*mock.Call
+}
+
+// DeleteAndReparent is a helper method to define mock.On call
+//   - category *models.Category
+func (_e *MockCategoryRepositoryInterface_Expecter) DeleteAndReparent(category interface{}) *MockCategoryRepositoryInterface_DeleteAndReparent_Call {
+	return &MockCategoryRepositoryInterface_DeleteAndReparent_Call{Call: _e.mock.On("DeleteAndReparent", category)}
+}
+
+func (_c *MockCategoryRepositoryInterface_DeleteAndReparent_Call) Run(run func(category *models.Category)) *MockCategoryRepositoryInterface_DeleteAndReparent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Category))
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_DeleteAndReparent_Call) Return(_a0 error) *MockCategoryRepositoryInterface_DeleteAndReparent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_DeleteAndReparent_Call) RunAndReturn(run func(*models.Category) error) *MockCategoryRepositoryInterface_DeleteAndReparent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: activeOnly
+func (_m *MockCategoryRepositoryInterface) GetAll(activeOnly bool) ([]models.Category, error) {
+	ret := _m.Called(activeOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(bool) ([]models.Category, error)); ok {
+		return rf(activeOnly)
+	}
+	if rf, ok := ret.Get(0).(func(bool) []models.Category); ok {
+		r0 = rf(activeOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = rf(activeOnly)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCategoryRepositoryInterface_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockCategoryRepositoryInterface_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - activeOnly bool
+func (_e *MockCategoryRepositoryInterface_Expecter) GetAll(activeOnly interface{}) *MockCategoryRepositoryInterface_GetAll_Call {
+	return &MockCategoryRepositoryInterface_GetAll_Call{Call: _e.mock.On("GetAll", activeOnly)}
+}
+
+func (_c *MockCategoryRepositoryInterface_GetAll_Call) Run(run func(activeOnly bool)) *MockCategoryRepositoryInterface_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(bool))
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_GetAll_Call) Return(_a0 []models.Category, _a1 error) *MockCategoryRepositoryInterface_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_GetAll_Call) RunAndReturn(run func(bool) ([]models.Category, error)) *MockCategoryRepositoryInterface_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAncestors provides a mock function with given fields: id
+func (_m *MockCategoryRepositoryInterface) GetAncestors(id uint) ([]models.Category, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAncestors")
+	}
+
+	var r0 []models.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]models.Category, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []models.Category); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCategoryRepositoryInterface_GetAncestors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAncestors'
+type MockCategoryRepositoryInterface_GetAncestors_Call struct {
+	*mock.Call
+}
+
+// GetAncestors is a helper method to define mock.On call
+//   - id uint
+func (_e *MockCategoryRepositoryInterface_Expecter) GetAncestors(id interface{}) *MockCategoryRepositoryInterface_GetAncestors_Call {
+	return &MockCategoryRepositoryInterface_GetAncestors_Call{Call: _e.mock.On("GetAncestors", id)}
+}
+
+func (_c *MockCategoryRepositoryInterface_GetAncestors_Call) Run(run func(id uint)) *MockCategoryRepositoryInterface_GetAncestors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_GetAncestors_Call) Return(_a0 []models.Category, _a1 error) *MockCategoryRepositoryInterface_GetAncestors_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_GetAncestors_Call) RunAndReturn(run func(uint) ([]models.Category, error)) *MockCategoryRepositoryInterface_GetAncestors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MockCategoryRepositoryInterface) GetByID(id uint) (*models.Category, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*models.Category, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *models.Category); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCategoryRepositoryInterface_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockCategoryRepositoryInterface_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - id uint
+func (_e *MockCategoryRepositoryInterface_Expecter) GetByID(id interface{}) *MockCategoryRepositoryInterface_GetByID_Call {
+	return &MockCategoryRepositoryInterface_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+}
+
+func (_c *MockCategoryRepositoryInterface_GetByID_Call) Run(run func(id uint)) *MockCategoryRepositoryInterface_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_GetByID_Call) Return(_a0 *models.Category, _a1 error) *MockCategoryRepositoryInterface_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_GetByID_Call) RunAndReturn(run func(uint) (*models.Category, error)) *MockCategoryRepositoryInterface_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBySlug provides a mock function with given fields: slug
+func (_m *MockCategoryRepositoryInterface) GetBySlug(slug string) (*models.Category, error) {
+	ret := _m.Called(slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySlug")
+	}
+
+	var r0 *models.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.Category, error)); ok {
+		return rf(slug)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.Category); ok {
+		r0 = rf(slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCategoryRepositoryInterface_GetBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySlug'
+type MockCategoryRepositoryInterface_GetBySlug_Call struct {
+	*mock.Call
+}
+
+// GetBySlug is a helper method to define mock.On call
+//   - slug string
+func (_e *MockCategoryRepositoryInterface_Expecter) GetBySlug(slug interface{}) *MockCategoryRepositoryInterface_GetBySlug_Call {
+	return &MockCategoryRepositoryInterface_GetBySlug_Call{Call: _e.mock.On("GetBySlug", slug)}
+}
+
+func (_c *MockCategoryRepositoryInterface_GetBySlug_Call) Run(run func(slug string)) *MockCategoryRepositoryInterface_GetBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_GetBySlug_Call) Return(_a0 *models.Category, _a1 error) *MockCategoryRepositoryInterface_GetBySlug_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_GetBySlug_Call) RunAndReturn(run func(string) (*models.Category, error)) *MockCategoryRepositoryInterface_GetBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDescendantIDs provides a mock function with given fields: id
+func (_m *MockCategoryRepositoryInterface) GetDescendantIDs(id uint) ([]uint, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDescendantIDs")
+	}
+
+	var r0 []uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]uint, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []uint); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCategoryRepositoryInterface_GetDescendantIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDescendantIDs'
+type MockCategoryRepositoryInterface_GetDescendantIDs_Call struct {
+	*mock.Call
+}
+
+// GetDescendantIDs is a helper method to define mock.On call
+//   - id uint
+func (_e *MockCategoryRepositoryInterface_Expecter) GetDescendantIDs(id interface{}) *MockCategoryRepositoryInterface_GetDescendantIDs_Call {
+	return &MockCategoryRepositoryInterface_GetDescendantIDs_Call{Call: _e.mock.On("GetDescendantIDs", id)}
+}
+
+func (_c *MockCategoryRepositoryInterface_GetDescendantIDs_Call) Run(run func(id uint)) *MockCategoryRepositoryInterface_GetDescendantIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_GetDescendantIDs_Call) Return(_a0 []uint, _a1 error) *MockCategoryRepositoryInterface_GetDescendantIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_GetDescendantIDs_Call) RunAndReturn(run func(uint) ([]uint, error)) *MockCategoryRepositoryInterface_GetDescendantIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: category
+func (_m *MockCategoryRepositoryInterface) Update(category *models.Category) error {
+	ret := _m.Called(category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Category) error); ok {
+		r0 = rf(category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCategoryRepositoryInterface_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockCategoryRepositoryInterface_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - category *models.Category
+func (_e *MockCategoryRepositoryInterface_Expecter) Update(category interface{}) *MockCategoryRepositoryInterface_Update_Call {
+	return &MockCategoryRepositoryInterface_Update_Call{Call: _e.mock.On("Update", category)}
+}
+
+func (_c *MockCategoryRepositoryInterface_Update_Call) Run(run func(category *models.Category)) *MockCategoryRepositoryInterface_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Category))
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_Update_Call) Return(_a0 error) *MockCategoryRepositoryInterface_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCategoryRepositoryInterface_Update_Call) RunAndReturn(run func(*models.Category) error) *MockCategoryRepositoryInterface_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCategoryRepositoryInterface creates a new instance of MockCategoryRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCategoryRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCategoryRepositoryInterface {
+	mock := &MockCategoryRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,243 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLoginAttemptStore is an autogenerated mock type for the LoginAttemptStore type
+type MockLoginAttemptStore struct {
+	mock.Mock
+}
+
+type MockLoginAttemptStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLoginAttemptStore) EXPECT() *MockLoginAttemptStore_Expecter {
+	return &MockLoginAttemptStore_Expecter{mock: &_m.Mock}
+}
+
+// Block provides a mock function with given fields: key, until
+func (_m *MockLoginAttemptStore) Block(key string, until time.Time) error {
+	ret := _m.Called(key, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Block")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(key, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLoginAttemptStore_Block_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Block'
+type MockLoginAttemptStore_Block_Call struct {
+	*mock.Call
+}
+
+// Block is a helper method to define mock.On call
+//   - key string
+//   - until time.Time
+func (_e *MockLoginAttemptStore_Expecter) Block(key interface{}, until interface{}) *MockLoginAttemptStore_Block_Call {
+	return &MockLoginAttemptStore_Block_Call{Call: _e.mock.On("Block", key, until)}
+}
+
+func (_c *MockLoginAttemptStore_Block_Call) Run(run func(key string, until time.Time)) *MockLoginAttemptStore_Block_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockLoginAttemptStore_Block_Call) Return(_a0 error) *MockLoginAttemptStore_Block_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoginAttemptStore_Block_Call) RunAndReturn(run func(string, time.Time) error) *MockLoginAttemptStore_Block_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BlockedUntil provides a mock function with given fields: key
+func (_m *MockLoginAttemptStore) BlockedUntil(key string) (time.Time, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockedUntil")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (time.Time, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) time.Time); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLoginAttemptStore_BlockedUntil_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockedUntil'
+type MockLoginAttemptStore_BlockedUntil_Call struct {
+	*mock.Call
+}
+
+// BlockedUntil is a helper method to define mock.On call
+//   - key string
+func (_e *MockLoginAttemptStore_Expecter) BlockedUntil(key interface{}) *MockLoginAttemptStore_BlockedUntil_Call {
+	return &MockLoginAttemptStore_BlockedUntil_Call{Call: _e.mock.On("BlockedUntil", key)}
+}
+
+func (_c *MockLoginAttemptStore_BlockedUntil_Call) Run(run func(key string)) *MockLoginAttemptStore_BlockedUntil_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockLoginAttemptStore_BlockedUntil_Call) Return(_a0 time.Time, _a1 error) *MockLoginAttemptStore_BlockedUntil_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLoginAttemptStore_BlockedUntil_Call) RunAndReturn(run func(string) (time.Time, error)) *MockLoginAttemptStore_BlockedUntil_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordFailure provides a mock function with given fields: key, now, window
+func (_m *MockLoginAttemptStore) RecordFailure(key string, now time.Time, window time.Duration) (int, error) {
+	ret := _m.Called(key, now, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFailure")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Duration) (int, error)); ok {
+		return rf(key, now, window)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Duration) int); ok {
+		r0 = rf(key, now, window)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Duration) error); ok {
+		r1 = rf(key, now, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLoginAttemptStore_RecordFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordFailure'
+type MockLoginAttemptStore_RecordFailure_Call struct {
+	*mock.Call
+}
+
+// RecordFailure is a helper method to define mock.On call
+//   - key string
+//   - now time.Time
+//   - window time.Duration
+func (_e *MockLoginAttemptStore_Expecter) RecordFailure(key interface{}, now interface{}, window interface{}) *MockLoginAttemptStore_RecordFailure_Call {
+	return &MockLoginAttemptStore_RecordFailure_Call{Call: _e.mock.On("RecordFailure", key, now, window)}
+}
+
+func (_c *MockLoginAttemptStore_RecordFailure_Call) Run(run func(key string, now time.Time, window time.Duration)) *MockLoginAttemptStore_RecordFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Time), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockLoginAttemptStore_RecordFailure_Call) Return(_a0 int, _a1 error) *MockLoginAttemptStore_RecordFailure_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLoginAttemptStore_RecordFailure_Call) RunAndReturn(run func(string, time.Time, time.Duration) (int, error)) *MockLoginAttemptStore_RecordFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reset provides a mock function with given fields: key
+func (_m *MockLoginAttemptStore) Reset(key string) error {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLoginAttemptStore_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type MockLoginAttemptStore_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+//   - key string
+func (_e *MockLoginAttemptStore_Expecter) Reset(key interface{}) *MockLoginAttemptStore_Reset_Call {
+	return &MockLoginAttemptStore_Reset_Call{Call: _e.mock.On("Reset", key)}
+}
+
+func (_c *MockLoginAttemptStore_Reset_Call) Run(run func(key string)) *MockLoginAttemptStore_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockLoginAttemptStore_Reset_Call) Return(_a0 error) *MockLoginAttemptStore_Reset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLoginAttemptStore_Reset_Call) RunAndReturn(run func(string) error) *MockLoginAttemptStore_Reset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLoginAttemptStore creates a new instance of MockLoginAttemptStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLoginAttemptStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLoginAttemptStore {
+	mock := &MockLoginAttemptStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -112,6 +112,65 @@ func (_c *MockOrderRepositoryInterface_Delete_Call) RunAndReturn(run func(uint)
 	return _c
 }
 
+// FindDeliveredOrderItem provides a mock function with given fields: userID, productID
+func (_m *MockOrderRepositoryInterface) FindDeliveredOrderItem(userID uint, productID uint) (*models.OrderItem, error) {
+	ret := _m.Called(userID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDeliveredOrderItem")
+	}
+
+	var r0 *models.OrderItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (*models.OrderItem, error)); ok {
+		return rf(userID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) *models.OrderItem); ok {
+		r0 = rf(userID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(userID, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrderRepositoryInterface_FindDeliveredOrderItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDeliveredOrderItem'
+type MockOrderRepositoryInterface_FindDeliveredOrderItem_Call struct {
+	*mock.Call
+}
+
+// FindDeliveredOrderItem is a helper method to define mock.On call
+//   - userID uint
+//   - productID uint
+func (_e *MockOrderRepositoryInterface_Expecter) FindDeliveredOrderItem(userID interface{}, productID interface{}) *MockOrderRepositoryInterface_FindDeliveredOrderItem_Call {
+	return &MockOrderRepositoryInterface_FindDeliveredOrderItem_Call{Call: _e.mock.On("FindDeliveredOrderItem", userID, productID)}
+}
+
+func (_c *MockOrderRepositoryInterface_FindDeliveredOrderItem_Call) Run(run func(userID uint, productID uint)) *MockOrderRepositoryInterface_FindDeliveredOrderItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockOrderRepositoryInterface_FindDeliveredOrderItem_Call) Return(_a0 *models.OrderItem, _a1 error) *MockOrderRepositoryInterface_FindDeliveredOrderItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrderRepositoryInterface_FindDeliveredOrderItem_Call) RunAndReturn(run func(uint, uint) (*models.OrderItem, error)) *MockOrderRepositoryInterface_FindDeliveredOrderItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAll provides a mock function with given fields: limit, offset
 func (_m *MockOrderRepositoryInterface) GetAll(limit int, offset int) ([]models.Order, error) {
 	ret := _m.Called(limit, offset)
@@ -289,6 +348,121 @@ func (_c *MockOrderRepositoryInterface_GetByUserID_Call) RunAndReturn(run func(u
 	return _c
 }
 
+// GetItemByID provides a mock function with given fields: id
+func (_m *MockOrderRepositoryInterface) GetItemByID(id uint) (*models.OrderItem, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItemByID")
+	}
+
+	var r0 *models.OrderItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*models.OrderItem, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *models.OrderItem); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrderRepositoryInterface_GetItemByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItemByID'
+type MockOrderRepositoryInterface_GetItemByID_Call struct {
+	*mock.Call
+}
+
+// GetItemByID is a helper method to define mock.On call
+//   - id uint
+func (_e *MockOrderRepositoryInterface_Expecter) GetItemByID(id interface{}) *MockOrderRepositoryInterface_GetItemByID_Call {
+	return &MockOrderRepositoryInterface_GetItemByID_Call{Call: _e.mock.On("GetItemByID", id)}
+}
+
+func (_c *MockOrderRepositoryInterface_GetItemByID_Call) Run(run func(id uint)) *MockOrderRepositoryInterface_GetItemByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockOrderRepositoryInterface_GetItemByID_Call) Return(_a0 *models.OrderItem, _a1 error) *MockOrderRepositoryInterface_GetItemByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrderRepositoryInterface_GetItemByID_Call) RunAndReturn(run func(uint) (*models.OrderItem, error)) *MockOrderRepositoryInterface_GetItemByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementDownloadCount provides a mock function with given fields: itemID, limit
+func (_m *MockOrderRepositoryInterface) IncrementDownloadCount(itemID uint, limit int) (bool, error) {
+	ret := _m.Called(itemID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementDownloadCount")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, int) (bool, error)); ok {
+		return rf(itemID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint, int) bool); ok {
+		r0 = rf(itemID, limit)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, int) error); ok {
+		r1 = rf(itemID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrderRepositoryInterface_IncrementDownloadCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementDownloadCount'
+type MockOrderRepositoryInterface_IncrementDownloadCount_Call struct {
+	*mock.Call
+}
+
+// IncrementDownloadCount is a helper method to define mock.On call
+//   - itemID uint
+//   - limit int
+func (_e *MockOrderRepositoryInterface_Expecter) IncrementDownloadCount(itemID interface{}, limit interface{}) *MockOrderRepositoryInterface_IncrementDownloadCount_Call {
+	return &MockOrderRepositoryInterface_IncrementDownloadCount_Call{Call: _e.mock.On("IncrementDownloadCount", itemID, limit)}
+}
+
+func (_c *MockOrderRepositoryInterface_IncrementDownloadCount_Call) Run(run func(itemID uint, limit int)) *MockOrderRepositoryInterface_IncrementDownloadCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockOrderRepositoryInterface_IncrementDownloadCount_Call) Return(_a0 bool, _a1 error) *MockOrderRepositoryInterface_IncrementDownloadCount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrderRepositoryInterface_IncrementDownloadCount_Call) RunAndReturn(run func(uint, int) (bool, error)) *MockOrderRepositoryInterface_IncrementDownloadCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: order
 func (_m *MockOrderRepositoryInterface) Update(order *models.Order) error {
 	ret := _m.Called(order)
@@ -335,22 +509,32 @@ func (_c *MockOrderRepositoryInterface_Update_Call) RunAndReturn(run func(*model
 	return _c
 }
 
-// UpdateStatus provides a mock function with given fields: id, status
-func (_m *MockOrderRepositoryInterface) UpdateStatus(id uint, status models.OrderStatus) error {
-	ret := _m.Called(id, status)
+// UpdateStatus provides a mock function with given fields: id, from, to
+func (_m *MockOrderRepositoryInterface) UpdateStatus(id uint, from models.OrderStatus, to models.OrderStatus) (bool, error) {
+	ret := _m.Called(id, from, to)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateStatus")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, models.OrderStatus) error); ok {
-		r0 = rf(id, status)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, models.OrderStatus, models.OrderStatus) (bool, error)); ok {
+		return rf(id, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(uint, models.OrderStatus, models.OrderStatus) bool); ok {
+		r0 = rf(id, from, to)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(uint, models.OrderStatus, models.OrderStatus) error); ok {
+		r1 = rf(id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // MockOrderRepositoryInterface_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
@@ -360,24 +544,25 @@ type MockOrderRepositoryInterface_UpdateStatus_Call struct {
 
 // UpdateStatus is a helper method to define mock.On call
 //   - id uint
-//   - status models.OrderStatus
-func (_e *MockOrderRepositoryInterface_Expecter) UpdateStatus(id interface{}, status interface{}) *MockOrderRepositoryInterface_UpdateStatus_Call {
-	return &MockOrderRepositoryInterface_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", id, status)}
+//   - from models.OrderStatus
+//   - to models.OrderStatus
+func (_e *MockOrderRepositoryInterface_Expecter) UpdateStatus(id interface{}, from interface{}, to interface{}) *MockOrderRepositoryInterface_UpdateStatus_Call {
+	return &MockOrderRepositoryInterface_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", id, from, to)}
 }
 
-func (_c *MockOrderRepositoryInterface_UpdateStatus_Call) Run(run func(id uint, status models.OrderStatus)) *MockOrderRepositoryInterface_UpdateStatus_Call {
+func (_c *MockOrderRepositoryInterface_UpdateStatus_Call) Run(run func(id uint, from models.OrderStatus, to models.OrderStatus)) *MockOrderRepositoryInterface_UpdateStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].(models.OrderStatus))
+		run(args[0].(uint), args[1].(models.OrderStatus), args[2].(models.OrderStatus))
 	})
 	return _c
 }
 
-func (_c *MockOrderRepositoryInterface_UpdateStatus_Call) Return(_a0 error) *MockOrderRepositoryInterface_UpdateStatus_Call {
-	_c.Call.Return(_a0)
+func (_c *MockOrderRepositoryInterface_UpdateStatus_Call) Return(_a0 bool, _a1 error) *MockOrderRepositoryInterface_UpdateStatus_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockOrderRepositoryInterface_UpdateStatus_Call) RunAndReturn(run func(uint, models.OrderStatus) error) *MockOrderRepositoryInterface_UpdateStatus_Call {
+func (_c *MockOrderRepositoryInterface_UpdateStatus_Call) RunAndReturn(run func(uint, models.OrderStatus, models.OrderStatus) (bool, error)) *MockOrderRepositoryInterface_UpdateStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	models "github.com/JihadRinaldi/go-shop/internal/models"
+	repositories "github.com/JihadRinaldi/go-shop/internal/repositories"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -66,6 +67,53 @@ func (_c *MockProductRepositoryInterface_Create_Call) RunAndReturn(run func(*mod
 	return _c
 }
 
+// CreateWithAttributes provides a mock function with given fields: product, values
+func (_m *MockProductRepositoryInterface) CreateWithAttributes(product *models.Product, values []models.ProductAttributeValue) error {
+	ret := _m.Called(product, values)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWithAttributes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Product, []models.ProductAttributeValue) error); ok {
+		r0 = rf(product, values)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProductRepositoryInterface_CreateWithAttributes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWithAttributes'
+type MockProductRepositoryInterface_CreateWithAttributes_Call struct {
+	*mock.Call
+}
+
+// CreateWithAttributes is a helper method to define mock.On call
+//   - product *models.Product
+//   - values []models.ProductAttributeValue
+func (_e *MockProductRepositoryInterface_Expecter) CreateWithAttributes(product interface{}, values interface{}) *MockProductRepositoryInterface_CreateWithAttributes_Call {
+	return &MockProductRepositoryInterface_CreateWithAttributes_Call{Call: _e.mock.On("CreateWithAttributes", product, values)}
+}
+
+func (_c *MockProductRepositoryInterface_CreateWithAttributes_Call) Run(run func(product *models.Product, values []models.ProductAttributeValue)) *MockProductRepositoryInterface_CreateWithAttributes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Product), args[1].([]models.ProductAttributeValue))
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_CreateWithAttributes_Call) Return(_a0 error) *MockProductRepositoryInterface_CreateWithAttributes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_CreateWithAttributes_Call) RunAndReturn(run func(*models.Product, []models.ProductAttributeValue) error) *MockProductRepositoryInterface_CreateWithAttributes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: id
 func (_m *MockProductRepositoryInterface) Delete(id uint) error {
 	ret := _m.Called(id)
@@ -347,6 +395,234 @@ func (_c *MockProductRepositoryInterface_GetBySKU_Call) RunAndReturn(run func(st
 	return _c
 }
 
+// GetBySlug provides a mock function with given fields: slug
+func (_m *MockProductRepositoryInterface) GetBySlug(slug string) (*models.Product, error) {
+	ret := _m.Called(slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySlug")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.Product, error)); ok {
+		return rf(slug)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.Product); ok {
+		r0 = rf(slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_GetBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySlug'
+type MockProductRepositoryInterface_GetBySlug_Call struct {
+	*mock.Call
+}
+
+// GetBySlug is a helper method to define mock.On call
+//   - slug string
+func (_e *MockProductRepositoryInterface_Expecter) GetBySlug(slug interface{}) *MockProductRepositoryInterface_GetBySlug_Call {
+	return &MockProductRepositoryInterface_GetBySlug_Call{Call: _e.mock.On("GetBySlug", slug)}
+}
+
+func (_c *MockProductRepositoryInterface_GetBySlug_Call) Run(run func(slug string)) *MockProductRepositoryInterface_GetBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_GetBySlug_Call) Return(_a0 *models.Product, _a1 error) *MockProductRepositoryInterface_GetBySlug_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_GetBySlug_Call) RunAndReturn(run func(string) (*models.Product, error)) *MockProductRepositoryInterface_GetBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsBundleComponent provides a mock function with given fields: id
+func (_m *MockProductRepositoryInterface) IsBundleComponent(id uint) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBundleComponent")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_IsBundleComponent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBundleComponent'
+type MockProductRepositoryInterface_IsBundleComponent_Call struct {
+	*mock.Call
+}
+
+// IsBundleComponent is a helper method to define mock.On call
+//   - id uint
+func (_e *MockProductRepositoryInterface_Expecter) IsBundleComponent(id interface{}) *MockProductRepositoryInterface_IsBundleComponent_Call {
+	return &MockProductRepositoryInterface_IsBundleComponent_Call{Call: _e.mock.On("IsBundleComponent", id)}
+}
+
+func (_c *MockProductRepositoryInterface_IsBundleComponent_Call) Run(run func(id uint)) *MockProductRepositoryInterface_IsBundleComponent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_IsBundleComponent_Call) Return(_a0 bool, _a1 error) *MockProductRepositoryInterface_IsBundleComponent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_IsBundleComponent_Call) RunAndReturn(run func(uint) (bool, error)) *MockProductRepositoryInterface_IsBundleComponent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: filter, limit, offset
+func (_m *MockProductRepositoryInterface) List(filter *repositories.ProductFilter, limit int, offset int) ([]models.Product, int64, error) {
+	ret := _m.Called(filter, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []models.Product
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*repositories.ProductFilter, int, int) ([]models.Product, int64, error)); ok {
+		return rf(filter, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(*repositories.ProductFilter, int, int) []models.Product); ok {
+		r0 = rf(filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*repositories.ProductFilter, int, int) int64); ok {
+		r1 = rf(filter, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(*repositories.ProductFilter, int, int) error); ok {
+		r2 = rf(filter, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockProductRepositoryInterface_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockProductRepositoryInterface_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - filter *repositories.ProductFilter
+//   - limit int
+//   - offset int
+func (_e *MockProductRepositoryInterface_Expecter) List(filter interface{}, limit interface{}, offset interface{}) *MockProductRepositoryInterface_List_Call {
+	return &MockProductRepositoryInterface_List_Call{Call: _e.mock.On("List", filter, limit, offset)}
+}
+
+func (_c *MockProductRepositoryInterface_List_Call) Run(run func(filter *repositories.ProductFilter, limit int, offset int)) *MockProductRepositoryInterface_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*repositories.ProductFilter), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_List_Call) Return(_a0 []models.Product, _a1 int64, _a2 error) *MockProductRepositoryInterface_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_List_Call) RunAndReturn(run func(*repositories.ProductFilter, int, int) ([]models.Product, int64, error)) *MockProductRepositoryInterface_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceBundleItems provides a mock function with given fields: bundleID, items
+func (_m *MockProductRepositoryInterface) ReplaceBundleItems(bundleID uint, items []models.BundleItem) error {
+	ret := _m.Called(bundleID, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceBundleItems")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, []models.BundleItem) error); ok {
+		r0 = rf(bundleID, items)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProductRepositoryInterface_ReplaceBundleItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceBundleItems'
+type MockProductRepositoryInterface_ReplaceBundleItems_Call struct {
+	*mock.Call
+}
+
+// ReplaceBundleItems is a helper method to define mock.On call
+//   - bundleID uint
+//   - items []models.BundleItem
+func (_e *MockProductRepositoryInterface_Expecter) ReplaceBundleItems(bundleID interface{}, items interface{}) *MockProductRepositoryInterface_ReplaceBundleItems_Call {
+	return &MockProductRepositoryInterface_ReplaceBundleItems_Call{Call: _e.mock.On("ReplaceBundleItems", bundleID, items)}
+}
+
+func (_c *MockProductRepositoryInterface_ReplaceBundleItems_Call) Run(run func(bundleID uint, items []models.BundleItem)) *MockProductRepositoryInterface_ReplaceBundleItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].([]models.BundleItem))
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_ReplaceBundleItems_Call) Return(_a0 error) *MockProductRepositoryInterface_ReplaceBundleItems_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_ReplaceBundleItems_Call) RunAndReturn(run func(uint, []models.BundleItem) error) *MockProductRepositoryInterface_ReplaceBundleItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: product
 func (_m *MockProductRepositoryInterface) Update(product *models.Product) error {
 	ret := _m.Called(product)
@@ -440,6 +716,53 @@ func (_c *MockProductRepositoryInterface_UpdateStock_Call) RunAndReturn(run func
 	return _c
 }
 
+// UpdateWithAttributes provides a mock function with given fields: product, values
+func (_m *MockProductRepositoryInterface) UpdateWithAttributes(product *models.Product, values []models.ProductAttributeValue) error {
+	ret := _m.Called(product, values)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWithAttributes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Product, []models.ProductAttributeValue) error); ok {
+		r0 = rf(product, values)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProductRepositoryInterface_UpdateWithAttributes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWithAttributes'
+type MockProductRepositoryInterface_UpdateWithAttributes_Call struct {
+	*mock.Call
+}
+
+// UpdateWithAttributes is a helper method to define mock.On call
+//   - product *models.Product
+//   - values []models.ProductAttributeValue
+func (_e *MockProductRepositoryInterface_Expecter) UpdateWithAttributes(product interface{}, values interface{}) *MockProductRepositoryInterface_UpdateWithAttributes_Call {
+	return &MockProductRepositoryInterface_UpdateWithAttributes_Call{Call: _e.mock.On("UpdateWithAttributes", product, values)}
+}
+
+func (_c *MockProductRepositoryInterface_UpdateWithAttributes_Call) Run(run func(product *models.Product, values []models.ProductAttributeValue)) *MockProductRepositoryInterface_UpdateWithAttributes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Product), args[1].([]models.ProductAttributeValue))
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_UpdateWithAttributes_Call) Return(_a0 error) *MockProductRepositoryInterface_UpdateWithAttributes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_UpdateWithAttributes_Call) RunAndReturn(run func(*models.Product, []models.ProductAttributeValue) error) *MockProductRepositoryInterface_UpdateWithAttributes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockProductRepositoryInterface creates a new instance of MockProductRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockProductRepositoryInterface(t interface {
@@ -0,0 +1,709 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/JihadRinaldi/go-shop/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockQuestionRepositoryInterface is an autogenerated mock type for the QuestionRepositoryInterface type
+type MockQuestionRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockQuestionRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockQuestionRepositoryInterface) EXPECT() *MockQuestionRepositoryInterface_Expecter {
+	return &MockQuestionRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// AddVote provides a mock function with given fields: vote
+func (_m *MockQuestionRepositoryInterface) AddVote(vote *models.QAVote) (bool, error) {
+	ret := _m.Called(vote)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddVote")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.QAVote) (bool, error)); ok {
+		return rf(vote)
+	}
+	if rf, ok := ret.Get(0).(func(*models.QAVote) bool); ok {
+		r0 = rf(vote)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.QAVote) error); ok {
+		r1 = rf(vote)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuestionRepositoryInterface_AddVote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddVote'
+type MockQuestionRepositoryInterface_AddVote_Call struct {
+	*mock.Call
+}
+
+// AddVote is a helper method to define mock.On call
+//   - vote *models.QAVote
+func (_e *MockQuestionRepositoryInterface_Expecter) AddVote(vote interface{}) *MockQuestionRepositoryInterface_AddVote_Call {
+	return &MockQuestionRepositoryInterface_AddVote_Call{Call: _e.mock.On("AddVote", vote)}
+}
+
+func (_c *MockQuestionRepositoryInterface_AddVote_Call) Run(run func(vote *models.QAVote)) *MockQuestionRepositoryInterface_AddVote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.QAVote))
+	})
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_AddVote_Call) Return(_a0 bool, _a1 error) *MockQuestionRepositoryInterface_AddVote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_AddVote_Call) RunAndReturn(run func(*models.QAVote) (bool, error)) *MockQuestionRepositoryInterface_AddVote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAnswer provides a mock function with given fields: answer
+func (_m *MockQuestionRepositoryInterface) CreateAnswer(answer *models.ProductAnswer) error {
+	ret := _m.Called(answer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAnswer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ProductAnswer) error); ok {
+		r0 = rf(answer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuestionRepositoryInterface_CreateAnswer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAnswer'
+type MockQuestionRepositoryInterface_CreateAnswer_Call struct {
+	*mock.Call
+}
+
+// CreateAnswer is a helper method to define mock.On call
+//   - answer *models.ProductAnswer
+func (_e *MockQuestionRepositoryInterface_Expecter) CreateAnswer(answer interface{}) *MockQuestionRepositoryInterface_CreateAnswer_Call {
+	return &MockQuestionRepositoryInterface_CreateAnswer_Call{Call: _e.mock.On("CreateAnswer", answer)}
+}
+
+func (_c *MockQuestionRepositoryInterface_CreateAnswer_Call) Run(run func(answer *models.ProductAnswer)) *MockQuestionRepositoryInterface_CreateAnswer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.ProductAnswer))
+	})
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_CreateAnswer_Call) Return(_a0 error) *MockQuestionRepositoryInterface_CreateAnswer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_CreateAnswer_Call) RunAndReturn(run func(*models.ProductAnswer) error) *MockQuestionRepositoryInterface_CreateAnswer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateQuestion provides a mock function with given fields: question
+func (_m *MockQuestionRepositoryInterface) CreateQuestion(question *models.ProductQuestion) error {
+	ret := _m.Called(question)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateQuestion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ProductQuestion) error); ok {
+		r0 = rf(question)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuestionRepositoryInterface_CreateQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQuestion'
+type MockQuestionRepositoryInterface_CreateQuestion_Call struct {
+	*mock.Call
+}
+
+// CreateQuestion is a helper method to define mock.On call
+//   - question *models.ProductQuestion
+func (_e *MockQuestionRepositoryInterface_Expecter) CreateQuestion(question interface{}) *MockQuestionRepositoryInterface_CreateQuestion_Call {
+	return &MockQuestionRepositoryInterface_CreateQuestion_Call{Call: _e.mock.On("CreateQuestion", question)}
+}
+
+func (_c *MockQuestionRepositoryInterface_CreateQuestion_Call) Run(run func(question *models.ProductQuestion)) *MockQuestionRepositoryInterface_CreateQuestion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.ProductQuestion))
+	})
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_CreateQuestion_Call) Return(_a0 error) *MockQuestionRepositoryInterface_CreateQuestion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_CreateQuestion_Call) RunAndReturn(run func(*models.ProductQuestion) error) *MockQuestionRepositoryInterface_CreateQuestion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAnswerByID provides a mock function with given fields: id
+func (_m *MockQuestionRepositoryInterface) GetAnswerByID(id uint) (*models.ProductAnswer, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAnswerByID")
+	}
+
+	var r0 *models.ProductAnswer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*models.ProductAnswer, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *models.ProductAnswer); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProductAnswer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuestionRepositoryInterface_GetAnswerByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAnswerByID'
+type MockQuestionRepositoryInterface_GetAnswerByID_Call struct {
+	*mock.Call
+}
+
+// GetAnswerByID is a helper method to define mock.On call
+//   - id uint
+func (_e *MockQuestionRepositoryInterface_Expecter) GetAnswerByID(id interface{}) *MockQuestionRepositoryInterface_GetAnswerByID_Call {
+	return &MockQuestionRepositoryInterface_GetAnswerByID_Call{Call: _e.mock.On("GetAnswerByID", id)}
+}
+
+func (_c *MockQuestionRepositoryInterface_GetAnswerByID_Call) Run(run func(id uint)) *MockQuestionRepositoryInterface_GetAnswerByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_GetAnswerByID_Call) Return(_a0 *models.ProductAnswer, _a1 error) *MockQuestionRepositoryInterface_GetAnswerByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_GetAnswerByID_Call) RunAndReturn(run func(uint) (*models.ProductAnswer, error)) *MockQuestionRepositoryInterface_GetAnswerByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAnswersByStatus provides a mock function with given fields: status, limit, offset
+func (_m *MockQuestionRepositoryInterface) GetAnswersByStatus(status models.QAStatus, limit int, offset int) ([]models.ProductAnswer, int64, error) {
+	ret := _m.Called(status, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAnswersByStatus")
+	}
+
+	var r0 []models.ProductAnswer
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(models.QAStatus, int, int) ([]models.ProductAnswer, int64, error)); ok {
+		return rf(status, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(models.QAStatus, int, int) []models.ProductAnswer); ok {
+		r0 = rf(status, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductAnswer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.QAStatus, int, int) int64); ok {
+		r1 = rf(status, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(models.QAStatus, int, int) error); ok {
+		r2 = rf(status, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockQuestionRepositoryInterface_GetAnswersByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAnswersByStatus'
+type MockQuestionRepositoryInterface_GetAnswersByStatus_Call struct {
+	*mock.Call
+}
+
+// GetAnswersByStatus is a helper method to define mock.On call
+//   - status models.QAStatus
+//   - limit int
+//   - offset int
+func (_e *MockQuestionRepositoryInterface_Expecter) GetAnswersByStatus(status interface{}, limit interface{}, offset interface{}) *MockQuestionRepositoryInterface_GetAnswersByStatus_Call {
+	return &MockQuestionRepositoryInterface_GetAnswersByStatus_Call{Call: _e.mock.On("GetAnswersByStatus", status, limit, offset)}
+}
+
+func (_c *MockQuestionRepositoryInterface_GetAnswersByStatus_Call) Run(run func(status models.QAStatus, limit int, offset int)) *MockQuestionRepositoryInterface_GetAnswersByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.QAStatus), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_GetAnswersByStatus_Call) Return(_a0 []models.ProductAnswer, _a1 int64, _a2 error) *MockQuestionRepositoryInterface_GetAnswersByStatus_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_GetAnswersByStatus_Call) RunAndReturn(run func(models.QAStatus, int, int) ([]models.ProductAnswer, int64, error)) *MockQuestionRepositoryInterface_GetAnswersByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPublishedQuestions provides a mock function with given fields: productID, limit, offset
+func (_m *MockQuestionRepositoryInterface) GetPublishedQuestions(productID uint, limit int, offset int) ([]models.ProductQuestion, int64, error) {
+	ret := _m.Called(productID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublishedQuestions")
+	}
+
+	var r0 []models.ProductQuestion
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, int, int) ([]models.ProductQuestion, int64, error)); ok {
+		return rf(productID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(uint, int, int) []models.ProductQuestion); ok {
+		r0 = rf(productID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductQuestion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, int, int) int64); ok {
+		r1 = rf(productID, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, int, int) error); ok {
+		r2 = rf(productID, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockQuestionRepositoryInterface_GetPublishedQuestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublishedQuestions'
+type MockQuestionRepositoryInterface_GetPublishedQuestions_Call struct {
+	*mock.Call
+}
+
+// GetPublishedQuestions is a helper method to define mock.On call
+//   - productID uint
+//   - limit int
+//   - offset int
+func (_e *MockQuestionRepositoryInterface_Expecter) GetPublishedQuestions(productID interface{}, limit interface{}, offset interface{}) *MockQuestionRepositoryInterface_GetPublishedQuestions_Call {
+	return &MockQuestionRepositoryInterface_GetPublishedQuestions_Call{Call: _e.mock.On("GetPublishedQuestions", productID, limit, offset)}
+}
+
+func (_c *MockQuestionRepositoryInterface_GetPublishedQuestions_Call) Run(run func(productID uint, limit int, offset int)) *MockQuestionRepositoryInterface_GetPublishedQuestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_GetPublishedQuestions_Call) Return(_a0 []models.ProductQuestion, _a1 int64, _a2 error) *MockQuestionRepositoryInterface_GetPublishedQuestions_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_GetPublishedQuestions_Call) RunAndReturn(run func(uint, int, int) ([]models.ProductQuestion, int64, error)) *MockQuestionRepositoryInterface_GetPublishedQuestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuestionByID provides a mock function with given fields: id
+func (_m *MockQuestionRepositoryInterface) GetQuestionByID(id uint) (*models.ProductQuestion, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuestionByID")
+	}
+
+	var r0 *models.ProductQuestion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*models.ProductQuestion, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *models.ProductQuestion); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProductQuestion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuestionRepositoryInterface_GetQuestionByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuestionByID'
+type MockQuestionRepositoryInterface_GetQuestionByID_Call struct {
+	*mock.Call
+}
+
+// GetQuestionByID is a helper method to define mock.On call
+//   - id uint
+func (_e *MockQuestionRepositoryInterface_Expecter) GetQuestionByID(id interface{}) *MockQuestionRepositoryInterface_GetQuestionByID_Call {
+	return &MockQuestionRepositoryInterface_GetQuestionByID_Call{Call: _e.mock.On("GetQuestionByID", id)}
+}
+
+func (_c *MockQuestionRepositoryInterface_GetQuestionByID_Call) Run(run func(id uint)) *MockQuestionRepositoryInterface_GetQuestionByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_GetQuestionByID_Call) Return(_a0 *models.ProductQuestion, _a1 error) *MockQuestionRepositoryInterface_GetQuestionByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_GetQuestionByID_Call) RunAndReturn(run func(uint) (*models.ProductQuestion, error)) *MockQuestionRepositoryInterface_GetQuestionByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuestionsByStatus provides a mock function with given fields: status, limit, offset
+func (_m *MockQuestionRepositoryInterface) GetQuestionsByStatus(status models.QAStatus, limit int, offset int) ([]models.ProductQuestion, int64, error) {
+	ret := _m.Called(status, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuestionsByStatus")
+	}
+
+	var r0 []models.ProductQuestion
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(models.QAStatus, int, int) ([]models.ProductQuestion, int64, error)); ok {
+		return rf(status, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(models.QAStatus, int, int) []models.ProductQuestion); ok {
+		r0 = rf(status, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductQuestion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.QAStatus, int, int) int64); ok {
+		r1 = rf(status, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(models.QAStatus, int, int) error); ok {
+		r2 = rf(status, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockQuestionRepositoryInterface_GetQuestionsByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuestionsByStatus'
+type MockQuestionRepositoryInterface_GetQuestionsByStatus_Call struct {
+	*mock.Call
+}
+
+// GetQuestionsByStatus is a helper method to define mock.On call
+//   - status models.QAStatus
+//   - limit int
+//   - offset int
+func (_e *MockQuestionRepositoryInterface_Expecter) GetQuestionsByStatus(status interface{}, limit interface{}, offset interface{}) *MockQuestionRepositoryInterface_GetQuestionsByStatus_Call {
+	return &MockQuestionRepositoryInterface_GetQuestionsByStatus_Call{Call: _e.mock.On("GetQuestionsByStatus", status, limit, offset)}
+}
+
+func (_c *MockQuestionRepositoryInterface_GetQuestionsByStatus_Call) Run(run func(status models.QAStatus, limit int, offset int)) *MockQuestionRepositoryInterface_GetQuestionsByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.QAStatus), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_GetQuestionsByStatus_Call) Return(_a0 []models.ProductQuestion, _a1 int64, _a2 error) *MockQuestionRepositoryInterface_GetQuestionsByStatus_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_GetQuestionsByStatus_Call) RunAndReturn(run func(models.QAStatus, int, int) ([]models.ProductQuestion, int64, error)) *MockQuestionRepositoryInterface_GetQuestionsByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAnswerNotified provides a mock function with given fields: id, now
+func (_m *MockQuestionRepositoryInterface) MarkAnswerNotified(id uint, now time.Time) (bool, error) {
+	ret := _m.Called(id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAnswerNotified")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time) (bool, error)); ok {
+		return rf(id, now)
+	}
+	if rf, ok := ret.Get(0).(func(uint, time.Time) bool); ok {
+		r0 = rf(id, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, time.Time) error); ok {
+		r1 = rf(id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuestionRepositoryInterface_MarkAnswerNotified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAnswerNotified'
+type MockQuestionRepositoryInterface_MarkAnswerNotified_Call struct {
+	*mock.Call
+}
+
+// MarkAnswerNotified is a helper method to define mock.On call
+//   - id uint
+//   - now time.Time
+func (_e *MockQuestionRepositoryInterface_Expecter) MarkAnswerNotified(id interface{}, now interface{}) *MockQuestionRepositoryInterface_MarkAnswerNotified_Call {
+	return &MockQuestionRepositoryInterface_MarkAnswerNotified_Call{Call: _e.mock.On("MarkAnswerNotified", id, now)}
+}
+
+func (_c *MockQuestionRepositoryInterface_MarkAnswerNotified_Call) Run(run func(id uint, now time.Time)) *MockQuestionRepositoryInterface_MarkAnswerNotified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_MarkAnswerNotified_Call) Return(_a0 bool, _a1 error) *MockQuestionRepositoryInterface_MarkAnswerNotified_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_MarkAnswerNotified_Call) RunAndReturn(run func(uint, time.Time) (bool, error)) *MockQuestionRepositoryInterface_MarkAnswerNotified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveVote provides a mock function with given fields: vote
+func (_m *MockQuestionRepositoryInterface) RemoveVote(vote *models.QAVote) (bool, error) {
+	ret := _m.Called(vote)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveVote")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.QAVote) (bool, error)); ok {
+		return rf(vote)
+	}
+	if rf, ok := ret.Get(0).(func(*models.QAVote) bool); ok {
+		r0 = rf(vote)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.QAVote) error); ok {
+		r1 = rf(vote)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuestionRepositoryInterface_RemoveVote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveVote'
+type MockQuestionRepositoryInterface_RemoveVote_Call struct {
+	*mock.Call
+}
+
+// RemoveVote is a helper method to define mock.On call
+//   - vote *models.QAVote
+func (_e *MockQuestionRepositoryInterface_Expecter) RemoveVote(vote interface{}) *MockQuestionRepositoryInterface_RemoveVote_Call {
+	return &MockQuestionRepositoryInterface_RemoveVote_Call{Call: _e.mock.On("RemoveVote", vote)}
+}
+
+func (_c *MockQuestionRepositoryInterface_RemoveVote_Call) Run(run func(vote *models.QAVote)) *MockQuestionRepositoryInterface_RemoveVote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.QAVote))
+	})
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_RemoveVote_Call) Return(_a0 bool, _a1 error) *MockQuestionRepositoryInterface_RemoveVote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_RemoveVote_Call) RunAndReturn(run func(*models.QAVote) (bool, error)) *MockQuestionRepositoryInterface_RemoveVote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAnswerStatus provides a mock function with given fields: id, status
+func (_m *MockQuestionRepositoryInterface) UpdateAnswerStatus(id uint, status models.QAStatus) error {
+	ret := _m.Called(id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAnswerStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, models.QAStatus) error); ok {
+		r0 = rf(id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuestionRepositoryInterface_UpdateAnswerStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAnswerStatus'
+type MockQuestionRepositoryInterface_UpdateAnswerStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateAnswerStatus is a helper method to define mock.On call
+//   - id uint
+//   - status models.QAStatus
+func (_e *MockQuestionRepositoryInterface_Expecter) UpdateAnswerStatus(id interface{}, status interface{}) *MockQuestionRepositoryInterface_UpdateAnswerStatus_Call {
+	return &MockQuestionRepositoryInterface_UpdateAnswerStatus_Call{Call: _e.mock.On("UpdateAnswerStatus", id, status)}
+}
+
+func (_c *MockQuestionRepositoryInterface_UpdateAnswerStatus_Call) Run(run func(id uint, status models.QAStatus)) *MockQuestionRepositoryInterface_UpdateAnswerStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(models.QAStatus))
+	})
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_UpdateAnswerStatus_Call) Return(_a0 error) *MockQuestionRepositoryInterface_UpdateAnswerStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_UpdateAnswerStatus_Call) RunAndReturn(run func(uint, models.QAStatus) error) *MockQuestionRepositoryInterface_UpdateAnswerStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateQuestionStatus provides a mock function with given fields: id, status
+func (_m *MockQuestionRepositoryInterface) UpdateQuestionStatus(id uint, status models.QAStatus) error {
+	ret := _m.Called(id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateQuestionStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, models.QAStatus) error); ok {
+		r0 = rf(id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuestionRepositoryInterface_UpdateQuestionStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateQuestionStatus'
+type MockQuestionRepositoryInterface_UpdateQuestionStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateQuestionStatus is a helper method to define mock.On call
+//   - id uint
+//   - status models.QAStatus
+func (_e *MockQuestionRepositoryInterface_Expecter) UpdateQuestionStatus(id interface{}, status interface{}) *MockQuestionRepositoryInterface_UpdateQuestionStatus_Call {
+	return &MockQuestionRepositoryInterface_UpdateQuestionStatus_Call{Call: _e.mock.On("UpdateQuestionStatus", id, status)}
+}
+
+func (_c *MockQuestionRepositoryInterface_UpdateQuestionStatus_Call) Run(run func(id uint, status models.QAStatus)) *MockQuestionRepositoryInterface_UpdateQuestionStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(models.QAStatus))
+	})
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_UpdateQuestionStatus_Call) Return(_a0 error) *MockQuestionRepositoryInterface_UpdateQuestionStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuestionRepositoryInterface_UpdateQuestionStatus_Call) RunAndReturn(run func(uint, models.QAStatus) error) *MockQuestionRepositoryInterface_UpdateQuestionStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockQuestionRepositoryInterface creates a new instance of MockQuestionRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockQuestionRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockQuestionRepositoryInterface {
+	mock := &MockQuestionRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
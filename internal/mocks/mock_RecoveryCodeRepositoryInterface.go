@@ -0,0 +1,187 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockRecoveryCodeRepositoryInterface is an autogenerated mock type for the RecoveryCodeRepositoryInterface type
+type MockRecoveryCodeRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockRecoveryCodeRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRecoveryCodeRepositoryInterface) EXPECT() *MockRecoveryCodeRepositoryInterface_Expecter {
+	return &MockRecoveryCodeRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// DeleteByUserID provides a mock function with given fields: userID
+func (_m *MockRecoveryCodeRepositoryInterface) DeleteByUserID(userID uint) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRecoveryCodeRepositoryInterface_DeleteByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUserID'
+type MockRecoveryCodeRepositoryInterface_DeleteByUserID_Call struct {
+	*mock.Call
+}
+
+// DeleteByUserID is a helper method to define mock.On call
+//   - userID uint
+func (_e *MockRecoveryCodeRepositoryInterface_Expecter) DeleteByUserID(userID interface{}) *MockRecoveryCodeRepositoryInterface_DeleteByUserID_Call {
+	return &MockRecoveryCodeRepositoryInterface_DeleteByUserID_Call{Call: _e.mock.On("DeleteByUserID", userID)}
+}
+
+func (_c *MockRecoveryCodeRepositoryInterface_DeleteByUserID_Call) Run(run func(userID uint)) *MockRecoveryCodeRepositoryInterface_DeleteByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockRecoveryCodeRepositoryInterface_DeleteByUserID_Call) Return(_a0 error) *MockRecoveryCodeRepositoryInterface_DeleteByUserID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRecoveryCodeRepositoryInterface_DeleteByUserID_Call) RunAndReturn(run func(uint) error) *MockRecoveryCodeRepositoryInterface_DeleteByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceAll provides a mock function with given fields: userID, hashes
+func (_m *MockRecoveryCodeRepositoryInterface) ReplaceAll(userID uint, hashes []string) error {
+	ret := _m.Called(userID, hashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, []string) error); ok {
+		r0 = rf(userID, hashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRecoveryCodeRepositoryInterface_ReplaceAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceAll'
+type MockRecoveryCodeRepositoryInterface_ReplaceAll_Call struct {
+	*mock.Call
+}
+
+// ReplaceAll is a helper method to define mock.On call
+//   - userID uint
+//   - hashes []string
+func (_e *MockRecoveryCodeRepositoryInterface_Expecter) ReplaceAll(userID interface{}, hashes interface{}) *MockRecoveryCodeRepositoryInterface_ReplaceAll_Call {
+	return &MockRecoveryCodeRepositoryInterface_ReplaceAll_Call{Call: _e.mock.On("ReplaceAll", userID, hashes)}
+}
+
+func (_c *MockRecoveryCodeRepositoryInterface_ReplaceAll_Call) Run(run func(userID uint, hashes []string)) *MockRecoveryCodeRepositoryInterface_ReplaceAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRecoveryCodeRepositoryInterface_ReplaceAll_Call) Return(_a0 error) *MockRecoveryCodeRepositoryInterface_ReplaceAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRecoveryCodeRepositoryInterface_ReplaceAll_Call) RunAndReturn(run func(uint, []string) error) *MockRecoveryCodeRepositoryInterface_ReplaceAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Use provides a mock function with given fields: userID, hash, now
+func (_m *MockRecoveryCodeRepositoryInterface) Use(userID uint, hash string, now time.Time) (bool, error) {
+	ret := _m.Called(userID, hash, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Use")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, string, time.Time) (bool, error)); ok {
+		return rf(userID, hash, now)
+	}
+	if rf, ok := ret.Get(0).(func(uint, string, time.Time) bool); ok {
+		r0 = rf(userID, hash, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, string, time.Time) error); ok {
+		r1 = rf(userID, hash, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRecoveryCodeRepositoryInterface_Use_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Use'
+type MockRecoveryCodeRepositoryInterface_Use_Call struct {
+	*mock.Call
+}
+
+// Use is a helper method to define mock.On call
+//   - userID uint
+//   - hash string
+//   - now time.Time
+func (_e *MockRecoveryCodeRepositoryInterface_Expecter) Use(userID interface{}, hash interface{}, now interface{}) *MockRecoveryCodeRepositoryInterface_Use_Call {
+	return &MockRecoveryCodeRepositoryInterface_Use_Call{Call: _e.mock.On("Use", userID, hash, now)}
+}
+
+func (_c *MockRecoveryCodeRepositoryInterface_Use_Call) Run(run func(userID uint, hash string, now time.Time)) *MockRecoveryCodeRepositoryInterface_Use_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRecoveryCodeRepositoryInterface_Use_Call) Return(_a0 bool, _a1 error) *MockRecoveryCodeRepositoryInterface_Use_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRecoveryCodeRepositoryInterface_Use_Call) RunAndReturn(run func(uint, string, time.Time) (bool, error)) *MockRecoveryCodeRepositoryInterface_Use_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRecoveryCodeRepositoryInterface creates a new instance of MockRecoveryCodeRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRecoveryCodeRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRecoveryCodeRepositoryInterface {
+	mock := &MockRecoveryCodeRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,529 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/JihadRinaldi/go-shop/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockReviewRepositoryInterface is an autogenerated mock type for the ReviewRepositoryInterface type
+type MockReviewRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockReviewRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReviewRepositoryInterface) EXPECT() *MockReviewRepositoryInterface_Expecter {
+	return &MockReviewRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// CountImages provides a mock function with given fields: reviewID
+func (_m *MockReviewRepositoryInterface) CountImages(reviewID uint) (int64, error) {
+	ret := _m.Called(reviewID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountImages")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (int64, error)); ok {
+		return rf(reviewID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) int64); ok {
+		r0 = rf(reviewID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(reviewID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReviewRepositoryInterface_CountImages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountImages'
+type MockReviewRepositoryInterface_CountImages_Call struct {
+	*mock.Call
+}
+
+// CountImages is a helper method to define mock.On call
+//   - reviewID uint
+func (_e *MockReviewRepositoryInterface_Expecter) CountImages(reviewID interface{}) *MockReviewRepositoryInterface_CountImages_Call {
+	return &MockReviewRepositoryInterface_CountImages_Call{Call: _e.mock.On("CountImages", reviewID)}
+}
+
+func (_c *MockReviewRepositoryInterface_CountImages_Call) Run(run func(reviewID uint)) *MockReviewRepositoryInterface_CountImages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_CountImages_Call) Return(_a0 int64, _a1 error) *MockReviewRepositoryInterface_CountImages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_CountImages_Call) RunAndReturn(run func(uint) (int64, error)) *MockReviewRepositoryInterface_CountImages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: review
+func (_m *MockReviewRepositoryInterface) Create(review *models.Review) error {
+	ret := _m.Called(review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Review) error); ok {
+		r0 = rf(review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReviewRepositoryInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockReviewRepositoryInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - review *models.Review
+func (_e *MockReviewRepositoryInterface_Expecter) Create(review interface{}) *MockReviewRepositoryInterface_Create_Call {
+	return &MockReviewRepositoryInterface_Create_Call{Call: _e.mock.On("Create", review)}
+}
+
+func (_c *MockReviewRepositoryInterface_Create_Call) Run(run func(review *models.Review)) *MockReviewRepositoryInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Review))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_Create_Call) Return(_a0 error) *MockReviewRepositoryInterface_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_Create_Call) RunAndReturn(run func(*models.Review) error) *MockReviewRepositoryInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateImage provides a mock function with given fields: image
+func (_m *MockReviewRepositoryInterface) CreateImage(image *models.ReviewImage) error {
+	ret := _m.Called(image)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateImage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ReviewImage) error); ok {
+		r0 = rf(image)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReviewRepositoryInterface_CreateImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateImage'
+type MockReviewRepositoryInterface_CreateImage_Call struct {
+	*mock.Call
+}
+
+// CreateImage is a helper method to define mock.On call
+//   - image *models.ReviewImage
+func (_e *MockReviewRepositoryInterface_Expecter) CreateImage(image interface{}) *MockReviewRepositoryInterface_CreateImage_Call {
+	return &MockReviewRepositoryInterface_CreateImage_Call{Call: _e.mock.On("CreateImage", image)}
+}
+
+func (_c *MockReviewRepositoryInterface_CreateImage_Call) Run(run func(image *models.ReviewImage)) *MockReviewRepositoryInterface_CreateImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.ReviewImage))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_CreateImage_Call) Return(_a0 error) *MockReviewRepositoryInterface_CreateImage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_CreateImage_Call) RunAndReturn(run func(*models.ReviewImage) error) *MockReviewRepositoryInterface_CreateImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: review
+func (_m *MockReviewRepositoryInterface) Delete(review *models.Review) error {
+	ret := _m.Called(review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Review) error); ok {
+		r0 = rf(review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReviewRepositoryInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockReviewRepositoryInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - review *models.Review
+func (_e *MockReviewRepositoryInterface_Expecter) Delete(review interface{}) *MockReviewRepositoryInterface_Delete_Call {
+	return &MockReviewRepositoryInterface_Delete_Call{Call: _e.mock.On("Delete", review)}
+}
+
+func (_c *MockReviewRepositoryInterface_Delete_Call) Run(run func(review *models.Review)) *MockReviewRepositoryInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Review))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_Delete_Call) Return(_a0 error) *MockReviewRepositoryInterface_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_Delete_Call) RunAndReturn(run func(*models.Review) error) *MockReviewRepositoryInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MockReviewRepositoryInterface) GetByID(id uint) (*models.Review, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*models.Review, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *models.Review); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReviewRepositoryInterface_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockReviewRepositoryInterface_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - id uint
+func (_e *MockReviewRepositoryInterface_Expecter) GetByID(id interface{}) *MockReviewRepositoryInterface_GetByID_Call {
+	return &MockReviewRepositoryInterface_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+}
+
+func (_c *MockReviewRepositoryInterface_GetByID_Call) Run(run func(id uint)) *MockReviewRepositoryInterface_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_GetByID_Call) Return(_a0 *models.Review, _a1 error) *MockReviewRepositoryInterface_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_GetByID_Call) RunAndReturn(run func(uint) (*models.Review, error)) *MockReviewRepositoryInterface_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByProductID provides a mock function with given fields: productID, status, limit, offset
+func (_m *MockReviewRepositoryInterface) GetByProductID(productID uint, status models.ReviewStatus, limit int, offset int) ([]models.Review, int64, error) {
+	ret := _m.Called(productID, status, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByProductID")
+	}
+
+	var r0 []models.Review
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, models.ReviewStatus, int, int) ([]models.Review, int64, error)); ok {
+		return rf(productID, status, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(uint, models.ReviewStatus, int, int) []models.Review); ok {
+		r0 = rf(productID, status, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, models.ReviewStatus, int, int) int64); ok {
+		r1 = rf(productID, status, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, models.ReviewStatus, int, int) error); ok {
+		r2 = rf(productID, status, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockReviewRepositoryInterface_GetByProductID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByProductID'
+type MockReviewRepositoryInterface_GetByProductID_Call struct {
+	*mock.Call
+}
+
+// GetByProductID is a helper method to define mock.On call
+//   - productID uint
+//   - status models.ReviewStatus
+//   - limit int
+//   - offset int
+func (_e *MockReviewRepositoryInterface_Expecter) GetByProductID(productID interface{}, status interface{}, limit interface{}, offset interface{}) *MockReviewRepositoryInterface_GetByProductID_Call {
+	return &MockReviewRepositoryInterface_GetByProductID_Call{Call: _e.mock.On("GetByProductID", productID, status, limit, offset)}
+}
+
+func (_c *MockReviewRepositoryInterface_GetByProductID_Call) Run(run func(productID uint, status models.ReviewStatus, limit int, offset int)) *MockReviewRepositoryInterface_GetByProductID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(models.ReviewStatus), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_GetByProductID_Call) Return(_a0 []models.Review, _a1 int64, _a2 error) *MockReviewRepositoryInterface_GetByProductID_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_GetByProductID_Call) RunAndReturn(run func(uint, models.ReviewStatus, int, int) ([]models.Review, int64, error)) *MockReviewRepositoryInterface_GetByProductID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByStatus provides a mock function with given fields: status, limit, offset
+func (_m *MockReviewRepositoryInterface) GetByStatus(status models.ReviewStatus, limit int, offset int) ([]models.Review, int64, error) {
+	ret := _m.Called(status, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByStatus")
+	}
+
+	var r0 []models.Review
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(models.ReviewStatus, int, int) ([]models.Review, int64, error)); ok {
+		return rf(status, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(models.ReviewStatus, int, int) []models.Review); ok {
+		r0 = rf(status, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.ReviewStatus, int, int) int64); ok {
+		r1 = rf(status, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(models.ReviewStatus, int, int) error); ok {
+		r2 = rf(status, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockReviewRepositoryInterface_GetByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByStatus'
+type MockReviewRepositoryInterface_GetByStatus_Call struct {
+	*mock.Call
+}
+
+// GetByStatus is a helper method to define mock.On call
+//   - status models.ReviewStatus
+//   - limit int
+//   - offset int
+func (_e *MockReviewRepositoryInterface_Expecter) GetByStatus(status interface{}, limit interface{}, offset interface{}) *MockReviewRepositoryInterface_GetByStatus_Call {
+	return &MockReviewRepositoryInterface_GetByStatus_Call{Call: _e.mock.On("GetByStatus", status, limit, offset)}
+}
+
+func (_c *MockReviewRepositoryInterface_GetByStatus_Call) Run(run func(status models.ReviewStatus, limit int, offset int)) *MockReviewRepositoryInterface_GetByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.ReviewStatus), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_GetByStatus_Call) Return(_a0 []models.Review, _a1 int64, _a2 error) *MockReviewRepositoryInterface_GetByStatus_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_GetByStatus_Call) RunAndReturn(run func(models.ReviewStatus, int, int) ([]models.Review, int64, error)) *MockReviewRepositoryInterface_GetByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserAndProduct provides a mock function with given fields: userID, productID
+func (_m *MockReviewRepositoryInterface) GetByUserAndProduct(userID uint, productID uint) (*models.Review, error) {
+	ret := _m.Called(userID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserAndProduct")
+	}
+
+	var r0 *models.Review
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (*models.Review, error)); ok {
+		return rf(userID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) *models.Review); ok {
+		r0 = rf(userID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Review)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(userID, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReviewRepositoryInterface_GetByUserAndProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserAndProduct'
+type MockReviewRepositoryInterface_GetByUserAndProduct_Call struct {
+	*mock.Call
+}
+
+// GetByUserAndProduct is a helper method to define mock.On call
+//   - userID uint
+//   - productID uint
+func (_e *MockReviewRepositoryInterface_Expecter) GetByUserAndProduct(userID interface{}, productID interface{}) *MockReviewRepositoryInterface_GetByUserAndProduct_Call {
+	return &MockReviewRepositoryInterface_GetByUserAndProduct_Call{Call: _e.mock.On("GetByUserAndProduct", userID, productID)}
+}
+
+func (_c *MockReviewRepositoryInterface_GetByUserAndProduct_Call) Run(run func(userID uint, productID uint)) *MockReviewRepositoryInterface_GetByUserAndProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_GetByUserAndProduct_Call) Return(_a0 *models.Review, _a1 error) *MockReviewRepositoryInterface_GetByUserAndProduct_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_GetByUserAndProduct_Call) RunAndReturn(run func(uint, uint) (*models.Review, error)) *MockReviewRepositoryInterface_GetByUserAndProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function with given fields: review, status, note
+func (_m *MockReviewRepositoryInterface) UpdateStatus(review *models.Review, status models.ReviewStatus, note string) error {
+	ret := _m.Called(review, status, note)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Review, models.ReviewStatus, string) error); ok {
+		r0 = rf(review, status, note)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReviewRepositoryInterface_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type MockReviewRepositoryInterface_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - review *models.Review
+//   - status models.ReviewStatus
+//   - note string
+func (_e *MockReviewRepositoryInterface_Expecter) UpdateStatus(review interface{}, status interface{}, note interface{}) *MockReviewRepositoryInterface_UpdateStatus_Call {
+	return &MockReviewRepositoryInterface_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", review, status, note)}
+}
+
+func (_c *MockReviewRepositoryInterface_UpdateStatus_Call) Run(run func(review *models.Review, status models.ReviewStatus, note string)) *MockReviewRepositoryInterface_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Review), args[1].(models.ReviewStatus), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_UpdateStatus_Call) Return(_a0 error) *MockReviewRepositoryInterface_UpdateStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReviewRepositoryInterface_UpdateStatus_Call) RunAndReturn(run func(*models.Review, models.ReviewStatus, string) error) *MockReviewRepositoryInterface_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReviewRepositoryInterface creates a new instance of MockReviewRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReviewRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReviewRepositoryInterface {
+	mock := &MockReviewRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,565 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/JihadRinaldi/go-shop/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRoleRepositoryInterface is an autogenerated mock type for the RoleRepositoryInterface type
+type MockRoleRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockRoleRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRoleRepositoryInterface) EXPECT() *MockRoleRepositoryInterface_Expecter {
+	return &MockRoleRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// AssignRole provides a mock function with given fields: userID, roleID
+func (_m *MockRoleRepositoryInterface) AssignRole(userID uint, roleID uint) error {
+	ret := _m.Called(userID, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRoleRepositoryInterface_AssignRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignRole'
+type MockRoleRepositoryInterface_AssignRole_Call struct {
+	*mock.Call
+}
+
+// AssignRole is a helper method to define mock.On call
+//   - userID uint
+//   - roleID uint
+func (_e *MockRoleRepositoryInterface_Expecter) AssignRole(userID interface{}, roleID interface{}) *MockRoleRepositoryInterface_AssignRole_Call {
+	return &MockRoleRepositoryInterface_AssignRole_Call{Call: _e.mock.On("AssignRole", userID, roleID)}
+}
+
+func (_c *MockRoleRepositoryInterface_AssignRole_Call) Run(run func(userID uint, roleID uint)) *MockRoleRepositoryInterface_AssignRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_AssignRole_Call) Return(_a0 error) *MockRoleRepositoryInterface_AssignRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_AssignRole_Call) RunAndReturn(run func(uint, uint) error) *MockRoleRepositoryInterface_AssignRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUsersWithRole provides a mock function with given fields: roleID
+func (_m *MockRoleRepositoryInterface) CountUsersWithRole(roleID uint) (int64, error) {
+	ret := _m.Called(roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsersWithRole")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (int64, error)); ok {
+		return rf(roleID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) int64); ok {
+		r0 = rf(roleID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleRepositoryInterface_CountUsersWithRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUsersWithRole'
+type MockRoleRepositoryInterface_CountUsersWithRole_Call struct {
+	*mock.Call
+}
+
+// CountUsersWithRole is a helper method to define mock.On call
+//   - roleID uint
+func (_e *MockRoleRepositoryInterface_Expecter) CountUsersWithRole(roleID interface{}) *MockRoleRepositoryInterface_CountUsersWithRole_Call {
+	return &MockRoleRepositoryInterface_CountUsersWithRole_Call{Call: _e.mock.On("CountUsersWithRole", roleID)}
+}
+
+func (_c *MockRoleRepositoryInterface_CountUsersWithRole_Call) Run(run func(roleID uint)) *MockRoleRepositoryInterface_CountUsersWithRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_CountUsersWithRole_Call) Return(_a0 int64, _a1 error) *MockRoleRepositoryInterface_CountUsersWithRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_CountUsersWithRole_Call) RunAndReturn(run func(uint) (int64, error)) *MockRoleRepositoryInterface_CountUsersWithRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: role
+func (_m *MockRoleRepositoryInterface) Create(role *models.Role) error {
+	ret := _m.Called(role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Role) error); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRoleRepositoryInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockRoleRepositoryInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - role *models.Role
+func (_e *MockRoleRepositoryInterface_Expecter) Create(role interface{}) *MockRoleRepositoryInterface_Create_Call {
+	return &MockRoleRepositoryInterface_Create_Call{Call: _e.mock.On("Create", role)}
+}
+
+func (_c *MockRoleRepositoryInterface_Create_Call) Run(run func(role *models.Role)) *MockRoleRepositoryInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Role))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_Create_Call) Return(_a0 error) *MockRoleRepositoryInterface_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_Create_Call) RunAndReturn(run func(*models.Role) error) *MockRoleRepositoryInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *MockRoleRepositoryInterface) Delete(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRoleRepositoryInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockRoleRepositoryInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uint
+func (_e *MockRoleRepositoryInterface_Expecter) Delete(id interface{}) *MockRoleRepositoryInterface_Delete_Call {
+	return &MockRoleRepositoryInterface_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *MockRoleRepositoryInterface_Delete_Call) Run(run func(id uint)) *MockRoleRepositoryInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_Delete_Call) Return(_a0 error) *MockRoleRepositoryInterface_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_Delete_Call) RunAndReturn(run func(uint) error) *MockRoleRepositoryInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with no fields
+func (_m *MockRoleRepositoryInterface) GetAll() ([]models.Role, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.Role, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.Role); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleRepositoryInterface_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockRoleRepositoryInterface_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+func (_e *MockRoleRepositoryInterface_Expecter) GetAll() *MockRoleRepositoryInterface_GetAll_Call {
+	return &MockRoleRepositoryInterface_GetAll_Call{Call: _e.mock.On("GetAll")}
+}
+
+func (_c *MockRoleRepositoryInterface_GetAll_Call) Run(run func()) *MockRoleRepositoryInterface_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_GetAll_Call) Return(_a0 []models.Role, _a1 error) *MockRoleRepositoryInterface_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_GetAll_Call) RunAndReturn(run func() ([]models.Role, error)) *MockRoleRepositoryInterface_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MockRoleRepositoryInterface) GetByID(id uint) (*models.Role, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*models.Role, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *models.Role); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleRepositoryInterface_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockRoleRepositoryInterface_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - id uint
+func (_e *MockRoleRepositoryInterface_Expecter) GetByID(id interface{}) *MockRoleRepositoryInterface_GetByID_Call {
+	return &MockRoleRepositoryInterface_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+}
+
+func (_c *MockRoleRepositoryInterface_GetByID_Call) Run(run func(id uint)) *MockRoleRepositoryInterface_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_GetByID_Call) Return(_a0 *models.Role, _a1 error) *MockRoleRepositoryInterface_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_GetByID_Call) RunAndReturn(run func(uint) (*models.Role, error)) *MockRoleRepositoryInterface_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByName provides a mock function with given fields: name
+func (_m *MockRoleRepositoryInterface) GetByName(name string) (*models.Role, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByName")
+	}
+
+	var r0 *models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.Role, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.Role); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleRepositoryInterface_GetByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByName'
+type MockRoleRepositoryInterface_GetByName_Call struct {
+	*mock.Call
+}
+
+// GetByName is a helper method to define mock.On call
+//   - name string
+func (_e *MockRoleRepositoryInterface_Expecter) GetByName(name interface{}) *MockRoleRepositoryInterface_GetByName_Call {
+	return &MockRoleRepositoryInterface_GetByName_Call{Call: _e.mock.On("GetByName", name)}
+}
+
+func (_c *MockRoleRepositoryInterface_GetByName_Call) Run(run func(name string)) *MockRoleRepositoryInterface_GetByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_GetByName_Call) Return(_a0 *models.Role, _a1 error) *MockRoleRepositoryInterface_GetByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_GetByName_Call) RunAndReturn(run func(string) (*models.Role, error)) *MockRoleRepositoryInterface_GetByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserRoles provides a mock function with given fields: userID
+func (_m *MockRoleRepositoryInterface) GetUserRoles(userID uint) ([]models.Role, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserRoles")
+	}
+
+	var r0 []models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]models.Role, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []models.Role); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleRepositoryInterface_GetUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserRoles'
+type MockRoleRepositoryInterface_GetUserRoles_Call struct {
+	*mock.Call
+}
+
+// GetUserRoles is a helper method to define mock.On call
+//   - userID uint
+func (_e *MockRoleRepositoryInterface_Expecter) GetUserRoles(userID interface{}) *MockRoleRepositoryInterface_GetUserRoles_Call {
+	return &MockRoleRepositoryInterface_GetUserRoles_Call{Call: _e.mock.On("GetUserRoles", userID)}
+}
+
+func (_c *MockRoleRepositoryInterface_GetUserRoles_Call) Run(run func(userID uint)) *MockRoleRepositoryInterface_GetUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_GetUserRoles_Call) Return(_a0 []models.Role, _a1 error) *MockRoleRepositoryInterface_GetUserRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_GetUserRoles_Call) RunAndReturn(run func(uint) ([]models.Role, error)) *MockRoleRepositoryInterface_GetUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveRole provides a mock function with given fields: userID, roleID, keepLastHolder
+func (_m *MockRoleRepositoryInterface) RemoveRole(userID uint, roleID uint, keepLastHolder bool) (bool, error) {
+	ret := _m.Called(userID, roleID, keepLastHolder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveRole")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint, bool) (bool, error)); ok {
+		return rf(userID, roleID, keepLastHolder)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint, bool) bool); ok {
+		r0 = rf(userID, roleID, keepLastHolder)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint, bool) error); ok {
+		r1 = rf(userID, roleID, keepLastHolder)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRoleRepositoryInterface_RemoveRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveRole'
+type MockRoleRepositoryInterface_RemoveRole_Call struct {
+	*mock.Call
+}
+
+// RemoveRole is a helper method to define mock.On call
+//   - userID uint
+//   - roleID uint
+//   - keepLastHolder bool
+func (_e *MockRoleRepositoryInterface_Expecter) RemoveRole(userID interface{}, roleID interface{}, keepLastHolder interface{}) *MockRoleRepositoryInterface_RemoveRole_Call {
+	return &MockRoleRepositoryInterface_RemoveRole_Call{Call: _e.mock.On("RemoveRole", userID, roleID, keepLastHolder)}
+}
+
+func (_c *MockRoleRepositoryInterface_RemoveRole_Call) Run(run func(userID uint, roleID uint, keepLastHolder bool)) *MockRoleRepositoryInterface_RemoveRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_RemoveRole_Call) Return(_a0 bool, _a1 error) *MockRoleRepositoryInterface_RemoveRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_RemoveRole_Call) RunAndReturn(run func(uint, uint, bool) (bool, error)) *MockRoleRepositoryInterface_RemoveRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: role
+func (_m *MockRoleRepositoryInterface) Update(role *models.Role) error {
+	ret := _m.Called(role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Role) error); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRoleRepositoryInterface_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockRoleRepositoryInterface_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - role *models.Role
+func (_e *MockRoleRepositoryInterface_Expecter) Update(role interface{}) *MockRoleRepositoryInterface_Update_Call {
+	return &MockRoleRepositoryInterface_Update_Call{Call: _e.mock.On("Update", role)}
+}
+
+func (_c *MockRoleRepositoryInterface_Update_Call) Run(run func(role *models.Role)) *MockRoleRepositoryInterface_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Role))
+	})
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_Update_Call) Return(_a0 error) *MockRoleRepositoryInterface_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRoleRepositoryInterface_Update_Call) RunAndReturn(run func(*models.Role) error) *MockRoleRepositoryInterface_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRoleRepositoryInterface creates a new instance of MockRoleRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRoleRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRoleRepositoryInterface {
+	mock := &MockRoleRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
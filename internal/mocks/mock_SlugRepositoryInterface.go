@@ -0,0 +1,152 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/JihadRinaldi/go-shop/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSlugRepositoryInterface is an autogenerated mock type for the SlugRepositoryInterface type
+type MockSlugRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockSlugRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSlugRepositoryInterface) EXPECT() *MockSlugRepositoryInterface_Expecter {
+	return &MockSlugRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// GetRedirect provides a mock function with given fields: entityType, slug
+func (_m *MockSlugRepositoryInterface) GetRedirect(entityType models.SlugEntityType, slug string) (*models.SlugRedirect, error) {
+	ret := _m.Called(entityType, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRedirect")
+	}
+
+	var r0 *models.SlugRedirect
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.SlugEntityType, string) (*models.SlugRedirect, error)); ok {
+		return rf(entityType, slug)
+	}
+	if rf, ok := ret.Get(0).(func(models.SlugEntityType, string) *models.SlugRedirect); ok {
+		r0 = rf(entityType, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SlugRedirect)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.SlugEntityType, string) error); ok {
+		r1 = rf(entityType, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSlugRepositoryInterface_GetRedirect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRedirect'
+type MockSlugRepositoryInterface_GetRedirect_Call struct {
+	*mock.Call
+}
+
+// GetRedirect is a helper method to define mock.On call
+//   - entityType models.SlugEntityType
+//   - slug string
+func (_e *MockSlugRepositoryInterface_Expecter) GetRedirect(entityType interface{}, slug interface{}) *MockSlugRepositoryInterface_GetRedirect_Call {
+	return &MockSlugRepositoryInterface_GetRedirect_Call{Call: _e.mock.On("GetRedirect", entityType, slug)}
+}
+
+func (_c *MockSlugRepositoryInterface_GetRedirect_Call) Run(run func(entityType models.SlugEntityType, slug string)) *MockSlugRepositoryInterface_GetRedirect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.SlugEntityType), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSlugRepositoryInterface_GetRedirect_Call) Return(_a0 *models.SlugRedirect, _a1 error) *MockSlugRepositoryInterface_GetRedirect_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSlugRepositoryInterface_GetRedirect_Call) RunAndReturn(run func(models.SlugEntityType, string) (*models.SlugRedirect, error)) *MockSlugRepositoryInterface_GetRedirect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsTaken provides a mock function with given fields: entityType, slug, excludeID
+func (_m *MockSlugRepositoryInterface) IsTaken(entityType models.SlugEntityType, slug string, excludeID uint) (bool, error) {
+	ret := _m.Called(entityType, slug, excludeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTaken")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.SlugEntityType, string, uint) (bool, error)); ok {
+		return rf(entityType, slug, excludeID)
+	}
+	if rf, ok := ret.Get(0).(func(models.SlugEntityType, string, uint) bool); ok {
+		r0 = rf(entityType, slug, excludeID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.SlugEntityType, string, uint) error); ok {
+		r1 = rf(entityType, slug, excludeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSlugRepositoryInterface_IsTaken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsTaken'
+type MockSlugRepositoryInterface_IsTaken_Call struct {
+	*mock.Call
+}
+
+// IsTaken is a helper method to define mock.On call
+//   - entityType models.SlugEntityType
+//   - slug string
+//   - excludeID uint
+func (_e *MockSlugRepositoryInterface_Expecter) IsTaken(entityType interface{}, slug interface{}, excludeID interface{}) *MockSlugRepositoryInterface_IsTaken_Call {
+	return &MockSlugRepositoryInterface_IsTaken_Call{Call: _e.mock.On("IsTaken", entityType, slug, excludeID)}
+}
+
+func (_c *MockSlugRepositoryInterface_IsTaken_Call) Run(run func(entityType models.SlugEntityType, slug string, excludeID uint)) *MockSlugRepositoryInterface_IsTaken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.SlugEntityType), args[1].(string), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockSlugRepositoryInterface_IsTaken_Call) Return(_a0 bool, _a1 error) *MockSlugRepositoryInterface_IsTaken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSlugRepositoryInterface_IsTaken_Call) RunAndReturn(run func(models.SlugEntityType, string, uint) (bool, error)) *MockSlugRepositoryInterface_IsTaken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSlugRepositoryInterface creates a new instance of MockSlugRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSlugRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSlugRepositoryInterface {
+	mock := &MockSlugRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,376 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/JihadRinaldi/go-shop/internal/models"
+	mock "github.com/stretchr/testify/mock"
+	gorm "gorm.io/gorm"
+
+	time "time"
+)
+
+// MockSubscriptionRepositoryInterface is an autogenerated mock type for the SubscriptionRepositoryInterface type
+type MockSubscriptionRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockSubscriptionRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSubscriptionRepositoryInterface) EXPECT() *MockSubscriptionRepositoryInterface_Expecter {
+	return &MockSubscriptionRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// Change provides a mock function with given fields: id, change
+func (_m *MockSubscriptionRepositoryInterface) Change(id uint, change func(*models.Subscription) ([]string, error)) (*models.Subscription, error) {
+	ret := _m.Called(id, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Change")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, func(*models.Subscription) ([]string, error)) (*models.Subscription, error)); ok {
+		return rf(id, change)
+	}
+	if rf, ok := ret.Get(0).(func(uint, func(*models.Subscription) ([]string, error)) *models.Subscription); ok {
+		r0 = rf(id, change)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, func(*models.Subscription) ([]string, error)) error); ok {
+		r1 = rf(id, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepositoryInterface_Change_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Change'
+type MockSubscriptionRepositoryInterface_Change_Call struct {
+	*mock.Call
+}
+
+// Change is a helper method to define mock.On call
+//   - id uint
+//   - change func(*models.Subscription)([]string , error)
+func (_e *MockSubscriptionRepositoryInterface_Expecter) Change(id interface{}, change interface{}) *MockSubscriptionRepositoryInterface_Change_Call {
+	return &MockSubscriptionRepositoryInterface_Change_Call{Call: _e.mock.On("Change", id, change)}
+}
+
+func (_c *MockSubscriptionRepositoryInterface_Change_Call) Run(run func(id uint, change func(*models.Subscription) ([]string, error))) *MockSubscriptionRepositoryInterface_Change_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(func(*models.Subscription) ([]string, error)))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepositoryInterface_Change_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionRepositoryInterface_Change_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepositoryInterface_Change_Call) RunAndReturn(run func(uint, func(*models.Subscription) ([]string, error)) (*models.Subscription, error)) *MockSubscriptionRepositoryInterface_Change_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: subscription
+func (_m *MockSubscriptionRepositoryInterface) Create(subscription *models.Subscription) error {
+	ret := _m.Called(subscription)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Subscription) error); ok {
+		r0 = rf(subscription)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSubscriptionRepositoryInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSubscriptionRepositoryInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - subscription *models.Subscription
+func (_e *MockSubscriptionRepositoryInterface_Expecter) Create(subscription interface{}) *MockSubscriptionRepositoryInterface_Create_Call {
+	return &MockSubscriptionRepositoryInterface_Create_Call{Call: _e.mock.On("Create", subscription)}
+}
+
+func (_c *MockSubscriptionRepositoryInterface_Create_Call) Run(run func(subscription *models.Subscription)) *MockSubscriptionRepositoryInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Subscription))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepositoryInterface_Create_Call) Return(_a0 error) *MockSubscriptionRepositoryInterface_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSubscriptionRepositoryInterface_Create_Call) RunAndReturn(run func(*models.Subscription) error) *MockSubscriptionRepositoryInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MockSubscriptionRepositoryInterface) GetByID(id uint) (*models.Subscription, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*models.Subscription, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *models.Subscription); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepositoryInterface_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockSubscriptionRepositoryInterface_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - id uint
+func (_e *MockSubscriptionRepositoryInterface_Expecter) GetByID(id interface{}) *MockSubscriptionRepositoryInterface_GetByID_Call {
+	return &MockSubscriptionRepositoryInterface_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+}
+
+func (_c *MockSubscriptionRepositoryInterface_GetByID_Call) Run(run func(id uint)) *MockSubscriptionRepositoryInterface_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepositoryInterface_GetByID_Call) Return(_a0 *models.Subscription, _a1 error) *MockSubscriptionRepositoryInterface_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepositoryInterface_GetByID_Call) RunAndReturn(run func(uint) (*models.Subscription, error)) *MockSubscriptionRepositoryInterface_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function with given fields: userID
+func (_m *MockSubscriptionRepositoryInterface) GetByUserID(userID uint) ([]models.Subscription, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]models.Subscription, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []models.Subscription); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepositoryInterface_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockSubscriptionRepositoryInterface_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - userID uint
+func (_e *MockSubscriptionRepositoryInterface_Expecter) GetByUserID(userID interface{}) *MockSubscriptionRepositoryInterface_GetByUserID_Call {
+	return &MockSubscriptionRepositoryInterface_GetByUserID_Call{Call: _e.mock.On("GetByUserID", userID)}
+}
+
+func (_c *MockSubscriptionRepositoryInterface_GetByUserID_Call) Run(run func(userID uint)) *MockSubscriptionRepositoryInterface_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepositoryInterface_GetByUserID_Call) Return(_a0 []models.Subscription, _a1 error) *MockSubscriptionRepositoryInterface_GetByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepositoryInterface_GetByUserID_Call) RunAndReturn(run func(uint) ([]models.Subscription, error)) *MockSubscriptionRepositoryInterface_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDueIDs provides a mock function with given fields: now, limit
+func (_m *MockSubscriptionRepositoryInterface) GetDueIDs(now time.Time, limit int) ([]uint, error) {
+	ret := _m.Called(now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueIDs")
+	}
+
+	var r0 []uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, int) ([]uint, error)); ok {
+		return rf(now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, int) []uint); ok {
+		r0 = rf(now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, int) error); ok {
+		r1 = rf(now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepositoryInterface_GetDueIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDueIDs'
+type MockSubscriptionRepositoryInterface_GetDueIDs_Call struct {
+	*mock.Call
+}
+
+// GetDueIDs is a helper method to define mock.On call
+//   - now time.Time
+//   - limit int
+func (_e *MockSubscriptionRepositoryInterface_Expecter) GetDueIDs(now interface{}, limit interface{}) *MockSubscriptionRepositoryInterface_GetDueIDs_Call {
+	return &MockSubscriptionRepositoryInterface_GetDueIDs_Call{Call: _e.mock.On("GetDueIDs", now, limit)}
+}
+
+func (_c *MockSubscriptionRepositoryInterface_GetDueIDs_Call) Run(run func(now time.Time, limit int)) *MockSubscriptionRepositoryInterface_GetDueIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepositoryInterface_GetDueIDs_Call) Return(_a0 []uint, _a1 error) *MockSubscriptionRepositoryInterface_GetDueIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepositoryInterface_GetDueIDs_Call) RunAndReturn(run func(time.Time, int) ([]uint, error)) *MockSubscriptionRepositoryInterface_GetDueIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessDue provides a mock function with given fields: id, now, process
+func (_m *MockSubscriptionRepositoryInterface) ProcessDue(id uint, now time.Time, process func(*gorm.DB, *models.Subscription) error) (bool, error) {
+	ret := _m.Called(id, now, process)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessDue")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time, func(*gorm.DB, *models.Subscription) error) (bool, error)); ok {
+		return rf(id, now, process)
+	}
+	if rf, ok := ret.Get(0).(func(uint, time.Time, func(*gorm.DB, *models.Subscription) error) bool); ok {
+		r0 = rf(id, now, process)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, time.Time, func(*gorm.DB, *models.Subscription) error) error); ok {
+		r1 = rf(id, now, process)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSubscriptionRepositoryInterface_ProcessDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessDue'
+type MockSubscriptionRepositoryInterface_ProcessDue_Call struct {
+	*mock.Call
+}
+
+// ProcessDue is a helper method to define mock.On call
+//   - id uint
+//   - now time.Time
+//   - process func(*gorm.DB , *models.Subscription) error
+func (_e *MockSubscriptionRepositoryInterface_Expecter) ProcessDue(id interface{}, now interface{}, process interface{}) *MockSubscriptionRepositoryInterface_ProcessDue_Call {
+	return &MockSubscriptionRepositoryInterface_ProcessDue_Call{Call: _e.mock.On("ProcessDue", id, now, process)}
+}
+
+func (_c *MockSubscriptionRepositoryInterface_ProcessDue_Call) Run(run func(id uint, now time.Time, process func(*gorm.DB, *models.Subscription) error)) *MockSubscriptionRepositoryInterface_ProcessDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(time.Time), args[2].(func(*gorm.DB, *models.Subscription) error))
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepositoryInterface_ProcessDue_Call) Return(_a0 bool, _a1 error) *MockSubscriptionRepositoryInterface_ProcessDue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSubscriptionRepositoryInterface_ProcessDue_Call) RunAndReturn(run func(uint, time.Time, func(*gorm.DB, *models.Subscription) error) (bool, error)) *MockSubscriptionRepositoryInterface_ProcessDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSubscriptionRepositoryInterface creates a new instance of MockSubscriptionRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSubscriptionRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSubscriptionRepositoryInterface {
+	mock := &MockSubscriptionRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
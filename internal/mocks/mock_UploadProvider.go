@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	io "io"
 	multipart "mime/multipart"
 
 	mock "github.com/stretchr/testify/mock"
@@ -67,6 +68,64 @@ func (_c *MockUploadProvider_DeleteFile_Call) RunAndReturn(run func(string) erro
 	return _c
 }
 
+// OpenFile provides a mock function with given fields: path
+func (_m *MockUploadProvider) OpenFile(path string) (io.ReadCloser, error) {
+	ret := _m.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenFile")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (io.ReadCloser, error)); ok {
+		return rf(path)
+	}
+	if rf, ok := ret.Get(0).(func(string) io.ReadCloser); ok {
+		r0 = rf(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUploadProvider_OpenFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenFile'
+type MockUploadProvider_OpenFile_Call struct {
+	*mock.Call
+}
+
+// OpenFile is a helper method to define mock.On call
+//   - path string
+func (_e *MockUploadProvider_Expecter) OpenFile(path interface{}) *MockUploadProvider_OpenFile_Call {
+	return &MockUploadProvider_OpenFile_Call{Call: _e.mock.On("OpenFile", path)}
+}
+
+func (_c *MockUploadProvider_OpenFile_Call) Run(run func(path string)) *MockUploadProvider_OpenFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockUploadProvider_OpenFile_Call) Return(_a0 io.ReadCloser, _a1 error) *MockUploadProvider_OpenFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUploadProvider_OpenFile_Call) RunAndReturn(run func(string) (io.ReadCloser, error)) *MockUploadProvider_OpenFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UploadFile provides a mock function with given fields: file, path
 func (_m *MockUploadProvider) UploadFile(file *multipart.FileHeader, path string) (string, error) {
 	ret := _m.Called(file, path)
@@ -20,6 +20,52 @@ func (_m *MockUploadRepositoryInterface) EXPECT() *MockUploadRepositoryInterface
 	return &MockUploadRepositoryInterface_Expecter{mock: &_m.Mock}
 }
 
+// CreateProductFile provides a mock function with given fields: file
+func (_m *MockUploadRepositoryInterface) CreateProductFile(file *models.ProductFile) error {
+	ret := _m.Called(file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateProductFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ProductFile) error); ok {
+		r0 = rf(file)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUploadRepositoryInterface_CreateProductFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProductFile'
+type MockUploadRepositoryInterface_CreateProductFile_Call struct {
+	*mock.Call
+}
+
+// CreateProductFile is a helper method to define mock.On call
+//   - file *models.ProductFile
+func (_e *MockUploadRepositoryInterface_Expecter) CreateProductFile(file interface{}) *MockUploadRepositoryInterface_CreateProductFile_Call {
+	return &MockUploadRepositoryInterface_CreateProductFile_Call{Call: _e.mock.On("CreateProductFile", file)}
+}
+
+func (_c *MockUploadRepositoryInterface_CreateProductFile_Call) Run(run func(file *models.ProductFile)) *MockUploadRepositoryInterface_CreateProductFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.ProductFile))
+	})
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_CreateProductFile_Call) Return(_a0 error) *MockUploadRepositoryInterface_CreateProductFile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_CreateProductFile_Call) RunAndReturn(run func(*models.ProductFile) error) *MockUploadRepositoryInterface_CreateProductFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateProductImage provides a mock function with given fields: image
 func (_m *MockUploadRepositoryInterface) CreateProductImage(image *models.ProductImage) error {
 	ret := _m.Called(image)
@@ -112,6 +158,122 @@ func (_c *MockUploadRepositoryInterface_DeleteProductImage_Call) RunAndReturn(ru
 	return _c
 }
 
+// GetProductFile provides a mock function with given fields: id
+func (_m *MockUploadRepositoryInterface) GetProductFile(id uint) (*models.ProductFile, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductFile")
+	}
+
+	var r0 *models.ProductFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*models.ProductFile, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *models.ProductFile); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProductFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUploadRepositoryInterface_GetProductFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductFile'
+type MockUploadRepositoryInterface_GetProductFile_Call struct {
+	*mock.Call
+}
+
+// GetProductFile is a helper method to define mock.On call
+//   - id uint
+func (_e *MockUploadRepositoryInterface_Expecter) GetProductFile(id interface{}) *MockUploadRepositoryInterface_GetProductFile_Call {
+	return &MockUploadRepositoryInterface_GetProductFile_Call{Call: _e.mock.On("GetProductFile", id)}
+}
+
+func (_c *MockUploadRepositoryInterface_GetProductFile_Call) Run(run func(id uint)) *MockUploadRepositoryInterface_GetProductFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_GetProductFile_Call) Return(_a0 *models.ProductFile, _a1 error) *MockUploadRepositoryInterface_GetProductFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_GetProductFile_Call) RunAndReturn(run func(uint) (*models.ProductFile, error)) *MockUploadRepositoryInterface_GetProductFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductFiles provides a mock function with given fields: productID
+func (_m *MockUploadRepositoryInterface) GetProductFiles(productID uint) ([]models.ProductFile, error) {
+	ret := _m.Called(productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductFiles")
+	}
+
+	var r0 []models.ProductFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]models.ProductFile, error)); ok {
+		return rf(productID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []models.ProductFile); ok {
+		r0 = rf(productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUploadRepositoryInterface_GetProductFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductFiles'
+type MockUploadRepositoryInterface_GetProductFiles_Call struct {
+	*mock.Call
+}
+
+// GetProductFiles is a helper method to define mock.On call
+//   - productID uint
+func (_e *MockUploadRepositoryInterface_Expecter) GetProductFiles(productID interface{}) *MockUploadRepositoryInterface_GetProductFiles_Call {
+	return &MockUploadRepositoryInterface_GetProductFiles_Call{Call: _e.mock.On("GetProductFiles", productID)}
+}
+
+func (_c *MockUploadRepositoryInterface_GetProductFiles_Call) Run(run func(productID uint)) *MockUploadRepositoryInterface_GetProductFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_GetProductFiles_Call) Return(_a0 []models.ProductFile, _a1 error) *MockUploadRepositoryInterface_GetProductFiles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUploadRepositoryInterface_GetProductFiles_Call) RunAndReturn(run func(uint) ([]models.ProductFile, error)) *MockUploadRepositoryInterface_GetProductFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetProductImages provides a mock function with given fields: productID
 func (_m *MockUploadRepositoryInterface) GetProductImages(productID uint) ([]models.ProductImage, error) {
 	ret := _m.Called(productID)
@@ -5,6 +5,8 @@ package mocks
 import (
 	models "github.com/JihadRinaldi/go-shop/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockUserRepositoryInterface is an autogenerated mock type for the UserRepositoryInterface type
@@ -20,6 +22,63 @@ func (_m *MockUserRepositoryInterface) EXPECT() *MockUserRepositoryInterface_Exp
 	return &MockUserRepositoryInterface_Expecter{mock: &_m.Mock}
 }
 
+// AdvanceTOTPStep provides a mock function with given fields: userID, step
+func (_m *MockUserRepositoryInterface) AdvanceTOTPStep(userID uint, step int64) (bool, error) {
+	ret := _m.Called(userID, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdvanceTOTPStep")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, int64) (bool, error)); ok {
+		return rf(userID, step)
+	}
+	if rf, ok := ret.Get(0).(func(uint, int64) bool); ok {
+		r0 = rf(userID, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, int64) error); ok {
+		r1 = rf(userID, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepositoryInterface_AdvanceTOTPStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdvanceTOTPStep'
+type MockUserRepositoryInterface_AdvanceTOTPStep_Call struct {
+	*mock.Call
+}
+
+// AdvanceTOTPStep is a helper method to define mock.On call
+//   - userID uint
+//   - step int64
+func (_e *MockUserRepositoryInterface_Expecter) AdvanceTOTPStep(userID interface{}, step interface{}) *MockUserRepositoryInterface_AdvanceTOTPStep_Call {
+	return &MockUserRepositoryInterface_AdvanceTOTPStep_Call{Call: _e.mock.On("AdvanceTOTPStep", userID, step)}
+}
+
+func (_c *MockUserRepositoryInterface_AdvanceTOTPStep_Call) Run(run func(userID uint, step int64)) *MockUserRepositoryInterface_AdvanceTOTPStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockUserRepositoryInterface_AdvanceTOTPStep_Call) Return(_a0 bool, _a1 error) *MockUserRepositoryInterface_AdvanceTOTPStep_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepositoryInterface_AdvanceTOTPStep_Call) RunAndReturn(run func(uint, int64) (bool, error)) *MockUserRepositoryInterface_AdvanceTOTPStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: user
 func (_m *MockUserRepositoryInterface) Create(user *models.User) error {
 	ret := _m.Called(user)
@@ -158,17 +217,17 @@ func (_c *MockUserRepositoryInterface_Delete_Call) RunAndReturn(run func(uint) e
 	return _c
 }
 
-// DeleteRefreshToken provides a mock function with given fields: token
-func (_m *MockUserRepositoryInterface) DeleteRefreshToken(token string) error {
-	ret := _m.Called(token)
+// DeleteOtherRefreshTokens provides a mock function with given fields: userID, currentFamilyID
+func (_m *MockUserRepositoryInterface) DeleteOtherRefreshTokens(userID uint, currentFamilyID string) error {
+	ret := _m.Called(userID, currentFamilyID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteRefreshToken")
+		panic("no return value specified for DeleteOtherRefreshTokens")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(userID, currentFamilyID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -176,45 +235,46 @@ func (_m *MockUserRepositoryInterface) DeleteRefreshToken(token string) error {
 	return r0
 }
 
-// MockUserRepositoryInterface_DeleteRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRefreshToken'
-type MockUserRepositoryInterface_DeleteRefreshToken_Call struct {
+// MockUserRepositoryInterface_DeleteOtherRefreshTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOtherRefreshTokens'
+type MockUserRepositoryInterface_DeleteOtherRefreshTokens_Call struct {
 	*mock.Call
 }
 
-// DeleteRefreshToken is a helper method to define mock.On call
-//   - token string
-func (_e *MockUserRepositoryInterface_Expecter) DeleteRefreshToken(token interface{}) *MockUserRepositoryInterface_DeleteRefreshToken_Call {
-	return &MockUserRepositoryInterface_DeleteRefreshToken_Call{Call: _e.mock.On("DeleteRefreshToken", token)}
+// DeleteOtherRefreshTokens is a helper method to define mock.On call
+//   - userID uint
+//   - currentFamilyID string
+func (_e *MockUserRepositoryInterface_Expecter) DeleteOtherRefreshTokens(userID interface{}, currentFamilyID interface{}) *MockUserRepositoryInterface_DeleteOtherRefreshTokens_Call {
+	return &MockUserRepositoryInterface_DeleteOtherRefreshTokens_Call{Call: _e.mock.On("DeleteOtherRefreshTokens", userID, currentFamilyID)}
 }
 
-func (_c *MockUserRepositoryInterface_DeleteRefreshToken_Call) Run(run func(token string)) *MockUserRepositoryInterface_DeleteRefreshToken_Call {
+func (_c *MockUserRepositoryInterface_DeleteOtherRefreshTokens_Call) Run(run func(userID uint, currentFamilyID string)) *MockUserRepositoryInterface_DeleteOtherRefreshTokens_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(uint), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockUserRepositoryInterface_DeleteRefreshToken_Call) Return(_a0 error) *MockUserRepositoryInterface_DeleteRefreshToken_Call {
+func (_c *MockUserRepositoryInterface_DeleteOtherRefreshTokens_Call) Return(_a0 error) *MockUserRepositoryInterface_DeleteOtherRefreshTokens_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockUserRepositoryInterface_DeleteRefreshToken_Call) RunAndReturn(run func(string) error) *MockUserRepositoryInterface_DeleteRefreshToken_Call {
+func (_c *MockUserRepositoryInterface_DeleteOtherRefreshTokens_Call) RunAndReturn(run func(uint, string) error) *MockUserRepositoryInterface_DeleteOtherRefreshTokens_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteRefreshTokenByID provides a mock function with given fields: id
-func (_m *MockUserRepositoryInterface) DeleteRefreshTokenByID(id uint) error {
-	ret := _m.Called(id)
+// DeleteRefreshTokensByUserID provides a mock function with given fields: userID
+func (_m *MockUserRepositoryInterface) DeleteRefreshTokensByUserID(userID uint) error {
+	ret := _m.Called(userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteRefreshTokenByID")
+		panic("no return value specified for DeleteRefreshTokensByUserID")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(id)
+		r0 = rf(userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -222,30 +282,30 @@ func (_m *MockUserRepositoryInterface) DeleteRefreshTokenByID(id uint) error {
 	return r0
 }
 
-// MockUserRepositoryInterface_DeleteRefreshTokenByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRefreshTokenByID'
-type MockUserRepositoryInterface_DeleteRefreshTokenByID_Call struct {
+// MockUserRepositoryInterface_DeleteRefreshTokensByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRefreshTokensByUserID'
+type MockUserRepositoryInterface_DeleteRefreshTokensByUserID_Call struct {
 	*mock.Call
 }
 
-// DeleteRefreshTokenByID is a helper method to define mock.On call
-//   - id uint
-func (_e *MockUserRepositoryInterface_Expecter) DeleteRefreshTokenByID(id interface{}) *MockUserRepositoryInterface_DeleteRefreshTokenByID_Call {
-	return &MockUserRepositoryInterface_DeleteRefreshTokenByID_Call{Call: _e.mock.On("DeleteRefreshTokenByID", id)}
+// DeleteRefreshTokensByUserID is a helper method to define mock.On call
+//   - userID uint
+func (_e *MockUserRepositoryInterface_Expecter) DeleteRefreshTokensByUserID(userID interface{}) *MockUserRepositoryInterface_DeleteRefreshTokensByUserID_Call {
+	return &MockUserRepositoryInterface_DeleteRefreshTokensByUserID_Call{Call: _e.mock.On("DeleteRefreshTokensByUserID", userID)}
 }
 
-func (_c *MockUserRepositoryInterface_DeleteRefreshTokenByID_Call) Run(run func(id uint)) *MockUserRepositoryInterface_DeleteRefreshTokenByID_Call {
+func (_c *MockUserRepositoryInterface_DeleteRefreshTokensByUserID_Call) Run(run func(userID uint)) *MockUserRepositoryInterface_DeleteRefreshTokensByUserID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *MockUserRepositoryInterface_DeleteRefreshTokenByID_Call) Return(_a0 error) *MockUserRepositoryInterface_DeleteRefreshTokenByID_Call {
+func (_c *MockUserRepositoryInterface_DeleteRefreshTokensByUserID_Call) Return(_a0 error) *MockUserRepositoryInterface_DeleteRefreshTokensByUserID_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockUserRepositoryInterface_DeleteRefreshTokenByID_Call) RunAndReturn(run func(uint) error) *MockUserRepositoryInterface_DeleteRefreshTokenByID_Call {
+func (_c *MockUserRepositoryInterface_DeleteRefreshTokensByUserID_Call) RunAndReturn(run func(uint) error) *MockUserRepositoryInterface_DeleteRefreshTokensByUserID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -425,21 +485,79 @@ func (_c *MockUserRepositoryInterface_GetByID_Call) RunAndReturn(run func(uint)
 	return _c
 }
 
-// GetValidRefreshToken provides a mock function with given fields: token
-func (_m *MockUserRepositoryInterface) GetValidRefreshToken(token string) (*models.RefreshToken, error) {
-	ret := _m.Called(token)
+// GetRefreshToken provides a mock function with given fields: tokenHash
+func (_m *MockUserRepositoryInterface) GetRefreshToken(tokenHash string) (*models.RefreshToken, error) {
+	ret := _m.Called(tokenHash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetValidRefreshToken")
+		panic("no return value specified for GetRefreshToken")
 	}
 
 	var r0 *models.RefreshToken
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (*models.RefreshToken, error)); ok {
-		return rf(token)
+		return rf(tokenHash)
 	}
 	if rf, ok := ret.Get(0).(func(string) *models.RefreshToken); ok {
-		r0 = rf(token)
+		r0 = rf(tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepositoryInterface_GetRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRefreshToken'
+type MockUserRepositoryInterface_GetRefreshToken_Call struct {
+	*mock.Call
+}
+
+// GetRefreshToken is a helper method to define mock.On call
+//   - tokenHash string
+func (_e *MockUserRepositoryInterface_Expecter) GetRefreshToken(tokenHash interface{}) *MockUserRepositoryInterface_GetRefreshToken_Call {
+	return &MockUserRepositoryInterface_GetRefreshToken_Call{Call: _e.mock.On("GetRefreshToken", tokenHash)}
+}
+
+func (_c *MockUserRepositoryInterface_GetRefreshToken_Call) Run(run func(tokenHash string)) *MockUserRepositoryInterface_GetRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepositoryInterface_GetRefreshToken_Call) Return(_a0 *models.RefreshToken, _a1 error) *MockUserRepositoryInterface_GetRefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepositoryInterface_GetRefreshToken_Call) RunAndReturn(run func(string) (*models.RefreshToken, error)) *MockUserRepositoryInterface_GetRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSession provides a mock function with given fields: familyID
+func (_m *MockUserRepositoryInterface) GetSession(familyID string) (*models.RefreshToken, error) {
+	ret := _m.Called(familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSession")
+	}
+
+	var r0 *models.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.RefreshToken, error)); ok {
+		return rf(familyID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.RefreshToken); ok {
+		r0 = rf(familyID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.RefreshToken)
@@ -447,7 +565,65 @@ func (_m *MockUserRepositoryInterface) GetValidRefreshToken(token string) (*mode
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(token)
+		r1 = rf(familyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepositoryInterface_GetSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSession'
+type MockUserRepositoryInterface_GetSession_Call struct {
+	*mock.Call
+}
+
+// GetSession is a helper method to define mock.On call
+//   - familyID string
+func (_e *MockUserRepositoryInterface_Expecter) GetSession(familyID interface{}) *MockUserRepositoryInterface_GetSession_Call {
+	return &MockUserRepositoryInterface_GetSession_Call{Call: _e.mock.On("GetSession", familyID)}
+}
+
+func (_c *MockUserRepositoryInterface_GetSession_Call) Run(run func(familyID string)) *MockUserRepositoryInterface_GetSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepositoryInterface_GetSession_Call) Return(_a0 *models.RefreshToken, _a1 error) *MockUserRepositoryInterface_GetSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepositoryInterface_GetSession_Call) RunAndReturn(run func(string) (*models.RefreshToken, error)) *MockUserRepositoryInterface_GetSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSessions provides a mock function with given fields: userID, now
+func (_m *MockUserRepositoryInterface) GetSessions(userID uint, now time.Time) ([]models.RefreshToken, error) {
+	ret := _m.Called(userID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessions")
+	}
+
+	var r0 []models.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time) ([]models.RefreshToken, error)); ok {
+		return rf(userID, now)
+	}
+	if rf, ok := ret.Get(0).(func(uint, time.Time) []models.RefreshToken); ok {
+		r0 = rf(userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, time.Time) error); ok {
+		r1 = rf(userID, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -455,30 +631,182 @@ func (_m *MockUserRepositoryInterface) GetValidRefreshToken(token string) (*mode
 	return r0, r1
 }
 
-// MockUserRepositoryInterface_GetValidRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetValidRefreshToken'
-type MockUserRepositoryInterface_GetValidRefreshToken_Call struct {
+// MockUserRepositoryInterface_GetSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessions'
+type MockUserRepositoryInterface_GetSessions_Call struct {
 	*mock.Call
 }
 
-// GetValidRefreshToken is a helper method to define mock.On call
-//   - token string
-func (_e *MockUserRepositoryInterface_Expecter) GetValidRefreshToken(token interface{}) *MockUserRepositoryInterface_GetValidRefreshToken_Call {
-	return &MockUserRepositoryInterface_GetValidRefreshToken_Call{Call: _e.mock.On("GetValidRefreshToken", token)}
+// GetSessions is a helper method to define mock.On call
+//   - userID uint
+//   - now time.Time
+func (_e *MockUserRepositoryInterface_Expecter) GetSessions(userID interface{}, now interface{}) *MockUserRepositoryInterface_GetSessions_Call {
+	return &MockUserRepositoryInterface_GetSessions_Call{Call: _e.mock.On("GetSessions", userID, now)}
 }
 
-func (_c *MockUserRepositoryInterface_GetValidRefreshToken_Call) Run(run func(token string)) *MockUserRepositoryInterface_GetValidRefreshToken_Call {
+func (_c *MockUserRepositoryInterface_GetSessions_Call) Run(run func(userID uint, now time.Time)) *MockUserRepositoryInterface_GetSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserRepositoryInterface_GetSessions_Call) Return(_a0 []models.RefreshToken, _a1 error) *MockUserRepositoryInterface_GetSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepositoryInterface_GetSessions_Call) RunAndReturn(run func(uint, time.Time) ([]models.RefreshToken, error)) *MockUserRepositoryInterface_GetSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeRefreshTokenFamily provides a mock function with given fields: familyID
+func (_m *MockUserRepositoryInterface) RevokeRefreshTokenFamily(familyID string) error {
+	ret := _m.Called(familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRefreshTokenFamily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserRepositoryInterface_RevokeRefreshTokenFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeRefreshTokenFamily'
+type MockUserRepositoryInterface_RevokeRefreshTokenFamily_Call struct {
+	*mock.Call
+}
+
+// RevokeRefreshTokenFamily is a helper method to define mock.On call
+//   - familyID string
+func (_e *MockUserRepositoryInterface_Expecter) RevokeRefreshTokenFamily(familyID interface{}) *MockUserRepositoryInterface_RevokeRefreshTokenFamily_Call {
+	return &MockUserRepositoryInterface_RevokeRefreshTokenFamily_Call{Call: _e.mock.On("RevokeRefreshTokenFamily", familyID)}
+}
+
+func (_c *MockUserRepositoryInterface_RevokeRefreshTokenFamily_Call) Run(run func(familyID string)) *MockUserRepositoryInterface_RevokeRefreshTokenFamily_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *MockUserRepositoryInterface_GetValidRefreshToken_Call) Return(_a0 *models.RefreshToken, _a1 error) *MockUserRepositoryInterface_GetValidRefreshToken_Call {
+func (_c *MockUserRepositoryInterface_RevokeRefreshTokenFamily_Call) Return(_a0 error) *MockUserRepositoryInterface_RevokeRefreshTokenFamily_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRepositoryInterface_RevokeRefreshTokenFamily_Call) RunAndReturn(run func(string) error) *MockUserRepositoryInterface_RevokeRefreshTokenFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateRefreshToken provides a mock function with given fields: id, successor, now
+func (_m *MockUserRepositoryInterface) RotateRefreshToken(id uint, successor *models.RefreshToken, now time.Time) (bool, error) {
+	ret := _m.Called(id, successor, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateRefreshToken")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, *models.RefreshToken, time.Time) (bool, error)); ok {
+		return rf(id, successor, now)
+	}
+	if rf, ok := ret.Get(0).(func(uint, *models.RefreshToken, time.Time) bool); ok {
+		r0 = rf(id, successor, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, *models.RefreshToken, time.Time) error); ok {
+		r1 = rf(id, successor, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepositoryInterface_RotateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateRefreshToken'
+type MockUserRepositoryInterface_RotateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// RotateRefreshToken is a helper method to define mock.On call
+//   - id uint
+//   - successor *models.RefreshToken
+//   - now time.Time
+func (_e *MockUserRepositoryInterface_Expecter) RotateRefreshToken(id interface{}, successor interface{}, now interface{}) *MockUserRepositoryInterface_RotateRefreshToken_Call {
+	return &MockUserRepositoryInterface_RotateRefreshToken_Call{Call: _e.mock.On("RotateRefreshToken", id, successor, now)}
+}
+
+func (_c *MockUserRepositoryInterface_RotateRefreshToken_Call) Run(run func(id uint, successor *models.RefreshToken, now time.Time)) *MockUserRepositoryInterface_RotateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(*models.RefreshToken), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserRepositoryInterface_RotateRefreshToken_Call) Return(_a0 bool, _a1 error) *MockUserRepositoryInterface_RotateRefreshToken_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockUserRepositoryInterface_GetValidRefreshToken_Call) RunAndReturn(run func(string) (*models.RefreshToken, error)) *MockUserRepositoryInterface_GetValidRefreshToken_Call {
+func (_c *MockUserRepositoryInterface_RotateRefreshToken_Call) RunAndReturn(run func(uint, *models.RefreshToken, time.Time) (bool, error)) *MockUserRepositoryInterface_RotateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchRefreshToken provides a mock function with given fields: id, now
+func (_m *MockUserRepositoryInterface) TouchRefreshToken(id uint, now time.Time) error {
+	ret := _m.Called(id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time) error); ok {
+		r0 = rf(id, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserRepositoryInterface_TouchRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchRefreshToken'
+type MockUserRepositoryInterface_TouchRefreshToken_Call struct {
+	*mock.Call
+}
+
+// TouchRefreshToken is a helper method to define mock.On call
+//   - id uint
+//   - now time.Time
+func (_e *MockUserRepositoryInterface_Expecter) TouchRefreshToken(id interface{}, now interface{}) *MockUserRepositoryInterface_TouchRefreshToken_Call {
+	return &MockUserRepositoryInterface_TouchRefreshToken_Call{Call: _e.mock.On("TouchRefreshToken", id, now)}
+}
+
+func (_c *MockUserRepositoryInterface_TouchRefreshToken_Call) Run(run func(id uint, now time.Time)) *MockUserRepositoryInterface_TouchRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserRepositoryInterface_TouchRefreshToken_Call) Return(_a0 error) *MockUserRepositoryInterface_TouchRefreshToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserRepositoryInterface_TouchRefreshToken_Call) RunAndReturn(run func(uint, time.Time) error) *MockUserRepositoryInterface_TouchRefreshToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,307 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/JihadRinaldi/go-shop/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockUserTokenRepositoryInterface is an autogenerated mock type for the UserTokenRepositoryInterface type
+type MockUserTokenRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockUserTokenRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserTokenRepositoryInterface) EXPECT() *MockUserTokenRepositoryInterface_Expecter {
+	return &MockUserTokenRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: token
+func (_m *MockUserTokenRepositoryInterface) Create(token *models.UserToken) error {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.UserToken) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserTokenRepositoryInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockUserTokenRepositoryInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - token *models.UserToken
+func (_e *MockUserTokenRepositoryInterface_Expecter) Create(token interface{}) *MockUserTokenRepositoryInterface_Create_Call {
+	return &MockUserTokenRepositoryInterface_Create_Call{Call: _e.mock.On("Create", token)}
+}
+
+func (_c *MockUserTokenRepositoryInterface_Create_Call) Run(run func(token *models.UserToken)) *MockUserTokenRepositoryInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.UserToken))
+	})
+	return _c
+}
+
+func (_c *MockUserTokenRepositoryInterface_Create_Call) Return(_a0 error) *MockUserTokenRepositoryInterface_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserTokenRepositoryInterface_Create_Call) RunAndReturn(run func(*models.UserToken) error) *MockUserTokenRepositoryInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatest provides a mock function with given fields: userID, purpose
+func (_m *MockUserTokenRepositoryInterface) GetLatest(userID uint, purpose models.UserTokenPurpose) (*models.UserToken, error) {
+	ret := _m.Called(userID, purpose)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatest")
+	}
+
+	var r0 *models.UserToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, models.UserTokenPurpose) (*models.UserToken, error)); ok {
+		return rf(userID, purpose)
+	}
+	if rf, ok := ret.Get(0).(func(uint, models.UserTokenPurpose) *models.UserToken); ok {
+		r0 = rf(userID, purpose)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, models.UserTokenPurpose) error); ok {
+		r1 = rf(userID, purpose)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserTokenRepositoryInterface_GetLatest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatest'
+type MockUserTokenRepositoryInterface_GetLatest_Call struct {
+	*mock.Call
+}
+
+// GetLatest is a helper method to define mock.On call
+//   - userID uint
+//   - purpose models.UserTokenPurpose
+func (_e *MockUserTokenRepositoryInterface_Expecter) GetLatest(userID interface{}, purpose interface{}) *MockUserTokenRepositoryInterface_GetLatest_Call {
+	return &MockUserTokenRepositoryInterface_GetLatest_Call{Call: _e.mock.On("GetLatest", userID, purpose)}
+}
+
+func (_c *MockUserTokenRepositoryInterface_GetLatest_Call) Run(run func(userID uint, purpose models.UserTokenPurpose)) *MockUserTokenRepositoryInterface_GetLatest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(models.UserTokenPurpose))
+	})
+	return _c
+}
+
+func (_c *MockUserTokenRepositoryInterface_GetLatest_Call) Return(_a0 *models.UserToken, _a1 error) *MockUserTokenRepositoryInterface_GetLatest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserTokenRepositoryInterface_GetLatest_Call) RunAndReturn(run func(uint, models.UserTokenPurpose) (*models.UserToken, error)) *MockUserTokenRepositoryInterface_GetLatest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsable provides a mock function with given fields: purpose, hash, now
+func (_m *MockUserTokenRepositoryInterface) GetUsable(purpose models.UserTokenPurpose, hash string, now time.Time) (*models.UserToken, error) {
+	ret := _m.Called(purpose, hash, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsable")
+	}
+
+	var r0 *models.UserToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.UserTokenPurpose, string, time.Time) (*models.UserToken, error)); ok {
+		return rf(purpose, hash, now)
+	}
+	if rf, ok := ret.Get(0).(func(models.UserTokenPurpose, string, time.Time) *models.UserToken); ok {
+		r0 = rf(purpose, hash, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.UserTokenPurpose, string, time.Time) error); ok {
+		r1 = rf(purpose, hash, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserTokenRepositoryInterface_GetUsable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsable'
+type MockUserTokenRepositoryInterface_GetUsable_Call struct {
+	*mock.Call
+}
+
+// GetUsable is a helper method to define mock.On call
+//   - purpose models.UserTokenPurpose
+//   - hash string
+//   - now time.Time
+func (_e *MockUserTokenRepositoryInterface_Expecter) GetUsable(purpose interface{}, hash interface{}, now interface{}) *MockUserTokenRepositoryInterface_GetUsable_Call {
+	return &MockUserTokenRepositoryInterface_GetUsable_Call{Call: _e.mock.On("GetUsable", purpose, hash, now)}
+}
+
+func (_c *MockUserTokenRepositoryInterface_GetUsable_Call) Run(run func(purpose models.UserTokenPurpose, hash string, now time.Time)) *MockUserTokenRepositoryInterface_GetUsable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.UserTokenPurpose), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserTokenRepositoryInterface_GetUsable_Call) Return(_a0 *models.UserToken, _a1 error) *MockUserTokenRepositoryInterface_GetUsable_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserTokenRepositoryInterface_GetUsable_Call) RunAndReturn(run func(models.UserTokenPurpose, string, time.Time) (*models.UserToken, error)) *MockUserTokenRepositoryInterface_GetUsable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateAll provides a mock function with given fields: userID, purpose, now
+func (_m *MockUserTokenRepositoryInterface) InvalidateAll(userID uint, purpose models.UserTokenPurpose, now time.Time) error {
+	ret := _m.Called(userID, purpose, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, models.UserTokenPurpose, time.Time) error); ok {
+		r0 = rf(userID, purpose, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUserTokenRepositoryInterface_InvalidateAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateAll'
+type MockUserTokenRepositoryInterface_InvalidateAll_Call struct {
+	*mock.Call
+}
+
+// InvalidateAll is a helper method to define mock.On call
+//   - userID uint
+//   - purpose models.UserTokenPurpose
+//   - now time.Time
+func (_e *MockUserTokenRepositoryInterface_Expecter) InvalidateAll(userID interface{}, purpose interface{}, now interface{}) *MockUserTokenRepositoryInterface_InvalidateAll_Call {
+	return &MockUserTokenRepositoryInterface_InvalidateAll_Call{Call: _e.mock.On("InvalidateAll", userID, purpose, now)}
+}
+
+func (_c *MockUserTokenRepositoryInterface_InvalidateAll_Call) Run(run func(userID uint, purpose models.UserTokenPurpose, now time.Time)) *MockUserTokenRepositoryInterface_InvalidateAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(models.UserTokenPurpose), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserTokenRepositoryInterface_InvalidateAll_Call) Return(_a0 error) *MockUserTokenRepositoryInterface_InvalidateAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUserTokenRepositoryInterface_InvalidateAll_Call) RunAndReturn(run func(uint, models.UserTokenPurpose, time.Time) error) *MockUserTokenRepositoryInterface_InvalidateAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkUsed provides a mock function with given fields: id, now
+func (_m *MockUserTokenRepositoryInterface) MarkUsed(id uint, now time.Time) (bool, error) {
+	ret := _m.Called(id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkUsed")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time) (bool, error)); ok {
+		return rf(id, now)
+	}
+	if rf, ok := ret.Get(0).(func(uint, time.Time) bool); ok {
+		r0 = rf(id, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, time.Time) error); ok {
+		r1 = rf(id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserTokenRepositoryInterface_MarkUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkUsed'
+type MockUserTokenRepositoryInterface_MarkUsed_Call struct {
+	*mock.Call
+}
+
+// MarkUsed is a helper method to define mock.On call
+//   - id uint
+//   - now time.Time
+func (_e *MockUserTokenRepositoryInterface_Expecter) MarkUsed(id interface{}, now interface{}) *MockUserTokenRepositoryInterface_MarkUsed_Call {
+	return &MockUserTokenRepositoryInterface_MarkUsed_Call{Call: _e.mock.On("MarkUsed", id, now)}
+}
+
+func (_c *MockUserTokenRepositoryInterface_MarkUsed_Call) Run(run func(id uint, now time.Time)) *MockUserTokenRepositoryInterface_MarkUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserTokenRepositoryInterface_MarkUsed_Call) Return(_a0 bool, _a1 error) *MockUserTokenRepositoryInterface_MarkUsed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserTokenRepositoryInterface_MarkUsed_Call) RunAndReturn(run func(uint, time.Time) (bool, error)) *MockUserTokenRepositoryInterface_MarkUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUserTokenRepositoryInterface creates a new instance of MockUserTokenRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserTokenRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserTokenRepositoryInterface {
+	mock := &MockUserTokenRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
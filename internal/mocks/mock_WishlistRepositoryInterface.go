@@ -0,0 +1,615 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/JihadRinaldi/go-shop/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWishlistRepositoryInterface is an autogenerated mock type for the WishlistRepositoryInterface type
+type MockWishlistRepositoryInterface struct {
+	mock.Mock
+}
+
+type MockWishlistRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWishlistRepositoryInterface) EXPECT() *MockWishlistRepositoryInterface_Expecter {
+	return &MockWishlistRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// AddItem provides a mock function with given fields: item
+func (_m *MockWishlistRepositoryInterface) AddItem(item *models.WishlistItem) error {
+	ret := _m.Called(item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.WishlistItem) error); ok {
+		r0 = rf(item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWishlistRepositoryInterface_AddItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddItem'
+type MockWishlistRepositoryInterface_AddItem_Call struct {
+	*mock.Call
+}
+
+// AddItem is a helper method to define mock.On call
+//   - item *models.WishlistItem
+func (_e *MockWishlistRepositoryInterface_Expecter) AddItem(item interface{}) *MockWishlistRepositoryInterface_AddItem_Call {
+	return &MockWishlistRepositoryInterface_AddItem_Call{Call: _e.mock.On("AddItem", item)}
+}
+
+func (_c *MockWishlistRepositoryInterface_AddItem_Call) Run(run func(item *models.WishlistItem)) *MockWishlistRepositoryInterface_AddItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.WishlistItem))
+	})
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_AddItem_Call) Return(_a0 error) *MockWishlistRepositoryInterface_AddItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_AddItem_Call) RunAndReturn(run func(*models.WishlistItem) error) *MockWishlistRepositoryInterface_AddItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: wishlist
+func (_m *MockWishlistRepositoryInterface) Create(wishlist *models.Wishlist) error {
+	ret := _m.Called(wishlist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Wishlist) error); ok {
+		r0 = rf(wishlist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWishlistRepositoryInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWishlistRepositoryInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - wishlist *models.Wishlist
+func (_e *MockWishlistRepositoryInterface_Expecter) Create(wishlist interface{}) *MockWishlistRepositoryInterface_Create_Call {
+	return &MockWishlistRepositoryInterface_Create_Call{Call: _e.mock.On("Create", wishlist)}
+}
+
+func (_c *MockWishlistRepositoryInterface_Create_Call) Run(run func(wishlist *models.Wishlist)) *MockWishlistRepositoryInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Wishlist))
+	})
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_Create_Call) Return(_a0 error) *MockWishlistRepositoryInterface_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_Create_Call) RunAndReturn(run func(*models.Wishlist) error) *MockWishlistRepositoryInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *MockWishlistRepositoryInterface) Delete(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWishlistRepositoryInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockWishlistRepositoryInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uint
+func (_e *MockWishlistRepositoryInterface_Expecter) Delete(id interface{}) *MockWishlistRepositoryInterface_Delete_Call {
+	return &MockWishlistRepositoryInterface_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *MockWishlistRepositoryInterface_Delete_Call) Run(run func(id uint)) *MockWishlistRepositoryInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_Delete_Call) Return(_a0 error) *MockWishlistRepositoryInterface_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_Delete_Call) RunAndReturn(run func(uint) error) *MockWishlistRepositoryInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExistsByName provides a mock function with given fields: userID, name, excludeID
+func (_m *MockWishlistRepositoryInterface) ExistsByName(userID uint, name string, excludeID uint) (bool, error) {
+	ret := _m.Called(userID, name, excludeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExistsByName")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, string, uint) (bool, error)); ok {
+		return rf(userID, name, excludeID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, string, uint) bool); ok {
+		r0 = rf(userID, name, excludeID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, string, uint) error); ok {
+		r1 = rf(userID, name, excludeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWishlistRepositoryInterface_ExistsByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistsByName'
+type MockWishlistRepositoryInterface_ExistsByName_Call struct {
+	*mock.Call
+}
+
+// ExistsByName is a helper method to define mock.On call
+//   - userID uint
+//   - name string
+//   - excludeID uint
+func (_e *MockWishlistRepositoryInterface_Expecter) ExistsByName(userID interface{}, name interface{}, excludeID interface{}) *MockWishlistRepositoryInterface_ExistsByName_Call {
+	return &MockWishlistRepositoryInterface_ExistsByName_Call{Call: _e.mock.On("ExistsByName", userID, name, excludeID)}
+}
+
+func (_c *MockWishlistRepositoryInterface_ExistsByName_Call) Run(run func(userID uint, name string, excludeID uint)) *MockWishlistRepositoryInterface_ExistsByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_ExistsByName_Call) Return(_a0 bool, _a1 error) *MockWishlistRepositoryInterface_ExistsByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_ExistsByName_Call) RunAndReturn(run func(uint, string, uint) (bool, error)) *MockWishlistRepositoryInterface_ExistsByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MockWishlistRepositoryInterface) GetByID(id uint) (*models.Wishlist, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*models.Wishlist, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *models.Wishlist); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWishlistRepositoryInterface_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockWishlistRepositoryInterface_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - id uint
+func (_e *MockWishlistRepositoryInterface_Expecter) GetByID(id interface{}) *MockWishlistRepositoryInterface_GetByID_Call {
+	return &MockWishlistRepositoryInterface_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+}
+
+func (_c *MockWishlistRepositoryInterface_GetByID_Call) Run(run func(id uint)) *MockWishlistRepositoryInterface_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_GetByID_Call) Return(_a0 *models.Wishlist, _a1 error) *MockWishlistRepositoryInterface_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_GetByID_Call) RunAndReturn(run func(uint) (*models.Wishlist, error)) *MockWishlistRepositoryInterface_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByShareToken provides a mock function with given fields: token
+func (_m *MockWishlistRepositoryInterface) GetByShareToken(token string) (*models.Wishlist, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByShareToken")
+	}
+
+	var r0 *models.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.Wishlist, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.Wishlist); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWishlistRepositoryInterface_GetByShareToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByShareToken'
+type MockWishlistRepositoryInterface_GetByShareToken_Call struct {
+	*mock.Call
+}
+
+// GetByShareToken is a helper method to define mock.On call
+//   - token string
+func (_e *MockWishlistRepositoryInterface_Expecter) GetByShareToken(token interface{}) *MockWishlistRepositoryInterface_GetByShareToken_Call {
+	return &MockWishlistRepositoryInterface_GetByShareToken_Call{Call: _e.mock.On("GetByShareToken", token)}
+}
+
+func (_c *MockWishlistRepositoryInterface_GetByShareToken_Call) Run(run func(token string)) *MockWishlistRepositoryInterface_GetByShareToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_GetByShareToken_Call) Return(_a0 *models.Wishlist, _a1 error) *MockWishlistRepositoryInterface_GetByShareToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_GetByShareToken_Call) RunAndReturn(run func(string) (*models.Wishlist, error)) *MockWishlistRepositoryInterface_GetByShareToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function with given fields: userID
+func (_m *MockWishlistRepositoryInterface) GetByUserID(userID uint) ([]models.Wishlist, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []models.Wishlist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]models.Wishlist, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []models.Wishlist); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Wishlist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWishlistRepositoryInterface_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockWishlistRepositoryInterface_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - userID uint
+func (_e *MockWishlistRepositoryInterface_Expecter) GetByUserID(userID interface{}) *MockWishlistRepositoryInterface_GetByUserID_Call {
+	return &MockWishlistRepositoryInterface_GetByUserID_Call{Call: _e.mock.On("GetByUserID", userID)}
+}
+
+func (_c *MockWishlistRepositoryInterface_GetByUserID_Call) Run(run func(userID uint)) *MockWishlistRepositoryInterface_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_GetByUserID_Call) Return(_a0 []models.Wishlist, _a1 error) *MockWishlistRepositoryInterface_GetByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_GetByUserID_Call) RunAndReturn(run func(uint) ([]models.Wishlist, error)) *MockWishlistRepositoryInterface_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItem provides a mock function with given fields: wishlistID, itemID
+func (_m *MockWishlistRepositoryInterface) GetItem(wishlistID uint, itemID uint) (*models.WishlistItem, error) {
+	ret := _m.Called(wishlistID, itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItem")
+	}
+
+	var r0 *models.WishlistItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (*models.WishlistItem, error)); ok {
+		return rf(wishlistID, itemID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) *models.WishlistItem); ok {
+		r0 = rf(wishlistID, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WishlistItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(wishlistID, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWishlistRepositoryInterface_GetItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItem'
+type MockWishlistRepositoryInterface_GetItem_Call struct {
+	*mock.Call
+}
+
+// GetItem is a helper method to define mock.On call
+//   - wishlistID uint
+//   - itemID uint
+func (_e *MockWishlistRepositoryInterface_Expecter) GetItem(wishlistID interface{}, itemID interface{}) *MockWishlistRepositoryInterface_GetItem_Call {
+	return &MockWishlistRepositoryInterface_GetItem_Call{Call: _e.mock.On("GetItem", wishlistID, itemID)}
+}
+
+func (_c *MockWishlistRepositoryInterface_GetItem_Call) Run(run func(wishlistID uint, itemID uint)) *MockWishlistRepositoryInterface_GetItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_GetItem_Call) Return(_a0 *models.WishlistItem, _a1 error) *MockWishlistRepositoryInterface_GetItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_GetItem_Call) RunAndReturn(run func(uint, uint) (*models.WishlistItem, error)) *MockWishlistRepositoryInterface_GetItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItemByProduct provides a mock function with given fields: wishlistID, productID
+func (_m *MockWishlistRepositoryInterface) GetItemByProduct(wishlistID uint, productID uint) (*models.WishlistItem, error) {
+	ret := _m.Called(wishlistID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItemByProduct")
+	}
+
+	var r0 *models.WishlistItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (*models.WishlistItem, error)); ok {
+		return rf(wishlistID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) *models.WishlistItem); ok {
+		r0 = rf(wishlistID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WishlistItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(wishlistID, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWishlistRepositoryInterface_GetItemByProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItemByProduct'
+type MockWishlistRepositoryInterface_GetItemByProduct_Call struct {
+	*mock.Call
+}
+
+// GetItemByProduct is a helper method to define mock.On call
+//   - wishlistID uint
+//   - productID uint
+func (_e *MockWishlistRepositoryInterface_Expecter) GetItemByProduct(wishlistID interface{}, productID interface{}) *MockWishlistRepositoryInterface_GetItemByProduct_Call {
+	return &MockWishlistRepositoryInterface_GetItemByProduct_Call{Call: _e.mock.On("GetItemByProduct", wishlistID, productID)}
+}
+
+func (_c *MockWishlistRepositoryInterface_GetItemByProduct_Call) Run(run func(wishlistID uint, productID uint)) *MockWishlistRepositoryInterface_GetItemByProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_GetItemByProduct_Call) Return(_a0 *models.WishlistItem, _a1 error) *MockWishlistRepositoryInterface_GetItemByProduct_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_GetItemByProduct_Call) RunAndReturn(run func(uint, uint) (*models.WishlistItem, error)) *MockWishlistRepositoryInterface_GetItemByProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveItem provides a mock function with given fields: itemID
+func (_m *MockWishlistRepositoryInterface) RemoveItem(itemID uint) error {
+	ret := _m.Called(itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(itemID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWishlistRepositoryInterface_RemoveItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveItem'
+type MockWishlistRepositoryInterface_RemoveItem_Call struct {
+	*mock.Call
+}
+
+// RemoveItem is a helper method to define mock.On call
+//   - itemID uint
+func (_e *MockWishlistRepositoryInterface_Expecter) RemoveItem(itemID interface{}) *MockWishlistRepositoryInterface_RemoveItem_Call {
+	return &MockWishlistRepositoryInterface_RemoveItem_Call{Call: _e.mock.On("RemoveItem", itemID)}
+}
+
+func (_c *MockWishlistRepositoryInterface_RemoveItem_Call) Run(run func(itemID uint)) *MockWishlistRepositoryInterface_RemoveItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_RemoveItem_Call) Return(_a0 error) *MockWishlistRepositoryInterface_RemoveItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_RemoveItem_Call) RunAndReturn(run func(uint) error) *MockWishlistRepositoryInterface_RemoveItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: wishlist
+func (_m *MockWishlistRepositoryInterface) Update(wishlist *models.Wishlist) error {
+	ret := _m.Called(wishlist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Wishlist) error); ok {
+		r0 = rf(wishlist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWishlistRepositoryInterface_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockWishlistRepositoryInterface_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - wishlist *models.Wishlist
+func (_e *MockWishlistRepositoryInterface_Expecter) Update(wishlist interface{}) *MockWishlistRepositoryInterface_Update_Call {
+	return &MockWishlistRepositoryInterface_Update_Call{Call: _e.mock.On("Update", wishlist)}
+}
+
+func (_c *MockWishlistRepositoryInterface_Update_Call) Run(run func(wishlist *models.Wishlist)) *MockWishlistRepositoryInterface_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Wishlist))
+	})
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_Update_Call) Return(_a0 error) *MockWishlistRepositoryInterface_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWishlistRepositoryInterface_Update_Call) RunAndReturn(run func(*models.Wishlist) error) *MockWishlistRepositoryInterface_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWishlistRepositoryInterface creates a new instance of MockWishlistRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWishlistRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWishlistRepositoryInterface {
+	mock := &MockWishlistRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AttributeType string
+
+const (
+	AttributeTypeText    AttributeType = "text"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeBoolean AttributeType = "boolean"
+	AttributeTypeEnum    AttributeType = "enum"
+)
+
+type CategoryAttribute struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	CategoryID uint           `json:"category_id" gorm:"not null"`
+	Code       string         `json:"code" gorm:"not null"`
+	Name       string         `json:"name" gorm:"not null"`
+	Type       AttributeType  `json:"type" gorm:"not null"`
+	Unit       string         `json:"unit"`
+	Options    StringList     `json:"options" gorm:"type:jsonb"`
+	IsRequired bool           `json:"is_required" gorm:"default:false"`
+	SortOrder  int            `json:"sort_order" gorm:"default:0"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Category Category `json:"-"`
+}
+
+type ProductAttributeValue struct {
+	ID                  uint           `json:"id" gorm:"primaryKey"`
+	ProductID           uint           `json:"product_id" gorm:"not null"`
+	CategoryAttributeID uint           `json:"category_attribute_id" gorm:"not null"`
+	TextValue           *string        `json:"text_value"`
+	NumberValue         *float64       `json:"number_value"`
+	BooleanValue        *bool          `json:"boolean_value"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Product           Product           `json:"-"`
+	CategoryAttribute CategoryAttribute `json:"attribute"`
+}
+
+// TypedValue returns the stored value according to the attribute type
+func (v *ProductAttributeValue) TypedValue() interface{} {
+	switch v.CategoryAttribute.Type {
+	case AttributeTypeNumber:
+		if v.NumberValue != nil {
+			return *v.NumberValue
+		}
+	case AttributeTypeBoolean:
+		if v.BooleanValue != nil {
+			return *v.BooleanValue
+		}
+	default:
+		if v.TextValue != nil {
+			return *v.TextValue
+		}
+	}
+	return nil
+}
+
+// StringList is a list of strings persisted as a JSON array
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (l *StringList) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for StringList")
+	}
+
+	return json.Unmarshal(data, l)
+}
+
+func (l StringList) Contains(value string) bool {
+	for _, v := range l {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// CartReminder records an abandoned cart reminder sent to a user. The cart
+// contents at the time of sending are kept so the restore link brings back
+// exactly what the email showed.
+type CartReminder struct {
+	ID         uint         `json:"id" gorm:"primaryKey"`
+	CartID     uint         `json:"cart_id" gorm:"not null"`
+	UserID     uint         `json:"user_id" gorm:"not null"`
+	Token      string       `json:"-" gorm:"uniqueIndex;not null"`
+	Items      CartSnapshot `json:"items" gorm:"type:jsonb;not null"`
+	SentAt     time.Time    `json:"sent_at" gorm:"not null"`
+	RestoredAt *time.Time   `json:"restored_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+
+	Cart Cart `json:"-"`
+	User User `json:"-"`
+}
+
+type CartSnapshotItem struct {
+	ProductID uint `json:"product_id"`
+	Quantity  int  `json:"quantity"`
+}
+
+// CartSnapshot is a list of cart lines persisted as a JSON array
+type CartSnapshot []CartSnapshotItem
+
+func (s CartSnapshot) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (s *CartSnapshot) Scan(value interface{}) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for CartSnapshot")
+	}
+
+	return json.Unmarshal(data, s)
+}
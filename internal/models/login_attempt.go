@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// LoginAttempt counts the recent failed logins of an account or a client IP
+type LoginAttempt struct {
+	ThrottleKey  string     `json:"throttle_key" gorm:"primaryKey"`
+	Failures     int        `json:"failures" gorm:"not null"`
+	LastFailedAt time.Time  `json:"last_failed_at" gorm:"not null"`
+	BlockedUntil *time.Time `json:"blocked_until"`
+}
@@ -1,19 +1,24 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type Order struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	UserID      uint           `json:"user_id" gorm:"not null"`
-	Status      OrderStatus    `json:"status" gorm:"default:pending"`
-	TotalAmount float64        `json:"total_amount" gorm:"not null"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	UserID         uint           `json:"user_id" gorm:"not null"`
+	SubscriptionID *uint          `json:"subscription_id"`
+	Status         OrderStatus    `json:"status" gorm:"default:pending"`
+	Subtotal       float64        `json:"subtotal" gorm:"not null"`
+	TaxAmount      float64        `json:"tax_amount" gorm:"not null"`
+	ShippingAmount float64        `json:"shipping_amount" gorm:"not null"`
+	TotalAmount    float64        `json:"total_amount" gorm:"not null"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
 
 	User       User        `json:"user"`
 	OrderItems []OrderItem `json:"order_items"`
@@ -29,37 +34,84 @@ const (
 	OrderStatusCancelled OrderStatus = "cancelled"
 )
 
+// IsPaid reports whether payment for the order has been confirmed
+func (s OrderStatus) IsPaid() bool {
+	return s == OrderStatusConfirmed || s == OrderStatusShipped || s == OrderStatusDelivered
+}
+
+// orderTransitions lists the statuses an order can move to from each status.
+// Delivered and cancelled orders are final.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:   {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed: {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped:   {OrderStatusDelivered},
+}
+
+// CanTransitionTo reports whether an order can move from s to next
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	return slices.Contains(orderTransitions[s], next)
+}
+
 type OrderItem struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	OrderID   uint           `json:"order_id" gorm:"not null"`
-	ProductID uint           `json:"product_id" gorm:"not null"`
-	Quantity  int            `json:"quantity" gorm:"not null"`
-	Price     float64        `json:"price" gorm:"not null"`
-	CreatedAt time.Time      `json:"created_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	OrderID     uint           `json:"order_id" gorm:"not null"`
+	ProductID   uint           `json:"product_id" gorm:"not null"`
+	Quantity    int            `json:"quantity" gorm:"not null"`
+	Price       float64        `json:"price" gorm:"not null"`
+	Note        string         `json:"note"`
+	GiftMessage string         `json:"gift_message"`
+	CreatedAt   time.Time      `json:"created_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// DownloadCount is maintained by the order repository, never by Save
+	DownloadCount int `json:"download_count" gorm:"->"`
+
+	Order      Order                `json:"-"`
+	Product    Product              `json:"product"`
+	Components []OrderItemComponent `json:"components,omitempty"`
+}
+
+// OrderItemComponent records which products were shipped for a bundle line,
+// with quantities already multiplied by the ordered quantity
+type OrderItemComponent struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	OrderItemID uint           `json:"order_item_id" gorm:"not null"`
+	ProductID   uint           `json:"product_id" gorm:"not null"`
+	Quantity    int            `json:"quantity" gorm:"not null"`
+	CreatedAt   time.Time      `json:"created_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 
-	Order   Order   `json:"-"`
 	Product Product `json:"product"`
 }
 
 type Cart struct {
 	ID        uint           `json:"id" gorm:"primaryKey"`
-	UserID    uint           `json:"user_id" gorm:"uniqueIndex;not null"`
+	UserID    *uint          `json:"user_id" gorm:"uniqueIndex"`
+	GuestID   *string        `json:"-" gorm:"uniqueIndex"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
-	CartItems []CartItem `json:"cart_items"`
+	User *User `json:"-"`
+	// CartItems and SavedItems share the cart_items table and are told apart by
+	// CartItem.SavedForLater, which the repository filters on when preloading
+	CartItems  []CartItem `json:"cart_items"`
+	SavedItems []CartItem `json:"saved_items" gorm:"foreignKey:CartID"`
 }
 
 type CartItem struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	CartID    uint           `json:"cart_id" gorm:"not null"`
-	ProductID uint           `json:"product_id" gorm:"not null"`
-	Quantity  int            `json:"quantity" gorm:"not null"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	CartID           uint           `json:"cart_id" gorm:"not null"`
+	ProductID        uint           `json:"product_id" gorm:"not null"`
+	Quantity         int            `json:"quantity" gorm:"not null"`
+	UnitPrice        float64        `json:"unit_price" gorm:"not null"`
+	SavedForLater    bool           `json:"saved_for_later" gorm:"not null;default:false"`
+	Note             string         `json:"note"`
+	GiftMessage      string         `json:"gift_message"`
+	ReservedQuantity int            `json:"-" gorm:"not null;default:0"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
 
 	Cart    Cart    `json:"-"`
 	Product Product `json:"product"`
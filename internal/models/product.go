@@ -8,31 +8,77 @@ import (
 
 type Category struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
+	ParentID    *uint          `json:"parent_id" gorm:"index"`
 	Name        string         `json:"name" gorm:"not null"`
+	Slug        string         `json:"slug" gorm:"not null"`
 	Description string         `json:"description"`
 	IsActive    bool           `json:"is_active" gorm:"default:true"`
+	SortOrder   int            `json:"sort_order" gorm:"default:0"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 
-	Products []Product `json:"-"`
+	Parent     *Category           `json:"-"`
+	Children   []Category          `json:"-" gorm:"foreignKey:ParentID"`
+	Products   []Product           `json:"-"`
+	Attributes []CategoryAttribute `json:"-"`
+
+	// Ancestors is filled in by the repositories, root first
+	Ancestors []Category `json:"-" gorm:"-"`
 }
 
 type Product struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
 	CategoryID  uint           `json:"category_id" gorm:"not null"`
 	Name        string         `json:"name" gorm:"not null"`
+	Slug        string         `json:"slug" gorm:"not null"`
 	Description string         `json:"description"`
 	Price       float64        `json:"price" gorm:"not null"`
 	Stock       int            `json:"stock" gorm:"default:0"`
 	SKU         string         `json:"sku" gorm:"uniqueIndex;not null"`
 	IsActive    bool           `json:"is_active" gorm:"default:true"`
+	IsBundle    bool           `json:"is_bundle" gorm:"default:false"`
+	IsDigital   bool           `json:"is_digital" gorm:"default:false"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 
-	Category Category       `json:"category"`
-	Images   []ProductImage `json:"images"`
+	// Rating aggregates are maintained by the review repository, never by Save
+	RatingSum     int     `json:"-" gorm:"->"`
+	RatingCount   int     `json:"rating_count" gorm:"->"`
+	RatingAverage float64 `json:"rating_average" gorm:"->"`
+
+	Category   Category                `json:"category"`
+	Images     []ProductImage          `json:"images"`
+	Attributes []ProductAttributeValue `json:"attributes"`
+
+	// BundleItems lists the components of a bundle. The stock of a bundle is
+	// derived from them and kept in sync by the product repository.
+	BundleItems []BundleItem `json:"bundle_items,omitempty" gorm:"foreignKey:BundleID"`
+}
+
+type BundleItem struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	BundleID    uint           `json:"bundle_id" gorm:"not null"`
+	ComponentID uint           `json:"component_id" gorm:"not null"`
+	Quantity    int            `json:"quantity" gorm:"not null"`
+	CreatedAt   time.Time      `json:"created_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Component Product `json:"component" gorm:"foreignKey:ComponentID"`
+}
+
+// ProductFile is a downloadable file of a digital product. Path is the key in
+// the upload provider and is never exposed to customers.
+type ProductFile struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	ProductID   uint           `json:"product_id" gorm:"not null"`
+	Path        string         `json:"-" gorm:"not null"`
+	FileName    string         `json:"file_name" gorm:"not null"`
+	ContentType string         `json:"content_type"`
+	Size        int64          `json:"size"`
+	CreatedAt   time.Time      `json:"created_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 type ProductImage struct {
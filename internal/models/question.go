@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type QAStatus string
+
+const (
+	QAStatusPending   QAStatus = "pending"
+	QAStatusPublished QAStatus = "published"
+	QAStatusRejected  QAStatus = "rejected"
+)
+
+type ProductQuestion struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	ProductID   uint           `json:"product_id" gorm:"not null"`
+	UserID      uint           `json:"user_id" gorm:"not null"`
+	Body        string         `json:"body" gorm:"not null"`
+	Status      QAStatus       `json:"status" gorm:"default:pending"`
+	UpvoteCount int            `json:"upvote_count" gorm:"->"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+
+	User    User            `json:"-"`
+	Product Product         `json:"-"`
+	Answers []ProductAnswer `json:"answers" gorm:"foreignKey:QuestionID"`
+}
+
+type ProductAnswer struct {
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	QuestionID      uint           `json:"question_id" gorm:"not null"`
+	UserID          uint           `json:"user_id" gorm:"not null"`
+	Body            string         `json:"body" gorm:"not null"`
+	Status          QAStatus       `json:"status" gorm:"default:pending"`
+	IsStaff         bool           `json:"is_staff" gorm:"default:false"`
+	IsVerifiedBuyer bool           `json:"is_verified_buyer" gorm:"default:false"`
+	UpvoteCount     int            `json:"upvote_count" gorm:"->"`
+	AskerNotifiedAt *time.Time     `json:"-"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
+
+	User     User            `json:"-"`
+	Question ProductQuestion `json:"-" gorm:"foreignKey:QuestionID"`
+}
+
+type QAVoteTarget string
+
+const (
+	QAVoteTargetQuestion QAVoteTarget = "question"
+	QAVoteTargetAnswer   QAVoteTarget = "answer"
+)
+
+// QAVote records a single upvote so a user can only count once per question or answer
+type QAVote struct {
+	ID         uint         `json:"id" gorm:"primaryKey"`
+	TargetType QAVoteTarget `json:"target_type" gorm:"not null"`
+	TargetID   uint         `json:"target_id" gorm:"not null"`
+	UserID     uint         `json:"user_id" gorm:"not null"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+func (QAVote) TableName() string {
+	return "qa_votes"
+}
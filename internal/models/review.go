@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReviewStatus string
+
+const (
+	ReviewStatusPending  ReviewStatus = "pending"
+	ReviewStatusApproved ReviewStatus = "approved"
+	ReviewStatusRejected ReviewStatus = "rejected"
+)
+
+type Review struct {
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	ProductID      uint           `json:"product_id" gorm:"not null"`
+	UserID         uint           `json:"user_id" gorm:"not null"`
+	OrderItemID    uint           `json:"order_item_id" gorm:"not null"`
+	Rating         int            `json:"rating" gorm:"not null"`
+	Title          string         `json:"title"`
+	Body           string         `json:"body" gorm:"not null"`
+	Status         ReviewStatus   `json:"status" gorm:"default:pending"`
+	ModerationNote string         `json:"moderation_note"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+
+	User    User          `json:"-"`
+	Product Product       `json:"-"`
+	Images  []ReviewImage `json:"images"`
+}
+
+type ReviewImage struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	ReviewID  uint           `json:"review_id" gorm:"not null"`
+	URL       string         `json:"url" gorm:"not null"`
+	CreatedAt time.Time      `json:"created_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Review Review `json:"-"`
+}
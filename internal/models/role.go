@@ -0,0 +1,69 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Permissions checked by the API. Roles are composed of these; the admin role
+// holds PermissionAll and so every permission, including ones added later.
+const (
+	PermissionAll               = "*"
+	PermissionCatalogManage     = "catalog:manage"
+	PermissionOrdersUpdate      = "orders:update"
+	PermissionReviewsModerate   = "reviews:moderate"
+	PermissionQuestionsModerate = "questions:moderate"
+	PermissionQuestionsAnswer   = "questions:answer"
+	PermissionUsersUnlock       = "users:unlock"
+	PermissionRolesManage       = "roles:manage"
+)
+
+// PermissionDescriptions lists every permission that can be granted to a role
+var PermissionDescriptions = map[string]string{
+	PermissionAll:               "Every permission",
+	PermissionCatalogManage:     "Manage categories, attributes and products",
+	PermissionOrdersUpdate:      "Update the status of orders",
+	PermissionReviewsModerate:   "Moderate product reviews",
+	PermissionQuestionsModerate: "Moderate product questions and answers",
+	PermissionQuestionsAnswer:   "Answer product questions on behalf of the shop",
+	PermissionUsersUnlock:       "Unlock accounts locked after failed logins",
+	PermissionRolesManage:       "Manage roles and assign them to users",
+}
+
+// RoleAdmin is the built in role holding every permission. It cannot be
+// changed or deleted, and its last holder cannot lose it.
+const RoleAdmin = "admin"
+
+type Role struct {
+	ID          uint             `json:"id" gorm:"primaryKey"`
+	Name        string           `json:"name" gorm:"uniqueIndex;not null"`
+	Description string           `json:"description"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	Permissions []RolePermission `json:"permissions"`
+}
+
+type RolePermission struct {
+	RoleID     uint   `json:"role_id" gorm:"primaryKey"`
+	Permission string `json:"permission" gorm:"primaryKey"`
+}
+
+// PermissionNames returns the names of the permissions granted by the role
+func (r *Role) PermissionNames() []string {
+	names := make([]string, len(r.Permissions))
+	for i, permission := range r.Permissions {
+		names[i] = permission.Permission
+	}
+	return names
+}
+
+// IsKnownPermission reports whether permission can be granted to a role
+func IsKnownPermission(permission string) bool {
+	_, ok := PermissionDescriptions[permission]
+	return ok
+}
+
+// HasPermission reports whether the granted permissions include permission
+func HasPermission(granted []string, permission string) bool {
+	return slices.Contains(granted, permission) || slices.Contains(granted, PermissionAll)
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type SlugEntityType string
+
+const (
+	SlugEntityProduct  SlugEntityType = "product"
+	SlugEntityCategory SlugEntityType = "category"
+)
+
+// SlugRedirect keeps a retired slug pointing at the entity that used it
+type SlugRedirect struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	EntityType SlugEntityType `json:"entity_type" gorm:"not null"`
+	EntityID   uint           `json:"entity_id" gorm:"not null"`
+	OldSlug    string         `json:"old_slug" gorm:"not null"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusActive    SubscriptionStatus = "active"
+	SubscriptionStatusPaused    SubscriptionStatus = "paused"
+	SubscriptionStatusCancelled SubscriptionStatus = "cancelled"
+)
+
+type SubscriptionFrequency string
+
+const (
+	SubscriptionFrequencyWeekly   SubscriptionFrequency = "weekly"
+	SubscriptionFrequencyBiweekly SubscriptionFrequency = "biweekly"
+	SubscriptionFrequencyMonthly  SubscriptionFrequency = "monthly"
+)
+
+// Next returns the delivery date one period after from. Monthly deliveries
+// fall on anchorDay, or on the last day of months too short for it, so a
+// subscription started on the 31st is not pushed into the next month.
+func (f SubscriptionFrequency) Next(from time.Time, anchorDay int) time.Time {
+	switch f {
+	case SubscriptionFrequencyWeekly:
+		return from.AddDate(0, 0, 7)
+	case SubscriptionFrequencyBiweekly:
+		return from.AddDate(0, 0, 14)
+	}
+
+	if anchorDay < 1 {
+		anchorDay = from.Day()
+	}
+	// Day 0 of the month after next is the last day of next month
+	lastDay := time.Date(from.Year(), from.Month()+2, 0, 0, 0, 0, 0, from.Location()).Day()
+	day := min(anchorDay, lastDay)
+	return time.Date(from.Year(), from.Month()+1, day, from.Hour(), from.Minute(), from.Second(), from.Nanosecond(), from.Location())
+}
+
+// Subscription places a recurring order for its items. NextRunAt is the date of
+// the upcoming delivery; when generating its order fails, RetryAt holds the
+// time of the next attempt while NextRunAt keeps the original schedule.
+// AnchorDay is the day of the month monthly deliveries fall on.
+type Subscription struct {
+	ID             uint                  `json:"id" gorm:"primaryKey"`
+	UserID         uint                  `json:"user_id" gorm:"not null"`
+	Status         SubscriptionStatus    `json:"status" gorm:"default:active"`
+	Frequency      SubscriptionFrequency `json:"frequency" gorm:"not null"`
+	NextRunAt      time.Time             `json:"next_run_at" gorm:"not null"`
+	AnchorDay      int                   `json:"-" gorm:"not null;default:0"`
+	RetryAt        *time.Time            `json:"retry_at"`
+	FailedAttempts int                   `json:"failed_attempts" gorm:"not null;default:0"`
+	LastError      string                `json:"last_error"`
+	LastOrderID    *uint                 `json:"last_order_id"`
+	CancelledAt    *time.Time            `json:"cancelled_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt        `json:"-" gorm:"index"`
+
+	User  User               `json:"-"`
+	Items []SubscriptionItem `json:"items"`
+}
+
+type SubscriptionItem struct {
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	SubscriptionID uint           `json:"subscription_id" gorm:"not null"`
+	ProductID      uint           `json:"product_id" gorm:"not null"`
+	Quantity       int            `json:"quantity" gorm:"not null"`
+	CreatedAt      time.Time      `json:"created_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Subscription Subscription `json:"-"`
+	Product      Product      `json:"product"`
+}
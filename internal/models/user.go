@@ -1,43 +1,97 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"gorm.io/gorm"
 )
 
-type UserRole string
-
-const (
-	UserRoleAdmin    UserRole = "admin"
-	UserRoleCustomer UserRole = "customer"
-)
-
 type User struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Email     string         `json:"email" gorm:"uniqueIndex;not null"`
-	Password  string         `json:"-" gorm:"not null"`
-	FirstName string         `json:"first_name" gorm:"not null"`
-	LastName  string         `json:"last_name" gorm:"not null"`
-	Phone     string         `json:"phone"`
-	IsActive  bool           `json:"is_active" gorm:"default:true"`
-	Role      UserRole       `json:"role" gorm:"default:customer"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	Email           string         `json:"email" gorm:"uniqueIndex;not null"`
+	Password        string         `json:"-" gorm:"not null"`
+	FirstName       string         `json:"first_name" gorm:"not null"`
+	LastName        string         `json:"last_name" gorm:"not null"`
+	Phone           string         `json:"phone"`
+	IsActive        bool           `json:"is_active" gorm:"default:true"`
+	EmailVerifiedAt *time.Time     `json:"email_verified_at"`
+	TOTPSecret      string         `json:"-" gorm:"column:totp_secret;not null;default:''"`
+	TOTPEnabledAt   *time.Time     `json:"-" gorm:"column:totp_enabled_at"`
+	TOTPLastStep    int64          `json:"-" gorm:"column:totp_last_step;not null;default:0"`
+	RolesChangedAt  *time.Time     `json:"-"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
 
+	Roles         []Role         `json:"roles" gorm:"many2many:user_roles"`
 	RefreshTokens []RefreshToken `json:"-"`
+	RecoveryCodes []RecoveryCode `json:"-"`
 	Orders        []Order        `json:"-"`
 	Cart          Cart           `json:"-"`
 }
 
+// IsEmailVerified reports whether the user confirmed owning their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// RoleNames returns the names of the roles of the user. Users without roles
+// are customers.
+func (u *User) RoleNames() []string {
+	names := make([]string, len(u.Roles))
+	for i, role := range u.Roles {
+		names[i] = role.Name
+	}
+	return names
+}
+
+// Permissions returns every permission granted by the roles of the user, they
+// must be loaded with their permissions
+func (u *User) Permissions() []string {
+	var permissions []string
+	for _, role := range u.Roles {
+		for _, permission := range role.PermissionNames() {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions
+}
+
+// TwoFactorEnabled reports whether signing in asks for an authenticator code.
+// A secret without TOTPEnabledAt is an enrollment that was not confirmed yet.
+func (u *User) TwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil
+}
+
+// RecoveryCode signs a user in when the authenticator app is lost. Only the
+// hash is stored and each code works once.
+type RecoveryCode struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// RefreshToken is stored by hash only. Each refresh rotates the token: the
+// presented one gets RotatedAt and a successor is issued in the same family.
+// A family is one sign in, the session the user sees, and its ID is the sid
+// claim of the access tokens; revoking it soft deletes all of its tokens.
 type RefreshToken struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	UserID    uint           `json:"user_id" gorm:"not null"`
-	Token     string         `json:"token" gorm:"uniqueIndex;not null"`
-	CreatedAt time.Time      `json:"created_at"`
-	ExpiredAt time.Time      `json:"expired_at" gorm:"not null"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	UserID     uint           `json:"user_id" gorm:"not null"`
+	TokenHash  string         `json:"-" gorm:"uniqueIndex;not null"`
+	FamilyID   string         `json:"family_id" gorm:"type:uuid;not null;index"`
+	UserAgent  string         `json:"user_agent"`
+	IPAddress  string         `json:"ip_address"`
+	LastUsedAt time.Time      `json:"last_used_at" gorm:"not null"`
+	CreatedAt  time.Time      `json:"created_at"`
+	ExpiredAt  time.Time      `json:"expired_at" gorm:"not null"`
+	RotatedAt  *time.Time     `json:"rotated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
 
 	User User `json:"-"`
 }
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type UserTokenPurpose string
+
+const (
+	UserTokenEmailVerification UserTokenPurpose = "email_verification"
+	UserTokenPasswordReset     UserTokenPurpose = "password_reset"
+	UserTokenEmailChange       UserTokenPurpose = "email_change"
+	UserTokenTwoFactorLogin    UserTokenPurpose = "two_factor_login"
+)
+
+// UserToken is a single-use token sent to the user by email, or handed out by
+// a login that still has to pass the second factor. Only the hash of
+// the token is stored. Email is the address the token was sent to; for an
+// email change that is the new address, otherwise a token is only honoured
+// while the account still uses that address.
+type UserToken struct {
+	ID        uint             `json:"id" gorm:"primaryKey"`
+	UserID    uint             `json:"user_id" gorm:"not null"`
+	Purpose   UserTokenPurpose `json:"purpose" gorm:"not null"`
+	TokenHash string           `json:"-" gorm:"uniqueIndex;not null"`
+	Email     string           `json:"email" gorm:"not null"`
+	ExpiresAt time.Time        `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time       `json:"used_at"`
+	CreatedAt time.Time        `json:"created_at"`
+
+	User User `json:"-"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Wishlist struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	UserID     uint           `json:"user_id" gorm:"not null"`
+	Name       string         `json:"name" gorm:"not null"`
+	ShareToken *string        `json:"-"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+
+	User  User           `json:"-"`
+	Items []WishlistItem `json:"items"`
+}
+
+type WishlistItem struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	WishlistID uint           `json:"wishlist_id" gorm:"not null"`
+	ProductID  uint           `json:"product_id" gorm:"not null"`
+	CreatedAt  time.Time      `json:"created_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Wishlist Wishlist `json:"-"`
+	Product  Product  `json:"product"`
+}
@@ -5,6 +5,8 @@ import (
 	"net"
 	"net/smtp"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type SMTPConfig struct {
@@ -91,3 +93,181 @@ The Shop Team`, userName),
 
 	return e.SendEmail(email)
 }
+
+func (e *EmailNotifier) SendQuestionAnsweredNotification(userEmail, userName, productName, question, answer string) error {
+	email := &EmailConfig{
+		To:      userEmail,
+		Subject: fmt.Sprintf("Your question about %s has been answered", productName),
+		Body: fmt.Sprintf(`Hello %s,
+
+Your question about %s has a new answer.
+
+Question: %s
+
+Answer: %s
+
+Best regards,
+The Shop Team`, userName, productName, question, answer),
+	}
+
+	return e.SendEmail(email)
+}
+
+func (e *EmailNotifier) SendCartReminder(userEmail, userName string, items []CartAbandonedPayloadItem, total float64, restoreURL string) error {
+	var lines strings.Builder
+	for _, item := range items {
+		fmt.Fprintf(&lines, "- %s x%d: %.2f\n", item.ProductName, item.Quantity, item.Price*float64(item.Quantity))
+	}
+
+	email := &EmailConfig{
+		To:      userEmail,
+		Subject: "You left something in your cart",
+		Body: fmt.Sprintf(`Hello %s,
+
+You still have items waiting in your cart:
+
+%s
+Total: %.2f
+
+Pick up where you left off: %s
+
+Best regards,
+The Shop Team`, userName, lines.String(), total, restoreURL),
+	}
+
+	return e.SendEmail(email)
+}
+
+func (e *EmailNotifier) SendSubscriptionOrderFailed(userEmail, userName string, subscriptionID uint, reason string, willRetry bool, nextAttemptAt time.Time) error {
+	next := fmt.Sprintf("This delivery has been skipped. Your next delivery is scheduled for %s.", nextAttemptAt.Format("January 2, 2006"))
+	if willRetry {
+		next = fmt.Sprintf("We will try again on %s.", nextAttemptAt.Format("January 2, 2006 at 15:04 MST"))
+	}
+
+	email := &EmailConfig{
+		To:      userEmail,
+		Subject: "We could not place your subscription order",
+		Body: fmt.Sprintf(`Hello %s,
+
+We were unable to place the order for your subscription #%d.
+
+Reason: %s
+
+%s
+
+You can review, skip or pause your subscription from your account at any time.
+
+Best regards,
+The Shop Team`, userName, subscriptionID, reason, next),
+	}
+
+	return e.SendEmail(email)
+}
+
+func (e *EmailNotifier) SendEmailVerification(userEmail, userName, verificationURL string, expiresAt time.Time) error {
+	email := &EmailConfig{
+		To:      userEmail,
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf(`Hello %s,
+
+Please confirm your email address by opening the link below:
+
+%s
+
+The link expires on %s. If you did not create an account, you can ignore this email.
+
+Best regards,
+The Shop Team`, userName, verificationURL, expiresAt.Format("January 2, 2006 at 15:04 MST")),
+	}
+
+	return e.SendEmail(email)
+}
+
+func (e *EmailNotifier) SendPasswordReset(userEmail, userName, resetURL string, expiresAt time.Time) error {
+	email := &EmailConfig{
+		To:      userEmail,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(`Hello %s,
+
+We received a request to reset the password of your account. Choose a new password by opening the link below:
+
+%s
+
+The link can be used once and expires on %s. If you did not ask for a password reset, you can ignore this email and your password stays the same.
+
+Best regards,
+The Shop Team`, userName, resetURL, expiresAt.Format("January 2, 2006 at 15:04 MST")),
+	}
+
+	return e.SendEmail(email)
+}
+
+func (e *EmailNotifier) SendEmailChangeConfirmation(newEmail, userName, confirmURL string, expiresAt time.Time) error {
+	email := &EmailConfig{
+		To:      newEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(`Hello %s,
+
+You asked to use this address for your account. Please confirm it by opening the link below while signed in:
+
+%s
+
+The link expires on %s. Until then your account keeps using its current address. If you did not ask for this change, you can ignore this email.
+
+Best regards,
+The Shop Team`, userName, confirmURL, expiresAt.Format("January 2, 2006 at 15:04 MST")),
+	}
+
+	return e.SendEmail(email)
+}
+
+func (e *EmailNotifier) SendEmailChanged(oldEmail, userName, newEmail string) error {
+	email := &EmailConfig{
+		To:      oldEmail,
+		Subject: "Your email address was changed",
+		Body: fmt.Sprintf(`Hello %s,
+
+The email address of your account was changed to %s. This address will no longer receive emails about your account or be used to sign in.
+
+If you did not make this change, please contact our support team right away.
+
+Best regards,
+The Shop Team`, userName, newEmail),
+	}
+
+	return e.SendEmail(email)
+}
+
+func (e *EmailNotifier) SendTokenReuseAlert(userEmail, userName string, detectedAt time.Time) error {
+	email := &EmailConfig{
+		To:      userEmail,
+		Subject: "We signed you out of a session",
+		Body: fmt.Sprintf(`Hello %s,
+
+On %s a sign in token of your account was used again after it had already been replaced. This can mean the token was copied from one of your devices, so we signed that session out.
+
+If this keeps happening, please change your password.
+
+Best regards,
+The Shop Team`, userName, detectedAt.Format("January 2, 2006 at 15:04 MST")),
+	}
+
+	return e.SendEmail(email)
+}
+
+func (e *EmailNotifier) SendAccountLockedOut(userEmail, userName, ipAddress string, lockedUntil time.Time) error {
+	email := &EmailConfig{
+		To:      userEmail,
+		Subject: "Your account was temporarily locked",
+		Body: fmt.Sprintf(`Hello %s,
+
+There were too many failed attempts to sign in to your account, the last one from %s. To protect your account, signing in is blocked until %s.
+
+If these attempts were not yours, we recommend resetting your password once the lock ends.
+
+Best regards,
+The Shop Team`, userName, ipAddress, lockedUntil.Format("January 2, 2006 at 15:04 MST")),
+	}
+
+	return e.SendEmail(email)
+}
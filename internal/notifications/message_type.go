@@ -1,5 +1,16 @@
 package notifications
 
 const (
-	UserLoggedIn = "USER_LOGGED_IN"
+	UserLoggedIn     = "USER_LOGGED_IN"
+	UserRegistered   = "USER_REGISTERED"
+	QuestionAnswered = "QUESTION_ANSWERED"
+	CartAbandoned    = "CART_ABANDONED"
+
+	SubscriptionOrderFailed    = "SUBSCRIPTION_ORDER_FAILED"
+	EmailVerificationRequested = "EMAIL_VERIFICATION_REQUESTED"
+	PasswordResetRequested     = "PASSWORD_RESET_REQUESTED"
+	EmailChangeRequested       = "EMAIL_CHANGE_REQUESTED"
+	EmailChanged               = "EMAIL_CHANGED"
+	SecurityTokenReuse         = "SECURITY_TOKEN_REUSE"
+	UserLockedOut              = "USER_LOCKED_OUT"
 )
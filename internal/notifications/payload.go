@@ -0,0 +1,87 @@
+package notifications
+
+import "time"
+
+// QuestionAnsweredPayload is published when an answer to a product question goes live
+type QuestionAnsweredPayload struct {
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	ProductID   uint   `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Question    string `json:"question"`
+	Answer      string `json:"answer"`
+}
+
+// CartAbandonedPayload is published when a cart has been left untouched long
+// enough to remind its owner about it
+type CartAbandonedPayload struct {
+	Email      string                     `json:"email"`
+	Name       string                     `json:"name"`
+	Items      []CartAbandonedPayloadItem `json:"items"`
+	Total      float64                    `json:"total"`
+	RestoreURL string                     `json:"restore_url"`
+}
+
+type CartAbandonedPayloadItem struct {
+	ProductName string  `json:"product_name"`
+	Quantity    int     `json:"quantity"`
+	Price       float64 `json:"price"`
+}
+
+// SubscriptionOrderFailedPayload is published when the order for a subscription
+// delivery could not be placed. WillRetry tells whether NextAttemptAt is a retry
+// of this delivery or the next scheduled one.
+type SubscriptionOrderFailedPayload struct {
+	Email          string    `json:"email"`
+	Name           string    `json:"name"`
+	SubscriptionID uint      `json:"subscription_id"`
+	Reason         string    `json:"reason"`
+	Attempt        int       `json:"attempt"`
+	WillRetry      bool      `json:"will_retry"`
+	NextAttemptAt  time.Time `json:"next_attempt_at"`
+}
+
+// EmailVerificationPayload carries the link that confirms an email address. It
+// is published with USER_REGISTERED and again whenever the link is resent, and
+// with EMAIL_CHANGE_REQUESTED to confirm the new address of an account.
+type EmailVerificationPayload struct {
+	Email           string    `json:"email"`
+	Name            string    `json:"name"`
+	VerificationURL string    `json:"verification_url"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+// EmailChangedPayload tells the previous address of an account that it is no
+// longer used for signing in
+type EmailChangedPayload struct {
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	NewEmail string `json:"new_email"`
+}
+
+// TokenReusePayload reports a refresh token that was presented again after it
+// had been rotated out. The sign in it belonged to has been revoked.
+type TokenReusePayload struct {
+	UserID     uint      `json:"user_id"`
+	Email      string    `json:"email"`
+	Name       string    `json:"name"`
+	FamilyID   string    `json:"family_id"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// UserLockedOutPayload reports an account locked after too many failed logins
+type UserLockedOutPayload struct {
+	UserID      uint      `json:"user_id"`
+	Email       string    `json:"email"`
+	Name        string    `json:"name"`
+	IPAddress   string    `json:"ip_address"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// PasswordResetPayload carries the link that lets the user choose a new password
+type PasswordResetPayload struct {
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	ResetURL  string    `json:"reset_url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -2,6 +2,7 @@ package providers
 
 import (
 	"fmt"
+	"io"
 	"mime/multipart"
 	"os"
 	"path/filepath"
@@ -48,3 +49,8 @@ func (p *LocalUploadProvider) DeleteFile(path string) error {
 	fullPath := filepath.Join(p.basePath, path)
 	return os.Remove(fullPath)
 }
+
+func (p *LocalUploadProvider) OpenFile(path string) (io.ReadCloser, error) {
+	fullPath := filepath.Join(p.basePath, path)
+	return os.Open(fullPath)
+}
@@ -2,6 +2,7 @@ package providers
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -78,3 +79,15 @@ func (p *S3Provider) DeleteFile(path string) error {
 
 	return err
 }
+
+func (p *S3Provider) OpenFile(path string) (io.ReadCloser, error) {
+	result, err := p.client.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(path),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Body, nil
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+)
+
+type AttributeRepository struct {
+	db *gorm.DB
+}
+
+func NewAttributeRepository(db *gorm.DB) *AttributeRepository {
+	return &AttributeRepository{db: db}
+}
+
+func (r *AttributeRepository) GetCategoryAttributes(categoryID uint) ([]models.CategoryAttribute, error) {
+	var attributes []models.CategoryAttribute
+	if err := r.db.Where("category_id = ?", categoryID).Order("sort_order ASC, id ASC").Find(&attributes).Error; err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+func (r *AttributeRepository) GetCategoryAttributeByID(id uint) (*models.CategoryAttribute, error) {
+	var attribute models.CategoryAttribute
+	if err := r.db.First(&attribute, id).Error; err != nil {
+		return nil, err
+	}
+	return &attribute, nil
+}
+
+func (r *AttributeRepository) CreateCategoryAttribute(attribute *models.CategoryAttribute) error {
+	return r.db.Create(attribute).Error
+}
+
+func (r *AttributeRepository) UpdateCategoryAttribute(attribute *models.CategoryAttribute) error {
+	return r.db.Save(attribute).Error
+}
+
+func (r *AttributeRepository) DeleteCategoryAttribute(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("category_attribute_id = ?", id).Delete(&models.ProductAttributeValue{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.CategoryAttribute{}, id).Error
+	})
+}
+
+func (r *AttributeRepository) ReplaceProductAttributeValues(productID uint, values []models.ProductAttributeValue) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", productID).Delete(&models.ProductAttributeValue{}).Error; err != nil {
+			return err
+		}
+
+		if len(values) == 0 {
+			return nil
+		}
+
+		for i := range values {
+			values[i].ProductID = productID
+		}
+
+		return tx.Omit("CategoryAttribute", "Product").Create(&values).Error
+	})
+}
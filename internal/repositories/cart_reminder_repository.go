@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+)
+
+// cartActivitySQL is the last time any line of the cart was added, changed or
+// removed
+const cartActivitySQL = `(SELECT MAX(GREATEST(ci.updated_at, COALESCE(ci.deleted_at, ci.updated_at)))
+	FROM cart_items ci WHERE ci.cart_id = carts.id)`
+
+type CartReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewCartReminderRepository(db *gorm.DB) *CartReminderRepository {
+	return &CartReminderRepository{db: db}
+}
+
+// FindAbandonedCarts returns carts of active users that still hold items, not
+// counting those saved for later, and have not been touched since idleSince.
+// Carts already reminded about since their last change are skipped, as are
+// users reminded after remindedSince.
+func (r *CartReminderRepository) FindAbandonedCarts(idleSince, remindedSince time.Time, limit int) ([]models.Cart, error) {
+	var carts []models.Cart
+	err := r.db.
+		Preload("User").
+		Preload("CartItems", "saved_for_later = ?", false).
+		Preload("CartItems.Product").
+		Joins("JOIN users ON users.id = carts.user_id AND users.deleted_at IS NULL AND users.is_active = ?", true).
+		Where("EXISTS (SELECT 1 FROM cart_items ci WHERE ci.cart_id = carts.id AND ci.deleted_at IS NULL AND ci.saved_for_later = ?)", false).
+		Where(cartActivitySQL+" < ?", idleSince).
+		Where("NOT EXISTS (SELECT 1 FROM cart_reminders cr WHERE cr.user_id = carts.user_id AND cr.sent_at > ?)", remindedSince).
+		Where("NOT EXISTS (SELECT 1 FROM cart_reminders cr WHERE cr.cart_id = carts.id AND cr.sent_at >= " + cartActivitySQL + ")").
+		Order("carts.id ASC").
+		Limit(limit).
+		Find(&carts).Error
+	if err != nil {
+		return nil, err
+	}
+	return carts, nil
+}
+
+func (r *CartReminderRepository) GetByToken(token string) (*models.CartReminder, error) {
+	var reminder models.CartReminder
+	if err := r.db.Where("token = ?", token).First(&reminder).Error; err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+func (r *CartReminderRepository) Create(reminder *models.CartReminder) error {
+	return r.db.Omit("Cart", "User").Create(reminder).Error
+}
+
+func (r *CartReminderRepository) Delete(id uint) error {
+	return r.db.Delete(&models.CartReminder{}, id).Error
+}
+
+func (r *CartReminderRepository) MarkRestored(id uint, restoredAt time.Time) error {
+	return r.db.Model(&models.CartReminder{}).Where("id = ?", id).Update("restored_at", restoredAt).Error
+}
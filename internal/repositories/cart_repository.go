@@ -1,10 +1,18 @@
 package repositories
 
 import (
+	"errors"
+	"time"
+
 	"github.com/JihadRinaldi/go-shop/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrInsufficientStock is returned when a bundle line cannot reserve the stock
+// of its components
+var ErrInsufficientStock = errors.New("insufficient product stock")
+
 type CartRepository struct {
 	db *gorm.DB
 }
@@ -20,6 +28,29 @@ func (r *CartRepository) GetByUserID(userID uint) (*models.Cart, error) {
 	}
 	return &cart, nil
 }
+
+func (r *CartRepository) GetByGuestID(guestID string) (*models.Cart, error) {
+	var cart models.Cart
+	if err := r.db.Where("guest_id = ?", guestID).First(&cart).Error; err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+
+func (r *CartRepository) GetWithItems(id uint) (*models.Cart, error) {
+	var cart models.Cart
+	err := r.db.
+		Preload("CartItems", "saved_for_later = ?", false).
+		Preload("CartItems.Product.Category").
+		Preload("SavedItems", "saved_for_later = ?", true).
+		Preload("SavedItems.Product.Category").
+		First(&cart, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+
 func (r *CartRepository) Create(cart *models.Cart) error {
 	return r.db.Create(cart).Error
 }
@@ -27,5 +58,219 @@ func (r *CartRepository) Update(cart *models.Cart) error {
 	return r.db.Save(cart).Error
 }
 func (r *CartRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Cart{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := NewCartRepository(tx).ReleaseReservations(id, nil); err != nil {
+			return err
+		}
+		if err := tx.Where("cart_id = ?", id).Delete(&models.CartItem{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.Cart{}, id).Error
+	})
+}
+
+// GetItems returns every line of the cart, both active and saved for later
+func (r *CartRepository) GetItems(cartID uint) ([]models.CartItem, error) {
+	var items []models.CartItem
+	if err := r.db.Preload("Product").Where("cart_id = ?", cartID).Order("created_at ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *CartRepository) GetItem(cartID, itemID uint) (*models.CartItem, error) {
+	var item models.CartItem
+	if err := r.db.Where("cart_id = ? AND id = ?", cartID, itemID).First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *CartRepository) GetItemByProduct(cartID, productID uint) (*models.CartItem, error) {
+	var item models.CartItem
+	if err := r.db.Where("cart_id = ? AND product_id = ?", cartID, productID).First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CreateItem adds the line, reserving the component stock when it is a bundle
+// in the active cart
+func (r *CartRepository) CreateItem(item *models.CartItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Cart", "Product", "ReservedQuantity").Create(item).Error; err != nil {
+			return err
+		}
+		return reserveCartItem(tx, item)
+	})
+}
+
+// UpdateItem saves the line and brings the component stock a bundle line
+// holds in line with its quantity. Saved for later lines hold none.
+func (r *CartRepository) UpdateItem(item *models.CartItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Cart", "Product", "ReservedQuantity").Save(item).Error; err != nil {
+			return err
+		}
+		return reserveCartItem(tx, item)
+	})
+}
+
+// DeleteItem removes the line and returns the stock it held
+func (r *CartRepository) DeleteItem(cartID, itemID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := NewCartRepository(tx).ReleaseReservations(cartID, []uint{itemID}); err != nil {
+			return err
+		}
+		return tx.Where("cart_id = ? AND id = ?", cartID, itemID).Delete(&models.CartItem{}).Error
+	})
+}
+
+// ReleaseReservations returns the component stock held by the given lines of
+// the cart, or by every line when no IDs are given. The lines stay in the
+// cart and reserve again when they are next changed.
+func (r *CartRepository) ReleaseReservations(cartID uint, itemIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.CartItem{}).Where("cart_id = ? AND reserved_quantity > 0", cartID)
+		if len(itemIDs) > 0 {
+			query = query.Where("id IN ?", itemIDs)
+		}
+
+		var ids []uint
+		if err := query.Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := releaseCartItem(tx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ReleaseExpiredReservations returns the component stock held by lines not
+// changed since idleSince and reports how many lines it released
+func (r *CartRepository) ReleaseExpiredReservations(idleSince time.Time) (int, error) {
+	var ids []uint
+	err := r.db.Model(&models.CartItem{}).
+		Where("reserved_quantity > 0 AND updated_at < ?", idleSince).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			return releaseCartItem(tx, id)
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(ids), nil
+}
+
+// reserveCartItem makes the line hold the component stock of as many bundles
+// as it asks for, taking or returning only the difference. Lines of other
+// products and lines saved for later hold nothing.
+func reserveCartItem(tx *gorm.DB, item *models.CartItem) error {
+	held, err := lockReservation(tx, item.ID)
+	if err != nil {
+		return err
+	}
+
+	var product models.Product
+	if err := tx.Select("id", "is_bundle").First(&product, item.ProductID).Error; err != nil {
+		return err
+	}
+
+	wanted := 0
+	if product.IsBundle && !item.SavedForLater {
+		wanted = item.Quantity
+	}
+	if err := setReservation(tx, item.ID, item.ProductID, held, wanted); err != nil {
+		return err
+	}
+	item.ReservedQuantity = wanted
+	return nil
+}
+
+func releaseCartItem(tx *gorm.DB, itemID uint) error {
+	var item models.CartItem
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "product_id", "reserved_quantity").
+		First(&item, itemID).Error
+	if err != nil {
+		return err
+	}
+	return setReservation(tx, item.ID, item.ProductID, item.ReservedQuantity, 0)
+}
+
+// releaseBundleReservations returns the stock every cart line holds for the
+// bundle, so its components can change
+func releaseBundleReservations(tx *gorm.DB, bundleID uint) error {
+	var ids []uint
+	err := tx.Model(&models.CartItem{}).
+		Where("product_id = ? AND reserved_quantity > 0", bundleID).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := releaseCartItem(tx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func lockReservation(tx *gorm.DB, itemID uint) (int, error) {
+	var row struct{ ReservedQuantity int }
+	err := tx.Model(&models.CartItem{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("reserved_quantity").Where("id = ?", itemID).Take(&row).Error
+	return row.ReservedQuantity, err
+}
+
+// setReservation moves the stock of the bundle's components between the shop
+// and the line so it holds wanted bundles instead of held. The bundle stock is
+// derived again afterwards.
+func setReservation(tx *gorm.DB, itemID, bundleID uint, held, wanted int) error {
+	if held == wanted {
+		return nil
+	}
+
+	var bundleItems []models.BundleItem
+	if err := tx.Where("bundle_id = ?", bundleID).Find(&bundleItems).Error; err != nil {
+		return err
+	}
+
+	changed := make([]uint, len(bundleItems))
+	for i, bundleItem := range bundleItems {
+		quantity := (wanted - held) * bundleItem.Quantity
+		// Stock goes back even to components that were deactivated or deleted
+		// since, so they hold the right count if they return
+		query := tx.Unscoped().Model(&models.Product{}).Where("id = ?", bundleItem.ComponentID)
+		if quantity > 0 {
+			query = query.Where("is_active = ? AND deleted_at IS NULL AND stock >= ?", true, quantity)
+		}
+
+		result := query.Update("stock", gorm.Expr("stock - ?", quantity))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInsufficientStock
+		}
+		changed[i] = bundleItem.ComponentID
+	}
+
+	err := tx.Model(&models.CartItem{}).Where("id = ?", itemID).
+		UpdateColumn("reserved_quantity", wanted).Error
+	if err != nil {
+		return err
+	}
+	return NewProductRepository(tx).SyncBundleStock(changed)
 }
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCartRepository_BundleReservations(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewCartRepository(db)
+	productRepo := NewProductRepository(db)
+
+	category := &models.Category{Name: "Coffee", Slug: "coffee", IsActive: true}
+	if err := NewCategoryRepository(db).Create(category); err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	beans := &models.Product{CategoryID: category.ID, Name: "Beans", Slug: "beans", SKU: "BEANS", Price: 10, Stock: 5, IsActive: true}
+	bundle := &models.Product{CategoryID: category.ID, Name: "Starter kit", Slug: "starter-kit", SKU: "KIT", Price: 25, IsActive: true}
+	for _, product := range []*models.Product{beans, bundle} {
+		if err := productRepo.Create(product); err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+	}
+	if err := productRepo.ReplaceBundleItems(bundle.ID, []models.BundleItem{{ComponentID: beans.ID, Quantity: 2}}); err != nil {
+		t.Fatalf("create bundle: %v", err)
+	}
+
+	guestID := "guest"
+	cart := &models.Cart{GuestID: &guestID}
+	if err := repo.Create(cart); err != nil {
+		t.Fatalf("create cart: %v", err)
+	}
+
+	stockOf := func(id uint) int {
+		product, err := productRepo.GetByID(id)
+		if err != nil {
+			t.Fatalf("get product: %v", err)
+		}
+		return product.Stock
+	}
+
+	item := &models.CartItem{CartID: cart.ID, ProductID: bundle.ID, Quantity: 2, UnitPrice: 25}
+
+	t.Run("adding a bundle holds its components", func(t *testing.T) {
+		assert.NoError(t, repo.CreateItem(item))
+		assert.Equal(t, 2, item.ReservedQuantity)
+		assert.Equal(t, 1, stockOf(beans.ID))
+		assert.Equal(t, 0, stockOf(bundle.ID))
+	})
+
+	t.Run("more than the components allow is refused", func(t *testing.T) {
+		item.Quantity = 3
+		assert.ErrorIs(t, repo.UpdateItem(item), ErrInsufficientStock)
+		assert.Equal(t, 1, stockOf(beans.ID))
+	})
+
+	t.Run("saving for later gives the stock back", func(t *testing.T) {
+		item.Quantity = 2
+		item.SavedForLater = true
+		assert.NoError(t, repo.UpdateItem(item))
+		assert.Equal(t, 5, stockOf(beans.ID))
+
+		item.SavedForLater = false
+		assert.NoError(t, repo.UpdateItem(item))
+		assert.Equal(t, 1, stockOf(beans.ID))
+	})
+
+	t.Run("idle lines are released", func(t *testing.T) {
+		released, err := repo.ReleaseExpiredReservations(time.Now().Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, released)
+		assert.Equal(t, 5, stockOf(beans.ID))
+	})
+
+	t.Run("removing the line gives the stock back", func(t *testing.T) {
+		assert.NoError(t, repo.UpdateItem(item))
+		assert.Equal(t, 1, stockOf(beans.ID))
+
+		assert.NoError(t, repo.DeleteItem(cart.ID, item.ID))
+		assert.Equal(t, 5, stockOf(beans.ID))
+	})
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+)
+
+type CategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+func (r *CategoryRepository) GetByID(id uint) (*models.Category, error) {
+	var category models.Category
+	if err := r.db.First(&category, id).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *CategoryRepository) GetBySlug(slug string) (*models.Category, error) {
+	var category models.Category
+	if err := r.db.Where("slug = ?", slug).First(&category).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *CategoryRepository) GetAll(activeOnly bool) ([]models.Category, error) {
+	var categories []models.Category
+	query := r.db.Order("sort_order ASC, name ASC")
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	if err := query.Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// GetAncestors returns the chain of parents of a category, root first
+func (r *CategoryRepository) GetAncestors(id uint) ([]models.Category, error) {
+	return categoryAncestors(r.db, id)
+}
+
+// GetDescendantIDs returns the IDs of the category and every category below it
+func (r *CategoryRepository) GetDescendantIDs(id uint) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Raw(descendantIDsQuery, id).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *CategoryRepository) CountChildren(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Category{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *CategoryRepository) CountProducts(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Product{}).Where("category_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *CategoryRepository) Create(category *models.Category) error {
+	return r.db.Create(category).Error
+}
+
+// Update saves the category. A changed slug is recorded as a redirect in the
+// same transaction, so the old links keep working.
+func (r *CategoryRepository) Update(category *models.Category) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		oldSlug, err := currentSlug(tx, &models.Category{}, category.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Save(category).Error; err != nil {
+			return err
+		}
+
+		if category.Slug == oldSlug {
+			return nil
+		}
+		return NewSlugRepository(tx).ChangeSlug(models.SlugEntityCategory, category.ID, oldSlug, category.Slug)
+	})
+}
+
+func (r *CategoryRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Category{}, id).Error
+}
+
+// DeleteAndReparent moves the children of a category to its parent before deleting it
+func (r *CategoryRepository) DeleteAndReparent(category *models.Category) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Category{}).Where("parent_id = ?", category.ID).
+			Update("parent_id", category.ParentID).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.Category{}, category.ID).Error
+	})
+}
+
+const descendantIDsQuery = `
+WITH RECURSIVE tree AS (
+	SELECT id FROM categories WHERE id = ? AND deleted_at IS NULL
+	UNION ALL
+	SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id WHERE c.deleted_at IS NULL
+)
+SELECT id FROM tree`
+
+const ancestorsQuery = `
+WITH RECURSIVE ancestors AS (
+	SELECT c.*, 0 AS depth FROM categories c WHERE c.id = ?
+	UNION ALL
+	SELECT c.*, a.depth + 1 FROM categories c JOIN ancestors a ON c.id = a.parent_id
+	WHERE c.deleted_at IS NULL AND a.depth < 32
+)
+SELECT * FROM ancestors WHERE depth > 0 ORDER BY depth DESC`
+
+func categoryAncestors(db *gorm.DB, id uint) ([]models.Category, error) {
+	var ancestors []models.Category
+	if err := db.Raw(ancestorsQuery, id).Scan(&ancestors).Error; err != nil {
+		return nil, err
+	}
+	return ancestors, nil
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryRepository_UpdateRecordsSlugRedirect(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewCategoryRepository(db)
+	slugRepo := NewSlugRepository(db)
+
+	category := &models.Category{Name: "Notebooks", Slug: "notebooks", IsActive: true}
+	if err := repo.Create(category); err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+
+	category.Slug = "laptops"
+	assert.NoError(t, repo.Update(category))
+
+	redirect, err := slugRepo.GetRedirect(models.SlugEntityCategory, "notebooks")
+	assert.NoError(t, err)
+	assert.Equal(t, category.ID, redirect.EntityID)
+
+	category.Name = "Laptops"
+	assert.NoError(t, repo.Update(category))
+
+	var count int64
+	db.Model(&models.SlugRedirect{}).Where("entity_id = ?", category.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
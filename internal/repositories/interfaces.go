@@ -1,6 +1,11 @@
 package repositories
 
-import "github.com/JihadRinaldi/go-shop/internal/models"
+import (
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+)
 
 type UserRepositoryInterface interface {
 	GetByEmail(email string) (*models.User, error)
@@ -9,38 +14,90 @@ type UserRepositoryInterface interface {
 	Create(user *models.User) error
 	Update(user *models.User) error
 	Delete(id uint) error
+	AdvanceTOTPStep(userID uint, step int64) (bool, error)
 
 	CreateRefreshToken(token *models.RefreshToken) error
-	GetValidRefreshToken(token string) (*models.RefreshToken, error)
-	DeleteRefreshToken(token string) error
-	DeleteRefreshTokenByID(id uint) error
+	GetRefreshToken(tokenHash string) (*models.RefreshToken, error)
+	RotateRefreshToken(id uint, successor *models.RefreshToken, now time.Time) (bool, error)
+	RevokeRefreshTokenFamily(familyID string) error
+	GetSessions(userID uint, now time.Time) ([]models.RefreshToken, error)
+	GetSession(familyID string) (*models.RefreshToken, error)
+	TouchRefreshToken(id uint, now time.Time) error
+	DeleteRefreshTokensByUserID(userID uint) error
+	DeleteOtherRefreshTokens(userID uint, currentFamilyID string) error
+}
+
+type UserTokenRepositoryInterface interface {
+	Create(token *models.UserToken) error
+	GetUsable(purpose models.UserTokenPurpose, hash string, now time.Time) (*models.UserToken, error)
+	GetLatest(userID uint, purpose models.UserTokenPurpose) (*models.UserToken, error)
+	MarkUsed(id uint, now time.Time) (bool, error)
+	InvalidateAll(userID uint, purpose models.UserTokenPurpose, now time.Time) error
+}
+
+type RecoveryCodeRepositoryInterface interface {
+	ReplaceAll(userID uint, hashes []string) error
+	Use(userID uint, hash string, now time.Time) (bool, error)
+	DeleteByUserID(userID uint) error
+}
+
+type RoleRepositoryInterface interface {
+	GetAll() ([]models.Role, error)
+	GetByID(id uint) (*models.Role, error)
+	GetByName(name string) (*models.Role, error)
+	Create(role *models.Role) error
+	Update(role *models.Role) error
+	Delete(id uint) error
+	GetUserRoles(userID uint) ([]models.Role, error)
+	AssignRole(userID, roleID uint) error
+	RemoveRole(userID, roleID uint, keepLastHolder bool) (bool, error)
+	CountUsersWithRole(roleID uint) (int64, error)
 }
 
 type CartRepositoryInterface interface {
 	GetByUserID(userID uint) (*models.Cart, error)
+	GetByGuestID(guestID string) (*models.Cart, error)
+	GetWithItems(id uint) (*models.Cart, error)
 	Create(cart *models.Cart) error
 	Update(cart *models.Cart) error
 	Delete(id uint) error
+	GetItems(cartID uint) ([]models.CartItem, error)
+	GetItem(cartID, itemID uint) (*models.CartItem, error)
+	GetItemByProduct(cartID, productID uint) (*models.CartItem, error)
+	CreateItem(item *models.CartItem) error
+	UpdateItem(item *models.CartItem) error
+	DeleteItem(cartID, itemID uint) error
+	ReleaseReservations(cartID uint, itemIDs []uint) error
+	ReleaseExpiredReservations(idleSince time.Time) (int, error)
 }
 
 type ProductRepositoryInterface interface {
 	GetByID(id uint) (*models.Product, error)
 	GetAll(limit, offset int) ([]models.Product, error)
+	List(filter *ProductFilter, limit, offset int) ([]models.Product, int64, error)
 	GetByCategoryID(categoryID uint, limit, offset int) ([]models.Product, error)
 	GetBySKU(sku string) (*models.Product, error)
+	GetBySlug(slug string) (*models.Product, error)
 	Create(product *models.Product) error
+	CreateWithAttributes(product *models.Product, values []models.ProductAttributeValue) error
 	Update(product *models.Product) error
+	UpdateWithAttributes(product *models.Product, values []models.ProductAttributeValue) error
 	Delete(id uint) error
 	UpdateStock(id uint, quantity int) error
+	ReplaceBundleItems(bundleID uint, items []models.BundleItem) error
+	IsBundleComponent(id uint) (bool, error)
 }
 
 type OrderRepositoryInterface interface {
 	GetByID(id uint) (*models.Order, error)
 	GetByUserID(userID uint, limit, offset int) ([]models.Order, error)
 	GetAll(limit, offset int) ([]models.Order, error)
+	FindDeliveredOrderItem(userID, productID uint) (*models.OrderItem, error)
+	GetItemByID(id uint) (*models.OrderItem, error)
+	IncrementDownloadCount(itemID uint, limit int) (bool, error)
 	Create(order *models.Order) error
 	Update(order *models.Order) error
-	UpdateStatus(id uint, status models.OrderStatus) error
+	UpdateStatus(id uint, from, to models.OrderStatus) (bool, error)
 	Delete(id uint) error
 }
 
@@ -49,4 +106,93 @@ type UploadRepositoryInterface interface {
 	GetProductImages(productID uint) ([]models.ProductImage, error)
 	DeleteProductImage(id uint) error
 	SetPrimaryImage(productID, imageID uint) error
+	CreateProductFile(file *models.ProductFile) error
+	GetProductFiles(productID uint) ([]models.ProductFile, error)
+	GetProductFile(id uint) (*models.ProductFile, error)
+}
+
+type AttributeRepositoryInterface interface {
+	GetCategoryAttributes(categoryID uint) ([]models.CategoryAttribute, error)
+	GetCategoryAttributeByID(id uint) (*models.CategoryAttribute, error)
+	CreateCategoryAttribute(attribute *models.CategoryAttribute) error
+	UpdateCategoryAttribute(attribute *models.CategoryAttribute) error
+	DeleteCategoryAttribute(id uint) error
+	ReplaceProductAttributeValues(productID uint, values []models.ProductAttributeValue) error
+}
+
+type CategoryRepositoryInterface interface {
+	GetByID(id uint) (*models.Category, error)
+	GetBySlug(slug string) (*models.Category, error)
+	GetAll(activeOnly bool) ([]models.Category, error)
+	GetAncestors(id uint) ([]models.Category, error)
+	GetDescendantIDs(id uint) ([]uint, error)
+	CountChildren(id uint) (int64, error)
+	CountProducts(id uint) (int64, error)
+	Create(category *models.Category) error
+	Update(category *models.Category) error
+	Delete(id uint) error
+	DeleteAndReparent(category *models.Category) error
+}
+
+type SlugRepositoryInterface interface {
+	IsTaken(entityType models.SlugEntityType, slug string, excludeID uint) (bool, error)
+	GetRedirect(entityType models.SlugEntityType, slug string) (*models.SlugRedirect, error)
+}
+
+type ReviewRepositoryInterface interface {
+	GetByID(id uint) (*models.Review, error)
+	GetByUserAndProduct(userID, productID uint) (*models.Review, error)
+	GetByProductID(productID uint, status models.ReviewStatus, limit, offset int) ([]models.Review, int64, error)
+	GetByStatus(status models.ReviewStatus, limit, offset int) ([]models.Review, int64, error)
+	Create(review *models.Review) error
+	UpdateStatus(review *models.Review, status models.ReviewStatus, note string) error
+	Delete(review *models.Review) error
+	CreateImage(image *models.ReviewImage) error
+	CountImages(reviewID uint) (int64, error)
+}
+
+type QuestionRepositoryInterface interface {
+	GetQuestionByID(id uint) (*models.ProductQuestion, error)
+	GetPublishedQuestions(productID uint, limit, offset int) ([]models.ProductQuestion, int64, error)
+	GetQuestionsByStatus(status models.QAStatus, limit, offset int) ([]models.ProductQuestion, int64, error)
+	CreateQuestion(question *models.ProductQuestion) error
+	UpdateQuestionStatus(id uint, status models.QAStatus) error
+	GetAnswerByID(id uint) (*models.ProductAnswer, error)
+	GetAnswersByStatus(status models.QAStatus, limit, offset int) ([]models.ProductAnswer, int64, error)
+	CreateAnswer(answer *models.ProductAnswer) error
+	UpdateAnswerStatus(id uint, status models.QAStatus) error
+	MarkAnswerNotified(id uint, now time.Time) (bool, error)
+	AddVote(vote *models.QAVote) (bool, error)
+	RemoveVote(vote *models.QAVote) (bool, error)
+}
+
+type WishlistRepositoryInterface interface {
+	GetByID(id uint) (*models.Wishlist, error)
+	GetByUserID(userID uint) ([]models.Wishlist, error)
+	GetByShareToken(token string) (*models.Wishlist, error)
+	ExistsByName(userID uint, name string, excludeID uint) (bool, error)
+	Create(wishlist *models.Wishlist) error
+	Update(wishlist *models.Wishlist) error
+	Delete(id uint) error
+	GetItem(wishlistID, itemID uint) (*models.WishlistItem, error)
+	GetItemByProduct(wishlistID, productID uint) (*models.WishlistItem, error)
+	AddItem(item *models.WishlistItem) error
+	RemoveItem(itemID uint) error
+}
+
+type CartReminderRepositoryInterface interface {
+	FindAbandonedCarts(idleSince, remindedSince time.Time, limit int) ([]models.Cart, error)
+	GetByToken(token string) (*models.CartReminder, error)
+	Create(reminder *models.CartReminder) error
+	Delete(id uint) error
+	MarkRestored(id uint, restoredAt time.Time) error
+}
+
+type SubscriptionRepositoryInterface interface {
+	GetByID(id uint) (*models.Subscription, error)
+	GetByUserID(userID uint) ([]models.Subscription, error)
+	GetDueIDs(now time.Time, limit int) ([]uint, error)
+	ProcessDue(id uint, now time.Time, process func(tx *gorm.DB, subscription *models.Subscription) error) (bool, error)
+	Create(subscription *models.Subscription) error
+	Change(id uint, change func(subscription *models.Subscription) ([]string, error)) (*models.Subscription, error)
 }
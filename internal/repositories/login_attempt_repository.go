@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+)
+
+// recordLoginFailureSQL counts a failure atomically, so concurrent attempts
+// against several API instances are all counted. Failures older than the
+// window start the count over.
+const recordLoginFailureSQL = `
+INSERT INTO login_attempts (throttle_key, failures, last_failed_at)
+VALUES (?, 1, ?)
+ON CONFLICT (throttle_key) DO UPDATE SET
+	failures = CASE WHEN login_attempts.last_failed_at < ? THEN 1 ELSE login_attempts.failures + 1 END,
+	last_failed_at = EXCLUDED.last_failed_at
+RETURNING failures`
+
+// LoginAttemptRepository is the database backed LoginAttemptStore
+type LoginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+func (r *LoginAttemptRepository) RecordFailure(key string, now time.Time, window time.Duration) (int, error) {
+	var failures int
+	if err := r.db.Raw(recordLoginFailureSQL, key, now, now.Add(-window)).Scan(&failures).Error; err != nil {
+		return 0, err
+	}
+	return failures, nil
+}
+
+func (r *LoginAttemptRepository) BlockedUntil(key string) (time.Time, error) {
+	var attempt models.LoginAttempt
+	err := r.db.Where("throttle_key = ?", key).First(&attempt).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if attempt.BlockedUntil == nil {
+		return time.Time{}, nil
+	}
+	return *attempt.BlockedUntil, nil
+}
+
+func (r *LoginAttemptRepository) Block(key string, until time.Time) error {
+	return r.db.Model(&models.LoginAttempt{}).Where("throttle_key = ?", key).Update("blocked_until", until).Error
+}
+
+func (r *LoginAttemptRepository) Reset(key string) error {
+	return r.db.Where("throttle_key = ?", key).Delete(&models.LoginAttempt{}).Error
+}
@@ -15,7 +15,7 @@ func NewOrderRepository(db *gorm.DB) *OrderRepository {
 
 func (r *OrderRepository) GetByID(id uint) (*models.Order, error) {
 	var order models.Order
-	if err := r.db.Preload("User").Preload("OrderItems.Product").First(&order, id).Error; err != nil {
+	if err := r.db.Preload("User").Preload("OrderItems.Product").Preload("OrderItems.Components.Product").First(&order, id).Error; err != nil {
 		return nil, err
 	}
 	return &order, nil
@@ -23,7 +23,7 @@ func (r *OrderRepository) GetByID(id uint) (*models.Order, error) {
 
 func (r *OrderRepository) GetByUserID(userID uint, limit, offset int) ([]models.Order, error) {
 	var orders []models.Order
-	query := r.db.Preload("OrderItems.Product").Where("user_id = ?", userID)
+	query := r.db.Preload("OrderItems.Product").Preload("OrderItems.Components.Product").Where("user_id = ?", userID)
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -40,7 +40,7 @@ func (r *OrderRepository) GetByUserID(userID uint, limit, offset int) ([]models.
 
 func (r *OrderRepository) GetAll(limit, offset int) ([]models.Order, error) {
 	var orders []models.Order
-	query := r.db.Preload("User").Preload("OrderItems.Product")
+	query := r.db.Preload("User").Preload("OrderItems.Product").Preload("OrderItems.Components.Product")
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -55,6 +55,39 @@ func (r *OrderRepository) GetAll(limit, offset int) ([]models.Order, error) {
 	return orders, nil
 }
 
+// FindDeliveredOrderItem returns an order item proving that the user received the product
+func (r *OrderRepository) FindDeliveredOrderItem(userID, productID uint) (*models.OrderItem, error) {
+	var item models.OrderItem
+	err := r.db.Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("orders.user_id = ? AND order_items.product_id = ? AND orders.status = ?", userID, productID, models.OrderStatusDelivered).
+		Order("order_items.created_at DESC").
+		First(&item).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *OrderRepository) GetItemByID(id uint) (*models.OrderItem, error) {
+	var item models.OrderItem
+	if err := r.db.Preload("Order").Preload("Product").First(&item, id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// IncrementDownloadCount counts one download of the item's files unless the
+// item already reached limit. It reports whether the download was counted.
+func (r *OrderRepository) IncrementDownloadCount(itemID uint, limit int) (bool, error) {
+	result := r.db.Model(&models.OrderItem{}).
+		Where("id = ? AND download_count < ?", itemID, limit).
+		UpdateColumn("download_count", gorm.Expr("download_count + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *OrderRepository) Create(order *models.Order) error {
 	return r.db.Create(order).Error
 }
@@ -63,8 +96,59 @@ func (r *OrderRepository) Update(order *models.Order) error {
 	return r.db.Save(order).Error
 }
 
-func (r *OrderRepository) UpdateStatus(id uint, status models.OrderStatus) error {
-	return r.db.Model(&models.Order{}).Where("id = ?", id).Update("status", status).Error
+// UpdateStatus moves the order from one status to another, reporting false
+// when it no longer has the status it is moved from. Cancelling puts the stock
+// of its items back in the same transaction, bundles through their components.
+func (r *OrderRepository) UpdateStatus(id uint, from, to models.OrderStatus) (bool, error) {
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Order{}).Where("id = ? AND status = ?", id, from).Update("status", to)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		updated = true
+
+		if to != models.OrderStatusCancelled {
+			return nil
+		}
+		return restockOrder(tx, id)
+	})
+	return updated, err
+}
+
+// restockOrder returns the stock an order took. Products deleted since get it
+// back as well, so they hold the right count if they return.
+func restockOrder(tx *gorm.DB, orderID uint) error {
+	var items []models.OrderItem
+	if err := tx.Preload("Components").Where("order_id = ?", orderID).Find(&items).Error; err != nil {
+		return err
+	}
+
+	stock := make(map[uint]int)
+	for _, item := range items {
+		if len(item.Components) == 0 {
+			stock[item.ProductID] += item.Quantity
+			continue
+		}
+		for _, component := range item.Components {
+			stock[component.ProductID] += component.Quantity
+		}
+	}
+
+	changed := make([]uint, 0, len(stock))
+	for productID, quantity := range stock {
+		err := tx.Unscoped().Model(&models.Product{}).Where("id = ?", productID).
+			Update("stock", gorm.Expr("stock + ?", quantity)).Error
+		if err != nil {
+			return err
+		}
+		changed = append(changed, productID)
+	}
+
+	return NewProductRepository(tx).SyncBundleStock(changed)
 }
 
 func (r *OrderRepository) Delete(id uint) error {
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderRepository_CancelRestocks(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewOrderRepository(db)
+	productRepo := NewProductRepository(db)
+
+	user := &models.User{Email: "buyer@example.com", Password: "hashed", FirstName: "Jane", LastName: "Doe", IsActive: true}
+	if err := NewUserRepository(db).Create(user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	category := &models.Category{Name: "Coffee", Slug: "coffee", IsActive: true}
+	if err := NewCategoryRepository(db).Create(category); err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	beans := &models.Product{CategoryID: category.ID, Name: "Beans", Slug: "beans", SKU: "BEANS", Price: 10, Stock: 3, IsActive: true}
+	mug := &models.Product{CategoryID: category.ID, Name: "Mug", Slug: "mug", SKU: "MUG", Price: 8, Stock: 1, IsActive: true}
+	for _, product := range []*models.Product{beans, mug} {
+		if err := productRepo.Create(product); err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+	}
+
+	order := &models.Order{
+		UserID: user.ID,
+		Status: models.OrderStatusConfirmed,
+		OrderItems: []models.OrderItem{
+			{ProductID: mug.ID, Quantity: 2, Price: 8},
+			{ProductID: beans.ID, Quantity: 1, Price: 25, Components: []models.OrderItemComponent{{ProductID: beans.ID, Quantity: 2}}},
+		},
+	}
+	if err := repo.Create(order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	updated, err := repo.UpdateStatus(order.ID, models.OrderStatusPending, models.OrderStatusCancelled)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+
+	updated, err = repo.UpdateStatus(order.ID, models.OrderStatusConfirmed, models.OrderStatusCancelled)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+
+	loaded, err := productRepo.GetByID(mug.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, loaded.Stock)
+
+	loaded, err = productRepo.GetByID(beans.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, loaded.Stock)
+}
@@ -1,10 +1,35 @@
 package repositories
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/JihadRinaldi/go-shop/internal/models"
 	"gorm.io/gorm"
 )
 
+// ProductFilter narrows down the public product listing
+type ProductFilter struct {
+	// CategoryID also matches products in descendant categories
+	CategoryID uint
+	Attributes []AttributeFilter
+	Sort       string
+}
+
+// AttributeFilter matches products by the value of a category attribute code
+type AttributeFilter struct {
+	Code     string
+	Operator string
+	Value    string
+}
+
+var productSortOrders = map[string]string{
+	"rating":     "products.rating_average DESC, products.rating_count DESC, products.id ASC",
+	"price_asc":  "products.price ASC, products.id ASC",
+	"price_desc": "products.price DESC, products.id ASC",
+	"newest":     "products.created_at DESC, products.id DESC",
+}
+
 type ProductRepository struct {
 	db *gorm.DB
 }
@@ -15,7 +40,11 @@ func NewProductRepository(db *gorm.DB) *ProductRepository {
 
 func (r *ProductRepository) GetByID(id uint) (*models.Product, error) {
 	var product models.Product
-	if err := r.db.Preload("Category").Preload("Images").First(&product, id).Error; err != nil {
+	if err := r.db.Preload("Category").Preload("Images").Preload("Attributes.CategoryAttribute").
+		Preload("BundleItems.Component").First(&product, id).Error; err != nil {
+		return nil, err
+	}
+	if err := r.attachCategoryAncestors([]*models.Product{&product}); err != nil {
 		return nil, err
 	}
 	return &product, nil
@@ -23,7 +52,7 @@ func (r *ProductRepository) GetByID(id uint) (*models.Product, error) {
 
 func (r *ProductRepository) GetAll(limit, offset int) ([]models.Product, error) {
 	var products []models.Product
-	query := r.db.Preload("Category").Preload("Images")
+	query := r.db.Preload("Category").Preload("Images").Preload("Attributes.CategoryAttribute")
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -35,12 +64,83 @@ func (r *ProductRepository) GetAll(limit, offset int) ([]models.Product, error)
 	if err := query.Find(&products).Error; err != nil {
 		return nil, err
 	}
+	if err := r.attachCategoryAncestors(productPointers(products)); err != nil {
+		return nil, err
+	}
 	return products, nil
 }
 
+func (r *ProductRepository) List(filter *ProductFilter, limit, offset int) ([]models.Product, int64, error) {
+	query := r.db.Model(&models.Product{}).Where("products.is_active = ?", true)
+
+	if filter != nil {
+		if filter.CategoryID != 0 {
+			query = query.Where("products.category_id IN (?)", r.db.Raw(descendantIDsQuery, filter.CategoryID))
+		}
+		for _, attr := range filter.Attributes {
+			query = applyAttributeFilter(query, attr)
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	order := "products.id ASC"
+	if filter != nil {
+		if sortOrder, ok := productSortOrders[filter.Sort]; ok {
+			order = sortOrder
+		}
+	}
+
+	var products []models.Product
+	if err := query.Preload("Category").Preload("Images").Preload("Attributes.CategoryAttribute").
+		Order(order).Find(&products).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := r.attachCategoryAncestors(productPointers(products)); err != nil {
+		return nil, 0, err
+	}
+	return products, total, nil
+}
+
+func applyAttributeFilter(query *gorm.DB, filter AttributeFilter) *gorm.DB {
+	const base = `EXISTS (SELECT 1 FROM product_attribute_values pav
+		JOIN category_attributes ca ON ca.id = pav.category_attribute_id AND ca.deleted_at IS NULL
+		WHERE pav.product_id = products.id AND pav.deleted_at IS NULL AND ca.code = ? AND `
+
+	switch filter.Operator {
+	case "gt", "gte", "lt", "lte":
+		number, err := strconv.ParseFloat(filter.Value, 64)
+		if err != nil {
+			return query.Where("1 = 0")
+		}
+		operators := map[string]string{"gt": ">", "gte": ">=", "lt": "<", "lte": "<="}
+		return query.Where(base+"pav.number_value "+operators[filter.Operator]+" ?)", filter.Code, number)
+	case "in":
+		return query.Where(base+"pav.text_value IN ?)", filter.Code, strings.Split(filter.Value, ","))
+	default:
+		if number, err := strconv.ParseFloat(filter.Value, 64); err == nil {
+			return query.Where(base+"(pav.number_value = ? OR pav.text_value = ?))", filter.Code, number, filter.Value)
+		}
+		if boolean, err := strconv.ParseBool(filter.Value); err == nil {
+			return query.Where(base+"(pav.boolean_value = ? OR pav.text_value = ?))", filter.Code, boolean, filter.Value)
+		}
+		return query.Where(base+"pav.text_value = ?)", filter.Code, filter.Value)
+	}
+}
+
 func (r *ProductRepository) GetByCategoryID(categoryID uint, limit, offset int) ([]models.Product, error) {
 	var products []models.Product
-	query := r.db.Preload("Category").Preload("Images").Where("category_id = ?", categoryID)
+	query := r.db.Preload("Category").Preload("Images").Preload("Attributes.CategoryAttribute").Where("category_id = ?", categoryID)
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -52,12 +152,30 @@ func (r *ProductRepository) GetByCategoryID(categoryID uint, limit, offset int)
 	if err := query.Find(&products).Error; err != nil {
 		return nil, err
 	}
+	if err := r.attachCategoryAncestors(productPointers(products)); err != nil {
+		return nil, err
+	}
 	return products, nil
 }
 
 func (r *ProductRepository) GetBySKU(sku string) (*models.Product, error) {
 	var product models.Product
-	if err := r.db.Preload("Category").Preload("Images").Where("sku = ?", sku).First(&product).Error; err != nil {
+	if err := r.db.Preload("Category").Preload("Images").Preload("Attributes.CategoryAttribute").Where("sku = ?", sku).First(&product).Error; err != nil {
+		return nil, err
+	}
+	if err := r.attachCategoryAncestors([]*models.Product{&product}); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *ProductRepository) GetBySlug(slug string) (*models.Product, error) {
+	var product models.Product
+	if err := r.db.Preload("Category").Preload("Images").Preload("Attributes.CategoryAttribute").
+		Preload("BundleItems.Component").Where("slug = ?", slug).First(&product).Error; err != nil {
+		return nil, err
+	}
+	if err := r.attachCategoryAncestors([]*models.Product{&product}); err != nil {
 		return nil, err
 	}
 	return &product, nil
@@ -67,14 +185,161 @@ func (r *ProductRepository) Create(product *models.Product) error {
 	return r.db.Create(product).Error
 }
 
+// CreateWithAttributes creates the product together with its attribute values
+func (r *ProductRepository) CreateWithAttributes(product *models.Product, values []models.ProductAttributeValue) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(product).Error; err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		return NewAttributeRepository(tx).ReplaceProductAttributeValues(product.ID, values)
+	})
+}
+
+// Update saves the product and refreshes the stock of the bundles it belongs
+// to, since a change of stock or availability carries over to them. A changed
+// slug is recorded as a redirect in the same transaction.
 func (r *ProductRepository) Update(product *models.Product) error {
-	return r.db.Save(product).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		oldSlug, err := currentSlug(tx, &models.Product{}, product.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Omit("BundleItems").Save(product).Error; err != nil {
+			return err
+		}
+
+		if product.Slug != oldSlug {
+			if err := NewSlugRepository(tx).ChangeSlug(models.SlugEntityProduct, product.ID, oldSlug, product.Slug); err != nil {
+				return err
+			}
+		}
+		return NewProductRepository(tx).SyncBundleStock([]uint{product.ID})
+	})
+}
+
+// UpdateWithAttributes saves the product and replaces its attribute values in
+// one transaction
+func (r *ProductRepository) UpdateWithAttributes(product *models.Product, values []models.ProductAttributeValue) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := NewProductRepository(tx).Update(product); err != nil {
+			return err
+		}
+		return NewAttributeRepository(tx).ReplaceProductAttributeValues(product.ID, values)
+	})
 }
 
 func (r *ProductRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Product{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Product{}, id).Error; err != nil {
+			return err
+		}
+		return NewProductRepository(tx).SyncBundleStock([]uint{id})
+	})
 }
 
 func (r *ProductRepository) UpdateStock(id uint, quantity int) error {
-	return r.db.Model(&models.Product{}).Where("id = ?", id).Update("stock", quantity).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Product{}).Where("id = ?", id).Update("stock", quantity).Error; err != nil {
+			return err
+		}
+		return NewProductRepository(tx).SyncBundleStock([]uint{id})
+	})
+}
+
+// ReplaceBundleItems turns the product into a bundle made of the given
+// components and derives its stock from them. Carts give back what they held
+// of the previous components.
+func (r *ProductRepository) ReplaceBundleItems(bundleID uint, items []models.BundleItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lines in carts hold stock of the old components, they reserve the new
+		// ones when next changed or at checkout
+		if err := releaseBundleReservations(tx, bundleID); err != nil {
+			return err
+		}
+		if err := tx.Where("bundle_id = ?", bundleID).Delete(&models.BundleItem{}).Error; err != nil {
+			return err
+		}
+
+		for i := range items {
+			items[i].ID = 0
+			items[i].BundleID = bundleID
+		}
+		if len(items) > 0 {
+			if err := tx.Omit("Component").Create(&items).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.Product{}).Where("id = ?", bundleID).Update("is_bundle", true).Error; err != nil {
+			return err
+		}
+		return NewProductRepository(tx).SyncBundleStock([]uint{bundleID})
+	})
+}
+
+// IsBundleComponent reports whether the product is part of any bundle
+func (r *ProductRepository) IsBundleComponent(id uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.BundleItem{}).Where("component_id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// bundleStockSQL is the number of complete bundles the components can make.
+// Inactive or deleted components count as out of stock.
+const bundleStockSQL = `COALESCE((
+	SELECT MIN(CASE WHEN c.is_active AND c.deleted_at IS NULL THEN c.stock / bi.quantity ELSE 0 END)
+	FROM bundle_items bi JOIN products c ON c.id = bi.component_id
+	WHERE bi.bundle_id = products.id AND bi.deleted_at IS NULL
+), 0)`
+
+// SyncBundleStock recomputes the stock of the given bundles and of every bundle
+// containing one of the given products. It has to run after each stock change
+// of a component, inside the same transaction.
+func (r *ProductRepository) SyncBundleStock(productIDs []uint) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	return r.db.Model(&models.Product{}).
+		Where("is_bundle = ?", true).
+		Where("id IN ? OR id IN (?)", productIDs,
+			r.db.Model(&models.BundleItem{}).Select("bundle_id").Where("component_id IN ?", productIDs)).
+		Update("stock", gorm.Expr(bundleStockSQL)).Error
+}
+
+// attachCategoryAncestors loads the parent chain of each product's category
+// so responses can render breadcrumbs
+func (r *ProductRepository) attachCategoryAncestors(products []*models.Product) error {
+	cache := make(map[uint][]models.Category)
+	for _, product := range products {
+		if product.Category.ID == 0 {
+			continue
+		}
+
+		ancestors, ok := cache[product.Category.ID]
+		if !ok {
+			var err error
+			ancestors, err = categoryAncestors(r.db, product.Category.ID)
+			if err != nil {
+				return err
+			}
+			cache[product.Category.ID] = ancestors
+		}
+		product.Category.Ancestors = ancestors
+	}
+	return nil
+}
+
+func productPointers(products []models.Product) []*models.Product {
+	pointers := make([]*models.Product, len(products))
+	for i := range products {
+		pointers[i] = &products[i]
+	}
+	return pointers
 }
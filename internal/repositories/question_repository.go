@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type QuestionRepository struct {
+	db *gorm.DB
+}
+
+func NewQuestionRepository(db *gorm.DB) *QuestionRepository {
+	return &QuestionRepository{db: db}
+}
+
+var qaVoteTables = map[models.QAVoteTarget]string{
+	models.QAVoteTargetQuestion: "product_questions",
+	models.QAVoteTargetAnswer:   "product_answers",
+}
+
+func (r *QuestionRepository) GetQuestionByID(id uint) (*models.ProductQuestion, error) {
+	var question models.ProductQuestion
+	if err := r.db.Preload("User").Preload("Product").First(&question, id).Error; err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
+
+// GetPublishedQuestions returns the published questions of a product together
+// with their published answers, most upvoted first
+func (r *QuestionRepository) GetPublishedQuestions(productID uint, limit, offset int) ([]models.ProductQuestion, int64, error) {
+	query := r.db.Where("product_id = ? AND status = ?", productID, models.QAStatusPublished)
+
+	var total int64
+	if err := query.Model(&models.ProductQuestion{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var questions []models.ProductQuestion
+	err := query.Preload("User").
+		Preload("Answers", func(db *gorm.DB) *gorm.DB {
+			return db.Where("status = ?", models.QAStatusPublished).
+				Order("is_staff DESC, upvote_count DESC, created_at ASC")
+		}).
+		Preload("Answers.User").
+		Order("upvote_count DESC, created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&questions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return questions, total, nil
+}
+
+func (r *QuestionRepository) GetQuestionsByStatus(status models.QAStatus, limit, offset int) ([]models.ProductQuestion, int64, error) {
+	query := r.db.Where("status = ?", status)
+
+	var total int64
+	if err := query.Model(&models.ProductQuestion{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var questions []models.ProductQuestion
+	if err := query.Preload("User").Order("created_at ASC").Limit(limit).Offset(offset).Find(&questions).Error; err != nil {
+		return nil, 0, err
+	}
+	return questions, total, nil
+}
+
+func (r *QuestionRepository) CreateQuestion(question *models.ProductQuestion) error {
+	return r.db.Omit("User", "Product", "Answers").Create(question).Error
+}
+
+func (r *QuestionRepository) UpdateQuestionStatus(id uint, status models.QAStatus) error {
+	return r.db.Model(&models.ProductQuestion{}).Where("id = ?", id).Update("status", status).Error
+}
+
+func (r *QuestionRepository) GetAnswerByID(id uint) (*models.ProductAnswer, error) {
+	var answer models.ProductAnswer
+	if err := r.db.Preload("User").Preload("Question.User").Preload("Question.Product").First(&answer, id).Error; err != nil {
+		return nil, err
+	}
+	return &answer, nil
+}
+
+func (r *QuestionRepository) GetAnswersByStatus(status models.QAStatus, limit, offset int) ([]models.ProductAnswer, int64, error) {
+	query := r.db.Where("status = ?", status)
+
+	var total int64
+	if err := query.Model(&models.ProductAnswer{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var answers []models.ProductAnswer
+	if err := query.Preload("User").Order("created_at ASC").Limit(limit).Offset(offset).Find(&answers).Error; err != nil {
+		return nil, 0, err
+	}
+	return answers, total, nil
+}
+
+func (r *QuestionRepository) CreateAnswer(answer *models.ProductAnswer) error {
+	return r.db.Omit("User", "Question").Create(answer).Error
+}
+
+func (r *QuestionRepository) UpdateAnswerStatus(id uint, status models.QAStatus) error {
+	return r.db.Model(&models.ProductAnswer{}).Where("id = ?", id).Update("status", status).Error
+}
+
+// MarkAnswerNotified records that the asker was told about the answer. It
+// reports false when that had already happened.
+func (r *QuestionRepository) MarkAnswerNotified(id uint, now time.Time) (bool, error) {
+	result := r.db.Model(&models.ProductAnswer{}).
+		Where("id = ? AND asker_notified_at IS NULL", id).
+		Update("asker_notified_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// AddVote records an upvote and bumps the counter of its target. It reports
+// false when the user had already voted.
+func (r *QuestionRepository) AddVote(vote *models.QAVote) (bool, error) {
+	added := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(vote)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		added = true
+		return adjustUpvoteCount(tx, vote.TargetType, vote.TargetID, 1)
+	})
+	return added, err
+}
+
+// RemoveVote withdraws an upvote. It reports false when there was nothing to remove.
+func (r *QuestionRepository) RemoveVote(vote *models.QAVote) (bool, error) {
+	removed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("target_type = ? AND target_id = ? AND user_id = ?", vote.TargetType, vote.TargetID, vote.UserID).
+			Delete(&models.QAVote{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		removed = true
+		return adjustUpvoteCount(tx, vote.TargetType, vote.TargetID, -1)
+	})
+	return removed, err
+}
+
+func adjustUpvoteCount(tx *gorm.DB, target models.QAVoteTarget, id uint, delta int) error {
+	return tx.Table(qaVoteTables[target]).Where("id = ?", id).
+		Update("upvote_count", gorm.Expr("upvote_count + ?", delta)).Error
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+)
+
+type RecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{db: db}
+}
+
+// ReplaceAll swaps the recovery codes of the user for a new set, the old codes
+// stop working
+func (r *RecoveryCodeRepository) ReplaceAll(userID uint, hashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]models.RecoveryCode, len(hashes))
+		for i, hash := range hashes {
+			codes[i] = models.RecoveryCode{UserID: userID, CodeHash: hash}
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// Use consumes the unused code with the given hash. It reports false when the
+// user has no such code, so two concurrent requests cannot both redeem it.
+func (r *RecoveryCodeRepository) Use(userID uint, hash string, now time.Time) (bool, error) {
+	result := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *RecoveryCodeRepository) DeleteByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+}
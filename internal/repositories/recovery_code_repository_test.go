@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoveryCodeRepository(t *testing.T) {
+	db := openTestDB(t)
+	userRepo := NewUserRepository(db)
+	repo := NewRecoveryCodeRepository(db)
+
+	user := &models.User{Email: "user@example.com", Password: "hashed", FirstName: "John", LastName: "Doe", IsActive: true}
+	if err := userRepo.Create(user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	if err := repo.ReplaceAll(user.ID, []string{"hash-1", "hash-2"}); err != nil {
+		t.Fatalf("replace recovery codes: %v", err)
+	}
+
+	t.Run("a code works once", func(t *testing.T) {
+		used, err := repo.Use(user.ID, "hash-1", time.Now())
+		assert.NoError(t, err)
+		assert.True(t, used)
+
+		used, err = repo.Use(user.ID, "hash-1", time.Now())
+		assert.NoError(t, err)
+		assert.False(t, used)
+	})
+
+	t.Run("codes of another user do not match", func(t *testing.T) {
+		used, err := repo.Use(user.ID+1, "hash-2", time.Now())
+		assert.NoError(t, err)
+		assert.False(t, used)
+	})
+
+	t.Run("replacing drops the old codes", func(t *testing.T) {
+		assert.NoError(t, repo.ReplaceAll(user.ID, []string{"hash-3"}))
+
+		used, err := repo.Use(user.ID, "hash-2", time.Now())
+		assert.NoError(t, err)
+		assert.False(t, used)
+
+		used, err = repo.Use(user.ID, "hash-3", time.Now())
+		assert.NoError(t, err)
+		assert.True(t, used)
+	})
+
+	t.Run("a TOTP step is accepted once", func(t *testing.T) {
+		advanced, err := userRepo.AdvanceTOTPStep(user.ID, 100)
+		assert.NoError(t, err)
+		assert.True(t, advanced)
+
+		advanced, err = userRepo.AdvanceTOTPStep(user.ID, 100)
+		assert.NoError(t, err)
+		assert.False(t, advanced)
+
+		advanced, err = userRepo.AdvanceTOTPStep(user.ID, 99)
+		assert.NoError(t, err)
+		assert.False(t, advanced)
+	})
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB applies every up migration to a fresh schema of the database at
+// TEST_DB_URL, so repositories are tested against the real schema rather than
+// the models. Tests using it are skipped when the variable is not set.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DB_URL")
+	if dsn == "" {
+		t.Skip("TEST_DB_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	// search_path is per connection, so the test keeps to a single one
+	sqlDB.SetMaxOpenConns(1)
+
+	schema := fmt.Sprintf("repository_test_%d", time.Now().UnixNano())
+	if err := db.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec("DROP SCHEMA " + schema + " CASCADE")
+		sqlDB.Close()
+	})
+
+	if err := db.Exec("SET search_path TO " + schema).Error; err != nil {
+		t.Fatalf("set search_path: %v", err)
+	}
+
+	migrations, err := filepath.Glob("../../db/migrations/*.up.sql")
+	if err != nil || len(migrations) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+	sort.Strings(migrations)
+
+	for _, migration := range migrations {
+		sql, err := os.ReadFile(migration)
+		if err != nil {
+			t.Fatalf("read %s: %v", migration, err)
+		}
+		if err := db.Exec(string(sql)).Error; err != nil {
+			t.Fatalf("apply %s: %v", filepath.Base(migration), err)
+		}
+	}
+
+	return db
+}
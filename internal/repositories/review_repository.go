@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+func (r *ReviewRepository) GetByID(id uint) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.Preload("User").Preload("Images").First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *ReviewRepository) GetByUserAndProduct(userID, productID uint) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.Where("user_id = ? AND product_id = ?", userID, productID).First(&review).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *ReviewRepository) GetByProductID(productID uint, status models.ReviewStatus, limit, offset int) ([]models.Review, int64, error) {
+	return r.list(r.db.Where("product_id = ? AND status = ?", productID, status), limit, offset)
+}
+
+func (r *ReviewRepository) GetByStatus(status models.ReviewStatus, limit, offset int) ([]models.Review, int64, error) {
+	return r.list(r.db.Where("status = ?", status), limit, offset)
+}
+
+func (r *ReviewRepository) list(query *gorm.DB, limit, offset int) ([]models.Review, int64, error) {
+	var total int64
+	if err := query.Model(&models.Review{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	var reviews []models.Review
+	if err := query.Preload("User").Preload("Images").Order("created_at DESC").Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+func (r *ReviewRepository) Create(review *models.Review) error {
+	return r.db.Create(review).Error
+}
+
+// UpdateStatus changes the moderation status and keeps the product's rating
+// aggregates in step, counting only approved reviews. The review is read again
+// under a row lock, so concurrent moderations apply their deltas in turn.
+func (r *ReviewRepository) UpdateStatus(review *models.Review, status models.ReviewStatus, note string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		current, err := lockReview(tx, review.ID)
+		if err != nil {
+			return err
+		}
+
+		wasApproved := current.Status == models.ReviewStatusApproved
+		isApproved := status == models.ReviewStatusApproved
+
+		if err := tx.Model(&models.Review{}).Where("id = ?", review.ID).Updates(map[string]interface{}{
+			"status":          status,
+			"moderation_note": note,
+		}).Error; err != nil {
+			return err
+		}
+
+		switch {
+		case isApproved && !wasApproved:
+			if err := adjustProductRating(tx, current.ProductID, 1, current.Rating); err != nil {
+				return err
+			}
+		case wasApproved && !isApproved:
+			if err := adjustProductRating(tx, current.ProductID, -1, -current.Rating); err != nil {
+				return err
+			}
+		}
+
+		review.Status = status
+		review.ModerationNote = note
+		return nil
+	})
+}
+
+func (r *ReviewRepository) Delete(review *models.Review) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		current, err := lockReview(tx, review.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&models.Review{}, current.ID).Error; err != nil {
+			return err
+		}
+
+		if current.Status == models.ReviewStatusApproved {
+			return adjustProductRating(tx, current.ProductID, -1, -current.Rating)
+		}
+		return nil
+	})
+}
+
+// lockReview reads the stored review and locks it until the transaction ends
+func lockReview(tx *gorm.DB, id uint) (*models.Review, error) {
+	var review models.Review
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *ReviewRepository) CreateImage(image *models.ReviewImage) error {
+	return r.db.Create(image).Error
+}
+
+func (r *ReviewRepository) CountImages(reviewID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ReviewImage{}).Where("review_id = ?", reviewID).Count(&count).Error
+	return count, err
+}
+
+func adjustProductRating(tx *gorm.DB, productID uint, countDelta, sumDelta int) error {
+	return tx.Exec(`UPDATE products SET
+		rating_count = rating_count + ?,
+		rating_sum = rating_sum + ?,
+		rating_average = CASE WHEN rating_count + ? > 0
+			THEN ROUND((rating_sum + ?)::numeric / (rating_count + ?), 2)
+			ELSE 0 END
+		WHERE id = ?`,
+		countDelta, sumDelta, countDelta, sumDelta, countDelta, productID).Error
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrLastRoleHolder is returned when removing a role from its only holder
+// while it has to keep one
+var ErrLastRoleHolder = errors.New("the role has no other holder")
+
+// RoleRepository keeps roles and their assignments. Every change that alters
+// the permissions of a user stamps their roles_changed_at, so access tokens
+// issued before it stop being accepted.
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) *RoleRepository {
+	return &RoleRepository{db: db}
+}
+
+func (r *RoleRepository) GetAll() ([]models.Role, error) {
+	var roles []models.Role
+	if err := r.db.Preload("Permissions").Order("name ASC").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *RoleRepository) GetByID(id uint) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.Preload("Permissions").First(&role, id).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *RoleRepository) GetByName(name string) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.Preload("Permissions").Where("name = ?", name).First(&role).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *RoleRepository) Create(role *models.Role) error {
+	return r.db.Create(role).Error
+}
+
+// Update saves the role and replaces its permissions with role.Permissions
+func (r *RoleRepository) Update(role *models.Role) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(role).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("role_id = ?", role.ID).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+		for i := range role.Permissions {
+			role.Permissions[i].RoleID = role.ID
+		}
+		if len(role.Permissions) > 0 {
+			if err := tx.Create(&role.Permissions).Error; err != nil {
+				return err
+			}
+		}
+
+		return touchRolesChanged(tx, tx.Table("user_roles").Select("user_id").Where("role_id = ?", role.ID))
+	})
+}
+
+func (r *RoleRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := touchRolesChanged(tx, tx.Table("user_roles").Select("user_id").Where("role_id = ?", id)); err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.Role{}, id).Error
+	})
+}
+
+func (r *RoleRepository) GetUserRoles(userID uint) ([]models.Role, error) {
+	var roles []models.Role
+	err := r.db.Preload("Permissions").
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name ASC").
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// AssignRole gives the role to the user, assigning it twice is a no-op
+func (r *RoleRepository) AssignRole(userID, roleID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("INSERT INTO user_roles (user_id, role_id) VALUES (?, ?) ON CONFLICT DO NOTHING", userID, roleID).Error
+		if err != nil {
+			return err
+		}
+
+		return touchRolesChanged(tx, []uint{userID})
+	})
+}
+
+// RemoveRole takes the role from the user. It reports false when the user did
+// not have it. With keepLastHolder it fails with ErrLastRoleHolder rather than
+// leave the role without holders; the role stays locked from the count to the
+// delete, so concurrent removals cannot both pass the check.
+func (r *RoleRepository) RemoveRole(userID, roleID uint, keepLastHolder bool) (bool, error) {
+	removed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var role models.Role
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&role, roleID).Error; err != nil {
+			return err
+		}
+
+		var held int64
+		err := tx.Table("user_roles").Where("user_id = ? AND role_id = ?", userID, roleID).Count(&held).Error
+		if err != nil || held == 0 {
+			return err
+		}
+
+		if keepLastHolder {
+			count, err := NewRoleRepository(tx).CountUsersWithRole(roleID)
+			if err != nil {
+				return err
+			}
+			if count <= 1 {
+				return ErrLastRoleHolder
+			}
+		}
+
+		if err := tx.Exec("DELETE FROM user_roles WHERE user_id = ? AND role_id = ?", userID, roleID).Error; err != nil {
+			return err
+		}
+
+		removed = true
+		return touchRolesChanged(tx, []uint{userID})
+	})
+	return removed, err
+}
+
+func (r *RoleRepository) CountUsersWithRole(roleID uint) (int64, error) {
+	var count int64
+	err := r.db.Table("user_roles").
+		Joins("JOIN users ON users.id = user_roles.user_id AND users.deleted_at IS NULL").
+		Where("user_roles.role_id = ?", roleID).
+		Count(&count).Error
+	return count, err
+}
+
+// touchRolesChanged stamps the users whose permissions just changed. userIDs
+// is a list of IDs or a subquery selecting them.
+func touchRolesChanged(tx *gorm.DB, userIDs interface{}) error {
+	return tx.Model(&models.User{}).Where("id IN (?)", userIDs).Update("roles_changed_at", time.Now()).Error
+}
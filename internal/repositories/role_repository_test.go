@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleRepository(t *testing.T) {
+	db := openTestDB(t)
+	userRepo := NewUserRepository(db)
+	repo := NewRoleRepository(db)
+
+	user := &models.User{Email: "staff@example.com", Password: "hashed", FirstName: "Jane", LastName: "Doe", IsActive: true}
+	if err := userRepo.Create(user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	support, err := repo.GetByName("support")
+	if err != nil {
+		t.Fatalf("get seeded role: %v", err)
+	}
+
+	t.Run("assigning stamps the user and loads with them", func(t *testing.T) {
+		assert.NoError(t, repo.AssignRole(user.ID, support.ID))
+		assert.NoError(t, repo.AssignRole(user.ID, support.ID))
+
+		loaded, err := userRepo.GetByID(user.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"support"}, loaded.RoleNames())
+		assert.ElementsMatch(t, []string{models.PermissionReviewsModerate, models.PermissionQuestionsModerate, models.PermissionQuestionsAnswer, models.PermissionUsersUnlock}, loaded.Permissions())
+		assert.NotNil(t, loaded.RolesChangedAt)
+	})
+
+	t.Run("updating replaces the permissions", func(t *testing.T) {
+		support.Permissions = []models.RolePermission{{Permission: models.PermissionUsersUnlock}}
+		assert.NoError(t, repo.Update(support))
+
+		roles, err := repo.GetUserRoles(user.ID)
+		assert.NoError(t, err)
+		assert.Len(t, roles, 1)
+		assert.Equal(t, []string{models.PermissionUsersUnlock}, roles[0].PermissionNames())
+	})
+
+	t.Run("saving the user keeps their roles", func(t *testing.T) {
+		loaded, err := userRepo.GetByID(user.ID)
+		assert.NoError(t, err)
+		loaded.FirstName = "Janet"
+		assert.NoError(t, userRepo.Update(loaded))
+
+		count, err := repo.CountUsersWithRole(support.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("the last holder can be kept", func(t *testing.T) {
+		removed, err := repo.RemoveRole(user.ID, support.ID, true)
+		assert.ErrorIs(t, err, ErrLastRoleHolder)
+		assert.False(t, removed)
+
+		removed, err = repo.RemoveRole(user.ID+1000, support.ID, true)
+		assert.NoError(t, err)
+		assert.False(t, removed)
+	})
+
+	t.Run("removing reports whether the user had the role", func(t *testing.T) {
+		removed, err := repo.RemoveRole(user.ID, support.ID, false)
+		assert.NoError(t, err)
+		assert.True(t, removed)
+
+		removed, err = repo.RemoveRole(user.ID, support.ID, false)
+		assert.NoError(t, err)
+		assert.False(t, removed)
+	})
+}
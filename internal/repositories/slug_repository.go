@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SlugRepository struct {
+	db *gorm.DB
+}
+
+func NewSlugRepository(db *gorm.DB) *SlugRepository {
+	return &SlugRepository{db: db}
+}
+
+// IsTaken reports whether slug is in use by another entity of the same type,
+// either as its current slug or as one of its redirects
+func (r *SlugRepository) IsTaken(entityType models.SlugEntityType, slug string, excludeID uint) (bool, error) {
+	var model interface{} = &models.Product{}
+	if entityType == models.SlugEntityCategory {
+		model = &models.Category{}
+	}
+
+	var count int64
+	if err := r.db.Model(model).Where("slug = ? AND id <> ?", slug, excludeID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	if err := r.db.Model(&models.SlugRedirect{}).
+		Where("entity_type = ? AND old_slug = ? AND entity_id <> ?", entityType, slug, excludeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *SlugRepository) GetRedirect(entityType models.SlugEntityType, slug string) (*models.SlugRedirect, error) {
+	var redirect models.SlugRedirect
+	if err := r.db.Where("entity_type = ? AND old_slug = ?", entityType, slug).First(&redirect).Error; err != nil {
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+// currentSlug reads the stored slug of a product or category and locks the row
+// until the transaction ends, so concurrent renames record their redirects in
+// turn
+func currentSlug(tx *gorm.DB, model interface{}, id uint) (string, error) {
+	var row struct{ Slug string }
+	err := tx.Model(model).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("slug").Where("id = ?", id).Take(&row).Error
+	return row.Slug, err
+}
+
+// ChangeSlug records oldSlug as a redirect to the entity and drops any
+// redirect that newSlug previously served, so the live slug always wins
+func (r *SlugRepository) ChangeSlug(entityType models.SlugEntityType, entityID uint, oldSlug, newSlug string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("entity_type = ? AND old_slug = ?", entityType, newSlug).
+			Delete(&models.SlugRedirect{}).Error; err != nil {
+			return err
+		}
+
+		if oldSlug == "" {
+			return nil
+		}
+
+		return tx.Create(&models.SlugRedirect{
+			EntityType: entityType,
+			EntityID:   entityID,
+			OldSlug:    oldSlug,
+		}).Error
+	})
+}
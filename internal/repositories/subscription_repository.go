@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepository(db *gorm.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+func (r *SubscriptionRepository) GetByID(id uint) (*models.Subscription, error) {
+	var subscription models.Subscription
+	if err := r.db.Preload("Items.Product").First(&subscription, id).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *SubscriptionRepository) GetByUserID(userID uint) ([]models.Subscription, error) {
+	var subscriptions []models.Subscription
+	err := r.db.Preload("Items.Product").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// GetDueIDs returns the IDs of active subscriptions whose delivery, or retry
+// of a failed delivery, is due at now. They are only candidates, ProcessDue
+// claims each of them.
+func (r *SubscriptionRepository) GetDueIDs(now time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.Subscription{}).
+		Where(dueCondition, models.SubscriptionStatusActive, now).
+		Order("next_run_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ProcessDue claims the subscription if it is still due at now and runs
+// process on it, then saves the subscription, all in one transaction. The row
+// stays locked meanwhile and workers skip rows locked by another, so a
+// delivery is placed once even when runs overlap. It reports false when the
+// subscription was taken or is no longer due.
+func (r *SubscriptionRepository) ProcessDue(id uint, now time.Time, process func(tx *gorm.DB, subscription *models.Subscription) error) (bool, error) {
+	claimed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked models.Subscription
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("id = ?", id).
+			Where(dueCondition, models.SubscriptionStatusActive, now).
+			Take(&locked).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var subscription models.Subscription
+		if err := tx.Preload("User").Preload("Items").First(&subscription, locked.ID).Error; err != nil {
+			return err
+		}
+
+		claimed = true
+		if err := process(tx, &subscription); err != nil {
+			return err
+		}
+		return tx.Omit("User", "Items").Save(&subscription).Error
+	})
+	return claimed, err
+}
+
+const dueCondition = "status = ? AND COALESCE(retry_at, next_run_at) <= ?"
+
+func (r *SubscriptionRepository) Create(subscription *models.Subscription) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "Items").Create(subscription).Error; err != nil {
+			return err
+		}
+
+		for i := range subscription.Items {
+			subscription.Items[i].SubscriptionID = subscription.ID
+			if err := tx.Omit("Subscription", "Product").Create(&subscription.Items[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Change locks the subscription and runs change on it, then writes the
+// columns change returns, all in one transaction. Holding the lock keeps the
+// change from interleaving with a delivery being placed by ProcessDue.
+func (r *SubscriptionRepository) Change(id uint, change func(subscription *models.Subscription) ([]string, error)) (*models.Subscription, error) {
+	var subscription models.Subscription
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Subscription{}, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Preload("Items.Product").First(&subscription, id).Error; err != nil {
+			return err
+		}
+
+		columns, err := change(&subscription)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&subscription).Select(columns).Updates(&subscription).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
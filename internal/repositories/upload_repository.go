@@ -29,6 +29,26 @@ func (r *UploadRepository) DeleteProductImage(id uint) error {
 	return r.db.Delete(&models.ProductImage{}, id).Error
 }
 
+func (r *UploadRepository) CreateProductFile(file *models.ProductFile) error {
+	return r.db.Create(file).Error
+}
+
+func (r *UploadRepository) GetProductFiles(productID uint) ([]models.ProductFile, error) {
+	var files []models.ProductFile
+	if err := r.db.Where("product_id = ?", productID).Order("created_at ASC").Find(&files).Error; err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (r *UploadRepository) GetProductFile(id uint) (*models.ProductFile, error) {
+	var file models.ProductFile
+	if err := r.db.First(&file, id).Error; err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
 func (r *UploadRepository) SetPrimaryImage(productID, imageID uint) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		// Set all images for this product to non-primary
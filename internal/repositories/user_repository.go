@@ -5,6 +5,7 @@ import (
 
 	"github.com/JihadRinaldi/go-shop/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository struct {
@@ -23,9 +24,10 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 	return &user, nil
 }
 
+// GetByID returns the user with their roles and permissions
 func (r *UserRepository) GetByID(id uint) (*models.User, error) {
 	var user models.User
-	if err := r.db.First(&user, id).Error; err != nil {
+	if err := r.db.Preload("Roles.Permissions").First(&user, id).Error; err != nil {
 		return nil, err
 	}
 
@@ -34,7 +36,7 @@ func (r *UserRepository) GetByID(id uint) (*models.User, error) {
 
 func (r *UserRepository) GetByEmailAndActive(email string, isActive bool) (*models.User, error) {
 	var user models.User
-	if err := r.db.Where("email = ? AND is_active = ?", email, isActive).First(&user).Error; err != nil {
+	if err := r.db.Preload("Roles.Permissions").Where("email = ? AND is_active = ?", email, isActive).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -44,30 +46,105 @@ func (r *UserRepository) Create(user *models.User) error {
 	return r.db.Create(user).Error
 }
 
+// Update saves the user's own columns, roles are assigned through the role
+// repository
 func (r *UserRepository) Update(user *models.User) error {
-	return r.db.Save(user).Error
+	return r.db.Omit(clause.Associations).Save(user).Error
 }
 
 func (r *UserRepository) Delete(id uint) error {
 	return r.db.Delete(&models.User{}, id).Error
 }
 
+// AdvanceTOTPStep records the time step of an accepted authenticator code. It
+// reports false when that step or a later one was already used, so the same
+// code cannot sign in twice.
+func (r *UserRepository) AdvanceTOTPStep(userID uint, step int64) (bool, error) {
+	result := r.db.Model(&models.User{}).Where("id = ? AND totp_last_step < ?", userID, step).Update("totp_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *UserRepository) CreateRefreshToken(token *models.RefreshToken) error {
-	return r.db.Create(token).Error
+	return r.db.Omit("User").Create(token).Error
 }
 
-func (r *UserRepository) GetValidRefreshToken(token string) (*models.RefreshToken, error) {
+// GetRefreshToken returns the token with the given hash unless its family was
+// revoked. Expired and rotated tokens are returned too, the caller decides
+// what presenting them means.
+func (r *UserRepository) GetRefreshToken(tokenHash string) (*models.RefreshToken, error) {
 	var refreshToken models.RefreshToken
-	if err := r.db.Where("token = ? AND expires_at > ?", token, time.Now()).First(&refreshToken).Error; err != nil {
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&refreshToken).Error; err != nil {
 		return nil, err
 	}
 	return &refreshToken, nil
 }
 
-func (r *UserRepository) DeleteRefreshToken(token string) error {
-	return r.db.Where("token = ?", token).Delete(&models.RefreshToken{}).Error
+// RotateRefreshToken marks the token as used and stores its successor in the
+// same transaction, so the session always keeps one usable token. It reports
+// false and stores nothing when the token had already been rotated, so only
+// one of two concurrent refreshes wins.
+func (r *UserRepository) RotateRefreshToken(id uint, successor *models.RefreshToken, now time.Time) (bool, error) {
+	rotated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).Where("id = ? AND rotated_at IS NULL", id).Update("rotated_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		rotated = true
+		return tx.Create(successor).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return rotated, nil
+}
+
+// GetSessions returns the current token of every session of the user that
+// can still be refreshed
+func (r *UserRepository) GetSessions(userID uint, now time.Time) ([]models.RefreshToken, error) {
+	var tokens []models.RefreshToken
+	err := r.db.Where("user_id = ? AND rotated_at IS NULL AND expired_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// GetSession returns the current token of the session, the one that has not
+// been rotated out yet, with its user
+func (r *UserRepository) GetSession(familyID string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.db.Preload("User").Where("family_id = ? AND rotated_at IS NULL", familyID).Order("created_at DESC").First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *UserRepository) TouchRefreshToken(id uint, now time.Time) error {
+	return r.db.Model(&models.RefreshToken{}).Where("id = ?", id).Update("last_used_at", now).Error
+}
+
+// RevokeRefreshTokenFamily ends the sign in the family belongs to
+func (r *UserRepository) RevokeRefreshTokenFamily(familyID string) error {
+	return r.db.Where("family_id = ?", familyID).Delete(&models.RefreshToken{}).Error
+}
+
+// DeleteRefreshTokensByUserID signs the user out of every session
+func (r *UserRepository) DeleteRefreshTokensByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.RefreshToken{}).Error
 }
 
-func (r *UserRepository) DeleteRefreshTokenByID(id uint) error {
-	return r.db.Delete(&models.RefreshToken{}, id).Error
+// DeleteOtherRefreshTokens signs the user out of every session except the one
+// of the given family
+func (r *UserRepository) DeleteOtherRefreshTokens(userID uint, currentFamilyID string) error {
+	return r.db.Where("user_id = ? AND family_id <> ?", userID, currentFamilyID).Delete(&models.RefreshToken{}).Error
 }
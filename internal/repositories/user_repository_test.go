@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserRepository_RefreshTokenRotation(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewUserRepository(db)
+
+	user := &models.User{Email: "user@example.com", Password: "hashed", FirstName: "John", LastName: "Doe", IsActive: true}
+	if err := repo.Create(user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	newToken := func(familyID, raw string) *models.RefreshToken {
+		token := &models.RefreshToken{
+			UserID:    user.ID,
+			TokenHash: utils.HashToken(raw),
+			FamilyID:  familyID,
+			ExpiredAt: time.Now().Add(time.Hour),
+		}
+		if err := repo.CreateRefreshToken(token); err != nil {
+			t.Fatalf("create refresh token: %v", err)
+		}
+		return token
+	}
+
+	family := uuid.NewString()
+	first := newToken(family, "first")
+
+	t.Run("lookup by hash", func(t *testing.T) {
+		found, err := repo.GetRefreshToken(utils.HashToken("first"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, first.ID, found.ID)
+		assert.Equal(t, family, found.FamilyID)
+		assert.Nil(t, found.RotatedAt)
+		assert.True(t, found.ExpiredAt.After(time.Now()))
+	})
+
+	t.Run("a token rotates only once", func(t *testing.T) {
+		successor := &models.RefreshToken{UserID: user.ID, TokenHash: utils.HashToken("successor"), FamilyID: family, ExpiredAt: time.Now().Add(time.Hour)}
+		rotated, err := repo.RotateRefreshToken(first.ID, successor, time.Now())
+		assert.NoError(t, err)
+		assert.True(t, rotated)
+
+		loser := &models.RefreshToken{UserID: user.ID, TokenHash: utils.HashToken("loser"), FamilyID: family, ExpiredAt: time.Now().Add(time.Hour)}
+		rotated, err = repo.RotateRefreshToken(first.ID, loser, time.Now())
+		assert.NoError(t, err)
+		assert.False(t, rotated)
+
+		found, err := repo.GetRefreshToken(utils.HashToken("first"))
+		assert.NoError(t, err)
+		assert.NotNil(t, found.RotatedAt)
+
+		found, err = repo.GetRefreshToken(utils.HashToken("successor"))
+		assert.NoError(t, err)
+		assert.Nil(t, found.RotatedAt)
+
+		_, err = repo.GetRefreshToken(utils.HashToken("loser"))
+		assert.Error(t, err)
+	})
+
+	t.Run("revoking a family keeps other sign ins", func(t *testing.T) {
+		second := newToken(family, "second")
+		other := newToken(uuid.NewString(), "other")
+
+		assert.NoError(t, repo.RevokeRefreshTokenFamily(family))
+
+		_, err := repo.GetRefreshToken(utils.HashToken("first"))
+		assert.Error(t, err)
+		_, err = repo.GetRefreshToken(utils.HashToken("second"))
+		assert.Error(t, err)
+
+		found, err := repo.GetRefreshToken(utils.HashToken("other"))
+		assert.NoError(t, err)
+		assert.Equal(t, other.ID, found.ID)
+		assert.NotEqual(t, second.FamilyID, found.FamilyID)
+	})
+
+	t.Run("revoking other sessions keeps the current family", func(t *testing.T) {
+		current := newToken(uuid.NewString(), "current")
+
+		assert.NoError(t, repo.DeleteOtherRefreshTokens(user.ID, current.FamilyID))
+
+		_, err := repo.GetRefreshToken(utils.HashToken("other"))
+		assert.Error(t, err)
+		_, err = repo.GetRefreshToken(utils.HashToken("current"))
+		assert.NoError(t, err)
+	})
+}
+
+func TestUserRepository_Sessions(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewUserRepository(db)
+
+	user := &models.User{Email: "user@example.com", Password: "hashed", FirstName: "John", LastName: "Doe", IsActive: true}
+	if err := repo.Create(user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	now := time.Now()
+	family := uuid.NewString()
+	tokens := []*models.RefreshToken{
+		{UserID: user.ID, TokenHash: utils.HashToken("rotated"), FamilyID: family, UserAgent: "Firefox", IPAddress: "203.0.113.7", LastUsedAt: now.Add(-time.Hour), ExpiredAt: now.Add(time.Hour)},
+		{UserID: user.ID, TokenHash: utils.HashToken("current"), FamilyID: family, UserAgent: "Firefox", IPAddress: "203.0.113.8", LastUsedAt: now.Add(-time.Minute), ExpiredAt: now.Add(time.Hour)},
+		{UserID: user.ID, TokenHash: utils.HashToken("expired"), FamilyID: uuid.NewString(), LastUsedAt: now.Add(-48 * time.Hour), ExpiredAt: now.Add(-time.Hour)},
+	}
+	for _, token := range tokens {
+		if err := repo.CreateRefreshToken(token); err != nil {
+			t.Fatalf("create refresh token: %v", err)
+		}
+	}
+	if err := db.Model(tokens[0]).Update("rotated_at", now).Error; err != nil {
+		t.Fatalf("rotate refresh token: %v", err)
+	}
+
+	t.Run("lists the current token of each live session", func(t *testing.T) {
+		sessions, err := repo.GetSessions(user.ID, now)
+
+		assert.NoError(t, err)
+		if assert.Len(t, sessions, 1) {
+			assert.Equal(t, tokens[1].ID, sessions[0].ID)
+			assert.Equal(t, "203.0.113.8", sessions[0].IPAddress)
+		}
+	})
+
+	t.Run("session lookup and touch", func(t *testing.T) {
+		session, err := repo.GetSession(family)
+		assert.NoError(t, err)
+		assert.Equal(t, tokens[1].ID, session.ID)
+
+		assert.NoError(t, repo.TouchRefreshToken(session.ID, now))
+
+		session, err = repo.GetSession(family)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, now, session.LastUsedAt, time.Second)
+	})
+}
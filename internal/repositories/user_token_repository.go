@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+)
+
+type UserTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) *UserTokenRepository {
+	return &UserTokenRepository{db: db}
+}
+
+func (r *UserTokenRepository) Create(token *models.UserToken) error {
+	return r.db.Omit("User").Create(token).Error
+}
+
+// GetUsable returns the token with the given hash if it was issued for
+// purpose, has not been used and has not expired. The user comes with their
+// roles, a two-factor challenge signs them in.
+func (r *UserTokenRepository) GetUsable(purpose models.UserTokenPurpose, hash string, now time.Time) (*models.UserToken, error) {
+	var token models.UserToken
+	err := r.db.Preload("User.Roles.Permissions").
+		Where("purpose = ? AND token_hash = ? AND used_at IS NULL AND expires_at > ?", purpose, hash, now).
+		First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetLatest returns the most recently issued token of the user for purpose
+func (r *UserTokenRepository) GetLatest(userID uint, purpose models.UserTokenPurpose) (*models.UserToken, error) {
+	var token models.UserToken
+	if err := r.db.Where("user_id = ? AND purpose = ?", userID, purpose).Order("created_at DESC").First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed consumes the token. It reports false when the token had already
+// been used, so two concurrent requests cannot both redeem it.
+func (r *UserTokenRepository) MarkUsed(id uint, now time.Time) (bool, error) {
+	result := r.db.Model(&models.UserToken{}).Where("id = ? AND used_at IS NULL", id).Update("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// InvalidateAll consumes every outstanding token of the user for purpose
+func (r *UserTokenRepository) InvalidateAll(userID uint, purpose models.UserTokenPurpose, now time.Time) error {
+	return r.db.Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", now).Error
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"gorm.io/gorm"
+)
+
+type WishlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWishlistRepository(db *gorm.DB) *WishlistRepository {
+	return &WishlistRepository{db: db}
+}
+
+func (r *WishlistRepository) GetByID(id uint) (*models.Wishlist, error) {
+	var wishlist models.Wishlist
+	if err := r.withItems(r.db).First(&wishlist, id).Error; err != nil {
+		return nil, err
+	}
+	return &wishlist, nil
+}
+
+func (r *WishlistRepository) GetByUserID(userID uint) ([]models.Wishlist, error) {
+	var wishlists []models.Wishlist
+	if err := r.withItems(r.db).Where("user_id = ?", userID).Order("created_at ASC").Find(&wishlists).Error; err != nil {
+		return nil, err
+	}
+	return wishlists, nil
+}
+
+func (r *WishlistRepository) GetByShareToken(token string) (*models.Wishlist, error) {
+	var wishlist models.Wishlist
+	if err := r.withItems(r.db).Where("share_token = ?", token).First(&wishlist).Error; err != nil {
+		return nil, err
+	}
+	return &wishlist, nil
+}
+
+func (r *WishlistRepository) ExistsByName(userID uint, name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Wishlist{}).
+		Where("user_id = ? AND name = ? AND id <> ?", userID, name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *WishlistRepository) Create(wishlist *models.Wishlist) error {
+	return r.db.Omit("User", "Items").Create(wishlist).Error
+}
+
+func (r *WishlistRepository) Update(wishlist *models.Wishlist) error {
+	return r.db.Omit("User", "Items").Save(wishlist).Error
+}
+
+func (r *WishlistRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("wishlist_id = ?", id).Delete(&models.WishlistItem{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.Wishlist{}, id).Error
+	})
+}
+
+func (r *WishlistRepository) GetItem(wishlistID, itemID uint) (*models.WishlistItem, error) {
+	var item models.WishlistItem
+	if err := r.db.Where("wishlist_id = ? AND id = ?", wishlistID, itemID).First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *WishlistRepository) GetItemByProduct(wishlistID, productID uint) (*models.WishlistItem, error) {
+	var item models.WishlistItem
+	if err := r.db.Where("wishlist_id = ? AND product_id = ?", wishlistID, productID).First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *WishlistRepository) AddItem(item *models.WishlistItem) error {
+	return r.db.Omit("Wishlist", "Product").Create(item).Error
+}
+
+func (r *WishlistRepository) RemoveItem(itemID uint) error {
+	return r.db.Delete(&models.WishlistItem{}, itemID).Error
+}
+
+func (r *WishlistRepository) withItems(db *gorm.DB) *gorm.DB {
+	return db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at DESC")
+	}).Preload("Items.Product.Category").Preload("Items.Product.Images")
+}
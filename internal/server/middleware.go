@@ -1,9 +1,11 @@
 package server
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/services"
 	"github.com/JihadRinaldi/go-shop/internal/utils"
 	"github.com/gin-gonic/gin"
 )
@@ -29,26 +31,71 @@ func (s *Server) authMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		claims, err := utils.ValidateToken(tokenString[1], s.config.JWT.SecretKey)
+		claims, err := utils.ValidateToken(tokenString[1], &s.config.JWT, utils.TokenTypeAccess)
 		if err != nil {
 			utils.UnauthorizedResponse(ctx, "Authorization header required")
 			ctx.Abort()
 			return
 		}
 
+		// Access tokens live on until they expire, so every request checks
+		// that their session has not been signed out in the meantime
+		active, err := s.sessionService.ValidateSession(claims.UserID, claims.SessionID, claims.IssuedAt.Time)
+		if errors.Is(err, services.ErrPermissionsChanged) {
+			utils.UnauthorizedResponse(ctx, "Permissions have changed, refresh the access token")
+			ctx.Abort()
+			return
+		}
+		if err != nil {
+			utils.InternalServerErrorResponse(ctx, "Failed to validate session", err)
+			ctx.Abort()
+			return
+		}
+		if !active {
+			utils.UnauthorizedResponse(ctx, "Session has been signed out")
+			ctx.Abort()
+			return
+		}
+
 		ctx.Set("user_id", claims.UserID)
 		ctx.Set("user_email", claims.Email)
-		ctx.Set("user_role", claims.Role)
+		ctx.Set("user_roles", claims.Roles)
+		ctx.Set("user_permissions", claims.Permissions)
+		ctx.Set("session_id", claims.SessionID)
+		ctx.Set("two_factor", claims.TwoFactor)
 
 		ctx.Next()
 	}
 }
 
-func (s *Server) adminMiddleware() gin.HandlerFunc {
+// optionalAuthMiddleware authenticates the request when an Authorization header
+// is present and lets anonymous requests through otherwise
+func (s *Server) optionalAuthMiddleware() gin.HandlerFunc {
+	authenticate := s.authMiddleware()
 	return func(ctx *gin.Context) {
-		role, exists := ctx.Get("user_role")
-		if !exists || role != string(models.UserRoleAdmin) {
-			utils.ForbiddenResponse(ctx, "Admin access required")
+		if ctx.GetHeader(AuthorizationHeader) == "" {
+			ctx.Next()
+			return
+		}
+
+		authenticate(ctx)
+	}
+}
+
+// requirePermission lets through users whose roles grant permission. When the
+// shop requires two-factor authentication for staff, tokens issued without it
+// are refused until the user enrolls and refreshes them; enrolling needs no
+// permission.
+func (s *Server) requirePermission(permission string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !models.HasPermission(ctx.GetStringSlice("user_permissions"), permission) {
+			utils.ForbiddenResponse(ctx, "Permission required: "+permission)
+			ctx.Abort()
+			return
+		}
+
+		if s.config.Auth.TwoFactor.RequireForStaff && !ctx.GetBool("two_factor") {
+			utils.ForbiddenResponse(ctx, "Two-factor authentication is required for staff accounts")
 			ctx.Abort()
 			return
 		}
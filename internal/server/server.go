@@ -8,22 +8,33 @@ import (
 	"github.com/JihadRinaldi/go-shop/internal/events"
 	"github.com/JihadRinaldi/go-shop/internal/handler"
 	"github.com/JihadRinaldi/go-shop/internal/interfaces"
+	"github.com/JihadRinaldi/go-shop/internal/models"
 	"github.com/JihadRinaldi/go-shop/internal/providers"
 	"github.com/JihadRinaldi/go-shop/internal/services"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 )
 
 type Server struct {
-	config         *config.Config
-	db             *gorm.DB
-	logger         zerolog.Logger
-	authHandler    *handler.AuthHandler
-	userHandler    *handler.UserHandler
-	productHandler *handler.ProductHandler
-	cartHandler    *handler.CartHandler
-	orderHandler   *handler.OrderHandler
+	config              *config.Config
+	db                  *gorm.DB
+	logger              zerolog.Logger
+	authHandler         *handler.AuthHandler
+	userHandler         *handler.UserHandler
+	productHandler      *handler.ProductHandler
+	cartHandler         *handler.CartHandler
+	orderHandler        *handler.OrderHandler
+	reviewHandler       *handler.ReviewHandler
+	questionHandler     *handler.QuestionHandler
+	wishlistHandler     *handler.WishlistHandler
+	subscriptionHandler *handler.SubscriptionHandler
+	downloadHandler     *handler.DownloadHandler
+	sessionHandler      *handler.SessionHandler
+	twoFactorHandler    *handler.TwoFactorHandler
+	roleHandler         *handler.RoleHandler
+	sessionService      *services.SessionService
 }
 
 func New(cfg *config.Config, db *gorm.DB, logger *zerolog.Logger) *Server {
@@ -42,39 +53,69 @@ func New(cfg *config.Config, db *gorm.DB, logger *zerolog.Logger) *Server {
 		return nil
 	}
 
-	authService := services.NewAuthService(db, cfg, eventPublisher)
-	userService := services.NewUserService(db, cfg)
+	cartService := services.NewCartService(db, cfg)
+	authService := services.NewAuthService(db, cfg, eventPublisher, cartService)
+	userService := services.NewUserService(db, cfg, eventPublisher)
 	productService := services.NewProductService(db, cfg)
 	uploadService := services.NewUploadService(db, uploadProvider)
-	cartService := services.NewCartService(db, cfg)
-	orderService := services.NewOrderService(db, cfg)
+	orderService := services.NewOrderService(db, cfg, cartService)
+	reviewService := services.NewReviewService(db, cfg)
+	questionService := services.NewQuestionService(db, cfg, eventPublisher)
+	wishlistService := services.NewWishlistService(db, cfg, cartService)
+	cartReminderService := services.NewCartReminderService(db, cfg, eventPublisher, cartService)
+	subscriptionService := services.NewSubscriptionService(db, cfg, eventPublisher, orderService)
+	downloadService := services.NewDownloadService(db, cfg, uploadProvider)
+	sessionService := services.NewSessionService(db, cfg)
+	twoFactorService := services.NewTwoFactorService(db, cfg)
+	roleService := services.NewRoleService(db, cfg)
 
 	authHandler := handler.NewAuthHandler(authService)
 	userHandler := handler.NewUserHandler(userService)
 	productHandler := handler.NewProductHandler(productService, uploadService)
-	cartHandler := handler.NewCartHandler(cartService)
+	cartHandler := handler.NewCartHandler(cartService, cartReminderService)
 	orderHandler := handler.NewOrderHandler(orderService)
+	reviewHandler := handler.NewReviewHandler(reviewService, uploadService)
+	questionHandler := handler.NewQuestionHandler(questionService)
+	wishlistHandler := handler.NewWishlistHandler(wishlistService)
+	subscriptionHandler := handler.NewSubscriptionHandler(subscriptionService)
+	downloadHandler := handler.NewDownloadHandler(downloadService)
+	sessionHandler := handler.NewSessionHandler(sessionService)
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorService)
+	roleHandler := handler.NewRoleHandler(roleService)
 
 	return &Server{
-		config:         cfg,
-		db:             db,
-		logger:         *logger,
-		authHandler:    authHandler,
-		userHandler:    userHandler,
-		productHandler: productHandler,
-		cartHandler:    cartHandler,
-		orderHandler:   orderHandler,
+		config:              cfg,
+		db:                  db,
+		logger:              *logger,
+		authHandler:         authHandler,
+		userHandler:         userHandler,
+		productHandler:      productHandler,
+		cartHandler:         cartHandler,
+		orderHandler:        orderHandler,
+		reviewHandler:       reviewHandler,
+		questionHandler:     questionHandler,
+		wishlistHandler:     wishlistHandler,
+		subscriptionHandler: subscriptionHandler,
+		downloadHandler:     downloadHandler,
+		sessionHandler:      sessionHandler,
+		twoFactorHandler:    twoFactorHandler,
+		roleHandler:         roleHandler,
+		sessionService:      sessionService,
 	}
 }
 
 func (s *Server) SetupRoutes() *gin.Engine {
-	router := gin.New()
+	router, err := newRouter(s.config.Server.TrustedProxies)
+	if err != nil {
+		s.logger.Fatal().Err(err).Msg("Failed to configure trusted proxies")
+	}
 
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 	router.Use(s.corsMiddleware())
 
 	router.GET("/healthz", s.healthCheck)
+	router.GET("/.well-known/jwks.json", s.jwks)
 
 	api := router.Group("/api/v1")
 	{
@@ -82,8 +123,27 @@ func (s *Server) SetupRoutes() *gin.Engine {
 		{
 			auth.POST("/register", s.authHandler.Register)
 			auth.POST("/login", s.authHandler.Login)
+			auth.POST("/login/2fa", s.authHandler.VerifyTwoFactor)
 			auth.POST("/refresh", s.authHandler.RefreshToken)
 			auth.POST("/logout", s.authHandler.Logout)
+			auth.POST("/verify-email", s.authHandler.VerifyEmail)
+			auth.POST("/resend-verification", s.authHandler.ResendVerification)
+			auth.POST("/forgot-password", s.authHandler.ForgotPassword)
+			auth.POST("/reset-password", s.authHandler.ResetPassword)
+		}
+
+		carts := api.Group("/carts")
+		carts.Use(s.optionalAuthMiddleware())
+		{
+			carts.GET("/", s.cartHandler.GetCart)
+			carts.POST("/items", s.cartHandler.AddToCart)
+			carts.PUT("/items/:id", s.cartHandler.UpdateCartItem)
+			carts.DELETE("/items/:id", s.cartHandler.RemoveCartItem)
+			carts.PUT("/items/:id/note", s.cartHandler.UpdateCartItemNote)
+			carts.POST("/items/:id/save-for-later", s.cartHandler.SaveForLater)
+			carts.POST("/items/:id/move-to-cart", s.cartHandler.MoveToCart)
+			carts.POST("/accept-changes", s.cartHandler.AcceptChanges)
+			carts.GET("/restore/:token", s.cartHandler.RestoreCart)
 		}
 
 		protected := api.Group("/")
@@ -93,43 +153,132 @@ func (s *Server) SetupRoutes() *gin.Engine {
 			{
 				user.GET("/profile", s.userHandler.GetProfile)
 				user.PUT("/profile", s.userHandler.UpdateProfile)
+				user.PUT("/password", s.userHandler.ChangePassword)
+				user.POST("/email", s.userHandler.RequestEmailChange)
+				user.POST("/email/confirm", s.userHandler.ConfirmEmailChange)
+				user.GET("/sessions", s.sessionHandler.GetSessions)
+				user.DELETE("/sessions", s.sessionHandler.RevokeAllSessions)
+				user.DELETE("/sessions/:id", s.sessionHandler.RevokeSession)
+				user.POST("/2fa/enroll", s.twoFactorHandler.Enroll)
+				user.POST("/2fa/confirm", s.twoFactorHandler.Confirm)
+				user.POST("/2fa/disable", s.twoFactorHandler.Disable)
+				user.POST("/2fa/recovery-codes", s.twoFactorHandler.RegenerateRecoveryCodes)
+			}
+
+			users := protected.Group("/users")
+			{
+				users.POST("/:id/unlock", s.requirePermission(models.PermissionUsersUnlock), s.authHandler.UnlockAccount)
+				users.GET("/:id/roles", s.requirePermission(models.PermissionRolesManage), s.roleHandler.GetUserRoles)
+				users.POST("/:id/roles/:roleId", s.requirePermission(models.PermissionRolesManage), s.roleHandler.AssignRole)
+				users.DELETE("/:id/roles/:roleId", s.requirePermission(models.PermissionRolesManage), s.roleHandler.RemoveRole)
+			}
+
+			protected.GET("/permissions", s.requirePermission(models.PermissionRolesManage), s.roleHandler.GetPermissions)
+
+			roles := protected.Group("/roles", s.requirePermission(models.PermissionRolesManage))
+			{
+				roles.GET("/", s.roleHandler.GetRoles)
+				roles.POST("/", s.roleHandler.CreateRole)
+				roles.PUT("/:id", s.roleHandler.UpdateRole)
+				roles.DELETE("/:id", s.roleHandler.DeleteRole)
 			}
 
 			categories := protected.Group("/categories")
 			{
-				categories.POST("/", s.adminMiddleware(), s.productHandler.CreateCategory)
-				categories.PUT("/:id", s.adminMiddleware(), s.productHandler.UpdateCategory)
-				categories.DELETE("/:id", s.adminMiddleware(), s.productHandler.DeleteCategory)
+				categories.POST("/", s.requirePermission(models.PermissionCatalogManage), s.productHandler.CreateCategory)
+				categories.PUT("/:id", s.requirePermission(models.PermissionCatalogManage), s.productHandler.UpdateCategory)
+				categories.DELETE("/:id", s.requirePermission(models.PermissionCatalogManage), s.productHandler.DeleteCategory)
+				categories.POST("/:id/attributes", s.requirePermission(models.PermissionCatalogManage), s.productHandler.CreateCategoryAttribute)
+				categories.PUT("/:id/attributes/:attributeId", s.requirePermission(models.PermissionCatalogManage), s.productHandler.UpdateCategoryAttribute)
+				categories.DELETE("/:id/attributes/:attributeId", s.requirePermission(models.PermissionCatalogManage), s.productHandler.DeleteCategoryAttribute)
 			}
 
 			products := protected.Group("/products")
 			{
 
-				products.POST("/", s.adminMiddleware(), s.productHandler.CreateProduct)
-				products.PUT("/:id", s.adminMiddleware(), s.productHandler.UpdateProduct)
-				products.DELETE("/:id", s.adminMiddleware(), s.productHandler.DeleteProduct)
-				products.POST("/:id/images", s.adminMiddleware(), s.productHandler.UploadProductImage)
+				products.POST("/", s.requirePermission(models.PermissionCatalogManage), s.productHandler.CreateProduct)
+				products.PUT("/:id", s.requirePermission(models.PermissionCatalogManage), s.productHandler.UpdateProduct)
+				products.PUT("/:id/bundle", s.requirePermission(models.PermissionCatalogManage), s.productHandler.SetBundleItems)
+				products.DELETE("/:id", s.requirePermission(models.PermissionCatalogManage), s.productHandler.DeleteProduct)
+				products.POST("/:id/images", s.requirePermission(models.PermissionCatalogManage), s.productHandler.UploadProductImage)
+				products.POST("/:id/files", s.requirePermission(models.PermissionCatalogManage), s.productHandler.UploadProductFile)
+				products.POST("/:id/reviews", s.reviewHandler.CreateReview)
+				products.POST("/:id/questions", s.questionHandler.AskQuestion)
+			}
+
+			reviews := protected.Group("/reviews")
+			{
+				reviews.POST("/:id/images", s.reviewHandler.UploadReviewImage)
+				reviews.DELETE("/:id", s.reviewHandler.DeleteReview)
+				reviews.GET("/", s.requirePermission(models.PermissionReviewsModerate), s.reviewHandler.GetReviewsForModeration)
+				reviews.PUT("/:id/moderation", s.requirePermission(models.PermissionReviewsModerate), s.reviewHandler.ModerateReview)
+			}
+
+			questions := protected.Group("/questions")
+			{
+				questions.POST("/:id/answers", s.questionHandler.AnswerQuestion)
+				questions.POST("/:id/upvote", s.questionHandler.UpvoteQuestion)
+				questions.DELETE("/:id/upvote", s.questionHandler.RemoveQuestionUpvote)
+				questions.GET("/", s.requirePermission(models.PermissionQuestionsModerate), s.questionHandler.GetQuestionsForModeration)
+				questions.PUT("/:id/moderation", s.requirePermission(models.PermissionQuestionsModerate), s.questionHandler.ModerateQuestion)
 			}
 
-			carts := protected.Group("/carts")
+			answers := protected.Group("/answers")
 			{
-				carts.GET("/", s.cartHandler.GetCart)
-				carts.POST("/items", s.cartHandler.AddToCart)
-				carts.PUT("/items/:id", s.cartHandler.UpdateCartItem)
-				carts.DELETE("/items/:id", s.cartHandler.RemoveCartItem)
+				answers.POST("/:id/upvote", s.questionHandler.UpvoteAnswer)
+				answers.DELETE("/:id/upvote", s.questionHandler.RemoveAnswerUpvote)
+				answers.GET("/", s.requirePermission(models.PermissionQuestionsModerate), s.questionHandler.GetAnswersForModeration)
+				answers.PUT("/:id/moderation", s.requirePermission(models.PermissionQuestionsModerate), s.questionHandler.ModerateAnswer)
+			}
+
+			wishlists := protected.Group("/wishlists")
+			{
+				wishlists.GET("/", s.wishlistHandler.GetWishlists)
+				wishlists.POST("/", s.wishlistHandler.CreateWishlist)
+				wishlists.GET("/:id", s.wishlistHandler.GetWishlist)
+				wishlists.PUT("/:id", s.wishlistHandler.UpdateWishlist)
+				wishlists.DELETE("/:id", s.wishlistHandler.DeleteWishlist)
+				wishlists.POST("/:id/items", s.wishlistHandler.AddItem)
+				wishlists.DELETE("/:id/items/:itemId", s.wishlistHandler.RemoveItem)
+				wishlists.POST("/:id/items/:itemId/move-to-cart", s.wishlistHandler.MoveToCart)
+				wishlists.POST("/:id/share", s.wishlistHandler.ShareWishlist)
+				wishlists.DELETE("/:id/share", s.wishlistHandler.UnshareWishlist)
 			}
 
 			orders := protected.Group("/orders")
 			{
 				orders.POST("/", s.orderHandler.CreateOrder)
+				orders.POST("/buy-now", s.orderHandler.BuyNow)
 				orders.GET("/:id", s.orderHandler.GetOrder)
+				orders.POST("/:id/reorder", s.orderHandler.Reorder)
+				orders.GET("/:id/downloads", s.downloadHandler.GetOrderDownloads)
+				orders.PUT("/:id/status", s.requirePermission(models.PermissionOrdersUpdate), s.orderHandler.UpdateOrderStatus)
 				orders.GET("/", s.orderHandler.GetOrders)
 			}
+
+			subscriptions := protected.Group("/subscriptions")
+			{
+				subscriptions.GET("/", s.subscriptionHandler.GetSubscriptions)
+				subscriptions.POST("/", s.subscriptionHandler.CreateSubscription)
+				subscriptions.GET("/:id", s.subscriptionHandler.GetSubscription)
+				subscriptions.POST("/:id/skip", s.subscriptionHandler.SkipNextDelivery)
+				subscriptions.POST("/:id/pause", s.subscriptionHandler.PauseSubscription)
+				subscriptions.POST("/:id/resume", s.subscriptionHandler.ResumeSubscription)
+				subscriptions.POST("/:id/cancel", s.subscriptionHandler.CancelSubscription)
+			}
 		}
 
 		api.GET("/categories", s.productHandler.GetCategories)
+		api.GET("/categories/tree", s.productHandler.GetCategoryTree)
+		api.GET("/categories/slug/:slug", s.productHandler.GetCategoryBySlug)
+		api.GET("/categories/:id/attributes", s.productHandler.GetCategoryAttributes)
 		api.GET("/products", s.productHandler.GetProducts)
 		api.GET("/products/:id", s.productHandler.GetProduct)
+		api.GET("/products/slug/:slug", s.productHandler.GetProductBySlug)
+		api.GET("/products/:id/reviews", s.reviewHandler.GetProductReviews)
+		api.GET("/products/:id/questions", s.questionHandler.GetProductQuestions)
+		api.GET("/wishlists/shared/:token", s.wishlistHandler.GetSharedWishlist)
+		api.GET("/downloads/:itemId/files/:fileId", s.downloadHandler.Download)
 	}
 
 	return router
@@ -139,11 +288,19 @@ func (s *Server) healthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
+// jwks publishes the keys our tokens are signed with. Verifiers cache it, a
+// new key is listed here well before it starts signing.
+func (s *Server) jwks(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, utils.JWKS(&s.config.JWT))
+}
+
 func (s *Server) corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, GET, PUT, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Cart-Token")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Cart-Token")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -153,3 +310,14 @@ func (s *Server) corsMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// newRouter only honours X-Forwarded-For from the given proxies, so clients
+// cannot pick their own IP for the per-IP login limits.
+func newRouter(trustedProxies []string) (*gin.Engine, error) {
+	router := gin.New()
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
+
+	return router, nil
+}
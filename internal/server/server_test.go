@@ -0,0 +1,60 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRouter_ClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// attempts stands in for the per-IP login counter, which is keyed by
+	// c.ClientIP() in the auth handler
+	serve := func(t *testing.T, trustedProxies []string, remoteAddr string, forwardedFor ...string) map[string]int {
+		router, err := newRouter(trustedProxies)
+		assert.NoError(t, err)
+
+		attempts := map[string]int{}
+		router.POST("/login", func(c *gin.Context) {
+			attempts[c.ClientIP()]++
+			c.Status(http.StatusUnauthorized)
+		})
+
+		for _, header := range forwardedFor {
+			req := httptest.NewRequest(http.MethodPost, "/login", nil)
+			req.RemoteAddr = remoteAddr
+			req.Header.Set("X-Forwarded-For", header)
+			router.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		return attempts
+	}
+
+	t.Run("spoofed header does not reset the counter", func(t *testing.T) {
+		attempts := serve(t, nil, "203.0.113.7:1234", "198.51.100.1", "198.51.100.2", "198.51.100.3")
+
+		assert.Equal(t, map[string]int{"203.0.113.7": 3}, attempts)
+	})
+
+	t.Run("untrusted remote cannot forward", func(t *testing.T) {
+		attempts := serve(t, []string{"10.0.0.0/8"}, "203.0.113.7:1234", "198.51.100.1", "198.51.100.2")
+
+		assert.Equal(t, map[string]int{"203.0.113.7": 2}, attempts)
+	})
+
+	t.Run("trusted proxy forwards the client address", func(t *testing.T) {
+		attempts := serve(t, []string{"10.0.0.0/8"}, "10.0.0.5:1234", "198.51.100.1", "198.51.100.1")
+
+		assert.Equal(t, map[string]int{"198.51.100.1": 2}, attempts)
+	})
+
+	t.Run("invalid proxy is rejected", func(t *testing.T) {
+		_, err := newRouter([]string{"not-an-ip"})
+
+		assert.Error(t, err)
+	})
+}
@@ -9,29 +9,56 @@ import (
 	"github.com/JihadRinaldi/go-shop/internal/config"
 	"github.com/JihadRinaldi/go-shop/internal/dto"
 	"github.com/JihadRinaldi/go-shop/internal/events"
+	"github.com/JihadRinaldi/go-shop/internal/interfaces"
 	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/notifications"
 	"github.com/JihadRinaldi/go-shop/internal/repositories"
 	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// ErrEmailNotVerified is returned when the shop requires a verified email
+// address for an action and the account has not been verified yet
+var ErrEmailNotVerified = errors.New("email address has not been verified")
+
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrRefreshTokenReused is returned when a refresh token that was already
+// rotated out is presented again. The whole sign in has been revoked by then.
+var ErrRefreshTokenReused = errors.New("refresh token has already been used")
+
+// guestCartMerger folds a guest cart into the user's cart on sign in.
+// CartService implements it.
+type guestCartMerger interface {
+	MergeGuestCart(userID uint, token string) (*dto.CartMergeResult, error)
+}
+
 type AuthService struct {
 	config         *config.Config
 	eventPublisher events.Publisher
 	userRepo       repositories.UserRepositoryInterface
+	tokenRepo      repositories.UserTokenRepositoryInterface
+	recoveryRepo   repositories.RecoveryCodeRepositoryInterface
 	cartRepo       repositories.CartRepositoryInterface
+	loginAttempts  interfaces.LoginAttemptStore
+	cartService    guestCartMerger
 }
 
-func NewAuthService(db *gorm.DB, config *config.Config, eventPublisher events.Publisher) *AuthService {
+func NewAuthService(db *gorm.DB, config *config.Config, eventPublisher events.Publisher, cartService *CartService) *AuthService {
 	return &AuthService{
 		config:         config,
 		eventPublisher: eventPublisher,
 		userRepo:       repositories.NewUserRepository(db),
+		tokenRepo:      repositories.NewUserTokenRepository(db),
+		recoveryRepo:   repositories.NewRecoveryCodeRepository(db),
 		cartRepo:       repositories.NewCartRepository(db),
+		loginAttempts:  repositories.NewLoginAttemptRepository(db),
+		cartService:    cartService,
 	}
 }
 
-func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, error) {
+func (s *AuthService) Register(req *dto.RegisterRequest, client dto.ClientInfo) (*dto.AuthResponse, error) {
 	if _, err := s.userRepo.GetByEmail(req.Email); err == nil {
 		return nil, errors.New("email already in use")
 	}
@@ -47,44 +74,157 @@ func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, err
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
 		Phone:     req.Phone,
-		Role:      models.UserRoleCustomer,
 	}
 
 	if err := s.userRepo.Create(&user); err != nil {
 		return nil, err
 	}
 
-	cart := models.Cart{UserID: user.ID}
+	cart := models.Cart{UserID: &user.ID}
 	if err := s.cartRepo.Create(&cart); err != nil {
 		fmt.Println("Unable to create cart")
 	}
 
-	return s.generateAuthResponse(&user)
+	if err := s.sendEmailVerification(&user, notifications.UserRegistered); err != nil {
+		fmt.Println("Failed to send email verification:", err)
+	}
+
+	// Without a verified address the account cannot sign in yet, so no
+	// tokens are issued until the link in the email has been followed
+	response := &dto.AuthResponse{User: toUserResponse(&user)}
+	if !s.config.Auth.VerifiedEmailRequiredForLogin() {
+		response, err = s.generateAuthResponse(&user, client)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	response.CartMerge = s.mergeGuestCart(user.ID, req.CartToken)
+	return response, nil
 }
 
-func (s *AuthService) Login(req *dto.LoginRequest) (*dto.AuthResponse, error) {
+// Login checks the credentials unless the account or the client IP is
+// throttled after failed attempts, see recordLoginFailure. Accounts with
+// two-factor authentication get a challenge to answer through
+// VerifyTwoFactorLogin instead of tokens.
+func (s *AuthService) Login(req *dto.LoginRequest, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	now := time.Now()
+	if err := s.checkLoginThrottle(loginThrottleKeys(req.Email, client), now); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userRepo.GetByEmailAndActive(req.Email, true)
 	if err != nil {
+		s.recordLoginFailure(req.Email, nil, client, now)
 		return nil, errors.New("invalid credentials")
 	}
 
 	if !utils.CheckPassword(req.Password, user.Password) {
+		s.recordLoginFailure(req.Email, user, client, now)
 		return nil, errors.New("invalid credentials")
 	}
 
-	return s.generateAuthResponse(user)
+	if s.config.Auth.VerifiedEmailRequiredForLogin() && !user.IsEmailVerified() {
+		return nil, ErrEmailNotVerified
+	}
+
+	// The failures keep counting until the second factor passes as well,
+	// otherwise the password alone would buy unlimited guesses at the code
+	if user.TwoFactorEnabled() {
+		return s.issueTwoFactorChallenge(user)
+	}
+
+	// The IP keeps its count, one valid account must not clear it for others
+	if err := s.loginAttempts.Reset(accountThrottleKey(req.Email)); err != nil {
+		log.Println("Failed to reset login attempts:", err)
+	}
+
+	response, err := s.generateAuthResponse(user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	response.CartMerge = s.mergeGuestCart(user.ID, req.CartToken)
+	return response, nil
 }
 
-func (s *AuthService) RefreshToken(req *dto.RefreshTokenRequest) (*dto.AuthResponse, error) {
-	claims, err := utils.ValidateToken(req.RefreshToken, s.config.JWT.SecretKey)
+// VerifyTwoFactorLogin completes a login that passed the password with an
+// authenticator or recovery code. Wrong codes count as failed logins of the
+// account, and the challenge can be answered correctly once.
+func (s *AuthService) VerifyTwoFactorLogin(req *dto.TwoFactorLoginRequest, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	invalid := errors.New("invalid or expired two-factor challenge")
+
+	hash, err := utils.ParseUserToken(req.ChallengeToken, string(models.UserTokenTwoFactorLogin), s.config.JWT.SecretKey)
 	if err != nil {
-		return nil, errors.New("invalid refresh token")
+		return nil, invalid
 	}
 
-	// var refreshToken models.RefreshToken
-	refreshToken, err := s.userRepo.GetValidRefreshToken(req.RefreshToken)
+	now := time.Now()
+	token, err := s.tokenRepo.GetUsable(models.UserTokenTwoFactorLogin, hash, now)
 	if err != nil {
+		return nil, invalid
+	}
+
+	user := &token.User
+	if user.ID == 0 || !user.IsActive || user.Email != token.Email || !user.TwoFactorEnabled() {
+		return nil, invalid
+	}
+
+	if err := s.checkLoginThrottle(loginThrottleKeys(user.Email, client), now); err != nil {
+		return nil, err
+	}
+
+	ok, err := verifySecondFactor(s.userRepo, s.recoveryRepo, user, req.Code, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		s.recordLoginFailure(user.Email, user, client, now)
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	used, err := s.tokenRepo.MarkUsed(token.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, invalid
+	}
+
+	if err := s.loginAttempts.Reset(accountThrottleKey(user.Email)); err != nil {
+		log.Println("Failed to reset login attempts:", err)
+	}
+
+	response, err := s.generateAuthResponse(user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	response.CartMerge = s.mergeGuestCart(user.ID, req.CartToken)
+	return response, nil
+}
+
+// RefreshToken rotates the presented refresh token. A token that was rotated
+// out before is treated as stolen: its family is revoked, so neither the thief
+// nor the legitimate client can keep using that sign in.
+func (s *AuthService) RefreshToken(req *dto.RefreshTokenRequest, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	claims, err := utils.ValidateToken(req.RefreshToken, &s.config.JWT, utils.TokenTypeRefresh)
+	if err != nil {
+		return nil, errors.New("invalid refresh token")
+	}
+
+	refreshToken, err := s.userRepo.GetRefreshToken(utils.HashToken(req.RefreshToken))
+	if err != nil || refreshToken.UserID != claims.UserID || refreshToken.FamilyID != claims.SessionID {
+		return nil, errors.New("refresh token not found or expired")
+	}
+
+	now := time.Now()
+	if refreshToken.RotatedAt != nil {
+		s.revokeReusedFamily(refreshToken, now)
+		return nil, ErrRefreshTokenReused
+	}
+
+	if !refreshToken.ExpiredAt.After(now) {
 		return nil, errors.New("refresh token not found or expired")
 	}
 
@@ -93,58 +233,345 @@ func (s *AuthService) RefreshToken(req *dto.RefreshTokenRequest) (*dto.AuthRespo
 		return nil, errors.New("user not found")
 	}
 
-	if err := s.userRepo.DeleteRefreshTokenByID(refreshToken.ID); err != nil {
-		log.Println(err)
-		_ = err
+	tokens, err := s.newTokenPair(user, refreshToken.FamilyID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	// The successor is stored in the transaction that rotates the presented
+	// token out, so a failure leaves the presented token valid for a retry
+	rotated, err := s.userRepo.RotateRefreshToken(refreshToken.ID, &tokens.stored, now)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		s.revokeReusedFamily(refreshToken, now)
+		return nil, ErrRefreshTokenReused
 	}
 
-	return s.generateAuthResponse(user)
+	return s.signedIn(user, tokens), nil
 }
 
+// Logout ends the sign in the refresh token belongs to. Unknown tokens are
+// ignored, the session is gone either way.
 func (s *AuthService) Logout(refreshToken string) error {
-	return s.userRepo.DeleteRefreshToken(refreshToken)
+	token, err := s.userRepo.GetRefreshToken(utils.HashToken(refreshToken))
+	if err != nil {
+		return nil
+	}
+
+	return s.userRepo.RevokeRefreshTokenFamily(token.FamilyID)
 }
 
-func (s *AuthService) generateAuthResponse(user *models.User) (*dto.AuthResponse, error) {
-	accessToken, refreshToken, err := utils.GenerateToken(
-		&s.config.JWT,
-		user.ID,
-		user.Email,
-		string(user.Role),
-	)
+// VerifyEmail redeems a verification token and marks the address it was sent
+// to as verified
+func (s *AuthService) VerifyEmail(req *dto.VerifyEmailRequest) (*dto.UserResponse, error) {
+	invalid := errors.New("invalid or expired verification token")
+
+	hash, err := utils.ParseUserToken(req.Token, string(models.UserTokenEmailVerification), s.config.JWT.SecretKey)
 	if err != nil {
-		return nil, err
+		return nil, invalid
 	}
 
-	refreshTokenModel := models.RefreshToken{
-		UserID:    user.ID,
-		Token:     refreshToken,
-		ExpiredAt: time.Now().Add(s.config.JWT.RefreshTokenExpires),
+	now := time.Now()
+	token, err := s.tokenRepo.GetUsable(models.UserTokenEmailVerification, hash, now)
+	if err != nil {
+		return nil, invalid
+	}
+
+	// The link only proves ownership of the address it was sent to
+	user := &token.User
+	if user.ID == 0 || user.Email != token.Email {
+		return nil, invalid
 	}
 
-	if err := s.userRepo.CreateRefreshToken(&refreshTokenModel); err != nil {
+	used, err := s.tokenRepo.MarkUsed(token.ID, now)
+	if err != nil {
 		return nil, err
 	}
+	if !used {
+		return nil, invalid
+	}
+
+	if !user.IsEmailVerified() {
+		user.EmailVerifiedAt = &now
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, err
+		}
+	}
+
+	response := toUserResponse(user)
+	return &response, nil
+}
+
+// ResendVerification emails a new verification link. It reports success for
+// unknown and already verified addresses alike so it cannot be used to probe
+// for accounts, and sends at most one link per resend interval.
+func (s *AuthService) ResendVerification(req *dto.ResendVerificationRequest) error {
+	user, err := s.userRepo.GetByEmailAndActive(req.Email, true)
+	if err != nil || user.IsEmailVerified() {
+		return nil
+	}
+
+	latest, err := s.tokenRepo.GetLatest(user.ID, models.UserTokenEmailVerification)
+	if err == nil && time.Since(latest.CreatedAt) < s.config.Auth.VerificationResendInterval {
+		return nil
+	}
 
-	err = s.eventPublisher.Publish("USER_LOGGED_IN", user, nil)
+	return s.sendEmailVerification(user, notifications.EmailVerificationRequested)
+}
+
+// ForgotPassword emails a password reset link, at most one per reset
+// interval. It returns before the address is even looked up, so neither the
+// outcome nor the time taken tells whether it belongs to an account; failures
+// are only logged.
+func (s *AuthService) ForgotPassword(req *dto.ForgotPasswordRequest) {
+	email := req.Email
+	go func() {
+		if err := s.sendPasswordReset(email); err != nil {
+			fmt.Println("Failed to send password reset:", err)
+		}
+	}()
+}
+
+// sendPasswordReset issues the reset token and publishes the email. Unknown
+// addresses and repeated requests are ignored.
+func (s *AuthService) sendPasswordReset(email string) error {
+	user, err := s.userRepo.GetByEmailAndActive(email, true)
 	if err != nil {
-		fmt.Println("Failed to publish USER_LOGGED_IN event:", err)
+		return nil
+	}
+
+	latest, err := s.tokenRepo.GetLatest(user.ID, models.UserTokenPasswordReset)
+	if err == nil && time.Since(latest.CreatedAt) < s.config.Auth.PasswordResetInterval {
+		return nil
+	}
+
+	token, userToken, err := issueUserToken(s.tokenRepo, s.config.JWT.SecretKey, user, user.Email, models.UserTokenPasswordReset, s.config.Auth.PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	payload := notifications.PasswordResetPayload{
+		Email:     user.Email,
+		Name:      displayName(user),
+		ResetURL:  frontendLink(s.config.Server.FrontendURL, "/reset-password", token),
+		ExpiresAt: userToken.ExpiresAt,
+	}
+	if err := s.eventPublisher.Publish(notifications.PasswordResetRequested, payload, nil); err != nil {
+		fmt.Println("Failed to publish PASSWORD_RESET_REQUESTED event:", err)
+	}
+
+	return nil
+}
+
+// ResetPassword redeems a reset token and sets the new password. Every other
+// outstanding reset link and every session of the user stop working.
+func (s *AuthService) ResetPassword(req *dto.ResetPasswordRequest) error {
+	invalid := errors.New("invalid or expired password reset token")
+
+	hash, err := utils.ParseUserToken(req.Token, string(models.UserTokenPasswordReset), s.config.JWT.SecretKey)
+	if err != nil {
+		return invalid
+	}
+
+	now := time.Now()
+	token, err := s.tokenRepo.GetUsable(models.UserTokenPasswordReset, hash, now)
+	if err != nil {
+		return invalid
+	}
+
+	user := &token.User
+	if user.ID == 0 || !user.IsActive || user.Email != token.Email {
+		return invalid
+	}
+
+	used, err := s.tokenRepo.MarkUsed(token.ID, now)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return invalid
+	}
+
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return err
+	}
+
+	user.Password = hashedPassword
+	// Following the emailed link proves the address as well
+	if !user.IsEmailVerified() {
+		user.EmailVerifiedAt = &now
+	}
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.InvalidateAll(user.ID, models.UserTokenPasswordReset, now); err != nil {
+		log.Println("Failed to invalidate password reset tokens:", err)
+	}
+
+	// Whoever guessed at the old password has nothing left to guess
+	if err := s.loginAttempts.Reset(accountThrottleKey(user.Email)); err != nil {
+		log.Println("Failed to reset login attempts:", err)
+	}
+
+	return s.userRepo.DeleteRefreshTokensByUserID(user.ID)
+}
+
+// UnlockAccount lifts the login lockout and backoff of the user's account
+func (s *AuthService) UnlockAccount(userID uint) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	return s.loginAttempts.Reset(accountThrottleKey(user.Email))
+}
+
+// sendEmailVerification issues a verification token for the user's current
+// address and publishes the event that emails the link
+func (s *AuthService) sendEmailVerification(user *models.User, eventType string) error {
+	token, userToken, err := issueUserToken(s.tokenRepo, s.config.JWT.SecretKey, user, user.Email, models.UserTokenEmailVerification, s.config.Auth.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	payload := notifications.EmailVerificationPayload{
+		Email:           user.Email,
+		Name:            displayName(user),
+		VerificationURL: frontendLink(s.config.Server.FrontendURL, "/verify-email", token),
+		ExpiresAt:       userToken.ExpiresAt,
+	}
+	if err := s.eventPublisher.Publish(eventType, payload, nil); err != nil {
+		fmt.Println("Failed to publish "+eventType+" event:", err)
+	}
+
+	return nil
+}
+
+// issueTwoFactorChallenge answers a correct password of an account with
+// two-factor authentication. The challenge stands in for the password in
+// VerifyTwoFactorLogin, so it is stored and expires like emailed tokens.
+func (s *AuthService) issueTwoFactorChallenge(user *models.User) (*dto.AuthResponse, error) {
+	token, userToken, err := issueUserToken(s.tokenRepo, s.config.JWT.SecretKey, user, user.Email, models.UserTokenTwoFactorLogin, s.config.Auth.TwoFactor.ChallengeTTL)
+	if err != nil {
+		return nil, err
 	}
 
 	return &dto.AuthResponse{
-		User: dto.UserResponse{
-			ID:        user.ID,
-			Email:     user.Email,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Phone:     user.Phone,
-			Role:      string(user.Role),
-			IsActive:  user.IsActive,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
+		User: toUserResponse(user),
+		TwoFactor: &dto.TwoFactorChallenge{
+			ChallengeToken: token,
+			ExpiresAt:      userToken.ExpiresAt,
 		},
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
 	}, nil
+}
+
+// mergeGuestCart folds the guest cart into the user's cart. A failed merge never
+// fails the login itself, the guest cart is simply left alone.
+func (s *AuthService) mergeGuestCart(userID uint, cartToken string) *dto.CartMergeResult {
+	if cartToken == "" {
+		return nil
+	}
+
+	result, err := s.cartService.MergeGuestCart(userID, cartToken)
+	if err != nil {
+		log.Println("Failed to merge guest cart:", err)
+		return nil
+	}
+	return result
+}
 
+// revokeReusedFamily signs out the family of a refresh token that was
+// presented after rotation and reports it for the owner to be alerted
+func (s *AuthService) revokeReusedFamily(token *models.RefreshToken, now time.Time) {
+	if err := s.userRepo.RevokeRefreshTokenFamily(token.FamilyID); err != nil {
+		log.Println("Failed to revoke refresh token family:", err)
+	}
+
+	payload := notifications.TokenReusePayload{
+		UserID:     token.UserID,
+		FamilyID:   token.FamilyID,
+		DetectedAt: now,
+	}
+	if user, err := s.userRepo.GetByID(token.UserID); err == nil {
+		payload.Email = user.Email
+		payload.Name = displayName(user)
+	}
+
+	if err := s.eventPublisher.Publish(notifications.SecurityTokenReuse, payload, nil); err != nil {
+		fmt.Println("Failed to publish SECURITY_TOKEN_REUSE event:", err)
+	}
+}
+
+// generateAuthResponse signs the user in, starting a new session
+func (s *AuthService) generateAuthResponse(user *models.User, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	return s.issueTokens(user, uuid.NewString(), client)
+}
+
+// issueTokens issues the tokens of the session identified by familyID. The
+// device details are refreshed every time, a session may move between networks.
+func (s *AuthService) issueTokens(user *models.User, familyID string, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	tokens, err := s.newTokenPair(user, familyID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.CreateRefreshToken(&tokens.stored); err != nil {
+		return nil, err
+	}
+
+	return s.signedIn(user, tokens), nil
+}
+
+// tokenPair is a freshly signed access and refresh token, with the record to
+// store for the refresh token
+type tokenPair struct {
+	accessToken  string
+	refreshToken string
+	stored       models.RefreshToken
+}
+
+func (s *AuthService) newTokenPair(user *models.User, familyID string, client dto.ClientInfo) (*tokenPair, error) {
+	accessToken, refreshToken, err := utils.GenerateToken(&s.config.JWT, utils.TokenSubject{
+		UserID:      user.ID,
+		Email:       user.Email,
+		Roles:       user.RoleNames(),
+		Permissions: user.Permissions(),
+		SessionID:   familyID,
+		TwoFactor:   user.TwoFactorEnabled(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &tokenPair{
+		accessToken:  accessToken,
+		refreshToken: refreshToken,
+		stored: models.RefreshToken{
+			UserID:     user.ID,
+			TokenHash:  utils.HashToken(refreshToken),
+			FamilyID:   familyID,
+			UserAgent:  truncate(client.UserAgent, maxUserAgentLength),
+			IPAddress:  client.IPAddress,
+			LastUsedAt: now,
+			ExpiredAt:  now.Add(s.config.JWT.RefreshTokenExpires),
+		},
+	}, nil
+}
+
+// signedIn announces the sign in once its refresh token is stored
+func (s *AuthService) signedIn(user *models.User, tokens *tokenPair) *dto.AuthResponse {
+	if err := s.eventPublisher.Publish("USER_LOGGED_IN", user, nil); err != nil {
+		fmt.Println("Failed to publish USER_LOGGED_IN event:", err)
+	}
+
+	return &dto.AuthResponse{
+		User:         toUserResponse(user),
+		AccessToken:  tokens.accessToken,
+		RefreshToken: tokens.refreshToken,
+	}
 }
@@ -1,6 +1,11 @@
 package services
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
 	"errors"
 	"testing"
 	"time"
@@ -9,7 +14,9 @@ import (
 	"github.com/JihadRinaldi/go-shop/internal/dto"
 	"github.com/JihadRinaldi/go-shop/internal/mocks"
 	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/notifications"
 	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -17,6 +24,7 @@ import (
 
 func TestAuthService_Register(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+	mockTokenRepo := new(mocks.MockUserTokenRepositoryInterface)
 	mockCartRepo := new(mocks.MockCartRepositoryInterface)
 	mockPublisher := new(mocks.MockPublisher)
 
@@ -32,6 +40,7 @@ func TestAuthService_Register(t *testing.T) {
 		config:         cfg,
 		eventPublisher: mockPublisher,
 		userRepo:       mockUserRepo,
+		tokenRepo:      mockTokenRepo,
 		cartRepo:       mockCartRepo,
 	}
 
@@ -47,17 +56,24 @@ func TestAuthService_Register(t *testing.T) {
 		mockUserRepo.On("GetByEmail", req.Email).Return(nil, gorm.ErrRecordNotFound).Once()
 		mockUserRepo.On("Create", mock.AnythingOfType("*models.User")).Return(nil).Once()
 		mockCartRepo.On("Create", mock.AnythingOfType("*models.Cart")).Return(nil).Once()
+		mockTokenRepo.On("Create", mock.MatchedBy(func(token *models.UserToken) bool {
+			return token.Purpose == models.UserTokenEmailVerification && token.Email == req.Email && token.TokenHash != ""
+		})).Return(nil).Once()
+		mockPublisher.On("Publish", "USER_REGISTERED", mock.AnythingOfType("notifications.EmailVerificationPayload"), mock.Anything).Return(nil).Once()
 		mockUserRepo.On("CreateRefreshToken", mock.AnythingOfType("*models.RefreshToken")).Return(nil).Once()
 		mockPublisher.On("Publish", "USER_LOGGED_IN", mock.AnythingOfType("*models.User"), mock.Anything).Return(nil).Once()
 
-		result, err := service.Register(req)
+		result, err := service.Register(req, dto.ClientInfo{})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, req.Email, result.User.Email)
+		assert.False(t, result.User.EmailVerified)
 		assert.NotEmpty(t, result.AccessToken)
 		assert.NotEmpty(t, result.RefreshToken)
 		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
 		mockCartRepo.AssertExpectations(t)
 	})
 
@@ -77,13 +93,47 @@ func TestAuthService_Register(t *testing.T) {
 
 		mockUserRepo.On("GetByEmail", req.Email).Return(existingUser, nil).Once()
 
-		result, err := service.Register(req)
+		result, err := service.Register(req, dto.ClientInfo{})
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "email already in use")
 		mockUserRepo.AssertExpectations(t)
 	})
+
+	t.Run("no tokens until verified when login requires it", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepositoryInterface)
+		mockTokenRepo := new(mocks.MockUserTokenRepositoryInterface)
+		mockCartRepo := new(mocks.MockCartRepositoryInterface)
+		mockPublisher := new(mocks.MockPublisher)
+
+		service := &AuthService{
+			config: &config.Config{
+				JWT:  cfg.JWT,
+				Auth: config.AuthConfig{RequireVerifiedEmail: config.RequireVerifiedEmailForLogin},
+			},
+			eventPublisher: mockPublisher,
+			userRepo:       mockUserRepo,
+			tokenRepo:      mockTokenRepo,
+			cartRepo:       mockCartRepo,
+		}
+
+		req := &dto.RegisterRequest{Email: "strict@example.com", Password: "password123", FirstName: "Jane", LastName: "Doe"}
+
+		mockUserRepo.On("GetByEmail", req.Email).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockUserRepo.On("Create", mock.AnythingOfType("*models.User")).Return(nil).Once()
+		mockCartRepo.On("Create", mock.AnythingOfType("*models.Cart")).Return(nil).Once()
+		mockTokenRepo.On("Create", mock.AnythingOfType("*models.UserToken")).Return(nil).Once()
+		mockPublisher.On("Publish", "USER_REGISTERED", mock.AnythingOfType("notifications.EmailVerificationPayload"), mock.Anything).Return(nil).Once()
+
+		result, err := service.Register(req, dto.ClientInfo{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, req.Email, result.User.Email)
+		assert.Empty(t, result.AccessToken)
+		assert.Empty(t, result.RefreshToken)
+		mockUserRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything)
+	})
 }
 
 func TestAuthService_Login(t *testing.T) {
@@ -99,11 +149,14 @@ func TestAuthService_Login(t *testing.T) {
 		},
 	}
 
+	mockAttempts := allowLogins()
+
 	service := &AuthService{
 		config:         cfg,
 		eventPublisher: mockPublisher,
 		userRepo:       mockUserRepo,
 		cartRepo:       mockCartRepo,
+		loginAttempts:  mockAttempts,
 	}
 
 	t.Run("success", func(t *testing.T) {
@@ -120,14 +173,13 @@ func TestAuthService_Login(t *testing.T) {
 			Email:    req.Email,
 			Password: hashedPassword,
 			IsActive: true,
-			Role:     models.UserRoleCustomer,
 		}
 
 		mockUserRepo.On("GetByEmailAndActive", req.Email, true).Return(user, nil).Once()
 		mockUserRepo.On("CreateRefreshToken", mock.AnythingOfType("*models.RefreshToken")).Return(nil).Once()
 		mockPublisher.On("Publish", "USER_LOGGED_IN", mock.AnythingOfType("*models.User"), mock.Anything).Return(nil).Once()
 
-		result, err := service.Login(req)
+		result, err := service.Login(req, dto.ClientInfo{})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -145,12 +197,13 @@ func TestAuthService_Login(t *testing.T) {
 
 		mockUserRepo.On("GetByEmailAndActive", req.Email, true).Return(nil, gorm.ErrRecordNotFound).Once()
 
-		result, err := service.Login(req)
+		result, err := service.Login(req, dto.ClientInfo{})
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "invalid credentials")
 		mockUserRepo.AssertExpectations(t)
+		mockAttempts.AssertCalled(t, "RecordFailure", "account:nonexistent@example.com", mock.Anything, mock.Anything)
 	})
 
 	t.Run("invalid password", func(t *testing.T) {
@@ -171,15 +224,25 @@ func TestAuthService_Login(t *testing.T) {
 
 		mockUserRepo.On("GetByEmailAndActive", req.Email, true).Return(user, nil).Once()
 
-		result, err := service.Login(req)
+		result, err := service.Login(req, dto.ClientInfo{})
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "invalid credentials")
 		mockUserRepo.AssertExpectations(t)
+		mockAttempts.AssertCalled(t, "RecordFailure", "account:user@example.com", mock.Anything, mock.Anything)
 	})
 }
 
+// allowLogins returns a login attempt store that never throttles
+func allowLogins() *mocks.MockLoginAttemptStore {
+	store := new(mocks.MockLoginAttemptStore)
+	store.On("BlockedUntil", mock.Anything).Return(time.Time{}, nil)
+	store.On("RecordFailure", mock.Anything, mock.Anything, mock.Anything).Return(1, nil)
+	store.On("Reset", mock.Anything).Return(nil)
+	return store
+}
+
 func TestAuthService_Logout(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepositoryInterface)
 	mockCartRepo := new(mocks.MockCartRepositoryInterface)
@@ -197,7 +260,20 @@ func TestAuthService_Logout(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		refreshToken := "valid-refresh-token"
 
-		mockUserRepo.On("DeleteRefreshToken", refreshToken).Return(nil).Once()
+		mockUserRepo.On("GetRefreshToken", utils.HashToken(refreshToken)).
+			Return(&models.RefreshToken{ID: 1, UserID: 1, FamilyID: "family-1"}, nil).Once()
+		mockUserRepo.On("RevokeRefreshTokenFamily", "family-1").Return(nil).Once()
+
+		err := service.Logout(refreshToken)
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		refreshToken := "unknown-token"
+
+		mockUserRepo.On("GetRefreshToken", utils.HashToken(refreshToken)).Return(nil, gorm.ErrRecordNotFound).Once()
 
 		err := service.Logout(refreshToken)
 
@@ -208,7 +284,9 @@ func TestAuthService_Logout(t *testing.T) {
 	t.Run("delete fails", func(t *testing.T) {
 		refreshToken := "invalid-token"
 
-		mockUserRepo.On("DeleteRefreshToken", refreshToken).Return(errors.New("delete failed")).Once()
+		mockUserRepo.On("GetRefreshToken", utils.HashToken(refreshToken)).
+			Return(&models.RefreshToken{ID: 2, UserID: 1, FamilyID: "family-2"}, nil).Once()
+		mockUserRepo.On("RevokeRefreshTokenFamily", "family-2").Return(errors.New("delete failed")).Once()
 
 		err := service.Logout(refreshToken)
 
@@ -216,3 +294,1008 @@ func TestAuthService_Logout(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 }
+
+func TestAuthService_RefreshToken(t *testing.T) {
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			SecretKey:           "test-secret-key",
+			Issuer:              "go-shop",
+			Audience:            "go-shop-api",
+			ExpireIn:            15 * time.Minute,
+			RefreshTokenExpires: 7 * 24 * time.Hour,
+		},
+	}
+
+	newService := func() (*AuthService, *mocks.MockUserRepositoryInterface, *mocks.MockPublisher) {
+		mockUserRepo := new(mocks.MockUserRepositoryInterface)
+		mockPublisher := new(mocks.MockPublisher)
+		return &AuthService{
+			config:         cfg,
+			eventPublisher: mockPublisher,
+			userRepo:       mockUserRepo,
+		}, mockUserRepo, mockPublisher
+	}
+
+	user := &models.User{ID: 1, Email: "user@example.com", FirstName: "John", IsActive: true}
+	_, presented, _ := utils.GenerateToken(&cfg.JWT, utils.TokenSubject{UserID: user.ID, Email: user.Email, SessionID: "family-1"})
+	presentedHash := utils.HashToken(presented)
+
+	t.Run("rotates within the family", func(t *testing.T) {
+		service, mockUserRepo, mockPublisher := newService()
+		stored := &models.RefreshToken{ID: 10, UserID: 1, FamilyID: "family-1", ExpiredAt: time.Now().Add(time.Hour)}
+
+		mockUserRepo.On("GetRefreshToken", presentedHash).Return(stored, nil).Once()
+		mockUserRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+
+		var created *models.RefreshToken
+		mockUserRepo.On("RotateRefreshToken", uint(10), mock.AnythingOfType("*models.RefreshToken"), mock.AnythingOfType("time.Time")).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*models.RefreshToken) }).
+			Return(true, nil).Once()
+		mockPublisher.On("Publish", "USER_LOGGED_IN", mock.Anything, mock.Anything).Return(nil).Once()
+
+		client := dto.ClientInfo{UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}
+		result, err := service.RefreshToken(&dto.RefreshTokenRequest{RefreshToken: presented}, client)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.AccessToken)
+		assert.NotEqual(t, presented, result.RefreshToken)
+		assert.Equal(t, "family-1", created.FamilyID)
+		assert.Equal(t, client.UserAgent, created.UserAgent)
+		assert.Equal(t, client.IPAddress, created.IPAddress)
+
+		claims, err := utils.ValidateToken(result.AccessToken, &cfg.JWT, utils.TokenTypeAccess)
+		assert.NoError(t, err)
+		assert.Equal(t, "family-1", claims.SessionID)
+		assert.Equal(t, utils.HashToken(result.RefreshToken), created.TokenHash)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("reuse of a rotated token revokes the family", func(t *testing.T) {
+		service, mockUserRepo, mockPublisher := newService()
+		rotatedAt := time.Now().Add(-time.Minute)
+		stored := &models.RefreshToken{ID: 10, UserID: 1, FamilyID: "family-1", ExpiredAt: time.Now().Add(time.Hour), RotatedAt: &rotatedAt}
+
+		mockUserRepo.On("GetRefreshToken", presentedHash).Return(stored, nil).Once()
+		mockUserRepo.On("RevokeRefreshTokenFamily", "family-1").Return(nil).Once()
+		mockUserRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+		mockPublisher.On("Publish", "SECURITY_TOKEN_REUSE", mock.MatchedBy(func(payload notifications.TokenReusePayload) bool {
+			return payload.UserID == 1 && payload.FamilyID == "family-1" && payload.Email == user.Email
+		}), mock.Anything).Return(nil).Once()
+
+		result, err := service.RefreshToken(&dto.RefreshTokenRequest{RefreshToken: presented}, dto.ClientInfo{})
+
+		assert.ErrorIs(t, err, ErrRefreshTokenReused)
+		assert.Nil(t, result)
+		mockUserRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything)
+	})
+
+	t.Run("losing a concurrent rotation counts as reuse", func(t *testing.T) {
+		service, mockUserRepo, mockPublisher := newService()
+		stored := &models.RefreshToken{ID: 10, UserID: 1, FamilyID: "family-1", ExpiredAt: time.Now().Add(time.Hour)}
+
+		mockUserRepo.On("GetRefreshToken", presentedHash).Return(stored, nil).Once()
+		mockUserRepo.On("GetByID", uint(1)).Return(user, nil).Twice()
+		mockUserRepo.On("RotateRefreshToken", uint(10), mock.AnythingOfType("*models.RefreshToken"), mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+		mockUserRepo.On("RevokeRefreshTokenFamily", "family-1").Return(nil).Once()
+		mockPublisher.On("Publish", "SECURITY_TOKEN_REUSE", mock.AnythingOfType("notifications.TokenReusePayload"), mock.Anything).Return(nil).Once()
+
+		result, err := service.RefreshToken(&dto.RefreshTokenRequest{RefreshToken: presented}, dto.ClientInfo{})
+
+		assert.ErrorIs(t, err, ErrRefreshTokenReused)
+		assert.Nil(t, result)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed rotation is not reuse", func(t *testing.T) {
+		service, mockUserRepo, mockPublisher := newService()
+		stored := &models.RefreshToken{ID: 10, UserID: 1, FamilyID: "family-1", ExpiredAt: time.Now().Add(time.Hour)}
+
+		mockUserRepo.On("GetRefreshToken", presentedHash).Return(stored, nil).Once()
+		mockUserRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+		mockUserRepo.On("RotateRefreshToken", uint(10), mock.AnythingOfType("*models.RefreshToken"), mock.AnythingOfType("time.Time")).
+			Return(false, errors.New("connection reset")).Once()
+
+		result, err := service.RefreshToken(&dto.RefreshTokenRequest{RefreshToken: presented}, dto.ClientInfo{})
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrRefreshTokenReused)
+		assert.Nil(t, result)
+		mockUserRepo.AssertNotCalled(t, "RevokeRefreshTokenFamily", mock.Anything)
+		mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		service, mockUserRepo, _ := newService()
+		stored := &models.RefreshToken{ID: 10, UserID: 1, FamilyID: "family-1", ExpiredAt: time.Now().Add(-time.Hour)}
+
+		mockUserRepo.On("GetRefreshToken", presentedHash).Return(stored, nil).Once()
+
+		result, err := service.RefreshToken(&dto.RefreshTokenRequest{RefreshToken: presented}, dto.ClientInfo{})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockUserRepo.AssertNotCalled(t, "RotateRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("token of another session", func(t *testing.T) {
+		service, mockUserRepo, _ := newService()
+		stored := &models.RefreshToken{ID: 10, UserID: 1, FamilyID: "family-2", ExpiredAt: time.Now().Add(time.Hour)}
+
+		mockUserRepo.On("GetRefreshToken", presentedHash).Return(stored, nil).Once()
+
+		result, err := service.RefreshToken(&dto.RefreshTokenRequest{RefreshToken: presented}, dto.ClientInfo{})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockUserRepo.AssertNotCalled(t, "RotateRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("revoked family", func(t *testing.T) {
+		service, mockUserRepo, _ := newService()
+
+		mockUserRepo.On("GetRefreshToken", presentedHash).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := service.RefreshToken(&dto.RefreshTokenRequest{RefreshToken: presented}, dto.ClientInfo{})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+type fakeGuestCartMerger struct {
+	result *dto.CartMergeResult
+	err    error
+	userID uint
+	token  string
+}
+
+func (f *fakeGuestCartMerger) MergeGuestCart(userID uint, token string) (*dto.CartMergeResult, error) {
+	f.userID, f.token = userID, token
+	return f.result, f.err
+}
+
+func TestAuthService_Login_MergesGuestCart(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+	mockCartRepo := new(mocks.MockCartRepositoryInterface)
+	carts := &fakeGuestCartMerger{result: &dto.CartMergeResult{MergedItems: 1}}
+	mockPublisher := new(mocks.MockPublisher)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			SecretKey:           "test-secret-key",
+			ExpireIn:            15 * time.Minute,
+			RefreshTokenExpires: 7 * 24 * time.Hour,
+		},
+	}
+
+	service := &AuthService{
+		config:         cfg,
+		eventPublisher: mockPublisher,
+		userRepo:       mockUserRepo,
+		cartRepo:       mockCartRepo,
+		loginAttempts:  allowLogins(),
+		cartService:    carts,
+	}
+
+	password := "password123"
+	hashedPassword, _ := utils.HashPassword(password)
+	user := &models.User{ID: 1, Email: "user@example.com", Password: hashedPassword, IsActive: true}
+
+	t.Run("guest cart merged", func(t *testing.T) {
+		guestID := "guest-1"
+		userID := user.ID
+
+		mockUserRepo.On("GetByEmailAndActive", user.Email, true).Return(user, nil).Once()
+		mockUserRepo.On("CreateRefreshToken", mock.AnythingOfType("*models.RefreshToken")).Return(nil).Once()
+		mockPublisher.On("Publish", "USER_LOGGED_IN", mock.AnythingOfType("*models.User"), mock.Anything).Return(nil).Once()
+		token := utils.SignCartToken(guestID, cfg.JWT.SecretKey)
+
+		result, err := service.Login(&dto.LoginRequest{
+			Email:     user.Email,
+			Password:  password,
+			CartToken: token,
+		}, dto.ClientInfo{})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result.CartMerge)
+		assert.Equal(t, 1, result.CartMerge.MergedItems)
+		assert.Equal(t, userID, carts.userID)
+		assert.Equal(t, token, carts.token)
+	})
+
+	t.Run("invalid cart token does not block login", func(t *testing.T) {
+		carts.result, carts.err = nil, errors.New("invalid cart token")
+
+		mockUserRepo.On("GetByEmailAndActive", user.Email, true).Return(user, nil).Once()
+		mockUserRepo.On("CreateRefreshToken", mock.AnythingOfType("*models.RefreshToken")).Return(nil).Once()
+		mockPublisher.On("Publish", "USER_LOGGED_IN", mock.AnythingOfType("*models.User"), mock.Anything).Return(nil).Once()
+
+		result, err := service.Login(&dto.LoginRequest{
+			Email:     user.Email,
+			Password:  password,
+			CartToken: "forged.token",
+		}, dto.ClientInfo{})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.AccessToken)
+		assert.Nil(t, result.CartMerge)
+	})
+}
+
+func TestAuthService_Login_RequiresVerifiedEmail(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+
+	cfg := &config.Config{
+		JWT:  config.JWTConfig{SecretKey: "test-secret-key"},
+		Auth: config.AuthConfig{RequireVerifiedEmail: config.RequireVerifiedEmailForLogin},
+	}
+
+	service := &AuthService{
+		config:        cfg,
+		userRepo:      mockUserRepo,
+		loginAttempts: allowLogins(),
+	}
+
+	hashedPassword, _ := utils.HashPassword("password123")
+	user := &models.User{ID: 1, Email: "user@example.com", Password: hashedPassword, IsActive: true}
+
+	mockUserRepo.On("GetByEmailAndActive", user.Email, true).Return(user, nil).Once()
+
+	result, err := service.Login(&dto.LoginRequest{Email: user.Email, Password: "password123"}, dto.ClientInfo{})
+
+	assert.ErrorIs(t, err, ErrEmailNotVerified)
+	assert.Nil(t, result)
+}
+
+func TestAuthService_VerifyEmail(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+	mockTokenRepo := new(mocks.MockUserTokenRepositoryInterface)
+
+	cfg := &config.Config{JWT: config.JWTConfig{SecretKey: "test-secret-key"}}
+
+	service := &AuthService{
+		config:    cfg,
+		userRepo:  mockUserRepo,
+		tokenRepo: mockTokenRepo,
+	}
+
+	purpose := string(models.UserTokenEmailVerification)
+
+	t.Run("success", func(t *testing.T) {
+		token, hash, _ := utils.GenerateUserToken(purpose, cfg.JWT.SecretKey)
+		stored := &models.UserToken{ID: 3, UserID: 1, Email: "user@example.com", User: models.User{ID: 1, Email: "user@example.com"}}
+
+		mockTokenRepo.On("GetUsable", models.UserTokenEmailVerification, hash, mock.AnythingOfType("time.Time")).Return(stored, nil).Once()
+		mockTokenRepo.On("MarkUsed", uint(3), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		mockUserRepo.On("Update", mock.MatchedBy(func(user *models.User) bool {
+			return user.ID == 1 && user.EmailVerifiedAt != nil
+		})).Return(nil).Once()
+
+		result, err := service.VerifyEmail(&dto.VerifyEmailRequest{Token: token})
+
+		assert.NoError(t, err)
+		assert.True(t, result.EmailVerified)
+		mockTokenRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("token signed for another purpose", func(t *testing.T) {
+		token, _, _ := utils.GenerateUserToken("password_reset", cfg.JWT.SecretKey)
+
+		result, err := service.VerifyEmail(&dto.VerifyEmailRequest{Token: token})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("used or expired token", func(t *testing.T) {
+		token, hash, _ := utils.GenerateUserToken(purpose, cfg.JWT.SecretKey)
+
+		mockTokenRepo.On("GetUsable", models.UserTokenEmailVerification, hash, mock.AnythingOfType("time.Time")).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := service.VerifyEmail(&dto.VerifyEmailRequest{Token: token})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("address changed since the link was sent", func(t *testing.T) {
+		token, hash, _ := utils.GenerateUserToken(purpose, cfg.JWT.SecretKey)
+		stored := &models.UserToken{ID: 4, UserID: 1, Email: "old@example.com", User: models.User{ID: 1, Email: "new@example.com"}}
+
+		mockTokenRepo.On("GetUsable", models.UserTokenEmailVerification, hash, mock.AnythingOfType("time.Time")).Return(stored, nil).Once()
+
+		result, err := service.VerifyEmail(&dto.VerifyEmailRequest{Token: token})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockTokenRepo.AssertNotCalled(t, "MarkUsed", uint(4), mock.Anything)
+	})
+}
+
+func TestAuthService_ResendVerification(t *testing.T) {
+	cfg := &config.Config{
+		JWT:  config.JWTConfig{SecretKey: "test-secret-key"},
+		Auth: config.AuthConfig{EmailVerificationTTL: time.Hour, VerificationResendInterval: time.Minute},
+	}
+
+	newService := func() (*AuthService, *mocks.MockUserRepositoryInterface, *mocks.MockUserTokenRepositoryInterface, *mocks.MockPublisher) {
+		mockUserRepo := new(mocks.MockUserRepositoryInterface)
+		mockTokenRepo := new(mocks.MockUserTokenRepositoryInterface)
+		mockPublisher := new(mocks.MockPublisher)
+		return &AuthService{
+			config:         cfg,
+			eventPublisher: mockPublisher,
+			userRepo:       mockUserRepo,
+			tokenRepo:      mockTokenRepo,
+		}, mockUserRepo, mockTokenRepo, mockPublisher
+	}
+
+	user := &models.User{ID: 1, Email: "user@example.com", IsActive: true}
+
+	t.Run("sends a new link", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, mockPublisher := newService()
+
+		mockUserRepo.On("GetByEmailAndActive", user.Email, true).Return(user, nil).Once()
+		mockTokenRepo.On("GetLatest", uint(1), models.UserTokenEmailVerification).
+			Return(&models.UserToken{CreatedAt: time.Now().Add(-10 * time.Minute)}, nil).Once()
+		mockTokenRepo.On("Create", mock.AnythingOfType("*models.UserToken")).Return(nil).Once()
+		mockPublisher.On("Publish", "EMAIL_VERIFICATION_REQUESTED", mock.AnythingOfType("notifications.EmailVerificationPayload"), mock.Anything).Return(nil).Once()
+
+		err := service.ResendVerification(&dto.ResendVerificationRequest{Email: user.Email})
+
+		assert.NoError(t, err)
+		mockTokenRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, mockPublisher := newService()
+
+		mockUserRepo.On("GetByEmailAndActive", user.Email, true).Return(user, nil).Once()
+		mockTokenRepo.On("GetLatest", uint(1), models.UserTokenEmailVerification).
+			Return(&models.UserToken{CreatedAt: time.Now().Add(-10 * time.Second)}, nil).Once()
+
+		err := service.ResendVerification(&dto.ResendVerificationRequest{Email: user.Email})
+
+		assert.NoError(t, err)
+		mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+		mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown address looks the same", func(t *testing.T) {
+		service, mockUserRepo, _, mockPublisher := newService()
+
+		mockUserRepo.On("GetByEmailAndActive", "nobody@example.com", true).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		err := service.ResendVerification(&dto.ResendVerificationRequest{Email: "nobody@example.com"})
+
+		assert.NoError(t, err)
+		mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_ForgotPassword(t *testing.T) {
+	cfg := &config.Config{
+		JWT:  config.JWTConfig{SecretKey: "test-secret-key"},
+		Auth: config.AuthConfig{PasswordResetTTL: 30 * time.Minute, PasswordResetInterval: time.Minute},
+	}
+
+	newService := func() (*AuthService, *mocks.MockUserRepositoryInterface, *mocks.MockUserTokenRepositoryInterface, *mocks.MockPublisher) {
+		mockUserRepo := new(mocks.MockUserRepositoryInterface)
+		mockTokenRepo := new(mocks.MockUserTokenRepositoryInterface)
+		mockPublisher := new(mocks.MockPublisher)
+		return &AuthService{
+			config:         cfg,
+			eventPublisher: mockPublisher,
+			userRepo:       mockUserRepo,
+			tokenRepo:      mockTokenRepo,
+		}, mockUserRepo, mockTokenRepo, mockPublisher
+	}
+
+	user := &models.User{ID: 1, Email: "user@example.com", IsActive: true}
+
+	t.Run("sends a reset link", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, mockPublisher := newService()
+
+		mockUserRepo.On("GetByEmailAndActive", user.Email, true).Return(user, nil).Once()
+		mockTokenRepo.On("GetLatest", uint(1), models.UserTokenPasswordReset).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockTokenRepo.On("Create", mock.MatchedBy(func(token *models.UserToken) bool {
+			return token.Purpose == models.UserTokenPasswordReset && token.Email == user.Email && token.ExpiresAt.After(time.Now())
+		})).Return(nil).Once()
+		mockPublisher.On("Publish", "PASSWORD_RESET_REQUESTED", mock.AnythingOfType("notifications.PasswordResetPayload"), mock.Anything).Return(nil).Once()
+
+		err := service.sendPasswordReset(user.Email)
+
+		assert.NoError(t, err)
+		mockTokenRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, mockPublisher := newService()
+
+		mockUserRepo.On("GetByEmailAndActive", user.Email, true).Return(user, nil).Once()
+		mockTokenRepo.On("GetLatest", uint(1), models.UserTokenPasswordReset).
+			Return(&models.UserToken{CreatedAt: time.Now().Add(-10 * time.Second)}, nil).Once()
+
+		err := service.sendPasswordReset(user.Email)
+
+		assert.NoError(t, err)
+		mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+		mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown address looks the same", func(t *testing.T) {
+		service, mockUserRepo, _, mockPublisher := newService()
+
+		mockUserRepo.On("GetByEmailAndActive", "nobody@example.com", true).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		err := service.sendPasswordReset("nobody@example.com")
+
+		assert.NoError(t, err)
+		mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failures stay in the background", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, mockPublisher := newService()
+		created := make(chan struct{})
+
+		mockUserRepo.On("GetByEmailAndActive", user.Email, true).Return(user, nil).Once()
+		mockTokenRepo.On("GetLatest", uint(1), models.UserTokenPasswordReset).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockTokenRepo.On("Create", mock.Anything).Run(func(mock.Arguments) {
+			close(created)
+		}).Return(errors.New("connection refused")).Once()
+
+		service.ForgotPassword(&dto.ForgotPasswordRequest{Email: user.Email})
+
+		select {
+		case <-created:
+		case <-time.After(time.Second):
+			t.Fatal("reset token was never issued")
+		}
+		mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{SecretKey: "test-secret-key"}}
+
+	newService := func() (*AuthService, *mocks.MockUserRepositoryInterface, *mocks.MockUserTokenRepositoryInterface) {
+		mockUserRepo := new(mocks.MockUserRepositoryInterface)
+		mockTokenRepo := new(mocks.MockUserTokenRepositoryInterface)
+		return &AuthService{
+			config:        cfg,
+			userRepo:      mockUserRepo,
+			tokenRepo:     mockTokenRepo,
+			loginAttempts: allowLogins(),
+		}, mockUserRepo, mockTokenRepo
+	}
+
+	purpose := string(models.UserTokenPasswordReset)
+
+	t.Run("success revokes every session", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo := newService()
+		token, hash, _ := utils.GenerateUserToken(purpose, cfg.JWT.SecretKey)
+		stored := &models.UserToken{ID: 5, UserID: 1, Email: "user@example.com", User: models.User{ID: 1, Email: "user@example.com", IsActive: true}}
+
+		mockTokenRepo.On("GetUsable", models.UserTokenPasswordReset, hash, mock.AnythingOfType("time.Time")).Return(stored, nil).Once()
+		mockTokenRepo.On("MarkUsed", uint(5), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		mockUserRepo.On("Update", mock.MatchedBy(func(user *models.User) bool {
+			return utils.CheckPassword("new-password", user.Password)
+		})).Return(nil).Once()
+		mockTokenRepo.On("InvalidateAll", uint(1), models.UserTokenPasswordReset, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockUserRepo.On("DeleteRefreshTokensByUserID", uint(1)).Return(nil).Once()
+
+		err := service.ResetPassword(&dto.ResetPasswordRequest{Token: token, Password: "new-password"})
+
+		assert.NoError(t, err)
+		mockTokenRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("verification token cannot reset a password", func(t *testing.T) {
+		service, _, mockTokenRepo := newService()
+		token, _, _ := utils.GenerateUserToken(string(models.UserTokenEmailVerification), cfg.JWT.SecretKey)
+
+		err := service.ResetPassword(&dto.ResetPasswordRequest{Token: token, Password: "new-password"})
+
+		assert.Error(t, err)
+		mockTokenRepo.AssertNotCalled(t, "GetUsable", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("token already redeemed", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo := newService()
+		token, hash, _ := utils.GenerateUserToken(purpose, cfg.JWT.SecretKey)
+		stored := &models.UserToken{ID: 6, UserID: 1, Email: "user@example.com", User: models.User{ID: 1, Email: "user@example.com", IsActive: true}}
+
+		mockTokenRepo.On("GetUsable", models.UserTokenPasswordReset, hash, mock.AnythingOfType("time.Time")).Return(stored, nil).Once()
+		mockTokenRepo.On("MarkUsed", uint(6), mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+
+		err := service.ResetPassword(&dto.ResetPasswordRequest{Token: token, Password: "new-password"})
+
+		assert.Error(t, err)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "DeleteRefreshTokensByUserID", mock.Anything)
+	})
+}
+
+func TestAuthService_LoginThrottle(t *testing.T) {
+	cfg := &config.Config{
+		JWT: config.JWTConfig{SecretKey: "test-secret-key"},
+		Auth: config.AuthConfig{LoginThrottle: config.LoginThrottleConfig{
+			Window:           time.Hour,
+			FreeAttempts:     3,
+			IPFreeAttempts:   20,
+			BackoffBase:      time.Second,
+			BackoffMax:       5 * time.Minute,
+			LockoutThreshold: 10,
+			LockoutDuration:  30 * time.Minute,
+		}},
+	}
+
+	newService := func() (*AuthService, *mocks.MockUserRepositoryInterface, *mocks.MockLoginAttemptStore, *mocks.MockPublisher) {
+		mockUserRepo := new(mocks.MockUserRepositoryInterface)
+		mockAttempts := new(mocks.MockLoginAttemptStore)
+		mockPublisher := new(mocks.MockPublisher)
+		return &AuthService{
+			config:         cfg,
+			eventPublisher: mockPublisher,
+			userRepo:       mockUserRepo,
+			loginAttempts:  mockAttempts,
+		}, mockUserRepo, mockAttempts, mockPublisher
+	}
+
+	hashedPassword, _ := utils.HashPassword("password123")
+	user := &models.User{ID: 1, Email: "user@example.com", FirstName: "John", Password: hashedPassword, IsActive: true}
+	client := dto.ClientInfo{IPAddress: "203.0.113.7"}
+	wrong := &dto.LoginRequest{Email: "User@Example.com", Password: "guess"}
+
+	blockedFor := func(expected time.Duration) interface{} {
+		return mock.MatchedBy(func(until time.Time) bool {
+			return time.Until(until) > expected-5*time.Second && time.Until(until) <= expected
+		})
+	}
+
+	t.Run("blocked account is rejected before the password check", func(t *testing.T) {
+		service, mockUserRepo, mockAttempts, _ := newService()
+
+		mockAttempts.On("BlockedUntil", "account:user@example.com").Return(time.Now().Add(time.Minute), nil).Once()
+		mockAttempts.On("BlockedUntil", "ip:203.0.113.7").Return(time.Time{}, nil).Once()
+
+		result, err := service.Login(&dto.LoginRequest{Email: user.Email, Password: "password123"}, client)
+
+		var throttled *LoginThrottledError
+		assert.ErrorAs(t, err, &throttled)
+		assert.InDelta(t, time.Minute.Seconds(), throttled.RetryAfter.Seconds(), 5)
+		assert.Nil(t, result)
+		mockUserRepo.AssertNotCalled(t, "GetByEmailAndActive", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failures past the free attempts back off", func(t *testing.T) {
+		service, mockUserRepo, mockAttempts, mockPublisher := newService()
+
+		mockAttempts.On("BlockedUntil", mock.Anything).Return(time.Time{}, nil).Twice()
+		mockUserRepo.On("GetByEmailAndActive", wrong.Email, true).Return(user, nil).Once()
+		mockAttempts.On("RecordFailure", "account:user@example.com", mock.AnythingOfType("time.Time"), time.Hour).Return(5, nil).Once()
+		mockAttempts.On("Block", "account:user@example.com", blockedFor(2*time.Second)).Return(nil).Once()
+		mockAttempts.On("RecordFailure", "ip:203.0.113.7", mock.AnythingOfType("time.Time"), time.Hour).Return(5, nil).Once()
+
+		result, err := service.Login(wrong, client)
+
+		assert.EqualError(t, err, "invalid credentials")
+		assert.Nil(t, result)
+		mockAttempts.AssertExpectations(t)
+		mockAttempts.AssertNotCalled(t, "Block", "ip:203.0.113.7", mock.Anything)
+		mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reaching the threshold locks the account and emails the owner", func(t *testing.T) {
+		service, mockUserRepo, mockAttempts, mockPublisher := newService()
+
+		mockAttempts.On("BlockedUntil", mock.Anything).Return(time.Time{}, nil).Twice()
+		mockUserRepo.On("GetByEmailAndActive", wrong.Email, true).Return(user, nil).Once()
+		mockAttempts.On("RecordFailure", "account:user@example.com", mock.AnythingOfType("time.Time"), time.Hour).Return(10, nil).Once()
+		mockAttempts.On("Block", "account:user@example.com", blockedFor(30*time.Minute)).Return(nil).Once()
+		mockAttempts.On("RecordFailure", "ip:203.0.113.7", mock.AnythingOfType("time.Time"), time.Hour).Return(10, nil).Once()
+		mockPublisher.On("Publish", "USER_LOCKED_OUT", mock.MatchedBy(func(payload notifications.UserLockedOutPayload) bool {
+			return payload.UserID == 1 && payload.Email == user.Email && payload.IPAddress == client.IPAddress
+		}), mock.Anything).Return(nil).Once()
+
+		_, err := service.Login(wrong, client)
+
+		assert.Error(t, err)
+		mockAttempts.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("unknown address is throttled without an email", func(t *testing.T) {
+		service, mockUserRepo, mockAttempts, mockPublisher := newService()
+		req := &dto.LoginRequest{Email: "nobody@example.com", Password: "guess"}
+
+		mockAttempts.On("BlockedUntil", mock.Anything).Return(time.Time{}, nil).Once()
+		mockUserRepo.On("GetByEmailAndActive", req.Email, true).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockAttempts.On("RecordFailure", "account:nobody@example.com", mock.AnythingOfType("time.Time"), time.Hour).Return(10, nil).Once()
+		mockAttempts.On("Block", "account:nobody@example.com", blockedFor(30*time.Minute)).Return(nil).Once()
+
+		_, err := service.Login(req, dto.ClientInfo{})
+
+		assert.Error(t, err)
+		mockAttempts.AssertExpectations(t)
+		mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestLoginBackoff(t *testing.T) {
+	throttle := config.LoginThrottleConfig{BackoffBase: time.Second, BackoffMax: time.Minute}
+
+	tests := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{failures: 1, expected: 0},
+		{failures: 3, expected: 0},
+		{failures: 4, expected: time.Second},
+		{failures: 5, expected: 2 * time.Second},
+		{failures: 8, expected: 16 * time.Second},
+		{failures: 30, expected: time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, loginBackoff(tt.failures, 3, throttle), "failures: %d", tt.failures)
+	}
+}
+
+func TestAuthService_UnlockAccount(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+	mockAttempts := new(mocks.MockLoginAttemptStore)
+
+	service := &AuthService{
+		config:        &config.Config{},
+		userRepo:      mockUserRepo,
+		loginAttempts: mockAttempts,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1, Email: "User@Example.com"}, nil).Once()
+		mockAttempts.On("Reset", "account:user@example.com").Return(nil).Once()
+
+		err := service.UnlockAccount(1)
+
+		assert.NoError(t, err)
+		mockAttempts.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockUserRepo.On("GetByID", uint(99)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		err := service.UnlockAccount(99)
+
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func TestAuthService_TwoFactorLogin(t *testing.T) {
+	cfg := &config.Config{
+		JWT:  config.JWTConfig{SecretKey: "test-secret-key", Issuer: "go-shop", Audience: "go-shop-api", ExpireIn: time.Hour, RefreshTokenExpires: 24 * time.Hour},
+		Auth: config.AuthConfig{TwoFactor: config.TwoFactorConfig{ChallengeTTL: 5 * time.Minute}},
+	}
+
+	secret, _ := utils.GenerateTOTPSecret()
+	hashedPassword, _ := utils.HashPassword("password123")
+	newUser := func() *models.User {
+		enabledAt := time.Now()
+		return &models.User{ID: 1, Email: "user@example.com", Password: hashedPassword, IsActive: true, TOTPSecret: secret, TOTPEnabledAt: &enabledAt}
+	}
+
+	newService := func() (*AuthService, *mocks.MockUserRepositoryInterface, *mocks.MockUserTokenRepositoryInterface, *mocks.MockRecoveryCodeRepositoryInterface, *mocks.MockLoginAttemptStore) {
+		mockUserRepo := new(mocks.MockUserRepositoryInterface)
+		mockTokenRepo := new(mocks.MockUserTokenRepositoryInterface)
+		mockRecoveryRepo := new(mocks.MockRecoveryCodeRepositoryInterface)
+		mockAttempts := new(mocks.MockLoginAttemptStore)
+		mockPublisher := new(mocks.MockPublisher)
+		mockPublisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockAttempts.On("BlockedUntil", mock.Anything).Return(time.Time{}, nil)
+		return &AuthService{
+			config:         cfg,
+			eventPublisher: mockPublisher,
+			userRepo:       mockUserRepo,
+			tokenRepo:      mockTokenRepo,
+			recoveryRepo:   mockRecoveryRepo,
+			loginAttempts:  mockAttempts,
+		}, mockUserRepo, mockTokenRepo, mockRecoveryRepo, mockAttempts
+	}
+
+	challenge := func(user *models.User) (string, *models.UserToken) {
+		token, hash, _ := utils.GenerateUserToken(string(models.UserTokenTwoFactorLogin), cfg.JWT.SecretKey)
+		return token, &models.UserToken{
+			ID:        7,
+			UserID:    user.ID,
+			Purpose:   models.UserTokenTwoFactorLogin,
+			TokenHash: hash,
+			Email:     user.Email,
+			ExpiresAt: time.Now().Add(5 * time.Minute),
+			User:      *user,
+		}
+	}
+
+	t.Run("password answers with a challenge", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, _, mockAttempts := newService()
+		req := &dto.LoginRequest{Email: "user@example.com", Password: "password123"}
+
+		mockUserRepo.On("GetByEmailAndActive", req.Email, true).Return(newUser(), nil).Once()
+		mockTokenRepo.On("Create", mock.MatchedBy(func(token *models.UserToken) bool {
+			return token.Purpose == models.UserTokenTwoFactorLogin && token.UserID == 1
+		})).Return(nil).Once()
+
+		result, err := service.Login(req, dto.ClientInfo{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.AccessToken)
+		assert.Empty(t, result.RefreshToken)
+		assert.NotEmpty(t, result.TwoFactor.ChallengeToken)
+		assert.WithinDuration(t, time.Now().Add(5*time.Minute), result.TwoFactor.ExpiresAt, 5*time.Second)
+		mockUserRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything)
+		mockAttempts.AssertNotCalled(t, "Reset", mock.Anything)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("authenticator code signs in", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, _, mockAttempts := newService()
+		user := newUser()
+		token, userToken := challenge(user)
+		code, _ := utils.TOTPCode(secret, time.Now())
+
+		mockTokenRepo.On("GetUsable", models.UserTokenTwoFactorLogin, userToken.TokenHash, mock.AnythingOfType("time.Time")).Return(userToken, nil).Once()
+		mockUserRepo.On("AdvanceTOTPStep", uint(1), mock.AnythingOfType("int64")).Return(true, nil).Once()
+		mockTokenRepo.On("MarkUsed", uint(7), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		mockAttempts.On("Reset", "account:user@example.com").Return(nil).Once()
+		mockUserRepo.On("CreateRefreshToken", mock.AnythingOfType("*models.RefreshToken")).Return(nil).Once()
+
+		result, err := service.VerifyTwoFactorLogin(&dto.TwoFactorLoginRequest{ChallengeToken: token, Code: code}, dto.ClientInfo{})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.AccessToken)
+		assert.Nil(t, result.TwoFactor)
+		claims, err := utils.ValidateToken(result.AccessToken, &cfg.JWT, utils.TokenTypeAccess)
+		assert.NoError(t, err)
+		assert.True(t, claims.TwoFactor)
+		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+		mockAttempts.AssertExpectations(t)
+	})
+
+	t.Run("recovery code signs in", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, mockRecoveryRepo, mockAttempts := newService()
+		user := newUser()
+		token, userToken := challenge(user)
+
+		mockTokenRepo.On("GetUsable", models.UserTokenTwoFactorLogin, userToken.TokenHash, mock.AnythingOfType("time.Time")).Return(userToken, nil).Once()
+		mockRecoveryRepo.On("Use", uint(1), utils.HashRecoveryCode("abcd-efgh"), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		mockTokenRepo.On("MarkUsed", uint(7), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		mockAttempts.On("Reset", "account:user@example.com").Return(nil).Once()
+		mockUserRepo.On("CreateRefreshToken", mock.AnythingOfType("*models.RefreshToken")).Return(nil).Once()
+
+		result, err := service.VerifyTwoFactorLogin(&dto.TwoFactorLoginRequest{ChallengeToken: token, Code: "abcd-efgh"}, dto.ClientInfo{})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.AccessToken)
+		mockRecoveryRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong code counts as a failed login", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, _, mockAttempts := newService()
+		user := newUser()
+		token, userToken := challenge(user)
+
+		mockTokenRepo.On("GetUsable", models.UserTokenTwoFactorLogin, userToken.TokenHash, mock.AnythingOfType("time.Time")).Return(userToken, nil).Once()
+		mockAttempts.On("RecordFailure", "account:user@example.com", mock.Anything, mock.Anything).Return(1, nil).Once()
+
+		result, err := service.VerifyTwoFactorLogin(&dto.TwoFactorLoginRequest{ChallengeToken: token, Code: "000000"}, dto.ClientInfo{})
+
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+		assert.Nil(t, result)
+		mockAttempts.AssertExpectations(t)
+		mockTokenRepo.AssertNotCalled(t, "MarkUsed", mock.Anything, mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything)
+	})
+
+	t.Run("challenge of another purpose is rejected", func(t *testing.T) {
+		service, _, mockTokenRepo, _, _ := newService()
+		token, _, _ := utils.GenerateUserToken(string(models.UserTokenPasswordReset), cfg.JWT.SecretKey)
+
+		result, err := service.VerifyTwoFactorLogin(&dto.TwoFactorLoginRequest{ChallengeToken: token, Code: "123456"}, dto.ClientInfo{})
+
+		assert.EqualError(t, err, "invalid or expired two-factor challenge")
+		assert.Nil(t, result)
+		mockTokenRepo.AssertNotCalled(t, "GetUsable", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_RefreshToken_SigningKeyRotation(t *testing.T) {
+	_, oldKey, _ := ed25519.GenerateKey(rand.Reader)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	jwtConfig := config.JWTConfig{SecretKey: "test-secret-key", Issuer: "go-shop", Audience: "go-shop-api", ExpireIn: 15 * time.Minute, RefreshTokenExpires: time.Hour}
+	before := jwtConfig
+	before.SigningKey = oldKey
+	during := jwtConfig
+	during.SigningKey = newKey
+	during.VerificationKeys = []crypto.PublicKey{oldKey.Public()}
+	after := jwtConfig
+	after.SigningKey = newKey
+
+	user := &models.User{ID: 1, Email: "user@example.com", IsActive: true}
+	_, presented, err := utils.GenerateToken(&before, utils.TokenSubject{UserID: user.ID, Email: user.Email, SessionID: "family-1"})
+	assert.NoError(t, err)
+
+	header, _, _ := jwt.NewParser().ParseUnverified(presented, &utils.Claims{})
+	assert.Equal(t, "EdDSA", header.Method.Alg())
+	assert.Equal(t, utils.KeyID(oldKey.Public()), header.Header["kid"])
+
+	t.Run("tokens of the previous key keep working", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepositoryInterface)
+		mockPublisher := new(mocks.MockPublisher)
+		service := &AuthService{config: &config.Config{JWT: during}, eventPublisher: mockPublisher, userRepo: mockUserRepo}
+
+		stored := &models.RefreshToken{ID: 10, UserID: 1, FamilyID: "family-1", ExpiredAt: time.Now().Add(time.Hour)}
+		mockUserRepo.On("GetRefreshToken", utils.HashToken(presented)).Return(stored, nil).Once()
+		mockUserRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+		mockUserRepo.On("RotateRefreshToken", uint(10), mock.AnythingOfType("*models.RefreshToken"), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		mockPublisher.On("Publish", "USER_LOGGED_IN", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := service.RefreshToken(&dto.RefreshTokenRequest{RefreshToken: presented}, dto.ClientInfo{})
+
+		assert.NoError(t, err)
+		header, _, _ := jwt.NewParser().ParseUnverified(result.AccessToken, &utils.Claims{})
+		assert.Equal(t, "RS256", header.Method.Alg())
+		assert.Equal(t, utils.KeyID(&newKey.PublicKey), header.Header["kid"])
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("tokens of a retired key are rejected", func(t *testing.T) {
+		_, err := utils.ValidateToken(presented, &after, utils.TokenTypeRefresh)
+		assert.Error(t, err)
+	})
+
+	t.Run("secret signed tokens are rejected once a key signs", func(t *testing.T) {
+		_, legacy, _ := utils.GenerateToken(&jwtConfig, utils.TokenSubject{UserID: user.ID, Email: user.Email, SessionID: "family-1"})
+
+		_, err := utils.ValidateToken(legacy, &after, utils.TokenTypeRefresh)
+		assert.Error(t, err)
+
+		accepting := after
+		accepting.AcceptLegacyTokens = true
+		claims, err := utils.ValidateToken(legacy, &accepting, utils.TokenTypeRefresh)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, claims.UserID)
+	})
+
+	t.Run("the algorithm of the key wins over the header", func(t *testing.T) {
+		// An HS256 token keyed with the public key bytes must not pass as EdDSA
+		forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &utils.Claims{UserID: 1})
+		forged.Header["kid"] = utils.KeyID(oldKey.Public())
+		signed, _ := forged.SignedString([]byte(oldKey.Public().(ed25519.PublicKey)))
+
+		_, err := utils.ValidateToken(signed, &during, utils.TokenTypeAccess)
+		assert.Error(t, err)
+	})
+
+	t.Run("JWKS lists every verification key", func(t *testing.T) {
+		set := utils.JWKS(&during)
+
+		assert.Len(t, set.Keys, 2)
+		assert.Equal(t, "RS256", set.Keys[0].Alg)
+		assert.Equal(t, utils.KeyID(&newKey.PublicKey), set.Keys[0].Kid)
+		assert.Equal(t, "EdDSA", set.Keys[1].Alg)
+		assert.Equal(t, "Ed25519", set.Keys[1].Crv)
+		assert.Empty(t, utils.JWKS(&jwtConfig).Keys, "the shared secret is never published")
+	})
+
+	t.Run("key IDs are RFC 7638 thumbprints", func(t *testing.T) {
+		// Example key of RFC 8037, appendix A.3
+		x, _ := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+		assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", utils.KeyID(ed25519.PublicKey(x)))
+	})
+}
+
+func TestAuthService_TokenTypes(t *testing.T) {
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			SecretKey:           "test-secret-key",
+			Issuer:              "go-shop",
+			Audience:            "go-shop-api",
+			ExpireIn:            15 * time.Minute,
+			RefreshTokenExpires: time.Hour,
+		},
+	}
+
+	accessToken, refreshToken, err := utils.GenerateToken(&cfg.JWT, utils.TokenSubject{UserID: 1, Email: "user@example.com", SessionID: "family-1"})
+	assert.NoError(t, err)
+
+	t.Run("each token carries its type, issuer, audience and ID", func(t *testing.T) {
+		access, err := utils.ValidateToken(accessToken, &cfg.JWT, utils.TokenTypeAccess)
+		assert.NoError(t, err)
+		refresh, err := utils.ValidateToken(refreshToken, &cfg.JWT, utils.TokenTypeRefresh)
+		assert.NoError(t, err)
+
+		assert.Equal(t, utils.TokenTypeAccess, access.TokenType)
+		assert.Equal(t, utils.TokenTypeRefresh, refresh.TokenType)
+		for _, claims := range []*utils.Claims{access, refresh} {
+			assert.Equal(t, "go-shop", claims.Issuer)
+			assert.Equal(t, jwt.ClaimStrings{"go-shop-api"}, claims.Audience)
+			assert.Equal(t, "1", claims.Subject)
+			assert.NotEmpty(t, claims.ID)
+		}
+		assert.NotEqual(t, access.ID, refresh.ID)
+	})
+
+	t.Run("only the access token carries roles and permissions", func(t *testing.T) {
+		staffAccess, staffRefresh, err := utils.GenerateToken(&cfg.JWT, utils.TokenSubject{
+			UserID:      2,
+			Email:       "staff@example.com",
+			Roles:       []string{"support"},
+			Permissions: []string{models.PermissionReviewsModerate, models.PermissionUsersUnlock},
+			SessionID:   "family-2",
+		})
+		assert.NoError(t, err)
+
+		access, err := utils.ValidateToken(staffAccess, &cfg.JWT, utils.TokenTypeAccess)
+		assert.NoError(t, err)
+		refresh, err := utils.ValidateToken(staffRefresh, &cfg.JWT, utils.TokenTypeRefresh)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{"support"}, access.Roles)
+		assert.Equal(t, []string{models.PermissionReviewsModerate, models.PermissionUsersUnlock}, access.Permissions)
+		assert.Empty(t, refresh.Roles)
+		assert.Empty(t, refresh.Permissions)
+	})
+
+	t.Run("a refresh token is not an access token", func(t *testing.T) {
+		_, err := utils.ValidateToken(refreshToken, &cfg.JWT, utils.TokenTypeAccess)
+		assert.Error(t, err)
+	})
+
+	t.Run("an access token cannot be refreshed", func(t *testing.T) {
+		mockUserRepo := new(mocks.MockUserRepositoryInterface)
+		service := &AuthService{config: cfg, userRepo: mockUserRepo}
+
+		result, err := service.RefreshToken(&dto.RefreshTokenRequest{RefreshToken: accessToken}, dto.ClientInfo{})
+
+		assert.EqualError(t, err, "invalid refresh token")
+		assert.Nil(t, result)
+		mockUserRepo.AssertNotCalled(t, "GetRefreshToken", mock.Anything)
+	})
+
+	t.Run("tokens for another audience or issuer are rejected", func(t *testing.T) {
+		otherAudience := cfg.JWT
+		otherAudience.Audience = "other-api"
+		_, err := utils.ValidateToken(accessToken, &otherAudience, utils.TokenTypeAccess)
+		assert.Error(t, err)
+
+		otherIssuer := cfg.JWT
+		otherIssuer.Issuer = "someone-else"
+		_, err = utils.ValidateToken(accessToken, &otherIssuer, utils.TokenTypeAccess)
+		assert.Error(t, err)
+	})
+
+	t.Run("tokens without a type are rejected", func(t *testing.T) {
+		untyped := jwt.NewWithClaims(jwt.SigningMethodHS256, &utils.Claims{
+			UserID: 1,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        "id-1",
+				Issuer:    "go-shop",
+				Subject:   "1",
+				Audience:  jwt.ClaimStrings{"go-shop-api"},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+				IssuedAt:  jwt.NewNumericDate(time.Now()),
+			},
+		})
+		signed, _ := untyped.SignedString([]byte(cfg.JWT.SecretKey))
+
+		_, err := utils.ValidateToken(signed, &cfg.JWT, utils.TokenTypeAccess)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/events"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/notifications"
+	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"gorm.io/gorm"
+)
+
+const cartReminderTokenBytes = 24
+
+type CartReminderService struct {
+	db             *gorm.DB
+	config         *config.Config
+	eventPublisher events.Publisher
+	reminderRepo   repositories.CartReminderRepositoryInterface
+	cartService    *CartService
+}
+
+func NewCartReminderService(db *gorm.DB, config *config.Config, eventPublisher events.Publisher, cartService *CartService) *CartReminderService {
+	return &CartReminderService{
+		db:             db,
+		config:         config,
+		eventPublisher: eventPublisher,
+		reminderRepo:   repositories.NewCartReminderRepository(db),
+		cartService:    cartService,
+	}
+}
+
+// SendAbandonedCartReminders publishes a CART_ABANDONED event for every cart
+// left untouched for the configured period. A user gets at most one reminder
+// per reminder window, and a cart is only reminded about once until it
+// changes again. It returns the number of reminders sent.
+func (s *CartReminderService) SendAbandonedCartReminders(now time.Time) (int, error) {
+	idleSince := now.Add(-s.config.Cart.AbandonedAfter)
+	remindedSince := now.Add(-s.config.Cart.ReminderWindow)
+
+	carts, err := s.reminderRepo.FindAbandonedCarts(idleSince, remindedSince, s.config.Cart.ReminderBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range carts {
+		if err := s.sendReminder(&carts[i], now); err != nil {
+			fmt.Println("Failed to send cart reminder:", err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// RestoreCart puts the lines of the reminded cart back into the user's cart and
+// returns the URL the customer should be sent to
+func (s *CartReminderService) RestoreCart(token string) (string, error) {
+	reminder, err := s.reminderRepo.GetByToken(token)
+	if err != nil {
+		return "", errors.New("cart reminder not found")
+	}
+
+	if err := s.cartService.RestoreItems(reminder.UserID, reminder.Items); err != nil {
+		return "", err
+	}
+
+	if reminder.RestoredAt == nil {
+		if err := s.reminderRepo.MarkRestored(reminder.ID, time.Now()); err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimRight(s.config.Server.FrontendURL, "/") + "/cart", nil
+}
+
+func (s *CartReminderService) sendReminder(cart *models.Cart, now time.Time) error {
+	if cart.User == nil || cart.UserID == nil {
+		return errors.New("cart has no owner")
+	}
+
+	token, err := utils.GenerateRandomToken(cartReminderTokenBytes)
+	if err != nil {
+		return err
+	}
+
+	reminder := models.CartReminder{
+		CartID: cart.ID,
+		UserID: *cart.UserID,
+		Token:  token,
+		Items:  make(models.CartSnapshot, len(cart.CartItems)),
+		SentAt: now,
+	}
+
+	payload := notifications.CartAbandonedPayload{
+		Email:      cart.User.Email,
+		Name:       strings.TrimSpace(cart.User.FirstName + " " + cart.User.LastName),
+		Items:      make([]notifications.CartAbandonedPayloadItem, len(cart.CartItems)),
+		RestoreURL: strings.TrimRight(s.config.Server.PublicURL, "/") + "/api/v1/carts/restore/" + token,
+	}
+
+	for i, item := range cart.CartItems {
+		reminder.Items[i] = models.CartSnapshotItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+		payload.Items[i] = notifications.CartAbandonedPayloadItem{
+			ProductName: item.Product.Name,
+			Quantity:    item.Quantity,
+			Price:       item.Product.Price,
+		}
+		payload.Total += float64(item.Quantity) * item.Product.Price
+	}
+
+	// The reminder is stored first so that it counts towards the throttle even
+	// if the worker runs again before the event is consumed
+	if err := s.reminderRepo.Create(&reminder); err != nil {
+		return err
+	}
+
+	if err := s.eventPublisher.Publish(notifications.CartAbandoned, payload, nil); err != nil {
+		if deleteErr := s.reminderRepo.Delete(reminder.ID); deleteErr != nil {
+			fmt.Println("Failed to delete unsent cart reminder:", deleteErr)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/mocks"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCartReminderService_SendAbandonedCartReminders(t *testing.T) {
+	mockReminderRepo := new(mocks.MockCartReminderRepositoryInterface)
+	mockPublisher := new(mocks.MockPublisher)
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			PublicURL:   "http://api.shop.test",
+			FrontendURL: "http://shop.test",
+		},
+		Cart: config.CartConfig{
+			AbandonedAfter:    24 * time.Hour,
+			ReminderWindow:    7 * 24 * time.Hour,
+			ReminderBatchSize: 50,
+		},
+	}
+
+	service := &CartReminderService{
+		db:             &gorm.DB{},
+		config:         cfg,
+		eventPublisher: mockPublisher,
+		reminderRepo:   mockReminderRepo,
+		cartService: &CartService{
+			db:          &gorm.DB{},
+			config:      cfg,
+			cartRepo:    new(mocks.MockCartRepositoryInterface),
+			productRepo: new(mocks.MockProductRepositoryInterface),
+		},
+	}
+
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	userID := uint(1)
+	abandonedCart := models.Cart{
+		ID:     3,
+		UserID: &userID,
+		User:   &models.User{ID: 1, Email: "jane@example.com", FirstName: "Jane", LastName: "Doe"},
+		CartItems: []models.CartItem{
+			{ProductID: 10, Quantity: 2, Product: models.Product{ID: 10, Name: "Mug", Price: 12.5}},
+			{ProductID: 11, Quantity: 1, Product: models.Product{ID: 11, Name: "Tea", Price: 5}},
+		},
+	}
+
+	t.Run("publishes reminder and stores snapshot", func(t *testing.T) {
+		mockReminderRepo.On("FindAbandonedCarts", now.Add(-24*time.Hour), now.Add(-7*24*time.Hour), 50).
+			Return([]models.Cart{abandonedCart}, nil).Once()
+
+		var token string
+		mockReminderRepo.On("Create", mock.MatchedBy(func(r *models.CartReminder) bool {
+			token = r.Token
+			return r.CartID == 3 && r.UserID == 1 && r.Token != "" && r.SentAt.Equal(now) &&
+				len(r.Items) == 2 && r.Items[0] == models.CartSnapshotItem{ProductID: 10, Quantity: 2}
+		})).Return(nil).Once()
+
+		mockPublisher.On("Publish", notifications.CartAbandoned, mock.MatchedBy(func(p notifications.CartAbandonedPayload) bool {
+			return p.Email == "jane@example.com" && p.Name == "Jane Doe" && p.Total == 30 &&
+				len(p.Items) == 2 && p.RestoreURL == "http://api.shop.test/api/v1/carts/restore/"+token
+		}), mock.Anything).Return(nil).Once()
+
+		sent, err := service.SendAbandonedCartReminders(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		mockReminderRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("publish failure removes reminder", func(t *testing.T) {
+		mockReminderRepo.On("FindAbandonedCarts", mock.Anything, mock.Anything, 50).
+			Return([]models.Cart{abandonedCart}, nil).Once()
+		mockReminderRepo.On("Create", mock.AnythingOfType("*models.CartReminder")).
+			Run(func(args mock.Arguments) {
+				args.Get(0).(*models.CartReminder).ID = 8
+			}).Return(nil).Once()
+		mockPublisher.On("Publish", notifications.CartAbandoned, mock.Anything, mock.Anything).
+			Return(errors.New("queue unavailable")).Once()
+		mockReminderRepo.On("Delete", uint(8)).Return(nil).Once()
+
+		sent, err := service.SendAbandonedCartReminders(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sent)
+		mockReminderRepo.AssertExpectations(t)
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		mockReminderRepo.On("FindAbandonedCarts", mock.Anything, mock.Anything, 50).
+			Return(nil, errors.New("database error")).Once()
+
+		sent, err := service.SendAbandonedCartReminders(now)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, sent)
+	})
+}
+
+func TestCartReminderService_RestoreCart(t *testing.T) {
+	mockReminderRepo := new(mocks.MockCartReminderRepositoryInterface)
+	mockCartRepo := new(mocks.MockCartRepositoryInterface)
+	mockProductRepo := new(mocks.MockProductRepositoryInterface)
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			PublicURL:   "http://api.shop.test",
+			FrontendURL: "http://shop.test",
+		},
+		Cart: config.CartConfig{
+			AbandonedAfter:    24 * time.Hour,
+			ReminderWindow:    7 * 24 * time.Hour,
+			ReminderBatchSize: 50,
+		},
+	}
+
+	service := &CartReminderService{
+		db:             &gorm.DB{},
+		config:         cfg,
+		eventPublisher: new(mocks.MockPublisher),
+		reminderRepo:   mockReminderRepo,
+		cartService: &CartService{
+			db:          &gorm.DB{},
+			config:      cfg,
+			cartRepo:    mockCartRepo,
+			productRepo: mockProductRepo,
+		},
+	}
+
+	t.Run("restores available items", func(t *testing.T) {
+		userID := uint(1)
+
+		mockReminderRepo.On("GetByToken", "token").Return(&models.CartReminder{
+			ID:     8,
+			UserID: 1,
+			Items: models.CartSnapshot{
+				{ProductID: 10, Quantity: 2},
+				{ProductID: 11, Quantity: 5},
+				{ProductID: 12, Quantity: 1},
+				{ProductID: 13, Quantity: 1},
+			},
+		}, nil).Once()
+		mockCartRepo.On("GetByUserID", uint(1)).Return(&models.Cart{ID: 3, UserID: &userID}, nil).Once()
+
+		// already in the cart with a lower quantity
+		mockProductRepo.On("GetByID", uint(10)).Return(&models.Product{ID: 10, Price: 12.5, Stock: 10, IsActive: true}, nil).Once()
+		mockCartRepo.On("GetItemByProduct", uint(3), uint(10)).Return(&models.CartItem{ID: 1, CartID: 3, ProductID: 10, Quantity: 1, UnitPrice: 10}, nil).Once()
+		mockCartRepo.On("UpdateItem", mock.MatchedBy(func(item *models.CartItem) bool {
+			return item.ID == 1 && item.Quantity == 2 && item.UnitPrice == 12.5
+		})).Return(nil).Once()
+
+		// removed from the cart, capped at stock
+		mockProductRepo.On("GetByID", uint(11)).Return(&models.Product{ID: 11, Price: 5, Stock: 3, IsActive: true}, nil).Once()
+		mockCartRepo.On("GetItemByProduct", uint(3), uint(11)).Return(nil, errors.New("not found")).Once()
+		mockCartRepo.On("CreateItem", mock.MatchedBy(func(item *models.CartItem) bool {
+			return item.CartID == 3 && item.ProductID == 11 && item.Quantity == 3 && item.UnitPrice == 5
+		})).Return(nil).Once()
+
+		// no longer sold
+		mockProductRepo.On("GetByID", uint(12)).Return(&models.Product{ID: 12, Stock: 4, IsActive: false}, nil).Once()
+		mockProductRepo.On("GetByID", uint(13)).Return(nil, errors.New("not found")).Once()
+
+		mockReminderRepo.On("MarkRestored", uint(8), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		redirectURL, err := service.RestoreCart("token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "http://shop.test/cart", redirectURL)
+		mockReminderRepo.AssertExpectations(t)
+		mockCartRepo.AssertExpectations(t)
+		mockProductRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		mockReminderRepo.On("GetByToken", "bogus").Return(nil, errors.New("not found")).Once()
+
+		redirectURL, err := service.RestoreCart("bogus")
+
+		assert.Error(t, err)
+		assert.Empty(t, redirectURL)
+		assert.Equal(t, "cart reminder not found", err.Error())
+	})
+}
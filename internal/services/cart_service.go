@@ -2,14 +2,30 @@ package services
 
 import (
 	"errors"
+	"time"
 
 	"github.com/JihadRinaldi/go-shop/internal/config"
 	"github.com/JihadRinaldi/go-shop/internal/dto"
 	"github.com/JihadRinaldi/go-shop/internal/models"
 	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+const (
+	MergeReasonUnavailable    = "unavailable"
+	MergeReasonOutOfStock     = "out_of_stock"
+	MergeReasonLimitedByStock = "limited_by_stock"
+)
+
+const (
+	ReorderReasonDiscontinued      = "discontinued"
+	ReorderReasonOutOfStock        = "out_of_stock"
+	ReorderReasonInsufficientStock = "insufficient_stock"
+	ReorderReasonPriceChanged      = "price_changed"
+)
+
 type CartService struct {
 	db          *gorm.DB
 	config      *config.Config
@@ -27,116 +43,705 @@ func NewCartService(db *gorm.DB, config *config.Config) *CartService {
 }
 
 func (s *CartService) GetCart(userID uint) (*dto.CartResponse, error) {
-	var cart models.Cart
-	err := s.db.Preload("CartItems.Product.Category").Where("user_id = ?", userID).First(&cart).Error
+	cart, err := s.cartRepo.GetByUserID(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.toCartResponse(&cart), nil
+	return s.cartResponse(cart.ID)
 }
 
 func (s *CartService) AddToCart(userID uint, req dto.AddToCartRequest) (*dto.CartResponse, error) {
-	product, err := s.productRepo.GetByID(req.ProductID)
+	product, err := s.getProductWithStock(req.ProductID, req.Quantity)
 	if err != nil {
-		return nil, errors.New("product not found")
+		return nil, err
 	}
 
-	if product.Stock < req.Quantity {
-		return nil, errors.New("insufficient product stock")
+	cart, err := s.cartRepo.GetByUserID(userID)
+	if err != nil {
+		cart = &models.Cart{UserID: &userID}
+		if err := s.cartRepo.Create(cart); err != nil {
+			return nil, err
+		}
 	}
 
+	if err := s.addItem(cart, product, req); err != nil {
+		return nil, err
+	}
+
+	return s.cartResponse(cart.ID)
+}
+
+func (s *CartService) UpdateCartItem(userID uint, itemID uint, req dto.UpdateCartItemRequest) (*dto.CartResponse, error) {
 	cart, err := s.cartRepo.GetByUserID(userID)
 	if err != nil {
-		cart = &models.Cart{UserID: userID}
+		return nil, errors.New("cart item not found")
+	}
+
+	if err := s.updateItem(cart, itemID, req.Quantity); err != nil {
+		return nil, err
+	}
+
+	return s.cartResponse(cart.ID)
+}
+
+func (s *CartService) RemoveCartItem(userID uint, itemID uint) error {
+	cart, err := s.cartRepo.GetByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	return s.cartRepo.DeleteItem(cart.ID, itemID)
+}
+
+// SaveForLater moves a line out of the active cart. Saved lines keep their
+// quantity, note and gift message but are left out of totals and checkout.
+func (s *CartService) SaveForLater(userID uint, itemID uint) (*dto.CartResponse, error) {
+	cart, err := s.cartRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, errors.New("cart item not found")
+	}
+
+	if err := s.setSavedForLater(cart, itemID, true); err != nil {
+		return nil, err
+	}
+
+	return s.cartResponse(cart.ID)
+}
+
+// MoveToCart moves a saved line back into the active cart at the current price
+func (s *CartService) MoveToCart(userID uint, itemID uint) (*dto.CartResponse, error) {
+	cart, err := s.cartRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, errors.New("cart item not found")
+	}
+
+	if err := s.setSavedForLater(cart, itemID, false); err != nil {
+		return nil, err
+	}
+
+	return s.cartResponse(cart.ID)
+}
+
+func (s *CartService) UpdateCartItemNote(userID uint, itemID uint, req dto.UpdateCartItemNoteRequest) (*dto.CartResponse, error) {
+	cart, err := s.cartRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, errors.New("cart item not found")
+	}
+
+	if err := s.updateItemNote(cart, itemID, req); err != nil {
+		return nil, err
+	}
+
+	return s.cartResponse(cart.ID)
+}
+
+// GetGuestCart returns the cart behind a guest cart token. A missing or invalid
+// token yields an empty cart rather than an error.
+func (s *CartService) GetGuestCart(token string) (*dto.CartResponse, error) {
+	cart, err := s.getGuestCart(token)
+	if err != nil {
+		return &dto.CartResponse{CartItems: []dto.CartItemResponse{}}, nil
+	}
+
+	return s.cartResponse(cart.ID)
+}
+
+// AddToGuestCart adds an item to a guest cart, starting a new one when the token
+// does not resolve to a cart. It returns the token the client should keep using.
+func (s *CartService) AddToGuestCart(token string, req dto.AddToCartRequest) (*dto.CartResponse, string, error) {
+	product, err := s.getProductWithStock(req.ProductID, req.Quantity)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cart, err := s.getGuestCart(token)
+	if err != nil {
+		guestID := uuid.New().String()
+		cart = &models.Cart{GuestID: &guestID}
 		if err := s.cartRepo.Create(cart); err != nil {
+			return nil, "", err
+		}
+		token = utils.SignCartToken(guestID, s.config.JWT.SecretKey)
+	}
+
+	if err := s.addItem(cart, product, req); err != nil {
+		return nil, "", err
+	}
+
+	response, err := s.cartResponse(cart.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	return response, token, nil
+}
+
+func (s *CartService) UpdateGuestCartItem(token string, itemID uint, req dto.UpdateCartItemRequest) (*dto.CartResponse, error) {
+	cart, err := s.getGuestCart(token)
+	if err != nil {
+		return nil, errors.New("cart item not found")
+	}
+
+	if err := s.updateItem(cart, itemID, req.Quantity); err != nil {
+		return nil, err
+	}
+
+	return s.cartResponse(cart.ID)
+}
+
+func (s *CartService) RemoveGuestCartItem(token string, itemID uint) error {
+	cart, err := s.getGuestCart(token)
+	if err != nil {
+		return errors.New("cart item not found")
+	}
+
+	return s.cartRepo.DeleteItem(cart.ID, itemID)
+}
+
+func (s *CartService) SaveGuestItemForLater(token string, itemID uint) (*dto.CartResponse, error) {
+	cart, err := s.getGuestCart(token)
+	if err != nil {
+		return nil, errors.New("cart item not found")
+	}
+
+	if err := s.setSavedForLater(cart, itemID, true); err != nil {
+		return nil, err
+	}
+
+	return s.cartResponse(cart.ID)
+}
+
+func (s *CartService) MoveGuestItemToCart(token string, itemID uint) (*dto.CartResponse, error) {
+	cart, err := s.getGuestCart(token)
+	if err != nil {
+		return nil, errors.New("cart item not found")
+	}
+
+	if err := s.setSavedForLater(cart, itemID, false); err != nil {
+		return nil, err
+	}
+
+	return s.cartResponse(cart.ID)
+}
+
+func (s *CartService) UpdateGuestCartItemNote(token string, itemID uint, req dto.UpdateCartItemNoteRequest) (*dto.CartResponse, error) {
+	cart, err := s.getGuestCart(token)
+	if err != nil {
+		return nil, errors.New("cart item not found")
+	}
+
+	if err := s.updateItemNote(cart, itemID, req); err != nil {
+		return nil, err
+	}
+
+	return s.cartResponse(cart.ID)
+}
+
+// MergeGuestCart moves the items of a guest cart into the user's cart and deletes
+// the guest cart. When both carts hold the same product the quantities are added
+// up. Quantities are capped at the available stock, and inactive, deleted or
+// sold out products are dropped. Lines saved for later in both carts stay saved
+// and are not limited by stock. Every line that was not merged as is gets
+// reported in the result. The merge happens in one transaction.
+func (s *CartService) MergeGuestCart(userID uint, token string) (*dto.CartMergeResult, error) {
+	guestCart, err := s.getGuestCart(token)
+	if err != nil {
+		return nil, errors.New("guest cart not found")
+	}
+
+	var result *dto.CartMergeResult
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		result, err = mergeGuestCart(repositories.NewCartRepository(tx), userID, guestCart.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func mergeGuestCart(cartRepo repositories.CartRepositoryInterface, userID, guestCartID uint) (*dto.CartMergeResult, error) {
+	// The guest lines give their stock back first, so it is there for the
+	// user's cart to take
+	if err := cartRepo.ReleaseReservations(guestCartID, nil); err != nil {
+		return nil, err
+	}
+
+	guestItems, err := cartRepo.GetItems(guestCartID)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := cartRepo.GetByUserID(userID)
+	if err != nil {
+		cart = &models.Cart{UserID: &userID}
+		if err := cartRepo.Create(cart); err != nil {
 			return nil, err
 		}
 	}
 
-	// Check if item already exists in cart
-	var cartItem models.CartItem
-	if err := s.db.Where("cart_id = ? AND product_id = ?", cart.ID, req.ProductID).First(&cartItem).Error; err != nil {
-		// Create new cart item
-		cartItem = models.CartItem{
-			CartID:    cart.ID,
-			ProductID: req.ProductID,
-			Quantity:  req.Quantity,
+	result := &dto.CartMergeResult{Adjustments: []dto.CartMergeAdjustment{}}
+	for _, guestItem := range guestItems {
+		product := guestItem.Product
+		adjustment := dto.CartMergeAdjustment{
+			ProductID:         guestItem.ProductID,
+			ProductName:       product.Name,
+			RequestedQuantity: guestItem.Quantity,
+		}
+
+		if product.ID == 0 || !product.IsActive {
+			adjustment.Reason = MergeReasonUnavailable
+			result.Adjustments = append(result.Adjustments, adjustment)
+			continue
+		}
+		if !guestItem.SavedForLater && product.Stock <= 0 {
+			adjustment.Reason = MergeReasonOutOfStock
+			result.Adjustments = append(result.Adjustments, adjustment)
+			continue
+		}
+
+		item, err := cartRepo.GetItemByProduct(cart.ID, product.ID)
+		if err != nil {
+			item = &models.CartItem{
+				CartID:        cart.ID,
+				ProductID:     product.ID,
+				UnitPrice:     guestItem.UnitPrice,
+				SavedForLater: guestItem.SavedForLater,
+			}
+		}
+		if item.Note == "" {
+			item.Note = guestItem.Note
 		}
-		s.db.Create(&cartItem)
-	} else {
-		// Update existing cart item
-		cartItem.Quantity += req.Quantity
-		if cartItem.Quantity > product.Stock {
-			return nil, errors.New("insufficient stock")
+		if item.GiftMessage == "" {
+			item.GiftMessage = guestItem.GiftMessage
 		}
-		s.db.Save(&cartItem)
+
+		requested := item.Quantity + guestItem.Quantity
+		if item.SavedForLater && guestItem.SavedForLater {
+			item.Quantity = requested
+		} else {
+			available := availableStock(&product, item)
+			if available <= 0 {
+				adjustment.Reason = MergeReasonOutOfStock
+				result.Adjustments = append(result.Adjustments, adjustment)
+				continue
+			}
+
+			item.SavedForLater = false
+			item.Quantity = min(requested, available)
+			if item.Quantity < requested {
+				adjustment.RequestedQuantity = requested
+				adjustment.Quantity = item.Quantity
+				adjustment.Reason = MergeReasonLimitedByStock
+				result.Adjustments = append(result.Adjustments, adjustment)
+			}
+		}
+
+		if item.ID == 0 {
+			err = cartRepo.CreateItem(item)
+		} else {
+			err = cartRepo.UpdateItem(item)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.MergedItems++
+	}
+
+	if err := cartRepo.Delete(guestCartID); err != nil {
+		return nil, err
 	}
 
-	return s.GetCart(userID)
+	return result, nil
 }
 
-func (s *CartService) UpdateCartItem(userID uint, itemID uint, req dto.UpdateCartItemRequest) (*dto.CartResponse, error) {
-	var cartItem models.CartItem
-	err := s.db.Joins("JOIN carts ON carts.id = cart_items.cart_id").
-		Where("carts.user_id = ? AND cart_items.id = ?", userID, itemID).
-		First(&cartItem).Error
+// AcceptCartChanges applies the changes reported as cart warnings: lines take
+// the current product price, quantities are reduced to the available stock and
+// unavailable or sold out products are removed. Lines saved for later are left
+// as they are.
+func (s *CartService) AcceptCartChanges(userID uint) (*dto.CartResponse, error) {
+	cart, err := s.cartRepo.GetByUserID(userID)
 	if err != nil {
-		return nil, errors.New("cart item not found")
+		return nil, errors.New("cart not found")
 	}
 
-	product, err := s.productRepo.GetByID(cartItem.ProductID)
+	if err := s.acceptChanges(cart); err != nil {
+		return nil, err
+	}
+
+	return s.cartResponse(cart.ID)
+}
+
+func (s *CartService) AcceptGuestCartChanges(token string) (*dto.CartResponse, error) {
+	cart, err := s.getGuestCart(token)
+	if err != nil {
+		return nil, errors.New("cart not found")
+	}
+
+	if err := s.acceptChanges(cart); err != nil {
+		return nil, err
+	}
+
+	return s.cartResponse(cart.ID)
+}
+
+func (s *CartService) acceptChanges(cart *models.Cart) error {
+	items, err := s.cartRepo.GetItems(cart.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		item := &items[i]
+		if item.SavedForLater || len(cartItemWarnings(item)) == 0 {
+			continue
+		}
+
+		available := availableStock(&item.Product, item)
+		if item.Product.ID == 0 || !item.Product.IsActive || available <= 0 {
+			if err := s.cartRepo.DeleteItem(cart.ID, item.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		item.UnitPrice = item.Product.Price
+		item.Quantity = min(item.Quantity, available)
+		if err := s.cartRepo.UpdateItem(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RestoreItems brings the user's cart back to hold at least the given lines,
+// moving them out of saved for later where needed. Products that are no longer
+// available are skipped, quantities are capped at the available stock and
+// restored lines take the current product price.
+func (s *CartService) RestoreItems(userID uint, items []models.CartSnapshotItem) error {
+	cart, err := s.cartRepo.GetByUserID(userID)
+	if err != nil {
+		cart = &models.Cart{UserID: &userID}
+		if err := s.cartRepo.Create(cart); err != nil {
+			return err
+		}
+	}
+
+	for _, snapshot := range items {
+		product, err := s.productRepo.GetByID(snapshot.ProductID)
+		if err != nil || !product.IsActive {
+			continue
+		}
+
+		item, err := s.cartRepo.GetItemByProduct(cart.ID, product.ID)
+		if err != nil {
+			item = &models.CartItem{CartID: cart.ID, ProductID: product.ID}
+		}
+		quantity := min(snapshot.Quantity, availableStock(product, item))
+		if quantity <= 0 {
+			continue
+		}
+
+		if item.ID == 0 {
+			item.Quantity = quantity
+			item.UnitPrice = product.Price
+			err = s.cartRepo.CreateItem(item)
+		} else if item.SavedForLater || item.Quantity < quantity {
+			item.SavedForLater = false
+			item.Quantity = quantity
+			item.UnitPrice = product.Price
+			err = s.cartRepo.UpdateItem(item)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddOrderItems copies the lines of a past order into the user's cart at the
+// current price. Discontinued products are skipped and quantities are reduced
+// to what is left in stock next to what the cart already holds. Every line that
+// was skipped, reduced or repriced is reported.
+func (s *CartService) AddOrderItems(userID uint, orderItems []models.OrderItem) (*dto.ReorderResponse, error) {
+	cart, err := s.cartRepo.GetByUserID(userID)
+	if err != nil {
+		cart = &models.Cart{UserID: &userID}
+		if err := s.cartRepo.Create(cart); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &dto.ReorderResponse{Issues: []dto.ReorderIssue{}}
+	for _, orderItem := range orderItems {
+		issue := dto.ReorderIssue{
+			ProductID:         orderItem.ProductID,
+			ProductName:       orderItem.Product.Name,
+			RequestedQuantity: orderItem.Quantity,
+		}
+
+		product, err := s.productRepo.GetByID(orderItem.ProductID)
+		if err != nil || !product.IsActive {
+			issue.Reason = ReorderReasonDiscontinued
+			result.Issues = append(result.Issues, issue)
+			continue
+		}
+		issue.ProductName = product.Name
+
+		item, err := s.cartRepo.GetItemByProduct(cart.ID, product.ID)
+		if err != nil {
+			item = &models.CartItem{
+				CartID:      cart.ID,
+				ProductID:   product.ID,
+				Note:        orderItem.Note,
+				GiftMessage: orderItem.GiftMessage,
+			}
+		}
+
+		quantity := min(orderItem.Quantity, availableStock(product, item)-item.Quantity)
+		if quantity <= 0 {
+			issue.Reason = ReorderReasonOutOfStock
+			if product.Stock > 0 {
+				issue.Reason = ReorderReasonInsufficientStock
+			}
+			result.Issues = append(result.Issues, issue)
+			continue
+		}
+		if quantity < orderItem.Quantity {
+			issue.Quantity = quantity
+			issue.Reason = ReorderReasonInsufficientStock
+			result.Issues = append(result.Issues, issue)
+		}
+		if product.Price != orderItem.Price {
+			result.Issues = append(result.Issues, dto.ReorderIssue{
+				ProductID:         product.ID,
+				ProductName:       product.Name,
+				RequestedQuantity: orderItem.Quantity,
+				Quantity:          quantity,
+				Reason:            ReorderReasonPriceChanged,
+				PreviousPrice:     orderItem.Price,
+				CurrentPrice:      product.Price,
+			})
+		}
+
+		item.Quantity += quantity
+		item.UnitPrice = product.Price
+		item.SavedForLater = false
+		if item.ID == 0 {
+			err = s.cartRepo.CreateItem(item)
+		} else {
+			err = s.cartRepo.UpdateItem(item)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.AddedItems++
+	}
+
+	result.Cart, err = s.cartResponse(cart.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReleaseExpiredReservations gives back the component stock held by bundle
+// lines left unchanged for longer than the reservation TTL. The lines stay in
+// their carts. It returns the number of lines released.
+func (s *CartService) ReleaseExpiredReservations(now time.Time) (int, error) {
+	return s.cartRepo.ReleaseExpiredReservations(now.Add(-s.config.Cart.ReservationTTL))
+}
+
+func (s *CartService) getGuestCart(token string) (*models.Cart, error) {
+	if token == "" {
+		return nil, errors.New("cart token required")
+	}
+
+	guestID, err := utils.ParseCartToken(token, s.config.JWT.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.cartRepo.GetByGuestID(guestID)
+}
+
+func (s *CartService) getProductWithStock(productID uint, quantity int) (*models.Product, error) {
+	product, err := s.productRepo.GetByID(productID)
 	if err != nil {
 		return nil, errors.New("product not found")
 	}
 
-	if req.Quantity > product.Stock {
+	if product.Stock < quantity {
 		return nil, errors.New("insufficient product stock")
 	}
 
-	cartItem.Quantity = req.Quantity
-	s.db.Save(&cartItem)
+	return product, nil
+}
 
-	return s.GetCart(userID)
+// availableStock is how many of the product the line can hold: what is left in
+// stock plus the bundles the line already reserves
+func availableStock(product *models.Product, item *models.CartItem) int {
+	return product.Stock + item.ReservedQuantity
 }
 
-func (s *CartService) RemoveCartItem(userID uint, itemID uint) error {
-	return s.db.Joins("JOIN carts ON carts.id = cart_items.cart_id").
-		Where("carts.user_id = ? ANd cart_items.id = ?", userID, itemID).
-		Delete(&models.CartItem{}).Error
+// addItem records the current product price with the line. Adding more of a
+// product that is already in the cart counts as seeing its current price, and
+// adding a product that was saved for later moves it back into the cart.
+func (s *CartService) addItem(cart *models.Cart, product *models.Product, req dto.AddToCartRequest) error {
+	cartItem, err := s.cartRepo.GetItemByProduct(cart.ID, product.ID)
+	if err != nil {
+		return s.cartRepo.CreateItem(&models.CartItem{
+			CartID:      cart.ID,
+			ProductID:   product.ID,
+			Quantity:    req.Quantity,
+			UnitPrice:   product.Price,
+			Note:        req.Note,
+			GiftMessage: req.GiftMessage,
+		})
+	}
+
+	cartItem.Quantity += req.Quantity
+	if cartItem.Quantity > availableStock(product, cartItem) {
+		return errors.New("insufficient stock")
+	}
+	cartItem.UnitPrice = product.Price
+	cartItem.SavedForLater = false
+	if req.Note != "" {
+		cartItem.Note = req.Note
+	}
+	if req.GiftMessage != "" {
+		cartItem.GiftMessage = req.GiftMessage
+	}
+	return s.cartRepo.UpdateItem(cartItem)
+}
+
+func (s *CartService) updateItem(cart *models.Cart, itemID uint, quantity int) error {
+	cartItem, err := s.cartRepo.GetItem(cart.ID, itemID)
+	if err != nil {
+		return errors.New("cart item not found")
+	}
+
+	product, err := s.productRepo.GetByID(cartItem.ProductID)
+	if err != nil {
+		return errors.New("product not found")
+	}
+
+	if quantity > availableStock(product, cartItem) {
+		return errors.New("insufficient product stock")
+	}
+
+	cartItem.Quantity = quantity
+	return s.cartRepo.UpdateItem(cartItem)
+}
+
+// setSavedForLater moves a line between the active cart and the saved section.
+// A line coming back into the cart must be in stock and takes the current price.
+func (s *CartService) setSavedForLater(cart *models.Cart, itemID uint, saved bool) error {
+	cartItem, err := s.cartRepo.GetItem(cart.ID, itemID)
+	if err != nil {
+		return errors.New("cart item not found")
+	}
+
+	if cartItem.SavedForLater == saved {
+		return nil
+	}
+
+	if !saved {
+		product, err := s.productRepo.GetByID(cartItem.ProductID)
+		if err != nil || !product.IsActive {
+			return errors.New("product not found")
+		}
+		if product.Stock < cartItem.Quantity {
+			return errors.New("insufficient product stock")
+		}
+		cartItem.UnitPrice = product.Price
+	}
+
+	cartItem.SavedForLater = saved
+	return s.cartRepo.UpdateItem(cartItem)
+}
+
+func (s *CartService) updateItemNote(cart *models.Cart, itemID uint, req dto.UpdateCartItemNoteRequest) error {
+	cartItem, err := s.cartRepo.GetItem(cart.ID, itemID)
+	if err != nil {
+		return errors.New("cart item not found")
+	}
+
+	cartItem.Note = req.Note
+	cartItem.GiftMessage = req.GiftMessage
+	return s.cartRepo.UpdateItem(cartItem)
 }
 
+func (s *CartService) cartResponse(cartID uint) (*dto.CartResponse, error) {
+	cart, err := s.cartRepo.GetWithItems(cartID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toCartResponse(cart), nil
+}
+
+// toCartResponse totals the active lines only. Saved lines carry their warnings
+// too, so customers can see when a saved product sells out, but they do not
+// count towards HasWarnings since they are not part of checkout.
 func (s *CartService) toCartResponse(cart *models.Cart) *dto.CartResponse {
 	cartItems := make([]dto.CartItemResponse, len(cart.CartItems))
 	var total float64
+	var hasWarnings bool
 
-	for i, item := range cart.CartItems {
-		subTotal := float64(cart.CartItems[i].Quantity) * cart.CartItems[i].Product.Price
-		total += subTotal
-
-		cartItems[i] = dto.CartItemResponse{
-			ID: item.ID,
-			Product: dto.ProductResponse{
-				ID:          item.Product.ID,
-				Name:        item.Product.Name,
-				Description: item.Product.Description,
-				Price:       item.Product.Price,
-				Category: dto.CategoryResponse{
-					ID:   item.Product.Category.ID,
-					Name: item.Product.Category.Name,
-				},
-			},
-			Quantity: item.Quantity,
-			Subtotal: subTotal,
-		}
+	for i := range cart.CartItems {
+		cartItems[i] = s.toCartItemResponse(&cart.CartItems[i])
+		total += cartItems[i].Subtotal
+		hasWarnings = hasWarnings || len(cartItems[i].Warnings) > 0
+	}
+
+	savedItems := make([]dto.CartItemResponse, len(cart.SavedItems))
+	for i := range cart.SavedItems {
+		savedItems[i] = s.toCartItemResponse(&cart.SavedItems[i])
+	}
+
+	var userID uint
+	if cart.UserID != nil {
+		userID = *cart.UserID
 	}
 
 	return &dto.CartResponse{
-		ID:        cart.ID,
-		UserID:    cart.UserID,
-		CartItems: cartItems,
-		Total:     total,
-		CreatedAt: cart.CreatedAt,
-		UpdatedAt: cart.UpdatedAt,
+		ID:          cart.ID,
+		UserID:      userID,
+		CartItems:   cartItems,
+		SavedItems:  savedItems,
+		Total:       total,
+		HasWarnings: hasWarnings,
+		CreatedAt:   cart.CreatedAt,
+		UpdatedAt:   cart.UpdatedAt,
+	}
+}
+
+func (s *CartService) toCartItemResponse(item *models.CartItem) dto.CartItemResponse {
+	return dto.CartItemResponse{
+		ID: item.ID,
+		Product: dto.ProductResponse{
+			ID:          item.ProductID,
+			Name:        item.Product.Name,
+			Description: item.Product.Description,
+			Price:       item.Product.Price,
+			Category: dto.CategoryResponse{
+				ID:   item.Product.Category.ID,
+				Name: item.Product.Category.Name,
+			},
+		},
+		Quantity:    item.Quantity,
+		UnitPrice:   item.UnitPrice,
+		Subtotal:    float64(item.Quantity) * item.Product.Price,
+		Note:        item.Note,
+		GiftMessage: item.GiftMessage,
+		Warnings:    cartItemWarnings(item),
+		CreatedAt:   item.CreatedAt,
+		UpdatedAt:   item.UpdatedAt,
 	}
 }
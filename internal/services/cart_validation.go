@@ -0,0 +1,84 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+)
+
+const (
+	CartWarningPriceChanged      = "price_changed"
+	CartWarningUnavailable       = "product_unavailable"
+	CartWarningOutOfStock        = "out_of_stock"
+	CartWarningInsufficientStock = "insufficient_stock"
+)
+
+// CartValidationError is returned by checkout when cart lines changed since
+// they were added. It carries the per-line warnings for the client.
+type CartValidationError struct {
+	Items []dto.CartItemIssue
+}
+
+func (e *CartValidationError) Error() string {
+	return "cart has changed since items were added, review it before checkout"
+}
+
+// validateCartItems returns the issues of every cart line that no longer matches
+// what the customer saw when adding it. Items must be loaded with their product;
+// a product that was deleted in the meantime comes back empty.
+func validateCartItems(items []models.CartItem) []dto.CartItemIssue {
+	var issues []dto.CartItemIssue
+	for i := range items {
+		warnings := cartItemWarnings(&items[i])
+		if len(warnings) == 0 {
+			continue
+		}
+
+		issues = append(issues, dto.CartItemIssue{
+			CartItemID:  items[i].ID,
+			ProductID:   items[i].ProductID,
+			ProductName: items[i].Product.Name,
+			Warnings:    warnings,
+		})
+	}
+	return issues
+}
+
+func cartItemWarnings(item *models.CartItem) []dto.CartItemWarning {
+	product := item.Product
+	if product.ID == 0 || !product.IsActive {
+		return []dto.CartItemWarning{{
+			Code:    CartWarningUnavailable,
+			Message: "This product is no longer available",
+		}}
+	}
+
+	var warnings []dto.CartItemWarning
+	if item.UnitPrice != product.Price {
+		warnings = append(warnings, dto.CartItemWarning{
+			Code:          CartWarningPriceChanged,
+			Message:       fmt.Sprintf("Price changed from %.2f to %.2f", item.UnitPrice, product.Price),
+			PreviousPrice: item.UnitPrice,
+			CurrentPrice:  product.Price,
+		})
+	}
+
+	stock := availableStock(&product, item)
+	switch {
+	case stock <= 0:
+		warnings = append(warnings, dto.CartItemWarning{
+			Code:           CartWarningOutOfStock,
+			Message:        "This product is out of stock",
+			AvailableStock: &stock,
+		})
+	case stock < item.Quantity:
+		warnings = append(warnings, dto.CartItemWarning{
+			Code:           CartWarningInsufficientStock,
+			Message:        fmt.Sprintf("Only %d left in stock", stock),
+			AvailableStock: &stock,
+		})
+	}
+
+	return warnings
+}
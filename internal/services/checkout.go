@@ -0,0 +1,203 @@
+package services
+
+import (
+	"errors"
+	"math"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// checkoutLine is one product to be ordered, whether it comes from the cart or
+// from a buy now request
+type checkoutLine struct {
+	Product     models.Product
+	Quantity    int
+	Note        string
+	GiftMessage string
+}
+
+type orderTotals struct {
+	Subtotal float64
+	Tax      float64
+	Shipping float64
+	Total    float64
+}
+
+// placeOrder is the checkout pipeline shared by every way of ordering. It
+// reserves stock for each line, taking bundles off their components, prices
+// the order and stores it. The given order carries who ordered and where from.
+// It must run inside a transaction so a failing line leaves stock untouched.
+func (s *OrderService) placeOrder(tx *gorm.DB, order models.Order, lines []checkoutLine) (*models.Order, error) {
+	if len(lines) == 0 {
+		return nil, errors.New("nothing to order")
+	}
+
+	var subtotal float64
+	var stockChanged []uint
+	requiresShipping := false
+	orderItems := make([]models.OrderItem, len(lines))
+	for i, line := range lines {
+		if err := reserveStock(tx, line.Product, line.Quantity); err != nil {
+			return nil, err
+		}
+		stockChanged = append(stockChanged, line.Product.ID)
+
+		subtotal += float64(line.Quantity) * line.Product.Price
+		if !line.Product.IsDigital {
+			requiresShipping = true
+		}
+		orderItems[i] = models.OrderItem{
+			ProductID:   line.Product.ID,
+			Quantity:    line.Quantity,
+			Price:       line.Product.Price,
+			Note:        line.Note,
+			GiftMessage: line.GiftMessage,
+		}
+
+		if line.Product.IsBundle {
+			components, err := reserveBundleComponents(tx, line)
+			if err != nil {
+				return nil, err
+			}
+			for _, component := range components {
+				stockChanged = append(stockChanged, component.ProductID)
+			}
+			orderItems[i].Components = components
+		}
+	}
+
+	// Bundle stock is derived from the components, so it is recomputed after
+	// every change instead of being trusted from the decrement above
+	if err := repositories.NewProductRepository(tx).SyncBundleStock(stockChanged); err != nil {
+		return nil, err
+	}
+
+	totals := s.priceOrder(subtotal, requiresShipping)
+	order.Status = models.OrderStatusPending
+	order.Subtotal = totals.Subtotal
+	order.TaxAmount = totals.Tax
+	order.ShippingAmount = totals.Shipping
+	order.TotalAmount = totals.Total
+	order.OrderItems = orderItems
+
+	if err := tx.Create(&order).Error; err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// ensureCanCheckout stops accounts that still have to verify their email
+// address from ordering when the shop requires verification
+func ensureCanCheckout(cfg *config.Config, userRepo repositories.UserRepositoryInterface, userID uint) error {
+	if !cfg.Auth.VerifiedEmailRequiredForCheckout() {
+		return nil
+	}
+
+	user, err := userRepo.GetByID(userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	return ensureEmailVerified(cfg, user)
+}
+
+// ensureEmailVerified is ensureCanCheckout for a user already loaded
+func ensureEmailVerified(cfg *config.Config, user *models.User) error {
+	if cfg.Auth.VerifiedEmailRequiredForCheckout() && !user.IsEmailVerified() {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// reserveStock takes quantity units off the product, failing when it is
+// inactive or does not have enough left
+func reserveStock(tx *gorm.DB, product models.Product, quantity int) error {
+	result := tx.Model(&models.Product{}).
+		Where("id = ? AND is_active = ? AND stock >= ?", product.ID, true, quantity).
+		Update("stock", gorm.Expr("stock - ?", quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("insufficient stock for product: " + product.Name)
+	}
+	return nil
+}
+
+// reserveBundleComponents takes the stock of a bundle line off its components
+// and returns the breakdown to record on the order item
+func reserveBundleComponents(tx *gorm.DB, line checkoutLine) ([]models.OrderItemComponent, error) {
+	var bundleItems []models.BundleItem
+	if err := tx.Preload("Component").Where("bundle_id = ?", line.Product.ID).Find(&bundleItems).Error; err != nil {
+		return nil, err
+	}
+	if len(bundleItems) == 0 {
+		return nil, errors.New("bundle has no components: " + line.Product.Name)
+	}
+
+	components := make([]models.OrderItemComponent, len(bundleItems))
+	for i, item := range bundleItems {
+		quantity := item.Quantity * line.Quantity
+		if err := reserveStock(tx, item.Component, quantity); err != nil {
+			return nil, errors.New("insufficient stock for product: " + line.Product.Name)
+		}
+		components[i] = models.OrderItemComponent{ProductID: item.ComponentID, Quantity: quantity}
+	}
+
+	return components, nil
+}
+
+// priceOrder computes the totals of an order. Orders made only of digital
+// products have nothing to ship and are never charged shipping.
+func (s *OrderService) priceOrder(subtotal float64, requiresShipping bool) orderTotals {
+	checkout := s.config.Checkout
+
+	shipping := checkout.ShippingFee
+	if !requiresShipping || (checkout.FreeShippingThreshold > 0 && subtotal >= checkout.FreeShippingThreshold) {
+		shipping = 0
+	}
+
+	totals := orderTotals{
+		Subtotal: roundPrice(subtotal),
+		Tax:      roundPrice(subtotal * checkout.TaxRate),
+		Shipping: roundPrice(shipping),
+	}
+	totals.Total = roundPrice(totals.Subtotal + totals.Tax + totals.Shipping)
+	return totals
+}
+
+// selectCartItems picks the cart items to check out. No IDs means all of them;
+// an ID that is not among the items is an error.
+func selectCartItems(items []models.CartItem, ids []uint) ([]models.CartItem, error) {
+	if len(ids) == 0 {
+		return items, nil
+	}
+
+	byID := make(map[uint]models.CartItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	selected := make([]models.CartItem, 0, len(ids))
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		item, ok := byID[id]
+		if !ok {
+			return nil, errors.New("cart item not found")
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		selected = append(selected, item)
+	}
+
+	return selected, nil
+}
+
+func roundPrice(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/interfaces"
+	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"gorm.io/gorm"
+)
+
+// DownloadService hands out the files of digital products. Customers get
+// short lived signed links once their order is paid, and every purchase can
+// be downloaded a limited number of times.
+type DownloadService struct {
+	db         *gorm.DB
+	config     *config.Config
+	provider   interfaces.UploadProvider
+	orderRepo  repositories.OrderRepositoryInterface
+	uploadRepo repositories.UploadRepositoryInterface
+}
+
+func NewDownloadService(db *gorm.DB, config *config.Config, provider interfaces.UploadProvider) *DownloadService {
+	return &DownloadService{
+		db:         db,
+		config:     config,
+		provider:   provider,
+		orderRepo:  repositories.NewOrderRepository(db),
+		uploadRepo: repositories.NewUploadRepository(db),
+	}
+}
+
+// DownloadFile is a product file opened for streaming. The caller closes Content.
+type DownloadFile struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Content     io.ReadCloser
+}
+
+// GetOrderDownloads returns a fresh link for every file of the digital
+// products in one of the user's paid orders
+func (s *DownloadService) GetOrderDownloads(userID, orderID uint) ([]dto.DownloadLinkResponse, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil || order.UserID != userID {
+		return nil, errors.New("order not found")
+	}
+
+	if !order.Status.IsPaid() {
+		return nil, errors.New("order has not been paid")
+	}
+
+	expiresAt := time.Now().Add(s.config.Download.LinkTTL).Truncate(time.Second)
+	links := []dto.DownloadLinkResponse{}
+	for _, item := range order.OrderItems {
+		if !item.Product.IsDigital {
+			continue
+		}
+
+		files, err := s.uploadRepo.GetProductFiles(item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := max(s.config.Download.MaxPerPurchase-item.DownloadCount, 0)
+		for _, file := range files {
+			link := dto.DownloadLinkResponse{
+				OrderItemID:        item.ID,
+				ProductID:          item.ProductID,
+				ProductName:        item.Product.Name,
+				FileID:             file.ID,
+				FileName:           file.FileName,
+				Size:               file.Size,
+				DownloadsRemaining: remaining,
+			}
+			if remaining > 0 {
+				link.URL = s.downloadURL(item.ID, file.ID, expiresAt)
+				link.ExpiresAt = &expiresAt
+			}
+			links = append(links, link)
+		}
+	}
+
+	return links, nil
+}
+
+// OpenDownload checks a signed link and opens the file it points to. A
+// successful call uses up one download of the purchase.
+func (s *DownloadService) OpenDownload(itemID, fileID uint, query dto.DownloadQuery) (*DownloadFile, error) {
+	if !utils.VerifyDownload(itemID, fileID, query.Expires, query.Signature, s.config.JWT.SecretKey) {
+		return nil, errors.New("invalid download link")
+	}
+
+	if time.Now().After(time.Unix(query.Expires, 0)) {
+		return nil, errors.New("download link has expired")
+	}
+
+	item, err := s.orderRepo.GetItemByID(itemID)
+	if err != nil || !item.Order.Status.IsPaid() {
+		return nil, errors.New("download not available")
+	}
+
+	file, err := s.uploadRepo.GetProductFile(fileID)
+	if err != nil || file.ProductID != item.ProductID {
+		return nil, errors.New("file not found")
+	}
+
+	content, err := s.provider.OpenFile(file.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Counted only once the file could be opened, so a storage error does
+	// not cost the customer a download
+	counted, err := s.orderRepo.IncrementDownloadCount(item.ID, s.config.Download.MaxPerPurchase)
+	if err != nil || !counted {
+		content.Close()
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New("download limit reached")
+	}
+
+	return &DownloadFile{
+		FileName:    file.FileName,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+		Content:     content,
+	}, nil
+}
+
+func (s *DownloadService) downloadURL(itemID, fileID uint, expiresAt time.Time) string {
+	expires := expiresAt.Unix()
+	signature := utils.SignDownload(itemID, fileID, expires, s.config.JWT.SecretKey)
+	return fmt.Sprintf("%s/api/v1/downloads/%d/files/%d?expires=%d&signature=%s",
+		strings.TrimRight(s.config.Server.PublicURL, "/"), itemID, fileID, expires, signature)
+}
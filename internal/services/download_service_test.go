@@ -0,0 +1,177 @@
+package services
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/mocks"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestDownloadService_GetOrderDownloads(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepositoryInterface)
+	mockUploadRepo := new(mocks.MockUploadRepositoryInterface)
+
+	service := &DownloadService{
+		db: &gorm.DB{},
+		config: &config.Config{
+			Server:   config.ServerConfig{PublicURL: "https://api.shop.test"},
+			JWT:      config.JWTConfig{SecretKey: "secret"},
+			Download: config.DownloadConfig{LinkTTL: 15 * time.Minute, MaxPerPurchase: 3},
+		},
+		provider:   new(mocks.MockUploadProvider),
+		orderRepo:  mockOrderRepo,
+		uploadRepo: mockUploadRepo,
+	}
+
+	ebook := models.Product{ID: 5, Name: "E-book", IsDigital: true}
+	mug := models.Product{ID: 6, Name: "Mug"}
+
+	t.Run("links for digital items of a paid order", func(t *testing.T) {
+		order := &models.Order{ID: 1, UserID: 7, Status: models.OrderStatusConfirmed, OrderItems: []models.OrderItem{
+			{ID: 11, ProductID: 5, Product: ebook, DownloadCount: 1},
+			{ID: 12, ProductID: 6, Product: mug},
+		}}
+
+		mockOrderRepo.On("GetByID", uint(1)).Return(order, nil).Once()
+		mockUploadRepo.On("GetProductFiles", uint(5)).Return([]models.ProductFile{
+			{ID: 21, ProductID: 5, FileName: "book.pdf", Size: 2048},
+		}, nil).Once()
+
+		links, err := service.GetOrderDownloads(7, 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, links, 1)
+		assert.Equal(t, uint(11), links[0].OrderItemID)
+		assert.Equal(t, 2, links[0].DownloadsRemaining)
+		assert.True(t, strings.HasPrefix(links[0].URL, "https://api.shop.test/api/v1/downloads/11/files/21?expires="))
+		assert.NotNil(t, links[0].ExpiresAt)
+
+		signature := utils.SignDownload(11, 21, links[0].ExpiresAt.Unix(), "secret")
+		assert.Contains(t, links[0].URL, "signature="+signature)
+		mockOrderRepo.AssertExpectations(t)
+		mockUploadRepo.AssertExpectations(t)
+	})
+
+	t.Run("no link once downloads are used up", func(t *testing.T) {
+		order := &models.Order{ID: 1, UserID: 7, Status: models.OrderStatusDelivered, OrderItems: []models.OrderItem{
+			{ID: 11, ProductID: 5, Product: ebook, DownloadCount: 3},
+		}}
+
+		mockOrderRepo.On("GetByID", uint(1)).Return(order, nil).Once()
+		mockUploadRepo.On("GetProductFiles", uint(5)).Return([]models.ProductFile{{ID: 21, ProductID: 5}}, nil).Once()
+
+		links, err := service.GetOrderDownloads(7, 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, links, 1)
+		assert.Empty(t, links[0].URL)
+		assert.Equal(t, 0, links[0].DownloadsRemaining)
+	})
+
+	t.Run("unpaid order", func(t *testing.T) {
+		mockOrderRepo.On("GetByID", uint(1)).Return(&models.Order{ID: 1, UserID: 7, Status: models.OrderStatusPending}, nil).Once()
+
+		links, err := service.GetOrderDownloads(7, 1)
+
+		assert.Error(t, err)
+		assert.Nil(t, links)
+		assert.Contains(t, err.Error(), "not been paid")
+	})
+
+	t.Run("order of another user", func(t *testing.T) {
+		mockOrderRepo.On("GetByID", uint(1)).Return(&models.Order{ID: 1, UserID: 8, Status: models.OrderStatusConfirmed}, nil).Once()
+
+		links, err := service.GetOrderDownloads(7, 1)
+
+		assert.Error(t, err)
+		assert.Nil(t, links)
+	})
+}
+
+func TestDownloadService_OpenDownload(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepositoryInterface)
+	mockUploadRepo := new(mocks.MockUploadRepositoryInterface)
+	mockProvider := new(mocks.MockUploadProvider)
+
+	service := &DownloadService{
+		db: &gorm.DB{},
+		config: &config.Config{
+			Server:   config.ServerConfig{PublicURL: "https://api.shop.test"},
+			JWT:      config.JWTConfig{SecretKey: "secret"},
+			Download: config.DownloadConfig{LinkTTL: 15 * time.Minute, MaxPerPurchase: 3},
+		},
+		provider:   mockProvider,
+		orderRepo:  mockOrderRepo,
+		uploadRepo: mockUploadRepo,
+	}
+
+	signedQuery := func(itemID, fileID uint, expiresAt time.Time) dto.DownloadQuery {
+		expires := expiresAt.Unix()
+		return dto.DownloadQuery{Expires: expires, Signature: utils.SignDownload(itemID, fileID, expires, "secret")}
+	}
+	paidItem := &models.OrderItem{ID: 11, ProductID: 5, Order: models.Order{Status: models.OrderStatusConfirmed}}
+	file := &models.ProductFile{ID: 21, ProductID: 5, Path: "digital/5/abc.pdf", FileName: "book.pdf", ContentType: "application/pdf", Size: 4}
+
+	t.Run("success", func(t *testing.T) {
+		mockOrderRepo.On("GetItemByID", uint(11)).Return(paidItem, nil).Once()
+		mockUploadRepo.On("GetProductFile", uint(21)).Return(file, nil).Once()
+		mockProvider.On("OpenFile", "digital/5/abc.pdf").Return(io.NopCloser(strings.NewReader("data")), nil).Once()
+		mockOrderRepo.On("IncrementDownloadCount", uint(11), 3).Return(true, nil).Once()
+
+		result, err := service.OpenDownload(11, 21, signedQuery(11, 21, time.Now().Add(time.Minute)))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "book.pdf", result.FileName)
+		assert.Equal(t, int64(4), result.Size)
+		mockOrderRepo.AssertExpectations(t)
+		mockProvider.AssertExpectations(t)
+	})
+
+	t.Run("tampered link", func(t *testing.T) {
+		query := signedQuery(11, 21, time.Now().Add(time.Minute))
+		result, err := service.OpenDownload(11, 22, query)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid")
+	})
+
+	t.Run("expired link", func(t *testing.T) {
+		result, err := service.OpenDownload(11, 21, signedQuery(11, 21, time.Now().Add(-time.Minute)))
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "expired")
+	})
+
+	t.Run("file of another product", func(t *testing.T) {
+		mockOrderRepo.On("GetItemByID", uint(11)).Return(paidItem, nil).Once()
+		mockUploadRepo.On("GetProductFile", uint(30)).Return(&models.ProductFile{ID: 30, ProductID: 9}, nil).Once()
+
+		result, err := service.OpenDownload(11, 30, signedQuery(11, 30, time.Now().Add(time.Minute)))
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("download limit reached", func(t *testing.T) {
+		mockOrderRepo.On("GetItemByID", uint(11)).Return(paidItem, nil).Once()
+		mockUploadRepo.On("GetProductFile", uint(21)).Return(file, nil).Once()
+		mockProvider.On("OpenFile", "digital/5/abc.pdf").Return(io.NopCloser(strings.NewReader("data")), nil).Once()
+		mockOrderRepo.On("IncrementDownloadCount", uint(11), 3).Return(false, nil).Once()
+
+		result, err := service.OpenDownload(11, 21, signedQuery(11, 21, time.Now().Add(time.Minute)))
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "limit")
+	})
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/notifications"
+)
+
+// LoginThrottledError is returned by Login while the account or the client IP
+// has to wait after failed attempts. It is returned before the password is
+// checked, so a locked account gives nothing away.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, try again in %s", e.RetryAfter.Round(time.Second))
+}
+
+func accountThrottleKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipThrottleKey(ip string) string {
+	return "ip:" + ip
+}
+
+func loginThrottleKeys(email string, client dto.ClientInfo) []string {
+	keys := []string{accountThrottleKey(email)}
+	if client.IPAddress != "" {
+		keys = append(keys, ipThrottleKey(client.IPAddress))
+	}
+	return keys
+}
+
+// checkLoginThrottle returns a LoginThrottledError when any of the keys is
+// still blocked
+func (s *AuthService) checkLoginThrottle(keys []string, now time.Time) error {
+	var until time.Time
+	for _, key := range keys {
+		blockedUntil, err := s.loginAttempts.BlockedUntil(key)
+		if err != nil {
+			return err
+		}
+		if blockedUntil.After(until) {
+			until = blockedUntil
+		}
+	}
+
+	if until.After(now) {
+		return &LoginThrottledError{RetryAfter: until.Sub(now)}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed login against the account and the client
+// IP. Unknown addresses are counted like real ones so the throttle does not
+// reveal which accounts exist; user is nil for them. Failures to record are
+// only logged, the caller reports invalid credentials either way.
+func (s *AuthService) recordLoginFailure(email string, user *models.User, client dto.ClientInfo, now time.Time) {
+	throttle := s.config.Auth.LoginThrottle
+
+	accountKey := accountThrottleKey(email)
+	failures, err := s.loginAttempts.RecordFailure(accountKey, now, throttle.Window)
+	if err != nil {
+		log.Println("Failed to record login failure:", err)
+	} else if throttle.LockoutThreshold > 0 && failures >= throttle.LockoutThreshold {
+		lockedUntil := now.Add(throttle.LockoutDuration)
+		s.blockLogin(accountKey, lockedUntil)
+
+		// Only the attempt that crosses the threshold emails the owner
+		if failures == throttle.LockoutThreshold && user != nil {
+			s.publishLockout(user, client, lockedUntil)
+		}
+	} else if delay := loginBackoff(failures, throttle.FreeAttempts, throttle); delay > 0 {
+		s.blockLogin(accountKey, now.Add(delay))
+	}
+
+	if client.IPAddress == "" {
+		return
+	}
+
+	ipKey := ipThrottleKey(client.IPAddress)
+	failures, err = s.loginAttempts.RecordFailure(ipKey, now, throttle.Window)
+	if err != nil {
+		log.Println("Failed to record login failure:", err)
+	} else if delay := loginBackoff(failures, throttle.IPFreeAttempts, throttle); delay > 0 {
+		s.blockLogin(ipKey, now.Add(delay))
+	}
+}
+
+func (s *AuthService) blockLogin(key string, until time.Time) {
+	if err := s.loginAttempts.Block(key, until); err != nil {
+		log.Println("Failed to block login:", err)
+	}
+}
+
+func (s *AuthService) publishLockout(user *models.User, client dto.ClientInfo, lockedUntil time.Time) {
+	payload := notifications.UserLockedOutPayload{
+		UserID:      user.ID,
+		Email:       user.Email,
+		Name:        displayName(user),
+		IPAddress:   client.IPAddress,
+		LockedUntil: lockedUntil,
+	}
+	if err := s.eventPublisher.Publish(notifications.UserLockedOut, payload, nil); err != nil {
+		fmt.Println("Failed to publish USER_LOCKED_OUT event:", err)
+	}
+}
+
+// loginBackoff returns how long to wait after the given number of failures.
+// The wait doubles with every failure past the free attempts.
+func loginBackoff(failures, freeAttempts int, throttle config.LoginThrottleConfig) time.Duration {
+	over := failures - freeAttempts
+	if over <= 0 || throttle.BackoffBase <= 0 {
+		return 0
+	}
+
+	delay := throttle.BackoffBase
+	for i := 1; i < over && (throttle.BackoffMax <= 0 || delay < throttle.BackoffMax); i++ {
+		delay *= 2
+	}
+	if throttle.BackoffMax > 0 && delay > throttle.BackoffMax {
+		delay = throttle.BackoffMax
+	}
+	return delay
+}
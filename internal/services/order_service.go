@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/JihadRinaldi/go-shop/internal/config"
 	"github.com/JihadRinaldi/go-shop/internal/dto"
@@ -17,80 +18,162 @@ type OrderService struct {
 	orderRepo   repositories.OrderRepositoryInterface
 	cartRepo    repositories.CartRepositoryInterface
 	productRepo repositories.ProductRepositoryInterface
+	userRepo    repositories.UserRepositoryInterface
+	cartService *CartService
 }
 
-func NewOrderService(db *gorm.DB, config *config.Config) *OrderService {
+func NewOrderService(db *gorm.DB, config *config.Config, cartService *CartService) *OrderService {
 	return &OrderService{
 		db:          db,
 		config:      config,
 		orderRepo:   repositories.NewOrderRepository(db),
 		cartRepo:    repositories.NewCartRepository(db),
 		productRepo: repositories.NewProductRepository(db),
+		userRepo:    repositories.NewUserRepository(db),
+		cartService: cartService,
 	}
 }
 
-func (s *OrderService) CreateOrder(userID uint) (*dto.OrderResponse, error) {
-	var orderResponse *dto.OrderResponse
+// CreateOrder checks out the selected items of the user's cart, or the whole
+// cart when none are selected. Only the ordered items leave the cart.
+func (s *OrderService) CreateOrder(userID uint, req dto.CreateOrderRequest) (*dto.OrderResponse, error) {
+	if err := ensureCanCheckout(s.config, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	var order *models.Order
 
 	err := s.db.Transaction(func(tx *gorm.DB) error {
 		var cart models.Cart
-		if err := tx.Preload("CartItems.Product").Where("user_id = ?", userID).First(&cart).Error; err != nil {
+		err := tx.
+			Preload("CartItems", "saved_for_later = ?", false).
+			Preload("CartItems.Product").
+			Where("user_id = ?", userID).
+			First(&cart).Error
+		if err != nil {
 			return errors.New("cart not found")
 		}
 
-		if len(cart.CartItems) == 0 {
+		cartItems, err := selectCartItems(cart.CartItems, req.CartItemIDs)
+		if err != nil {
+			return err
+		}
+
+		if len(cartItems) == 0 {
 			return errors.New("cart is empty")
 		}
 
-		var totalAmount float64
-		var orderItems []models.OrderItem
+		if issues := validateCartItems(cartItems); len(issues) > 0 {
+			return &CartValidationError{Items: issues}
+		}
 
-		for _, cartItem := range cart.CartItems {
-			if cartItem.Product.Stock < cartItem.Quantity {
-				return errors.New("insufficient stock for product: " + cartItem.Product.Name)
+		lines := make([]checkoutLine, len(cartItems))
+		itemIDs := make([]uint, len(cartItems))
+		for i, cartItem := range cartItems {
+			lines[i] = checkoutLine{
+				Product:     cartItem.Product,
+				Quantity:    cartItem.Quantity,
+				Note:        cartItem.Note,
+				GiftMessage: cartItem.GiftMessage,
 			}
+			itemIDs[i] = cartItem.ID
+		}
 
-			itemTotal := float64(cartItem.Quantity) * cartItem.Product.Price
-			totalAmount += itemTotal
+		// Bundle lines hand the component stock they hold over to the order
+		if err := repositories.NewCartRepository(tx).ReleaseReservations(cart.ID, itemIDs); err != nil {
+			return err
+		}
 
-			orderItems = append(orderItems, models.OrderItem{
-				ProductID: cartItem.ProductID,
-				Quantity:  cartItem.Quantity,
-				Price:     cartItem.Product.Price,
-			})
+		order, err = s.placeOrder(tx, models.Order{UserID: userID}, lines)
+		if err != nil {
+			return err
+		}
 
-			cartItem.Product.Stock -= cartItem.Quantity
-			if err := tx.Save(&cartItem.Product).Error; err != nil {
-				return err
-			}
+		return tx.Where("cart_id = ? AND id IN ?", cart.ID, itemIDs).Delete(&models.CartItem{}).Error
+	})
 
-			order := models.Order{
-				UserID:      userID,
-				Status:      models.OrderStatusPending,
-				TotalAmount: totalAmount,
-				OrderItems:  orderItems,
-			}
+	if err != nil {
+		return nil, err
+	}
 
-			if err := tx.Create(&order).Error; err != nil {
-				return err
-			}
+	return s.orderResponse(order.ID)
+}
 
-			if err := tx.Where("cart_id = ?", cart.ID).Delete(&models.CartItem{}).Error; err != nil {
-				return err
-			}
+// BuyNow orders a single product straight away, leaving the cart untouched
+func (s *OrderService) BuyNow(userID uint, req dto.BuyNowRequest) (*dto.OrderResponse, error) {
+	if err := ensureCanCheckout(s.config, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	var order *models.Order
 
-			response := s.toOrderResponse(&order)
-			orderResponse = &response
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.First(&product, req.ProductID).Error; err != nil || !product.IsActive {
+			return errors.New("product not found")
 		}
 
-		return nil
+		var err error
+		order, err = s.placeOrder(tx, models.Order{UserID: userID}, []checkoutLine{{
+			Product:     product,
+			Quantity:    req.Quantity,
+			Note:        req.Note,
+			GiftMessage: req.GiftMessage,
+		}})
+		return err
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	return orderResponse, nil
+	return s.orderResponse(order.ID)
+}
+
+// CreateSubscriptionOrder places the order for one delivery of a subscription
+// at current prices. It fails as a whole when any product is no longer sold or
+// short on stock, or when the owner still has to verify their email address.
+// The subscription's User must be loaded.
+func (s *OrderService) CreateSubscriptionOrder(tx *gorm.DB, subscription *models.Subscription) (*models.Order, error) {
+	if err := ensureEmailVerified(s.config, &subscription.User); err != nil {
+		return nil, err
+	}
+
+	var order *models.Order
+
+	err := tx.Transaction(func(tx *gorm.DB) error {
+		lines := make([]checkoutLine, len(subscription.Items))
+		for i, item := range subscription.Items {
+			var product models.Product
+			if err := tx.First(&product, item.ProductID).Error; err != nil || !product.IsActive {
+				return fmt.Errorf("product %d is no longer available", item.ProductID)
+			}
+			lines[i] = checkoutLine{Product: product, Quantity: item.Quantity}
+		}
+
+		var err error
+		order, err = s.placeOrder(tx, models.Order{
+			UserID:         subscription.UserID,
+			SubscriptionID: &subscription.ID,
+		}, lines)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Reorder puts the items of one of the user's past orders back into the cart
+func (s *OrderService) Reorder(userID uint, orderID uint) (*dto.ReorderResponse, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil || order.UserID != userID {
+		return nil, errors.New("order not found")
+	}
+
+	return s.cartService.AddOrderItems(userID, order.OrderItems)
 }
 
 func (s *OrderService) GetOrder(userID uint, orderID uint) (*dto.OrderResponse, error) {
@@ -108,6 +191,32 @@ func (s *OrderService) GetOrder(userID uint, orderID uint) (*dto.OrderResponse,
 	return &resp, nil
 }
 
+// UpdateOrderStatus moves an order along its lifecycle: pending, confirmed,
+// shipped and delivered, with cancelling allowed until it ships. Confirming an
+// order records its payment and unlocks the downloads of digital products;
+// cancelling it puts its stock back.
+func (s *OrderService) UpdateOrderStatus(orderID uint, req dto.UpdateOrderStatusRequest) (*dto.OrderResponse, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+
+	status := models.OrderStatus(req.Status)
+	if !order.Status.CanTransitionTo(status) {
+		return nil, fmt.Errorf("order cannot go from %s to %s", order.Status, status)
+	}
+
+	updated, err := s.orderRepo.UpdateStatus(order.ID, order.Status, status)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, errors.New("order status changed meanwhile, please try again")
+	}
+
+	return s.orderResponse(order.ID)
+}
+
 func (s *OrderService) GetOrders(userID uint, page, limit int) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
 	if page < 1 {
 		page = 1
@@ -147,6 +256,16 @@ func (s *OrderService) GetOrders(userID uint, page, limit int) ([]dto.OrderRespo
 	return response, meta, nil
 }
 
+func (s *OrderService) orderResponse(orderID uint) (*dto.OrderResponse, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toOrderResponse(order)
+	return &response, nil
+}
+
 func (s *OrderService) toOrderResponse(order *models.Order) dto.OrderResponse {
 	var orderItems []dto.OrderItemResponse
 
@@ -161,6 +280,15 @@ func (s *OrderService) toOrderResponse(order *models.Order) dto.OrderResponse {
 				IsPrimary: img.IsPrimary,
 			})
 		}
+		var components []dto.OrderItemComponentResponse
+		for _, component := range item.Components {
+			components = append(components, dto.OrderItemComponentResponse{
+				ProductID: component.ProductID,
+				Name:      component.Product.Name,
+				SKU:       component.Product.SKU,
+				Quantity:  component.Quantity,
+			})
+		}
 		orderItems = append(orderItems, dto.OrderItemResponse{
 			ID: item.ID,
 			Product: dto.ProductResponse{
@@ -172,6 +300,8 @@ func (s *OrderService) toOrderResponse(order *models.Order) dto.OrderResponse {
 				Stock:       item.Product.Stock,
 				SKU:         item.Product.SKU,
 				IsActive:    item.Product.IsActive,
+				IsBundle:    item.Product.IsBundle,
+				IsDigital:   item.Product.IsDigital,
 				Category: dto.CategoryResponse{
 					ID:          item.Product.Category.ID,
 					Name:        item.Product.Category.Name,
@@ -182,19 +312,26 @@ func (s *OrderService) toOrderResponse(order *models.Order) dto.OrderResponse {
 				},
 				Images: images,
 			},
-			Quantity:  item.Quantity,
-			Price:     item.Price,
-			CreatedAt: item.CreatedAt,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			Note:        item.Note,
+			GiftMessage: item.GiftMessage,
+			Components:  components,
+			CreatedAt:   item.CreatedAt,
 		})
 	}
 
 	return dto.OrderResponse{
-		ID:          order.ID,
-		UserID:      order.UserID,
-		Status:      string(order.Status),
-		TotalAmount: order.TotalAmount,
-		OrderItems:  orderItems,
-		CreatedAt:   order.CreatedAt,
-		UpdatedAt:   order.UpdatedAt,
+		ID:             order.ID,
+		UserID:         order.UserID,
+		SubscriptionID: order.SubscriptionID,
+		Status:         string(order.Status),
+		Subtotal:       order.Subtotal,
+		TaxAmount:      order.TaxAmount,
+		ShippingAmount: order.ShippingAmount,
+		TotalAmount:    order.TotalAmount,
+		OrderItems:     orderItems,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
 	}
 }
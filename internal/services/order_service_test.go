@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
 	"github.com/JihadRinaldi/go-shop/internal/mocks"
 	"github.com/JihadRinaldi/go-shop/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
@@ -134,3 +136,214 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		assert.True(t, true, "CreateOrder requires integration testing with real DB")
 	})
 }
+
+func TestOrderService_CreateSubscriptionOrder(t *testing.T) {
+	service := &OrderService{
+		db: &gorm.DB{},
+		config: &config.Config{
+			Auth: config.AuthConfig{RequireVerifiedEmail: config.RequireVerifiedEmailForCheckout},
+		},
+	}
+
+	t.Run("owner has not verified their email", func(t *testing.T) {
+		order, err := service.CreateSubscriptionOrder(nil, &models.Subscription{ID: 4, UserID: 1, User: models.User{ID: 1}})
+
+		assert.ErrorIs(t, err, ErrEmailNotVerified)
+		assert.Nil(t, order)
+	})
+}
+
+func TestOrderService_UpdateOrderStatus(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepositoryInterface)
+
+	service := &OrderService{
+		db:        &gorm.DB{},
+		config:    &config.Config{},
+		orderRepo: mockOrderRepo,
+	}
+
+	t.Run("moves the order forward", func(t *testing.T) {
+		mockOrderRepo.On("GetByID", uint(1)).Return(&models.Order{ID: 1, Status: models.OrderStatusPending}, nil).Once()
+		mockOrderRepo.On("UpdateStatus", uint(1), models.OrderStatusPending, models.OrderStatusConfirmed).Return(true, nil).Once()
+		mockOrderRepo.On("GetByID", uint(1)).Return(&models.Order{ID: 1, Status: models.OrderStatusConfirmed}, nil).Once()
+
+		result, err := service.UpdateOrderStatus(1, dto.UpdateOrderStatusRequest{Status: "confirmed"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "confirmed", result.Status)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("refuses a transition outside the lifecycle", func(t *testing.T) {
+		for _, tc := range []struct {
+			from models.OrderStatus
+			to   string
+		}{
+			{models.OrderStatusDelivered, "pending"},
+			{models.OrderStatusShipped, "cancelled"},
+			{models.OrderStatusPending, "delivered"},
+			{models.OrderStatusCancelled, "confirmed"},
+			{models.OrderStatusPending, "refunded"},
+		} {
+			mockOrderRepo.On("GetByID", uint(2)).Return(&models.Order{ID: 2, Status: tc.from}, nil).Once()
+
+			result, err := service.UpdateOrderStatus(2, dto.UpdateOrderStatusRequest{Status: tc.to})
+
+			assert.Error(t, err, "%s to %s", tc.from, tc.to)
+			assert.Nil(t, result)
+		}
+		mockOrderRepo.AssertNotCalled(t, "UpdateStatus", uint(2), mock.Anything, mock.Anything)
+	})
+
+	t.Run("order changed meanwhile", func(t *testing.T) {
+		mockOrderRepo.On("GetByID", uint(3)).Return(&models.Order{ID: 3, Status: models.OrderStatusConfirmed}, nil).Once()
+		mockOrderRepo.On("UpdateStatus", uint(3), models.OrderStatusConfirmed, models.OrderStatusCancelled).Return(false, nil).Once()
+
+		result, err := service.UpdateOrderStatus(3, dto.UpdateOrderStatusRequest{Status: "cancelled"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockOrderRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrderService_PriceOrder(t *testing.T) {
+	service := &OrderService{
+		db: &gorm.DB{},
+		config: &config.Config{Checkout: config.CheckoutConfig{
+			TaxRate:               0.11,
+			ShippingFee:           5,
+			FreeShippingThreshold: 100,
+		}},
+	}
+
+	t.Run("below free shipping threshold", func(t *testing.T) {
+		totals := service.priceOrder(40.5, true)
+
+		assert.Equal(t, 40.5, totals.Subtotal)
+		assert.Equal(t, 4.46, totals.Tax)
+		assert.Equal(t, 5.0, totals.Shipping)
+		assert.Equal(t, 49.96, totals.Total)
+	})
+
+	t.Run("free shipping", func(t *testing.T) {
+		totals := service.priceOrder(100, true)
+
+		assert.Equal(t, 0.0, totals.Shipping)
+		assert.Equal(t, 111.0, totals.Total)
+	})
+
+	t.Run("digital only order has no shipping", func(t *testing.T) {
+		totals := service.priceOrder(20, false)
+
+		assert.Equal(t, 0.0, totals.Shipping)
+		assert.Equal(t, 22.2, totals.Total)
+	})
+}
+
+func TestOrderService_SelectCartItems(t *testing.T) {
+	items := []models.CartItem{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	t.Run("no selection checks out everything", func(t *testing.T) {
+		selected, err := selectCartItems(items, nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, selected, 3)
+	})
+
+	t.Run("subset", func(t *testing.T) {
+		selected, err := selectCartItems(items, []uint{3, 1, 3})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []models.CartItem{{ID: 3}, {ID: 1}}, selected)
+	})
+
+	t.Run("unknown item", func(t *testing.T) {
+		selected, err := selectCartItems(items, []uint{1, 9})
+
+		assert.Error(t, err)
+		assert.Nil(t, selected)
+		assert.Equal(t, "cart item not found", err.Error())
+	})
+}
+
+func TestOrderService_Reorder(t *testing.T) {
+	newService := func() (*OrderService, *mocks.MockOrderRepositoryInterface, *mocks.MockCartRepositoryInterface, *mocks.MockProductRepositoryInterface) {
+		mockOrderRepo := new(mocks.MockOrderRepositoryInterface)
+		mockCartRepo := new(mocks.MockCartRepositoryInterface)
+		mockProductRepo := new(mocks.MockProductRepositoryInterface)
+
+		service := &OrderService{
+			db:          &gorm.DB{},
+			config:      &config.Config{},
+			orderRepo:   mockOrderRepo,
+			cartRepo:    mockCartRepo,
+			productRepo: mockProductRepo,
+			cartService: &CartService{
+				db:          &gorm.DB{},
+				config:      &config.Config{},
+				cartRepo:    mockCartRepo,
+				productRepo: mockProductRepo,
+			},
+		}
+		return service, mockOrderRepo, mockCartRepo, mockProductRepo
+	}
+
+	t.Run("adds available lines and reports the rest", func(t *testing.T) {
+		service, mockOrderRepo, mockCartRepo, mockProductRepo := newService()
+		userID := uint(1)
+
+		mockOrderRepo.On("GetByID", uint(7)).Return(&models.Order{
+			ID:     7,
+			UserID: 1,
+			OrderItems: []models.OrderItem{
+				{ProductID: 10, Quantity: 2, Price: 10, Note: "gift wrap", Product: models.Product{Name: "Mug"}},
+				{ProductID: 11, Quantity: 5, Price: 4, Product: models.Product{Name: "Tea"}},
+				{ProductID: 12, Quantity: 1, Price: 8, Product: models.Product{Name: "Spoon"}},
+				{ProductID: 13, Quantity: 1, Price: 3, Product: models.Product{Name: "Lid"}},
+			},
+		}, nil).Once()
+		mockCartRepo.On("GetByUserID", userID).Return(&models.Cart{ID: 5, UserID: &userID}, nil).Once()
+
+		mockProductRepo.On("GetByID", uint(10)).Return(&models.Product{ID: 10, Name: "Mug", Price: 12, Stock: 10, IsActive: true}, nil).Once()
+		mockCartRepo.On("GetItemByProduct", uint(5), uint(10)).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockCartRepo.On("CreateItem", &models.CartItem{CartID: 5, ProductID: 10, Quantity: 2, UnitPrice: 12, Note: "gift wrap"}).Return(nil).Once()
+
+		mockProductRepo.On("GetByID", uint(11)).Return(&models.Product{ID: 11, Name: "Tea", Price: 4, Stock: 3, IsActive: true}, nil).Once()
+		mockCartRepo.On("GetItemByProduct", uint(5), uint(11)).Return(&models.CartItem{ID: 2, CartID: 5, ProductID: 11, Quantity: 1, UnitPrice: 4}, nil).Once()
+		mockCartRepo.On("UpdateItem", &models.CartItem{ID: 2, CartID: 5, ProductID: 11, Quantity: 3, UnitPrice: 4}).Return(nil).Once()
+
+		mockProductRepo.On("GetByID", uint(12)).Return(&models.Product{ID: 12, Name: "Spoon", IsActive: false}, nil).Once()
+
+		mockProductRepo.On("GetByID", uint(13)).Return(&models.Product{ID: 13, Name: "Lid", Price: 3, Stock: 0, IsActive: true}, nil).Once()
+		mockCartRepo.On("GetItemByProduct", uint(5), uint(13)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		mockCartRepo.On("GetWithItems", uint(5)).Return(&models.Cart{ID: 5, UserID: &userID}, nil).Once()
+
+		result, err := service.Reorder(userID, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.AddedItems)
+		assert.Equal(t, []dto.ReorderIssue{
+			{ProductID: 10, ProductName: "Mug", RequestedQuantity: 2, Quantity: 2, Reason: ReorderReasonPriceChanged, PreviousPrice: 10, CurrentPrice: 12},
+			{ProductID: 11, ProductName: "Tea", RequestedQuantity: 5, Quantity: 2, Reason: ReorderReasonInsufficientStock},
+			{ProductID: 12, ProductName: "Spoon", RequestedQuantity: 1, Reason: ReorderReasonDiscontinued},
+			{ProductID: 13, ProductName: "Lid", RequestedQuantity: 1, Reason: ReorderReasonOutOfStock},
+		}, result.Issues)
+		mockCartRepo.AssertExpectations(t)
+		mockProductRepo.AssertExpectations(t)
+	})
+
+	t.Run("order of another user", func(t *testing.T) {
+		service, mockOrderRepo, mockCartRepo, _ := newService()
+
+		mockOrderRepo.On("GetByID", uint(7)).Return(&models.Order{ID: 7, UserID: 2}, nil).Once()
+
+		result, err := service.Reorder(1, 7)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "order not found", err.Error())
+		mockCartRepo.AssertNotCalled(t, "GetByUserID", mock.Anything)
+	})
+}
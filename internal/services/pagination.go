@@ -0,0 +1,28 @@
+package services
+
+import "github.com/JihadRinaldi/go-shop/internal/utils"
+
+func normalizePage(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	return page, limit
+}
+
+func paginationMeta(page, limit int, total int64) *utils.PaginationMeta {
+	return &utils.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}
+}
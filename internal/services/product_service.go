@@ -1,6 +1,11 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
 	"github.com/JihadRinaldi/go-shop/internal/config"
 	"github.com/JihadRinaldi/go-shop/internal/dto"
 	"github.com/JihadRinaldi/go-shop/internal/models"
@@ -9,108 +14,350 @@ import (
 	"gorm.io/gorm"
 )
 
+const maxSlugAttempts = 50
+
+var attributeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
 type ProductService struct {
-	db          *gorm.DB
-	config      *config.Config
-	productRepo repositories.ProductRepositoryInterface
-	uploadRepo  repositories.UploadRepositoryInterface
+	db            *gorm.DB
+	config        *config.Config
+	productRepo   repositories.ProductRepositoryInterface
+	uploadRepo    repositories.UploadRepositoryInterface
+	attributeRepo repositories.AttributeRepositoryInterface
+	categoryRepo  repositories.CategoryRepositoryInterface
+	slugRepo      repositories.SlugRepositoryInterface
 }
 
 func NewProductService(db *gorm.DB, config *config.Config) *ProductService {
 	return &ProductService{
-		db:          db,
-		config:      config,
-		productRepo: repositories.NewProductRepository(db),
-		uploadRepo:  repositories.NewUploadRepository(db),
+		db:            db,
+		config:        config,
+		productRepo:   repositories.NewProductRepository(db),
+		uploadRepo:    repositories.NewUploadRepository(db),
+		attributeRepo: repositories.NewAttributeRepository(db),
+		categoryRepo:  repositories.NewCategoryRepository(db),
+		slugRepo:      repositories.NewSlugRepository(db),
 	}
 }
 
 func (s *ProductService) CreateCategory(req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
+	if req.ParentID != nil {
+		if _, err := s.categoryRepo.GetByID(*req.ParentID); err != nil {
+			return nil, errors.New("parent category not found")
+		}
+	}
+
+	slug, err := s.prepareSlug(models.SlugEntityCategory, 0, req.Slug, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	category := models.Category{
+		ParentID:    req.ParentID,
 		Name:        req.Name,
+		Slug:        slug,
 		Description: req.Description,
+		SortOrder:   req.SortOrder,
 	}
 
-	if err := s.db.Create(&category).Error; err != nil {
+	if err := s.categoryRepo.Create(&category); err != nil {
 		return nil, err
 	}
 
-	return &dto.CategoryResponse{
-		ID:          category.ID,
-		Name:        category.Name,
-		Description: category.Description,
-		IsActive:    category.IsActive,
-		CreatedAt:   category.CreatedAt,
-		UpdatedAt:   category.UpdatedAt,
-	}, nil
+	response := s.convertToCategoryResponse(&category)
+	return &response, nil
 }
 
 func (s *ProductService) GetCategories() ([]*dto.CategoryResponse, error) {
-	var categories []models.Category
-	if err := s.db.Where("is_active = ?", true).Find(&categories).Error; err != nil {
+	categories, err := s.categoryRepo.GetAll(true)
+	if err != nil {
 		return nil, err
 	}
 
 	var response = make([]*dto.CategoryResponse, len(categories))
 	for i := range categories {
-		response[i] = &dto.CategoryResponse{
-			ID:          categories[i].ID,
-			Name:        categories[i].Name,
-			Description: categories[i].Description,
-			IsActive:    categories[i].IsActive,
-			CreatedAt:   categories[i].CreatedAt,
-			UpdatedAt:   categories[i].UpdatedAt,
-		}
+		category := s.convertToCategoryResponse(&categories[i])
+		response[i] = &category
 	}
 
 	return response, nil
 }
 
+// GetCategoryTree returns active categories nested under their parents.
+// Children of an inactive category are hidden along with it.
+func (s *ProductService) GetCategoryTree() ([]dto.CategoryTreeResponse, error) {
+	categories, err := s.categoryRepo.GetAll(true)
+	if err != nil {
+		return nil, err
+	}
+
+	childrenOf := make(map[uint][]*models.Category)
+	var roots []*models.Category
+	for i := range categories {
+		if categories[i].ParentID == nil {
+			roots = append(roots, &categories[i])
+			continue
+		}
+		childrenOf[*categories[i].ParentID] = append(childrenOf[*categories[i].ParentID], &categories[i])
+	}
+
+	var build func(nodes []*models.Category) []dto.CategoryTreeResponse
+	build = func(nodes []*models.Category) []dto.CategoryTreeResponse {
+		tree := make([]dto.CategoryTreeResponse, len(nodes))
+		for i, node := range nodes {
+			tree[i] = dto.CategoryTreeResponse{
+				CategoryResponse: s.convertToCategoryResponse(node),
+				Children:         build(childrenOf[node.ID]),
+			}
+		}
+		return tree
+	}
+
+	return build(roots), nil
+}
+
 func (s *ProductService) UpdateCategory(id uint, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error) {
-	var category models.Category
-	if err := s.db.First(&category, id).Error; err != nil {
+	category, err := s.categoryRepo.GetByID(id)
+	if err != nil {
 		return nil, err
 	}
 
+	if req.ParentID != nil && req.DetachParent {
+		return nil, errors.New("parent_id and detach_parent cannot be combined")
+	}
+	if req.ParentID != nil {
+		if err := s.validateCategoryParent(id, *req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Slug != "" && req.Slug != category.Slug {
+		if err := s.validateSlug(models.SlugEntityCategory, id, req.Slug); err != nil {
+			return nil, err
+		}
+		category.Slug = req.Slug
+	}
+
+	if req.ParentID != nil {
+		category.ParentID = req.ParentID
+	} else if req.DetachParent {
+		category.ParentID = nil
+	}
 	category.Name = req.Name
 	category.Description = req.Description
-	category.IsActive = *req.IsActive
-	if err := s.db.Save(&category).Error; err != nil {
+	category.SortOrder = req.SortOrder
+	if req.IsActive != nil {
+		category.IsActive = *req.IsActive
+	}
+
+	if err := s.categoryRepo.Update(category); err != nil {
 		return nil, err
 	}
 
-	return &dto.CategoryResponse{
-		ID:          category.ID,
-		Name:        category.Name,
-		Description: category.Description,
-		IsActive:    category.IsActive,
-		CreatedAt:   category.CreatedAt,
-		UpdatedAt:   category.UpdatedAt,
-	}, nil
+	response := s.convertToCategoryResponse(category)
+	return &response, nil
+}
+
+// GetCategoryBySlug looks up a category by its current slug. When the slug
+// has been retired it returns the category's current slug instead.
+func (s *ProductService) GetCategoryBySlug(slug string) (*dto.CategoryResponse, string, error) {
+	category, err := s.categoryRepo.GetBySlug(slug)
+	if err != nil {
+		redirect, redirectErr := s.slugRepo.GetRedirect(models.SlugEntityCategory, slug)
+		if redirectErr != nil {
+			return nil, "", errors.New("category not found")
+		}
+
+		category, err = s.categoryRepo.GetByID(redirect.EntityID)
+		if err != nil {
+			return nil, "", errors.New("category not found")
+		}
+		return nil, category.Slug, nil
+	}
+
+	ancestors, err := s.categoryRepo.GetAncestors(category.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	category.Ancestors = ancestors
+
+	response := s.convertToCategoryResponse(category)
+	response.Breadcrumbs = s.categoryBreadcrumbs(category)
+	return &response, "", nil
+}
+
+// DeleteCategory refuses to delete a category that still holds products. A
+// category with children is only deleted when reparent is set, in which case
+// the children move up to the deleted category's parent.
+func (s *ProductService) DeleteCategory(id uint, reparent bool) error {
+	category, err := s.categoryRepo.GetByID(id)
+	if err != nil {
+		return errors.New("category not found")
+	}
+
+	products, err := s.categoryRepo.CountProducts(id)
+	if err != nil {
+		return err
+	}
+	if products > 0 {
+		return errors.New("category still has products")
+	}
+
+	children, err := s.categoryRepo.CountChildren(id)
+	if err != nil {
+		return err
+	}
+	if children == 0 {
+		return s.categoryRepo.Delete(id)
+	}
+
+	if !reparent {
+		return errors.New("category has subcategories")
+	}
+
+	return s.categoryRepo.DeleteAndReparent(category)
 }
 
-func (s *ProductService) DeleteCategory(id uint) error {
-	return s.db.Delete(&models.Category{}, id).Error
+// validateCategoryParent makes sure that parentID exists and that placing
+// the category under it does not create a cycle
+func (s *ProductService) validateCategoryParent(id, parentID uint) error {
+	if parentID == id {
+		return errors.New("category cannot be its own parent")
+	}
+
+	if _, err := s.categoryRepo.GetByID(parentID); err != nil {
+		return errors.New("parent category not found")
+	}
+
+	ancestors, err := s.categoryRepo.GetAncestors(parentID)
+	if err != nil {
+		return err
+	}
+	for i := range ancestors {
+		if ancestors[i].ID == id {
+			return errors.New("category cannot be moved under its own descendant")
+		}
+	}
+
+	return nil
+}
+
+func (s *ProductService) CreateCategoryAttribute(categoryID uint, req *dto.CreateCategoryAttributeRequest) (*dto.CategoryAttributeResponse, error) {
+	if !attributeCodePattern.MatchString(req.Code) {
+		return nil, errors.New("attribute code must be lowercase letters, digits and underscores")
+	}
+
+	attributeType := models.AttributeType(req.Type)
+	if attributeType == models.AttributeTypeEnum && len(req.Options) == 0 {
+		return nil, errors.New("enum attribute requires options")
+	}
+
+	attributes, err := s.attributeRepo.GetCategoryAttributes(categoryID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range attributes {
+		if attributes[i].Code == req.Code {
+			return nil, errors.New("attribute code already exists in category")
+		}
+	}
+
+	attribute := models.CategoryAttribute{
+		CategoryID: categoryID,
+		Code:       req.Code,
+		Name:       req.Name,
+		Type:       attributeType,
+		Unit:       req.Unit,
+		Options:    models.StringList(req.Options),
+		IsRequired: req.IsRequired,
+		SortOrder:  req.SortOrder,
+	}
+
+	if err := s.attributeRepo.CreateCategoryAttribute(&attribute); err != nil {
+		return nil, err
+	}
+
+	response := s.convertToCategoryAttributeResponse(&attribute)
+	return &response, nil
+}
+
+func (s *ProductService) GetCategoryAttributes(categoryID uint) ([]dto.CategoryAttributeResponse, error) {
+	attributes, err := s.attributeRepo.GetCategoryAttributes(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.CategoryAttributeResponse, len(attributes))
+	for i := range attributes {
+		response[i] = s.convertToCategoryAttributeResponse(&attributes[i])
+	}
+
+	return response, nil
+}
+
+func (s *ProductService) UpdateCategoryAttribute(categoryID, attributeID uint, req *dto.UpdateCategoryAttributeRequest) (*dto.CategoryAttributeResponse, error) {
+	attribute, err := s.attributeRepo.GetCategoryAttributeByID(attributeID)
+	if err != nil || attribute.CategoryID != categoryID {
+		return nil, errors.New("attribute not found")
+	}
+
+	if attribute.Type == models.AttributeTypeEnum && len(req.Options) == 0 {
+		return nil, errors.New("enum attribute requires options")
+	}
+
+	attribute.Name = req.Name
+	attribute.Unit = req.Unit
+	attribute.Options = models.StringList(req.Options)
+	attribute.IsRequired = req.IsRequired
+	attribute.SortOrder = req.SortOrder
+
+	if err := s.attributeRepo.UpdateCategoryAttribute(attribute); err != nil {
+		return nil, err
+	}
+
+	response := s.convertToCategoryAttributeResponse(attribute)
+	return &response, nil
+}
+
+func (s *ProductService) DeleteCategoryAttribute(categoryID, attributeID uint) error {
+	attribute, err := s.attributeRepo.GetCategoryAttributeByID(attributeID)
+	if err != nil || attribute.CategoryID != categoryID {
+		return errors.New("attribute not found")
+	}
+
+	return s.attributeRepo.DeleteCategoryAttribute(attributeID)
 }
 
 func (s *ProductService) CreateProduct(req *dto.CreateProductRequest) (*dto.ProductResponse, error) {
+	attributeValues, err := s.buildAttributeValues(req.CategoryID, req.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	slug, err := s.prepareSlug(models.SlugEntityProduct, 0, req.Slug, req.Name, req.SKU)
+	if err != nil {
+		return nil, err
+	}
+
 	product := models.Product{
 		CategoryID:  req.CategoryID,
 		Name:        req.Name,
+		Slug:        slug,
 		Description: req.Description,
 		Price:       req.Price,
 		Stock:       req.Stock,
 		SKU:         req.SKU,
+		IsDigital:   req.IsDigital,
 	}
 
-	if err := s.productRepo.Create(&product); err != nil {
+	if err := s.productRepo.CreateWithAttributes(&product, attributeValues); err != nil {
 		return nil, err
 	}
 
 	return s.GetProduct(product.ID)
 }
 
-func (s *ProductService) GetProducts(page, limit int) ([]dto.ProductResponse, *utils.PaginationMeta, error) {
+func (s *ProductService) GetProducts(page, limit int, query *dto.ProductListQuery) ([]dto.ProductResponse, *utils.PaginationMeta, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -121,10 +368,12 @@ func (s *ProductService) GetProducts(page, limit int) ([]dto.ProductResponse, *u
 
 	offset := (page - 1) * limit
 
-	var total int64
-	s.db.Model(&models.Product{}).Where("is_active = ?", true).Count(&total)
+	filter := &repositories.ProductFilter{CategoryID: query.CategoryID, Sort: query.Sort}
+	for _, attr := range query.Attributes {
+		filter.Attributes = append(filter.Attributes, repositories.AttributeFilter{Code: attr.Code, Operator: attr.Operator, Value: attr.Value})
+	}
 
-	products, err := s.productRepo.GetAll(limit, offset)
+	products, total, err := s.productRepo.List(filter, limit, offset)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -155,26 +404,119 @@ func (s *ProductService) GetProduct(id uint) (*dto.ProductResponse, error) {
 	return &response, nil
 }
 
+// GetProductBySlug looks up a product by its current slug. When the slug has
+// been retired it returns the product's current slug instead.
+func (s *ProductService) GetProductBySlug(slug string) (*dto.ProductResponse, string, error) {
+	product, err := s.productRepo.GetBySlug(slug)
+	if err != nil {
+		redirect, redirectErr := s.slugRepo.GetRedirect(models.SlugEntityProduct, slug)
+		if redirectErr != nil {
+			return nil, "", errors.New("product not found")
+		}
+
+		product, err = s.productRepo.GetByID(redirect.EntityID)
+		if err != nil {
+			return nil, "", errors.New("product not found")
+		}
+		return nil, product.Slug, nil
+	}
+
+	response := s.convertToProductResponse(product)
+	return &response, "", nil
+}
+
 func (s *ProductService) UpdateProduct(id uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error) {
 	product, err := s.productRepo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	// Values belong to the category's attribute set, so moving a product to
+	// another category without new values drops the old ones
+	categoryChanged := product.CategoryID != req.CategoryID
+	var attributeValues []models.ProductAttributeValue
+	if req.Attributes != nil || categoryChanged {
+		attributeValues, err = s.buildAttributeValues(req.CategoryID, req.Attributes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Slug != "" && req.Slug != product.Slug {
+		if err := s.validateSlug(models.SlugEntityProduct, id, req.Slug); err != nil {
+			return nil, err
+		}
+		product.Slug = req.Slug
+	}
+
 	product.CategoryID = req.CategoryID
 	product.Name = req.Name
 	product.Description = req.Description
 	product.Price = req.Price
-	product.Stock = req.Stock
 	product.IsActive = *req.IsActive
+	if req.IsDigital != nil {
+		product.IsDigital = *req.IsDigital
+	}
+	if !product.IsBundle {
+		product.Stock = req.Stock
+	}
 
-	if err := s.productRepo.Update(product); err != nil {
+	if req.Attributes != nil || categoryChanged {
+		err = s.productRepo.UpdateWithAttributes(product, attributeValues)
+	} else {
+		err = s.productRepo.Update(product)
+	}
+	if err != nil {
 		return nil, err
 	}
 
 	return s.GetProduct(product.ID)
 }
 
+// SetBundleItems makes the product a bundle of the given components, replacing
+// any it had before. Bundles cannot be nested.
+func (s *ProductService) SetBundleItems(productID uint, req *dto.SetBundleItemsRequest) (*dto.ProductResponse, error) {
+	if _, err := s.productRepo.GetByID(productID); err != nil {
+		return nil, errors.New("product not found")
+	}
+
+	isComponent, err := s.productRepo.IsBundleComponent(productID)
+	if err != nil {
+		return nil, err
+	}
+	if isComponent {
+		return nil, errors.New("product is a component of another bundle")
+	}
+
+	items := make([]models.BundleItem, 0, len(req.Items))
+	seen := make(map[uint]bool, len(req.Items))
+	for _, item := range req.Items {
+		if item.ProductID == productID {
+			return nil, errors.New("bundle cannot contain itself")
+		}
+		if seen[item.ProductID] {
+			return nil, fmt.Errorf("product %d is listed more than once", item.ProductID)
+		}
+		seen[item.ProductID] = true
+
+		component, err := s.productRepo.GetByID(item.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("product %d not found", item.ProductID)
+		}
+		if component.IsBundle {
+			return nil, errors.New("bundle cannot contain another bundle: " + component.Name)
+		}
+
+		items = append(items, models.BundleItem{ComponentID: component.ID, Quantity: item.Quantity})
+	}
+
+	if err := s.productRepo.ReplaceBundleItems(productID, items); err != nil {
+		return nil, err
+	}
+
+	return s.GetProduct(productID)
+}
+
 func (s *ProductService) DeleteProduct(id uint) error {
 	return s.productRepo.Delete(id)
 }
@@ -193,6 +535,161 @@ func (s *ProductService) AddProductImage(productID uint, url, altText string) er
 	return s.uploadRepo.CreateProductImage(&image)
 }
 
+// prepareSlug validates a requested slug or, when none was given, derives a
+// free one from the first usable source
+func (s *ProductService) prepareSlug(entityType models.SlugEntityType, id uint, requested string, sources ...string) (string, error) {
+	if requested != "" {
+		if err := s.validateSlug(entityType, id, requested); err != nil {
+			return "", err
+		}
+		return requested, nil
+	}
+
+	base := string(entityType)
+	for _, source := range sources {
+		if slug := utils.Slugify(source); slug != "" {
+			base = slug
+			break
+		}
+	}
+
+	slug := base
+	for i := 2; i <= maxSlugAttempts; i++ {
+		taken, err := s.slugRepo.IsTaken(entityType, slug, id)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	return "", errors.New("unable to generate a unique slug")
+}
+
+func (s *ProductService) validateSlug(entityType models.SlugEntityType, id uint, slug string) error {
+	if !utils.IsValidSlug(slug) {
+		return errors.New("slug must be lowercase letters, digits and single hyphens")
+	}
+
+	taken, err := s.slugRepo.IsTaken(entityType, slug, id)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errors.New("slug already in use")
+	}
+
+	return nil
+}
+
+// buildAttributeValues validates the submitted values against the category's
+// attribute definitions and converts them to typed rows
+func (s *ProductService) buildAttributeValues(categoryID uint, input map[string]interface{}) ([]models.ProductAttributeValue, error) {
+	attributes, err := s.attributeRepo.GetCategoryAttributes(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(attributes))
+	var values []models.ProductAttributeValue
+
+	for i := range attributes {
+		attribute := attributes[i]
+		known[attribute.Code] = true
+
+		raw, ok := input[attribute.Code]
+		if !ok || raw == nil {
+			if attribute.IsRequired {
+				return nil, fmt.Errorf("attribute %s is required", attribute.Code)
+			}
+			continue
+		}
+
+		value := models.ProductAttributeValue{CategoryAttributeID: attribute.ID}
+		switch attribute.Type {
+		case models.AttributeTypeNumber:
+			number, ok := raw.(float64)
+			if !ok {
+				return nil, fmt.Errorf("attribute %s must be a number", attribute.Code)
+			}
+			value.NumberValue = &number
+		case models.AttributeTypeBoolean:
+			boolean, ok := raw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("attribute %s must be a boolean", attribute.Code)
+			}
+			value.BooleanValue = &boolean
+		case models.AttributeTypeEnum:
+			text, ok := raw.(string)
+			if !ok || !attribute.Options.Contains(text) {
+				return nil, fmt.Errorf("attribute %s must be one of %v", attribute.Code, []string(attribute.Options))
+			}
+			value.TextValue = &text
+		default:
+			text, ok := raw.(string)
+			if !ok {
+				return nil, fmt.Errorf("attribute %s must be a string", attribute.Code)
+			}
+			value.TextValue = &text
+		}
+
+		values = append(values, value)
+	}
+
+	for code := range input {
+		if !known[code] {
+			return nil, fmt.Errorf("unknown attribute: %s", code)
+		}
+	}
+
+	return values, nil
+}
+
+func (s *ProductService) categoryBreadcrumbs(category *models.Category) []dto.CategoryBreadcrumb {
+	breadcrumbs := make([]dto.CategoryBreadcrumb, 0, len(category.Ancestors)+1)
+	for _, ancestor := range category.Ancestors {
+		breadcrumbs = append(breadcrumbs, dto.CategoryBreadcrumb{ID: ancestor.ID, Name: ancestor.Name, Slug: ancestor.Slug})
+	}
+	return append(breadcrumbs, dto.CategoryBreadcrumb{ID: category.ID, Name: category.Name, Slug: category.Slug})
+}
+
+func (s *ProductService) convertToCategoryResponse(category *models.Category) dto.CategoryResponse {
+	return dto.CategoryResponse{
+		ID:          category.ID,
+		ParentID:    category.ParentID,
+		Name:        category.Name,
+		Slug:        category.Slug,
+		Description: category.Description,
+		IsActive:    category.IsActive,
+		SortOrder:   category.SortOrder,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+}
+
+func (s *ProductService) convertToCategoryAttributeResponse(attribute *models.CategoryAttribute) dto.CategoryAttributeResponse {
+	options := []string(attribute.Options)
+	if options == nil {
+		options = []string{}
+	}
+
+	return dto.CategoryAttributeResponse{
+		ID:         attribute.ID,
+		CategoryID: attribute.CategoryID,
+		Code:       attribute.Code,
+		Name:       attribute.Name,
+		Type:       string(attribute.Type),
+		Unit:       attribute.Unit,
+		Options:    options,
+		IsRequired: attribute.IsRequired,
+		SortOrder:  attribute.SortOrder,
+		CreatedAt:  attribute.CreatedAt,
+		UpdatedAt:  attribute.UpdatedAt,
+	}
+}
+
 func (s *ProductService) convertToProductResponse(product *models.Product) dto.ProductResponse {
 	images := make([]dto.ProductImageResponse, len(product.Images))
 	for i := range product.Images {
@@ -205,25 +702,57 @@ func (s *ProductService) convertToProductResponse(product *models.Product) dto.P
 		}
 	}
 
+	sort.SliceStable(product.Attributes, func(i, j int) bool {
+		return product.Attributes[i].CategoryAttribute.SortOrder < product.Attributes[j].CategoryAttribute.SortOrder
+	})
+
+	attributes := make([]dto.ProductAttributeResponse, len(product.Attributes))
+	for i := range product.Attributes {
+		attribute := product.Attributes[i].CategoryAttribute
+		attributes[i] = dto.ProductAttributeResponse{
+			AttributeID: attribute.ID,
+			Code:        attribute.Code,
+			Name:        attribute.Name,
+			Type:        string(attribute.Type),
+			Unit:        attribute.Unit,
+			Value:       product.Attributes[i].TypedValue(),
+		}
+	}
+
+	var bundleItems []dto.BundleItemResponse
+	for _, item := range product.BundleItems {
+		bundleItems = append(bundleItems, dto.BundleItemResponse{
+			ProductID: item.ComponentID,
+			Name:      item.Component.Name,
+			SKU:       item.Component.SKU,
+			Quantity:  item.Quantity,
+			Stock:     item.Component.Stock,
+			IsActive:  item.Component.IsActive,
+		})
+	}
+
+	category := s.convertToCategoryResponse(&product.Category)
+	category.Breadcrumbs = s.categoryBreadcrumbs(&product.Category)
+
 	return dto.ProductResponse{
-		ID:          product.ID,
-		CategoryID:  product.CategoryID,
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		Stock:       product.Stock,
-		SKU:         product.SKU,
-		IsActive:    product.IsActive,
-		Category: dto.CategoryResponse{
-			ID:          product.Category.ID,
-			Name:        product.Category.Name,
-			Description: product.Category.Description,
-			IsActive:    product.Category.IsActive,
-			CreatedAt:   product.Category.CreatedAt,
-			UpdatedAt:   product.Category.UpdatedAt,
-		},
-		Images:    images,
-		CreatedAt: product.CreatedAt,
-		UpdatedAt: product.UpdatedAt,
+		ID:            product.ID,
+		CategoryID:    product.CategoryID,
+		Name:          product.Name,
+		Slug:          product.Slug,
+		Description:   product.Description,
+		Price:         product.Price,
+		Stock:         product.Stock,
+		SKU:           product.SKU,
+		IsActive:      product.IsActive,
+		IsBundle:      product.IsBundle,
+		IsDigital:     product.IsDigital,
+		RatingAverage: product.RatingAverage,
+		RatingCount:   product.RatingCount,
+		Category:      category,
+		Images:        images,
+		Attributes:    attributes,
+		BundleItems:   bundleItems,
+		CreatedAt:     product.CreatedAt,
+		UpdatedAt:     product.UpdatedAt,
 	}
 }
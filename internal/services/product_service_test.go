@@ -17,12 +17,16 @@ import (
 func TestProductService_GetProduct(t *testing.T) {
 	mockProductRepo := new(mocks.MockProductRepositoryInterface)
 	mockUploadRepo := new(mocks.MockUploadRepositoryInterface)
+	mockAttributeRepo := new(mocks.MockAttributeRepositoryInterface)
+	mockSlugRepo := new(mocks.MockSlugRepositoryInterface)
 
 	service := &ProductService{
-		db:          &gorm.DB{},
-		config:      &config.Config{},
-		productRepo: mockProductRepo,
-		uploadRepo:  mockUploadRepo,
+		db:            &gorm.DB{},
+		config:        &config.Config{},
+		productRepo:   mockProductRepo,
+		uploadRepo:    mockUploadRepo,
+		attributeRepo: mockAttributeRepo,
+		slugRepo:      mockSlugRepo,
 	}
 
 	t.Run("success", func(t *testing.T) {
@@ -75,12 +79,16 @@ func TestProductService_GetProduct(t *testing.T) {
 func TestProductService_CreateProduct(t *testing.T) {
 	mockProductRepo := new(mocks.MockProductRepositoryInterface)
 	mockUploadRepo := new(mocks.MockUploadRepositoryInterface)
+	mockAttributeRepo := new(mocks.MockAttributeRepositoryInterface)
+	mockSlugRepo := new(mocks.MockSlugRepositoryInterface)
 
 	service := &ProductService{
-		db:          &gorm.DB{},
-		config:      &config.Config{},
-		productRepo: mockProductRepo,
-		uploadRepo:  mockUploadRepo,
+		db:            &gorm.DB{},
+		config:        &config.Config{},
+		productRepo:   mockProductRepo,
+		uploadRepo:    mockUploadRepo,
+		attributeRepo: mockAttributeRepo,
+		slugRepo:      mockSlugRepo,
 	}
 
 	t.Run("success", func(t *testing.T) {
@@ -109,7 +117,9 @@ func TestProductService_CreateProduct(t *testing.T) {
 			Images: []models.ProductImage{},
 		}
 
-		mockProductRepo.On("Create", mock.AnythingOfType("*models.Product")).Return(nil).Once()
+		mockAttributeRepo.On("GetCategoryAttributes", req.CategoryID).Return([]models.CategoryAttribute{}, nil).Once()
+		mockSlugRepo.On("IsTaken", models.SlugEntityProduct, "new-product", uint(0)).Return(false, nil).Once()
+		mockProductRepo.On("CreateWithAttributes", mock.AnythingOfType("*models.Product"), mock.Anything).Return(nil).Once()
 		mockProductRepo.On("GetByID", mock.AnythingOfType("uint")).Return(createdProduct, nil).Once()
 
 		result, err := service.CreateProduct(req)
@@ -131,7 +141,9 @@ func TestProductService_CreateProduct(t *testing.T) {
 			SKU:         "NEW-001",
 		}
 
-		mockProductRepo.On("Create", mock.AnythingOfType("*models.Product")).Return(errors.New("create failed")).Once()
+		mockAttributeRepo.On("GetCategoryAttributes", req.CategoryID).Return([]models.CategoryAttribute{}, nil).Once()
+		mockSlugRepo.On("IsTaken", models.SlugEntityProduct, "new-product", uint(0)).Return(false, nil).Once()
+		mockProductRepo.On("CreateWithAttributes", mock.AnythingOfType("*models.Product"), mock.Anything).Return(errors.New("create failed")).Once()
 
 		result, err := service.CreateProduct(req)
 
@@ -144,12 +156,16 @@ func TestProductService_CreateProduct(t *testing.T) {
 func TestProductService_UpdateProduct(t *testing.T) {
 	mockProductRepo := new(mocks.MockProductRepositoryInterface)
 	mockUploadRepo := new(mocks.MockUploadRepositoryInterface)
+	mockAttributeRepo := new(mocks.MockAttributeRepositoryInterface)
+	mockSlugRepo := new(mocks.MockSlugRepositoryInterface)
 
 	service := &ProductService{
-		db:          &gorm.DB{},
-		config:      &config.Config{},
-		productRepo: mockProductRepo,
-		uploadRepo:  mockUploadRepo,
+		db:            &gorm.DB{},
+		config:        &config.Config{},
+		productRepo:   mockProductRepo,
+		uploadRepo:    mockUploadRepo,
+		attributeRepo: mockAttributeRepo,
+		slugRepo:      mockSlugRepo,
 	}
 
 	t.Run("success", func(t *testing.T) {
@@ -207,6 +223,25 @@ func TestProductService_UpdateProduct(t *testing.T) {
 		mockProductRepo.AssertExpectations(t)
 	})
 
+	t.Run("moving category replaces the attributes with the product", func(t *testing.T) {
+		isActive := true
+		req := &dto.UpdateProductRequest{CategoryID: 2, Name: "Moved Product", Price: 200.0, IsActive: &isActive}
+		existingProduct := &models.Product{ID: 2, CategoryID: 1, Name: "Old Product", Slug: "old-product"}
+
+		mockProductRepo.On("GetByID", uint(2)).Return(existingProduct, nil).Once()
+		mockAttributeRepo.On("GetCategoryAttributes", uint(2)).Return([]models.CategoryAttribute{}, nil).Once()
+		mockProductRepo.On("UpdateWithAttributes", existingProduct, mock.MatchedBy(func(values []models.ProductAttributeValue) bool {
+			return len(values) == 0
+		})).Return(nil).Once()
+		mockProductRepo.On("GetByID", uint(2)).Return(existingProduct, nil).Once()
+
+		result, err := service.UpdateProduct(2, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), result.CategoryID)
+		mockProductRepo.AssertExpectations(t)
+	})
+
 	t.Run("product not found", func(t *testing.T) {
 		productID := uint(999)
 		isActive := true
@@ -232,12 +267,16 @@ func TestProductService_UpdateProduct(t *testing.T) {
 func TestProductService_DeleteProduct(t *testing.T) {
 	mockProductRepo := new(mocks.MockProductRepositoryInterface)
 	mockUploadRepo := new(mocks.MockUploadRepositoryInterface)
+	mockAttributeRepo := new(mocks.MockAttributeRepositoryInterface)
+	mockSlugRepo := new(mocks.MockSlugRepositoryInterface)
 
 	service := &ProductService{
-		db:          &gorm.DB{},
-		config:      &config.Config{},
-		productRepo: mockProductRepo,
-		uploadRepo:  mockUploadRepo,
+		db:            &gorm.DB{},
+		config:        &config.Config{},
+		productRepo:   mockProductRepo,
+		uploadRepo:    mockUploadRepo,
+		attributeRepo: mockAttributeRepo,
+		slugRepo:      mockSlugRepo,
 	}
 
 	t.Run("success", func(t *testing.T) {
@@ -266,12 +305,16 @@ func TestProductService_DeleteProduct(t *testing.T) {
 func TestProductService_AddProductImage(t *testing.T) {
 	mockProductRepo := new(mocks.MockProductRepositoryInterface)
 	mockUploadRepo := new(mocks.MockUploadRepositoryInterface)
+	mockAttributeRepo := new(mocks.MockAttributeRepositoryInterface)
+	mockSlugRepo := new(mocks.MockSlugRepositoryInterface)
 
 	service := &ProductService{
-		db:          &gorm.DB{},
-		config:      &config.Config{},
-		productRepo: mockProductRepo,
-		uploadRepo:  mockUploadRepo,
+		db:            &gorm.DB{},
+		config:        &config.Config{},
+		productRepo:   mockProductRepo,
+		uploadRepo:    mockUploadRepo,
+		attributeRepo: mockAttributeRepo,
+		slugRepo:      mockSlugRepo,
 	}
 
 	t.Run("success - first image", func(t *testing.T) {
@@ -325,3 +368,587 @@ func TestProductService_AddProductImage(t *testing.T) {
 		mockUploadRepo.AssertExpectations(t)
 	})
 }
+
+func TestProductService_CreateProductAttributes(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepositoryInterface)
+	mockUploadRepo := new(mocks.MockUploadRepositoryInterface)
+	mockAttributeRepo := new(mocks.MockAttributeRepositoryInterface)
+	mockSlugRepo := new(mocks.MockSlugRepositoryInterface)
+
+	service := &ProductService{
+		db:            &gorm.DB{},
+		config:        &config.Config{},
+		productRepo:   mockProductRepo,
+		uploadRepo:    mockUploadRepo,
+		attributeRepo: mockAttributeRepo,
+		slugRepo:      mockSlugRepo,
+	}
+
+	categoryAttributes := []models.CategoryAttribute{
+		{ID: 1, CategoryID: 1, Code: "screen_size", Name: "Screen size", Type: models.AttributeTypeNumber, Unit: "in", IsRequired: true},
+		{ID: 2, CategoryID: 1, Code: "color", Name: "Color", Type: models.AttributeTypeEnum, Options: models.StringList{"black", "silver"}},
+		{ID: 3, CategoryID: 1, Code: "touchscreen", Name: "Touchscreen", Type: models.AttributeTypeBoolean},
+	}
+
+	newRequest := func(attributes map[string]interface{}) *dto.CreateProductRequest {
+		return &dto.CreateProductRequest{
+			CategoryID: 1,
+			Name:       "Laptop",
+			Price:      999.0,
+			Stock:      5,
+			SKU:        "LAP-001",
+			Attributes: attributes,
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		req := newRequest(map[string]interface{}{"screen_size": 13.3, "color": "silver", "touchscreen": false})
+		screenSize := 13.3
+
+		createdProduct := &models.Product{
+			ID:         1,
+			CategoryID: 1,
+			Name:       req.Name,
+			Price:      req.Price,
+			Attributes: []models.ProductAttributeValue{
+				{CategoryAttributeID: 1, NumberValue: &screenSize, CategoryAttribute: categoryAttributes[0]},
+			},
+		}
+
+		mockAttributeRepo.On("GetCategoryAttributes", uint(1)).Return(categoryAttributes, nil).Once()
+		mockSlugRepo.On("IsTaken", models.SlugEntityProduct, "laptop", uint(0)).Return(false, nil).Once()
+		mockProductRepo.On("CreateWithAttributes", mock.AnythingOfType("*models.Product"), mock.MatchedBy(func(values []models.ProductAttributeValue) bool {
+			return len(values) == 3
+		})).Return(nil).Once()
+		mockProductRepo.On("GetByID", mock.AnythingOfType("uint")).Return(createdProduct, nil).Once()
+
+		result, err := service.CreateProduct(req)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Attributes, 1)
+		assert.Equal(t, "screen_size", result.Attributes[0].Code)
+		assert.Equal(t, 13.3, result.Attributes[0].Value)
+		mockAttributeRepo.AssertExpectations(t)
+		mockProductRepo.AssertExpectations(t)
+	})
+
+	t.Run("missing required attribute", func(t *testing.T) {
+		req := newRequest(map[string]interface{}{"color": "black"})
+
+		mockAttributeRepo.On("GetCategoryAttributes", uint(1)).Return(categoryAttributes, nil).Once()
+
+		result, err := service.CreateProduct(req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "attribute screen_size is required")
+	})
+
+	t.Run("wrong value type", func(t *testing.T) {
+		req := newRequest(map[string]interface{}{"screen_size": "large"})
+
+		mockAttributeRepo.On("GetCategoryAttributes", uint(1)).Return(categoryAttributes, nil).Once()
+
+		result, err := service.CreateProduct(req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "must be a number")
+	})
+
+	t.Run("enum value not in options", func(t *testing.T) {
+		req := newRequest(map[string]interface{}{"screen_size": 15.0, "color": "gold"})
+
+		mockAttributeRepo.On("GetCategoryAttributes", uint(1)).Return(categoryAttributes, nil).Once()
+
+		result, err := service.CreateProduct(req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "attribute color must be one of")
+	})
+
+	t.Run("unknown attribute", func(t *testing.T) {
+		req := newRequest(map[string]interface{}{"screen_size": 15.0, "weight": 2.0})
+
+		mockAttributeRepo.On("GetCategoryAttributes", uint(1)).Return(categoryAttributes, nil).Once()
+
+		result, err := service.CreateProduct(req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "unknown attribute: weight")
+	})
+}
+
+func TestProductService_CreateCategoryAttribute(t *testing.T) {
+	mockAttributeRepo := new(mocks.MockAttributeRepositoryInterface)
+
+	service := &ProductService{
+		db:            &gorm.DB{},
+		config:        &config.Config{},
+		attributeRepo: mockAttributeRepo,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		req := &dto.CreateCategoryAttributeRequest{
+			Code: "screen_size",
+			Name: "Screen size",
+			Type: "number",
+			Unit: "in",
+		}
+
+		mockAttributeRepo.On("GetCategoryAttributes", uint(1)).Return([]models.CategoryAttribute{}, nil).Once()
+		mockAttributeRepo.On("CreateCategoryAttribute", mock.AnythingOfType("*models.CategoryAttribute")).Return(nil).Once()
+
+		result, err := service.CreateCategoryAttribute(1, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "screen_size", result.Code)
+		assert.Equal(t, "number", result.Type)
+		mockAttributeRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		req := &dto.CreateCategoryAttributeRequest{Code: "Screen Size", Name: "Screen size", Type: "number"}
+
+		result, err := service.CreateCategoryAttribute(1, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("enum without options", func(t *testing.T) {
+		req := &dto.CreateCategoryAttributeRequest{Code: "color", Name: "Color", Type: "enum"}
+
+		result, err := service.CreateCategoryAttribute(1, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "enum attribute requires options")
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		req := &dto.CreateCategoryAttributeRequest{Code: "color", Name: "Color", Type: "text"}
+
+		mockAttributeRepo.On("GetCategoryAttributes", uint(1)).Return([]models.CategoryAttribute{{ID: 1, Code: "color"}}, nil).Once()
+
+		result, err := service.CreateCategoryAttribute(1, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "already exists")
+	})
+}
+
+func TestProductService_UpdateCategory(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepositoryInterface)
+
+	service := &ProductService{
+		db:           &gorm.DB{},
+		config:       &config.Config{},
+		categoryRepo: mockCategoryRepo,
+	}
+
+	t.Run("success - move under new parent", func(t *testing.T) {
+		parentID := uint(2)
+		req := &dto.UpdateCategoryRequest{Name: "Laptops", ParentID: &parentID, SortOrder: 1}
+
+		mockCategoryRepo.On("GetByID", uint(3)).Return(&models.Category{ID: 3, Name: "Notebooks", IsActive: true}, nil).Once()
+		mockCategoryRepo.On("GetByID", parentID).Return(&models.Category{ID: 2, Name: "Computers"}, nil).Once()
+		mockCategoryRepo.On("GetAncestors", parentID).Return([]models.Category{{ID: 1, Name: "Electronics"}}, nil).Once()
+		mockCategoryRepo.On("Update", mock.AnythingOfType("*models.Category")).Return(nil).Once()
+
+		result, err := service.UpdateCategory(3, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Laptops", result.Name)
+		assert.Equal(t, &parentID, result.ParentID)
+		assert.True(t, result.IsActive)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("parent is kept when not given", func(t *testing.T) {
+		parentID := uint(2)
+		req := &dto.UpdateCategoryRequest{Name: "Laptops"}
+
+		mockCategoryRepo.On("GetByID", uint(3)).Return(&models.Category{ID: 3, Name: "Notebooks", ParentID: &parentID}, nil).Once()
+		mockCategoryRepo.On("Update", mock.AnythingOfType("*models.Category")).Return(nil).Once()
+
+		result, err := service.UpdateCategory(3, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &parentID, result.ParentID)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("detach from parent", func(t *testing.T) {
+		parentID := uint(2)
+		req := &dto.UpdateCategoryRequest{Name: "Laptops", DetachParent: true}
+
+		mockCategoryRepo.On("GetByID", uint(3)).Return(&models.Category{ID: 3, Name: "Notebooks", ParentID: &parentID}, nil).Once()
+		mockCategoryRepo.On("Update", mock.AnythingOfType("*models.Category")).Return(nil).Once()
+
+		result, err := service.UpdateCategory(3, req)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.ParentID)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("own parent", func(t *testing.T) {
+		parentID := uint(3)
+		req := &dto.UpdateCategoryRequest{Name: "Laptops", ParentID: &parentID}
+
+		mockCategoryRepo.On("GetByID", uint(3)).Return(&models.Category{ID: 3}, nil).Once()
+
+		result, err := service.UpdateCategory(3, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "own parent")
+	})
+
+	t.Run("cycle through descendant", func(t *testing.T) {
+		parentID := uint(5)
+		req := &dto.UpdateCategoryRequest{Name: "Electronics", ParentID: &parentID}
+
+		mockCategoryRepo.On("GetByID", uint(1)).Return(&models.Category{ID: 1}, nil).Once()
+		mockCategoryRepo.On("GetByID", parentID).Return(&models.Category{ID: 5}, nil).Once()
+		mockCategoryRepo.On("GetAncestors", parentID).Return([]models.Category{{ID: 1}, {ID: 2}}, nil).Once()
+
+		result, err := service.UpdateCategory(1, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "own descendant")
+		mockCategoryRepo.AssertExpectations(t)
+	})
+}
+
+func TestProductService_DeleteCategory(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepositoryInterface)
+
+	service := &ProductService{
+		db:           &gorm.DB{},
+		config:       &config.Config{},
+		categoryRepo: mockCategoryRepo,
+	}
+
+	t.Run("blocked by products", func(t *testing.T) {
+		mockCategoryRepo.On("GetByID", uint(1)).Return(&models.Category{ID: 1}, nil).Once()
+		mockCategoryRepo.On("CountProducts", uint(1)).Return(int64(3), nil).Once()
+
+		err := service.DeleteCategory(1, true)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "still has products")
+		mockCategoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("blocked by children", func(t *testing.T) {
+		mockCategoryRepo.On("GetByID", uint(2)).Return(&models.Category{ID: 2}, nil).Once()
+		mockCategoryRepo.On("CountProducts", uint(2)).Return(int64(0), nil).Once()
+		mockCategoryRepo.On("CountChildren", uint(2)).Return(int64(2), nil).Once()
+
+		err := service.DeleteCategory(2, false)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "has subcategories")
+		mockCategoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("reparent children", func(t *testing.T) {
+		parentID := uint(1)
+		category := &models.Category{ID: 2, ParentID: &parentID}
+
+		mockCategoryRepo.On("GetByID", uint(2)).Return(category, nil).Once()
+		mockCategoryRepo.On("CountProducts", uint(2)).Return(int64(0), nil).Once()
+		mockCategoryRepo.On("CountChildren", uint(2)).Return(int64(2), nil).Once()
+		mockCategoryRepo.On("DeleteAndReparent", category).Return(nil).Once()
+
+		err := service.DeleteCategory(2, true)
+
+		assert.NoError(t, err)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+}
+
+func TestProductService_GetCategoryTree(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepositoryInterface)
+
+	service := &ProductService{
+		db:           &gorm.DB{},
+		config:       &config.Config{},
+		categoryRepo: mockCategoryRepo,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		electronics, computers, orphanParent := uint(1), uint(2), uint(99)
+		categories := []models.Category{
+			{ID: 1, Name: "Electronics"},
+			{ID: 2, Name: "Computers", ParentID: &electronics},
+			{ID: 3, Name: "Laptops", ParentID: &computers},
+			{ID: 4, Name: "Books"},
+			{ID: 5, Name: "Hidden", ParentID: &orphanParent},
+		}
+
+		mockCategoryRepo.On("GetAll", true).Return(categories, nil).Once()
+
+		result, err := service.GetCategoryTree()
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, "Electronics", result[0].Name)
+		assert.Len(t, result[0].Children, 1)
+		assert.Equal(t, "Laptops", result[0].Children[0].Children[0].Name)
+		assert.Equal(t, "Books", result[1].Name)
+		mockCategoryRepo.AssertExpectations(t)
+	})
+}
+
+func TestProductService_GetProductBreadcrumbs(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepositoryInterface)
+
+	service := &ProductService{
+		db:          &gorm.DB{},
+		config:      &config.Config{},
+		productRepo: mockProductRepo,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		product := &models.Product{
+			ID:   1,
+			Name: "Laptop",
+			Category: models.Category{
+				ID:        3,
+				Name:      "Laptops",
+				Ancestors: []models.Category{{ID: 1, Name: "Electronics"}, {ID: 2, Name: "Computers"}},
+			},
+		}
+
+		mockProductRepo.On("GetByID", uint(1)).Return(product, nil).Once()
+
+		result, err := service.GetProduct(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.CategoryBreadcrumb{
+			{ID: 1, Name: "Electronics"},
+			{ID: 2, Name: "Computers"},
+			{ID: 3, Name: "Laptops"},
+		}, result.Category.Breadcrumbs)
+		mockProductRepo.AssertExpectations(t)
+	})
+}
+
+func TestProductService_ProductSlugs(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepositoryInterface)
+	mockAttributeRepo := new(mocks.MockAttributeRepositoryInterface)
+	mockSlugRepo := new(mocks.MockSlugRepositoryInterface)
+
+	service := &ProductService{
+		db:            &gorm.DB{},
+		config:        &config.Config{},
+		productRepo:   mockProductRepo,
+		attributeRepo: mockAttributeRepo,
+		slugRepo:      mockSlugRepo,
+	}
+
+	t.Run("generated slug skips taken candidates", func(t *testing.T) {
+		req := &dto.CreateProductRequest{CategoryID: 1, Name: "Gaming Mouse!", Price: 50, SKU: "GM-1"}
+
+		mockAttributeRepo.On("GetCategoryAttributes", uint(1)).Return([]models.CategoryAttribute{}, nil).Once()
+		mockSlugRepo.On("IsTaken", models.SlugEntityProduct, "gaming-mouse", uint(0)).Return(true, nil).Once()
+		mockSlugRepo.On("IsTaken", models.SlugEntityProduct, "gaming-mouse-2", uint(0)).Return(false, nil).Once()
+		mockProductRepo.On("CreateWithAttributes", mock.MatchedBy(func(p *models.Product) bool {
+			return p.Slug == "gaming-mouse-2"
+		}), mock.Anything).Return(nil).Once()
+		mockProductRepo.On("GetByID", mock.AnythingOfType("uint")).Return(&models.Product{ID: 1, Slug: "gaming-mouse-2"}, nil).Once()
+
+		result, err := service.CreateProduct(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "gaming-mouse-2", result.Slug)
+		mockSlugRepo.AssertExpectations(t)
+		mockProductRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid explicit slug", func(t *testing.T) {
+		req := &dto.CreateProductRequest{CategoryID: 1, Name: "Mouse", Slug: "Bad Slug", Price: 50, SKU: "M-1"}
+
+		mockAttributeRepo.On("GetCategoryAttributes", uint(1)).Return([]models.CategoryAttribute{}, nil).Once()
+
+		result, err := service.CreateProduct(req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("changing slug", func(t *testing.T) {
+		isActive := true
+		req := &dto.UpdateProductRequest{CategoryID: 1, Name: "Mouse", Slug: "wireless-mouse", Price: 60, IsActive: &isActive}
+		existing := &models.Product{ID: 7, CategoryID: 1, Name: "Mouse", Slug: "mouse"}
+
+		mockProductRepo.On("GetByID", uint(7)).Return(existing, nil).Once()
+		mockSlugRepo.On("IsTaken", models.SlugEntityProduct, "wireless-mouse", uint(7)).Return(false, nil).Once()
+		mockProductRepo.On("Update", mock.AnythingOfType("*models.Product")).Return(nil).Once()
+		mockProductRepo.On("GetByID", uint(7)).Return(&models.Product{ID: 7, Slug: "wireless-mouse"}, nil).Once()
+
+		result, err := service.UpdateProduct(7, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "wireless-mouse", result.Slug)
+		mockSlugRepo.AssertExpectations(t)
+	})
+
+	t.Run("slug already in use", func(t *testing.T) {
+		isActive := true
+		req := &dto.UpdateProductRequest{CategoryID: 1, Name: "Mouse", Slug: "keyboard", Price: 60, IsActive: &isActive}
+
+		mockProductRepo.On("GetByID", uint(7)).Return(&models.Product{ID: 7, CategoryID: 1, Slug: "mouse"}, nil).Once()
+		mockSlugRepo.On("IsTaken", models.SlugEntityProduct, "keyboard", uint(7)).Return(true, nil).Once()
+
+		result, err := service.UpdateProduct(7, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "slug already in use")
+	})
+
+	t.Run("lookup by current slug", func(t *testing.T) {
+		mockProductRepo.On("GetBySlug", "wireless-mouse").Return(&models.Product{ID: 7, Slug: "wireless-mouse"}, nil).Once()
+
+		result, currentSlug, err := service.GetProductBySlug("wireless-mouse")
+
+		assert.NoError(t, err)
+		assert.Empty(t, currentSlug)
+		assert.Equal(t, uint(7), result.ID)
+	})
+
+	t.Run("lookup by retired slug", func(t *testing.T) {
+		mockProductRepo.On("GetBySlug", "mouse").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockSlugRepo.On("GetRedirect", models.SlugEntityProduct, "mouse").Return(&models.SlugRedirect{EntityID: 7}, nil).Once()
+		mockProductRepo.On("GetByID", uint(7)).Return(&models.Product{ID: 7, Slug: "wireless-mouse"}, nil).Once()
+
+		result, currentSlug, err := service.GetProductBySlug("mouse")
+
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "wireless-mouse", currentSlug)
+	})
+
+	t.Run("unknown slug", func(t *testing.T) {
+		mockProductRepo.On("GetBySlug", "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockSlugRepo.On("GetRedirect", models.SlugEntityProduct, "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, _, err := service.GetProductBySlug("missing")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestProductService_SetBundleItems(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepositoryInterface)
+
+	service := &ProductService{
+		db:          &gorm.DB{},
+		config:      &config.Config{},
+		productRepo: mockProductRepo,
+	}
+
+	bundle := &models.Product{ID: 10, Name: "Starter Kit", Price: 80}
+	mouse := &models.Product{ID: 1, Name: "Mouse", SKU: "M-1", Stock: 9, IsActive: true}
+	pad := &models.Product{ID: 2, Name: "Mouse Pad", SKU: "P-1", Stock: 4, IsActive: true}
+
+	t.Run("defines components", func(t *testing.T) {
+		req := &dto.SetBundleItemsRequest{Items: []dto.BundleItemRequest{
+			{ProductID: 1, Quantity: 1},
+			{ProductID: 2, Quantity: 2},
+		}}
+		saved := &models.Product{
+			ID: 10, Name: "Starter Kit", Price: 80, Stock: 2, IsBundle: true,
+			BundleItems: []models.BundleItem{
+				{BundleID: 10, ComponentID: 1, Quantity: 1, Component: *mouse},
+				{BundleID: 10, ComponentID: 2, Quantity: 2, Component: *pad},
+			},
+		}
+
+		mockProductRepo.On("GetByID", uint(10)).Return(bundle, nil).Once()
+		mockProductRepo.On("IsBundleComponent", uint(10)).Return(false, nil).Once()
+		mockProductRepo.On("GetByID", uint(1)).Return(mouse, nil).Once()
+		mockProductRepo.On("GetByID", uint(2)).Return(pad, nil).Once()
+		mockProductRepo.On("ReplaceBundleItems", uint(10), mock.MatchedBy(func(items []models.BundleItem) bool {
+			return len(items) == 2 &&
+				items[0].ComponentID == 1 && items[0].Quantity == 1 &&
+				items[1].ComponentID == 2 && items[1].Quantity == 2
+		})).Return(nil).Once()
+		mockProductRepo.On("GetByID", uint(10)).Return(saved, nil).Once()
+
+		result, err := service.SetBundleItems(10, req)
+
+		assert.NoError(t, err)
+		assert.True(t, result.IsBundle)
+		assert.Equal(t, 2, result.Stock)
+		assert.Len(t, result.BundleItems, 2)
+		assert.Equal(t, "Mouse Pad", result.BundleItems[1].Name)
+		assert.Equal(t, 2, result.BundleItems[1].Quantity)
+		mockProductRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects itself as component", func(t *testing.T) {
+		req := &dto.SetBundleItemsRequest{Items: []dto.BundleItemRequest{{ProductID: 10, Quantity: 1}}}
+
+		mockProductRepo.On("GetByID", uint(10)).Return(bundle, nil).Once()
+		mockProductRepo.On("IsBundleComponent", uint(10)).Return(false, nil).Once()
+
+		result, err := service.SetBundleItems(10, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "cannot contain itself")
+	})
+
+	t.Run("rejects nested bundles", func(t *testing.T) {
+		req := &dto.SetBundleItemsRequest{Items: []dto.BundleItemRequest{{ProductID: 11, Quantity: 1}}}
+
+		mockProductRepo.On("GetByID", uint(10)).Return(bundle, nil).Once()
+		mockProductRepo.On("IsBundleComponent", uint(10)).Return(false, nil).Once()
+		mockProductRepo.On("GetByID", uint(11)).Return(&models.Product{ID: 11, Name: "Pro Kit", IsBundle: true}, nil).Once()
+
+		result, err := service.SetBundleItems(10, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "another bundle")
+	})
+
+	t.Run("rejects product used in another bundle", func(t *testing.T) {
+		req := &dto.SetBundleItemsRequest{Items: []dto.BundleItemRequest{{ProductID: 2, Quantity: 1}}}
+
+		mockProductRepo.On("GetByID", uint(1)).Return(mouse, nil).Once()
+		mockProductRepo.On("IsBundleComponent", uint(1)).Return(true, nil).Once()
+
+		result, err := service.SetBundleItems(1, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("rejects duplicate components", func(t *testing.T) {
+		req := &dto.SetBundleItemsRequest{Items: []dto.BundleItemRequest{
+			{ProductID: 1, Quantity: 1},
+			{ProductID: 1, Quantity: 2},
+		}}
+
+		mockProductRepo.On("GetByID", uint(10)).Return(bundle, nil).Once()
+		mockProductRepo.On("IsBundleComponent", uint(10)).Return(false, nil).Once()
+		mockProductRepo.On("GetByID", uint(1)).Return(mouse, nil).Once()
+
+		result, err := service.SetBundleItems(10, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockProductRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/events"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/notifications"
+	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"gorm.io/gorm"
+)
+
+type QuestionService struct {
+	db             *gorm.DB
+	config         *config.Config
+	eventPublisher events.Publisher
+	questionRepo   repositories.QuestionRepositoryInterface
+	productRepo    repositories.ProductRepositoryInterface
+	orderRepo      repositories.OrderRepositoryInterface
+}
+
+func NewQuestionService(db *gorm.DB, config *config.Config, eventPublisher events.Publisher) *QuestionService {
+	return &QuestionService{
+		db:             db,
+		config:         config,
+		eventPublisher: eventPublisher,
+		questionRepo:   repositories.NewQuestionRepository(db),
+		productRepo:    repositories.NewProductRepository(db),
+		orderRepo:      repositories.NewOrderRepository(db),
+	}
+}
+
+// AskQuestion stores a customer question. It stays hidden until a moderator publishes it.
+func (s *QuestionService) AskQuestion(userID, productID uint, req *dto.CreateQuestionRequest) (*dto.QuestionResponse, error) {
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil || !product.IsActive {
+		return nil, errors.New("product not found")
+	}
+
+	question := models.ProductQuestion{
+		ProductID: productID,
+		UserID:    userID,
+		Body:      strings.TrimSpace(req.Body),
+		Status:    models.QAStatusPending,
+	}
+
+	if err := s.questionRepo.CreateQuestion(&question); err != nil {
+		return nil, err
+	}
+
+	response := s.toQuestionResponse(&question)
+	return &response, nil
+}
+
+// AnswerQuestion accepts answers from staff allowed to answer questions and from
+// customers who received the product. Staff answers are published right away,
+// buyer answers wait for moderation.
+func (s *QuestionService) AnswerQuestion(userID uint, isStaff bool, questionID uint, req *dto.CreateAnswerRequest) (*dto.AnswerResponse, error) {
+	question, err := s.questionRepo.GetQuestionByID(questionID)
+	if err != nil || question.Status != models.QAStatusPublished {
+		return nil, errors.New("question not found")
+	}
+
+	if !isStaff {
+		if _, err := s.orderRepo.FindDeliveredOrderItem(userID, question.ProductID); err != nil {
+			return nil, errors.New("only staff or verified buyers can answer this question")
+		}
+	}
+
+	answer := models.ProductAnswer{
+		QuestionID:      questionID,
+		UserID:          userID,
+		Body:            strings.TrimSpace(req.Body),
+		Status:          models.QAStatusPending,
+		IsStaff:         isStaff,
+		IsVerifiedBuyer: !isStaff,
+	}
+	if isStaff {
+		now := time.Now()
+		answer.Status = models.QAStatusPublished
+		answer.AskerNotifiedAt = &now
+	}
+
+	if err := s.questionRepo.CreateAnswer(&answer); err != nil {
+		return nil, err
+	}
+
+	if answer.Status == models.QAStatusPublished {
+		s.notifyAnswerPublished(question, &answer)
+	}
+
+	response := s.toAnswerResponse(&answer)
+	return &response, nil
+}
+
+func (s *QuestionService) GetProductQuestions(productID uint, page, limit int) ([]dto.QuestionResponse, *utils.PaginationMeta, error) {
+	page, limit = normalizePage(page, limit)
+
+	questions, total, err := s.questionRepo.GetPublishedQuestions(productID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.toQuestionResponses(questions), paginationMeta(page, limit, total), nil
+}
+
+func (s *QuestionService) GetQuestionsByStatus(status string, page, limit int) ([]dto.QuestionResponse, *utils.PaginationMeta, error) {
+	page, limit = normalizePage(page, limit)
+
+	questions, total, err := s.questionRepo.GetQuestionsByStatus(models.QAStatus(status), limit, (page-1)*limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.toQuestionResponses(questions), paginationMeta(page, limit, total), nil
+}
+
+func (s *QuestionService) GetAnswersByStatus(status string, page, limit int) ([]dto.AnswerResponse, *utils.PaginationMeta, error) {
+	page, limit = normalizePage(page, limit)
+
+	answers, total, err := s.questionRepo.GetAnswersByStatus(models.QAStatus(status), limit, (page-1)*limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.AnswerResponse, len(answers))
+	for i := range answers {
+		response[i] = s.toAnswerResponse(&answers[i])
+	}
+	return response, paginationMeta(page, limit, total), nil
+}
+
+func (s *QuestionService) ModerateQuestion(questionID uint, req *dto.ModerateQARequest) (*dto.QuestionResponse, error) {
+	question, err := s.questionRepo.GetQuestionByID(questionID)
+	if err != nil {
+		return nil, errors.New("question not found")
+	}
+
+	status := models.QAStatus(req.Status)
+	if err := s.questionRepo.UpdateQuestionStatus(questionID, status); err != nil {
+		return nil, err
+	}
+	question.Status = status
+
+	response := s.toQuestionResponse(question)
+	return &response, nil
+}
+
+// ModerateAnswer changes the status of an answer. The asker is told about it
+// once, by whichever moderation first publishes it.
+func (s *QuestionService) ModerateAnswer(answerID uint, req *dto.ModerateQARequest) (*dto.AnswerResponse, error) {
+	answer, err := s.questionRepo.GetAnswerByID(answerID)
+	if err != nil {
+		return nil, errors.New("answer not found")
+	}
+
+	status := models.QAStatus(req.Status)
+	if err := s.questionRepo.UpdateAnswerStatus(answerID, status); err != nil {
+		return nil, err
+	}
+	answer.Status = status
+
+	if status == models.QAStatusPublished {
+		notify, err := s.questionRepo.MarkAnswerNotified(answerID, time.Now())
+		if err != nil {
+			fmt.Println("Failed to record answer notification:", err)
+		} else if notify {
+			s.notifyAnswerPublished(&answer.Question, answer)
+		}
+	}
+
+	response := s.toAnswerResponse(answer)
+	return &response, nil
+}
+
+func (s *QuestionService) UpvoteQuestion(userID, questionID uint) error {
+	question, err := s.questionRepo.GetQuestionByID(questionID)
+	if err != nil || question.Status != models.QAStatusPublished {
+		return errors.New("question not found")
+	}
+	if question.UserID == userID {
+		return errors.New("cannot upvote your own question")
+	}
+
+	return s.addVote(userID, models.QAVoteTargetQuestion, questionID)
+}
+
+func (s *QuestionService) UpvoteAnswer(userID, answerID uint) error {
+	answer, err := s.questionRepo.GetAnswerByID(answerID)
+	if err != nil || answer.Status != models.QAStatusPublished {
+		return errors.New("answer not found")
+	}
+	if answer.UserID == userID {
+		return errors.New("cannot upvote your own answer")
+	}
+
+	return s.addVote(userID, models.QAVoteTargetAnswer, answerID)
+}
+
+func (s *QuestionService) RemoveQuestionUpvote(userID, questionID uint) error {
+	return s.removeVote(userID, models.QAVoteTargetQuestion, questionID)
+}
+
+func (s *QuestionService) RemoveAnswerUpvote(userID, answerID uint) error {
+	return s.removeVote(userID, models.QAVoteTargetAnswer, answerID)
+}
+
+func (s *QuestionService) addVote(userID uint, target models.QAVoteTarget, targetID uint) error {
+	added, err := s.questionRepo.AddVote(&models.QAVote{
+		TargetType: target,
+		TargetID:   targetID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return err
+	}
+	if !added {
+		return fmt.Errorf("%s already upvoted", target)
+	}
+	return nil
+}
+
+func (s *QuestionService) removeVote(userID uint, target models.QAVoteTarget, targetID uint) error {
+	removed, err := s.questionRepo.RemoveVote(&models.QAVote{
+		TargetType: target,
+		TargetID:   targetID,
+		UserID:     userID,
+	})
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("%s not upvoted", target)
+	}
+	return nil
+}
+
+func (s *QuestionService) notifyAnswerPublished(question *models.ProductQuestion, answer *models.ProductAnswer) {
+	payload := notifications.QuestionAnsweredPayload{
+		Email:       question.User.Email,
+		Name:        strings.TrimSpace(question.User.FirstName + " " + question.User.LastName),
+		ProductID:   question.ProductID,
+		ProductName: question.Product.Name,
+		Question:    question.Body,
+		Answer:      answer.Body,
+	}
+
+	if err := s.eventPublisher.Publish(notifications.QuestionAnswered, payload, nil); err != nil {
+		fmt.Println("Failed to publish QUESTION_ANSWERED event:", err)
+	}
+}
+
+func (s *QuestionService) toQuestionResponses(questions []models.ProductQuestion) []dto.QuestionResponse {
+	response := make([]dto.QuestionResponse, len(questions))
+	for i := range questions {
+		response[i] = s.toQuestionResponse(&questions[i])
+	}
+	return response
+}
+
+func (s *QuestionService) toQuestionResponse(question *models.ProductQuestion) dto.QuestionResponse {
+	answers := make([]dto.AnswerResponse, len(question.Answers))
+	for i := range question.Answers {
+		answers[i] = s.toAnswerResponse(&question.Answers[i])
+	}
+
+	return dto.QuestionResponse{
+		ID:          question.ID,
+		ProductID:   question.ProductID,
+		UserID:      question.UserID,
+		AuthorName:  question.User.FirstName,
+		Body:        question.Body,
+		Status:      string(question.Status),
+		UpvoteCount: question.UpvoteCount,
+		Answers:     answers,
+		CreatedAt:   question.CreatedAt,
+		UpdatedAt:   question.UpdatedAt,
+	}
+}
+
+func (s *QuestionService) toAnswerResponse(answer *models.ProductAnswer) dto.AnswerResponse {
+	return dto.AnswerResponse{
+		ID:            answer.ID,
+		QuestionID:    answer.QuestionID,
+		UserID:        answer.UserID,
+		AuthorName:    answer.User.FirstName,
+		Body:          answer.Body,
+		Status:        string(answer.Status),
+		IsStaff:       answer.IsStaff,
+		VerifiedBuyer: answer.IsVerifiedBuyer,
+		UpvoteCount:   answer.UpvoteCount,
+		CreatedAt:     answer.CreatedAt,
+		UpdatedAt:     answer.UpdatedAt,
+	}
+}
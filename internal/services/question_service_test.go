@@ -0,0 +1,237 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/mocks"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestQuestionService_AskQuestion(t *testing.T) {
+	mockQuestionRepo := new(mocks.MockQuestionRepositoryInterface)
+	mockProductRepo := new(mocks.MockProductRepositoryInterface)
+
+	service := &QuestionService{
+		db:             &gorm.DB{},
+		config:         &config.Config{},
+		eventPublisher: new(mocks.MockPublisher),
+		questionRepo:   mockQuestionRepo,
+		productRepo:    mockProductRepo,
+		orderRepo:      new(mocks.MockOrderRepositoryInterface),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockProductRepo.On("GetByID", uint(10)).Return(&models.Product{ID: 10, IsActive: true}, nil).Once()
+		mockQuestionRepo.On("CreateQuestion", mock.MatchedBy(func(q *models.ProductQuestion) bool {
+			return q.Status == models.QAStatusPending && q.Body == "Is it waterproof?"
+		})).Return(nil).Once()
+
+		result, err := service.AskQuestion(1, 10, &dto.CreateQuestionRequest{Body: "  Is it waterproof? "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pending", result.Status)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+
+	t.Run("inactive product", func(t *testing.T) {
+		mockProductRepo.On("GetByID", uint(11)).Return(&models.Product{ID: 11, IsActive: false}, nil).Once()
+
+		result, err := service.AskQuestion(1, 11, &dto.CreateQuestionRequest{Body: "Question"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "product not found")
+	})
+}
+
+func TestQuestionService_AnswerQuestion(t *testing.T) {
+	mockQuestionRepo := new(mocks.MockQuestionRepositoryInterface)
+	mockOrderRepo := new(mocks.MockOrderRepositoryInterface)
+	mockPublisher := new(mocks.MockPublisher)
+
+	service := &QuestionService{
+		db:             &gorm.DB{},
+		config:         &config.Config{},
+		eventPublisher: mockPublisher,
+		questionRepo:   mockQuestionRepo,
+		productRepo:    new(mocks.MockProductRepositoryInterface),
+		orderRepo:      mockOrderRepo,
+	}
+
+	question := &models.ProductQuestion{
+		ID:        5,
+		ProductID: 10,
+		UserID:    1,
+		Body:      "Is it waterproof?",
+		Status:    models.QAStatusPublished,
+		User:      models.User{Email: "asker@example.com", FirstName: "Ann"},
+		Product:   models.Product{Name: "Jacket"},
+	}
+
+	t.Run("admin answer is published and notifies the asker", func(t *testing.T) {
+		mockQuestionRepo.On("GetQuestionByID", uint(5)).Return(question, nil).Once()
+		mockQuestionRepo.On("CreateAnswer", mock.MatchedBy(func(a *models.ProductAnswer) bool {
+			return a.IsStaff && a.Status == models.QAStatusPublished && a.AskerNotifiedAt != nil
+		})).Return(nil).Once()
+		mockPublisher.On("Publish", notifications.QuestionAnswered, mock.MatchedBy(func(p notifications.QuestionAnsweredPayload) bool {
+			return p.Email == "asker@example.com" && p.ProductName == "Jacket" && p.Answer == "Yes"
+		}), mock.Anything).Return(nil).Once()
+
+		result, err := service.AnswerQuestion(99, true, 5, &dto.CreateAnswerRequest{Body: "Yes"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "published", result.Status)
+		assert.True(t, result.IsStaff)
+		mockQuestionRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("verified buyer answer waits for moderation", func(t *testing.T) {
+		mockQuestionRepo.On("GetQuestionByID", uint(5)).Return(question, nil).Once()
+		mockOrderRepo.On("FindDeliveredOrderItem", uint(2), uint(10)).Return(&models.OrderItem{ID: 3}, nil).Once()
+		mockQuestionRepo.On("CreateAnswer", mock.MatchedBy(func(a *models.ProductAnswer) bool {
+			return !a.IsStaff && a.IsVerifiedBuyer && a.Status == models.QAStatusPending
+		})).Return(nil).Once()
+
+		result, err := service.AnswerQuestion(2, false, 5, &dto.CreateAnswerRequest{Body: "Mine held up in the rain"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pending", result.Status)
+		assert.True(t, result.VerifiedBuyer)
+		mockQuestionRepo.AssertExpectations(t)
+		mockPublisher.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("customer without purchase", func(t *testing.T) {
+		mockQuestionRepo.On("GetQuestionByID", uint(5)).Return(question, nil).Once()
+		mockOrderRepo.On("FindDeliveredOrderItem", uint(3), uint(10)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := service.AnswerQuestion(3, false, 5, &dto.CreateAnswerRequest{Body: "Probably"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "only staff or verified buyers")
+	})
+
+	t.Run("unpublished question", func(t *testing.T) {
+		mockQuestionRepo.On("GetQuestionByID", uint(6)).Return(&models.ProductQuestion{ID: 6, Status: models.QAStatusPending}, nil).Once()
+
+		result, err := service.AnswerQuestion(99, true, 6, &dto.CreateAnswerRequest{Body: "Yes"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "question not found")
+	})
+}
+
+func TestQuestionService_ModerateAnswer(t *testing.T) {
+	mockQuestionRepo := new(mocks.MockQuestionRepositoryInterface)
+	mockPublisher := new(mocks.MockPublisher)
+
+	service := &QuestionService{
+		db:             &gorm.DB{},
+		config:         &config.Config{},
+		eventPublisher: mockPublisher,
+		questionRepo:   mockQuestionRepo,
+		productRepo:    new(mocks.MockProductRepositoryInterface),
+		orderRepo:      new(mocks.MockOrderRepositoryInterface),
+	}
+
+	t.Run("publishing notifies the asker once", func(t *testing.T) {
+		answer := &models.ProductAnswer{
+			ID:     8,
+			Body:   "Yes",
+			Status: models.QAStatusPending,
+			Question: models.ProductQuestion{
+				Body: "Is it waterproof?",
+				User: models.User{Email: "asker@example.com"},
+			},
+		}
+
+		mockQuestionRepo.On("GetAnswerByID", uint(8)).Return(answer, nil).Once()
+		mockQuestionRepo.On("UpdateAnswerStatus", uint(8), models.QAStatusPublished).Return(nil).Once()
+		mockQuestionRepo.On("MarkAnswerNotified", uint(8), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		mockPublisher.On("Publish", notifications.QuestionAnswered, mock.AnythingOfType("notifications.QuestionAnsweredPayload"), mock.Anything).Return(nil).Once()
+
+		result, err := service.ModerateAnswer(8, &dto.ModerateQARequest{Status: "published"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "published", result.Status)
+		mockQuestionRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("publishing again does not notify", func(t *testing.T) {
+		mockQuestionRepo.On("GetAnswerByID", uint(8)).Return(&models.ProductAnswer{ID: 8, Status: models.QAStatusRejected}, nil).Once()
+		mockQuestionRepo.On("UpdateAnswerStatus", uint(8), models.QAStatusPublished).Return(nil).Once()
+		mockQuestionRepo.On("MarkAnswerNotified", uint(8), mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+
+		result, err := service.ModerateAnswer(8, &dto.ModerateQARequest{Status: "published"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "published", result.Status)
+		mockQuestionRepo.AssertExpectations(t)
+		mockPublisher.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("rejecting does not notify", func(t *testing.T) {
+		mockQuestionRepo.On("GetAnswerByID", uint(9)).Return(&models.ProductAnswer{ID: 9, Status: models.QAStatusPending}, nil).Once()
+		mockQuestionRepo.On("UpdateAnswerStatus", uint(9), models.QAStatusRejected).Return(nil).Once()
+
+		result, err := service.ModerateAnswer(9, &dto.ModerateQARequest{Status: "rejected"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "rejected", result.Status)
+		mockPublisher.AssertNumberOfCalls(t, "Publish", 1)
+	})
+}
+
+func TestQuestionService_UpvoteQuestion(t *testing.T) {
+	mockQuestionRepo := new(mocks.MockQuestionRepositoryInterface)
+
+	service := &QuestionService{
+		db:             &gorm.DB{},
+		config:         &config.Config{},
+		eventPublisher: new(mocks.MockPublisher),
+		questionRepo:   mockQuestionRepo,
+		productRepo:    new(mocks.MockProductRepositoryInterface),
+		orderRepo:      new(mocks.MockOrderRepositoryInterface),
+	}
+
+	published := &models.ProductQuestion{ID: 5, UserID: 1, Status: models.QAStatusPublished}
+
+	t.Run("success", func(t *testing.T) {
+		mockQuestionRepo.On("GetQuestionByID", uint(5)).Return(published, nil).Once()
+		mockQuestionRepo.On("AddVote", &models.QAVote{TargetType: models.QAVoteTargetQuestion, TargetID: 5, UserID: 2}).Return(true, nil).Once()
+
+		err := service.UpvoteQuestion(2, 5)
+
+		assert.NoError(t, err)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+
+	t.Run("already upvoted", func(t *testing.T) {
+		mockQuestionRepo.On("GetQuestionByID", uint(5)).Return(published, nil).Once()
+		mockQuestionRepo.On("AddVote", mock.AnythingOfType("*models.QAVote")).Return(false, nil).Once()
+
+		err := service.UpvoteQuestion(2, 5)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already upvoted")
+	})
+
+	t.Run("own question", func(t *testing.T) {
+		mockQuestionRepo.On("GetQuestionByID", uint(5)).Return(published, nil).Once()
+
+		err := service.UpvoteQuestion(1, 5)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "own question")
+	})
+}
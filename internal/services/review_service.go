@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"gorm.io/gorm"
+)
+
+const maxReviewImages = 5
+
+type ReviewService struct {
+	db          *gorm.DB
+	config      *config.Config
+	reviewRepo  repositories.ReviewRepositoryInterface
+	productRepo repositories.ProductRepositoryInterface
+	orderRepo   repositories.OrderRepositoryInterface
+}
+
+func NewReviewService(db *gorm.DB, config *config.Config) *ReviewService {
+	return &ReviewService{
+		db:          db,
+		config:      config,
+		reviewRepo:  repositories.NewReviewRepository(db),
+		productRepo: repositories.NewProductRepository(db),
+		orderRepo:   repositories.NewOrderRepository(db),
+	}
+}
+
+// CreateReview accepts a review only from customers who received the product
+// in a delivered order. New reviews wait for moderation before they count.
+func (s *ReviewService) CreateReview(userID, productID uint, req *dto.CreateReviewRequest) (*dto.ReviewResponse, error) {
+	if _, err := s.productRepo.GetByID(productID); err != nil {
+		return nil, errors.New("product not found")
+	}
+
+	orderItem, err := s.orderRepo.FindDeliveredOrderItem(userID, productID)
+	if err != nil {
+		return nil, errors.New("only customers who received this product can review it")
+	}
+
+	if _, err := s.reviewRepo.GetByUserAndProduct(userID, productID); err == nil {
+		return nil, errors.New("product already reviewed")
+	}
+
+	review := models.Review{
+		ProductID:   productID,
+		UserID:      userID,
+		OrderItemID: orderItem.ID,
+		Rating:      req.Rating,
+		Title:       req.Title,
+		Body:        req.Body,
+		Status:      models.ReviewStatusPending,
+	}
+
+	if err := s.reviewRepo.Create(&review); err != nil {
+		return nil, err
+	}
+
+	response := s.toReviewResponse(&review)
+	return &response, nil
+}
+
+// GetOwnReview returns a review only if it was written by the user
+func (s *ReviewService) GetOwnReview(userID, reviewID uint) (*dto.ReviewResponse, error) {
+	review, err := s.reviewRepo.GetByID(reviewID)
+	if err != nil || review.UserID != userID {
+		return nil, errors.New("review not found")
+	}
+
+	response := s.toReviewResponse(review)
+	return &response, nil
+}
+
+func (s *ReviewService) AddReviewImage(userID, reviewID uint, url string) (*dto.ReviewResponse, error) {
+	review, err := s.reviewRepo.GetByID(reviewID)
+	if err != nil || review.UserID != userID {
+		return nil, errors.New("review not found")
+	}
+
+	count, err := s.reviewRepo.CountImages(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxReviewImages {
+		return nil, errors.New("review image limit reached")
+	}
+
+	image := models.ReviewImage{
+		ReviewID: reviewID,
+		URL:      url,
+	}
+	if err := s.reviewRepo.CreateImage(&image); err != nil {
+		return nil, err
+	}
+
+	review.Images = append(review.Images, image)
+	response := s.toReviewResponse(review)
+	return &response, nil
+}
+
+func (s *ReviewService) DeleteReview(userID, reviewID uint) error {
+	review, err := s.reviewRepo.GetByID(reviewID)
+	if err != nil || review.UserID != userID {
+		return errors.New("review not found")
+	}
+
+	return s.reviewRepo.Delete(review)
+}
+
+func (s *ReviewService) GetProductReviews(productID uint, page, limit int) ([]dto.ReviewResponse, *utils.PaginationMeta, error) {
+	page, limit = normalizePage(page, limit)
+
+	reviews, total, err := s.reviewRepo.GetByProductID(productID, models.ReviewStatusApproved, limit, (page-1)*limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.toReviewResponses(reviews), paginationMeta(page, limit, total), nil
+}
+
+func (s *ReviewService) GetReviewsByStatus(status string, page, limit int) ([]dto.ReviewResponse, *utils.PaginationMeta, error) {
+	page, limit = normalizePage(page, limit)
+
+	reviews, total, err := s.reviewRepo.GetByStatus(models.ReviewStatus(status), limit, (page-1)*limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.toReviewResponses(reviews), paginationMeta(page, limit, total), nil
+}
+
+func (s *ReviewService) ModerateReview(reviewID uint, req *dto.ModerateReviewRequest) (*dto.ReviewResponse, error) {
+	review, err := s.reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return nil, errors.New("review not found")
+	}
+
+	if err := s.reviewRepo.UpdateStatus(review, models.ReviewStatus(req.Status), req.Note); err != nil {
+		return nil, err
+	}
+
+	response := s.toReviewResponse(review)
+	return &response, nil
+}
+
+func (s *ReviewService) toReviewResponses(reviews []models.Review) []dto.ReviewResponse {
+	response := make([]dto.ReviewResponse, len(reviews))
+	for i := range reviews {
+		response[i] = s.toReviewResponse(&reviews[i])
+	}
+	return response
+}
+
+func (s *ReviewService) toReviewResponse(review *models.Review) dto.ReviewResponse {
+	images := make([]dto.ReviewImageResponse, len(review.Images))
+	for i := range review.Images {
+		images[i] = dto.ReviewImageResponse{
+			ID:        review.Images[i].ID,
+			URL:       review.Images[i].URL,
+			CreatedAt: review.Images[i].CreatedAt,
+		}
+	}
+
+	return dto.ReviewResponse{
+		ID:               review.ID,
+		ProductID:        review.ProductID,
+		UserID:           review.UserID,
+		AuthorName:       review.User.FirstName,
+		Rating:           review.Rating,
+		Title:            review.Title,
+		Body:             review.Body,
+		Status:           string(review.Status),
+		VerifiedPurchase: review.OrderItemID != 0,
+		Images:           images,
+		CreatedAt:        review.CreatedAt,
+		UpdatedAt:        review.UpdatedAt,
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/mocks"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestReviewService_CreateReview(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepositoryInterface)
+	mockProductRepo := new(mocks.MockProductRepositoryInterface)
+	mockOrderRepo := new(mocks.MockOrderRepositoryInterface)
+
+	service := &ReviewService{
+		db:          &gorm.DB{},
+		config:      &config.Config{},
+		reviewRepo:  mockReviewRepo,
+		productRepo: mockProductRepo,
+		orderRepo:   mockOrderRepo,
+	}
+
+	req := &dto.CreateReviewRequest{
+		Rating: 5,
+		Title:  "Great",
+		Body:   "Works as described",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		userID, productID := uint(1), uint(10)
+
+		mockProductRepo.On("GetByID", productID).Return(&models.Product{ID: productID}, nil).Once()
+		mockOrderRepo.On("FindDeliveredOrderItem", userID, productID).Return(&models.OrderItem{ID: 42}, nil).Once()
+		mockReviewRepo.On("GetByUserAndProduct", userID, productID).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockReviewRepo.On("Create", mock.MatchedBy(func(r *models.Review) bool {
+			return r.OrderItemID == 42 && r.Status == models.ReviewStatusPending && r.Rating == 5
+		})).Return(nil).Once()
+
+		result, err := service.CreateReview(userID, productID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pending", result.Status)
+		assert.True(t, result.VerifiedPurchase)
+		mockReviewRepo.AssertExpectations(t)
+		mockProductRepo.AssertExpectations(t)
+	})
+
+	t.Run("not a verified purchase", func(t *testing.T) {
+		userID, productID := uint(2), uint(10)
+
+		mockProductRepo.On("GetByID", productID).Return(&models.Product{ID: productID}, nil).Once()
+		mockOrderRepo.On("FindDeliveredOrderItem", userID, productID).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := service.CreateReview(userID, productID, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "only customers who received this product")
+		mockReviewRepo.AssertExpectations(t)
+	})
+
+	t.Run("already reviewed", func(t *testing.T) {
+		userID, productID := uint(3), uint(10)
+
+		mockProductRepo.On("GetByID", productID).Return(&models.Product{ID: productID}, nil).Once()
+		mockOrderRepo.On("FindDeliveredOrderItem", userID, productID).Return(&models.OrderItem{ID: 7}, nil).Once()
+		mockReviewRepo.On("GetByUserAndProduct", userID, productID).Return(&models.Review{ID: 1}, nil).Once()
+
+		result, err := service.CreateReview(userID, productID, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "already reviewed")
+		mockReviewRepo.AssertExpectations(t)
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		mockProductRepo.On("GetByID", uint(999)).Return(nil, errors.New("not found")).Once()
+
+		result, err := service.CreateReview(1, 999, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "product not found")
+	})
+}
+
+func TestReviewService_ModerateReview(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepositoryInterface)
+
+	service := &ReviewService{
+		db:         &gorm.DB{},
+		config:     &config.Config{},
+		reviewRepo: mockReviewRepo,
+	}
+
+	t.Run("approve", func(t *testing.T) {
+		review := &models.Review{ID: 1, ProductID: 10, Rating: 4, Status: models.ReviewStatusPending}
+
+		mockReviewRepo.On("GetByID", uint(1)).Return(review, nil).Once()
+		mockReviewRepo.On("UpdateStatus", review, models.ReviewStatusApproved, "").
+			Run(func(args mock.Arguments) {
+				args.Get(0).(*models.Review).Status = models.ReviewStatusApproved
+			}).Return(nil).Once()
+
+		result, err := service.ModerateReview(1, &dto.ModerateReviewRequest{Status: "approved"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "approved", result.Status)
+		mockReviewRepo.AssertExpectations(t)
+	})
+
+	t.Run("review not found", func(t *testing.T) {
+		mockReviewRepo.On("GetByID", uint(999)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := service.ModerateReview(999, &dto.ModerateReviewRequest{Status: "rejected"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestReviewService_AddReviewImage(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepositoryInterface)
+
+	service := &ReviewService{
+		db:         &gorm.DB{},
+		config:     &config.Config{},
+		reviewRepo: mockReviewRepo,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockReviewRepo.On("GetByID", uint(1)).Return(&models.Review{ID: 1, UserID: 5}, nil).Once()
+		mockReviewRepo.On("CountImages", uint(1)).Return(int64(0), nil).Once()
+		mockReviewRepo.On("CreateImage", mock.AnythingOfType("*models.ReviewImage")).Return(nil).Once()
+
+		result, err := service.AddReviewImage(5, 1, "http://example.com/reviews/1/a.jpg")
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Images, 1)
+		mockReviewRepo.AssertExpectations(t)
+	})
+
+	t.Run("another user's review", func(t *testing.T) {
+		mockReviewRepo.On("GetByID", uint(1)).Return(&models.Review{ID: 1, UserID: 5}, nil).Once()
+
+		result, err := service.AddReviewImage(6, 1, "http://example.com/reviews/1/b.jpg")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("image limit reached", func(t *testing.T) {
+		mockReviewRepo.On("GetByID", uint(1)).Return(&models.Review{ID: 1, UserID: 5}, nil).Once()
+		mockReviewRepo.On("CountImages", uint(1)).Return(int64(maxReviewImages), nil).Once()
+
+		result, err := service.AddReviewImage(5, 1, "http://example.com/reviews/1/c.jpg")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "limit reached")
+	})
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRoleNotFound    = errors.New("role not found")
+	ErrRoleExists      = errors.New("a role with this name already exists")
+	ErrRoleNotAssigned = errors.New("user does not have this role")
+	// ErrBuiltInRole is returned when changing or deleting the admin role
+	ErrBuiltInRole = errors.New("the admin role cannot be changed")
+	// ErrLastAdmin keeps the shop from being left without an admin
+	ErrLastAdmin = errors.New("the last admin cannot lose the admin role")
+	// ErrPrivilegedRole keeps role managers from handing out more than they
+	// hold
+	ErrPrivilegedRole = errors.New("only admins can manage roles granting every permission or role management")
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// RoleService manages roles and who holds them. Changes take effect on the
+// next refresh of the affected users' access tokens; until then their current
+// tokens are refused. Privileged roles, the admin role and any role granting
+// every permission or role management, are only managed by holders of every
+// permission. The grantor passed in is the permissions of the caller.
+type RoleService struct {
+	config   *config.Config
+	roleRepo repositories.RoleRepositoryInterface
+	userRepo repositories.UserRepositoryInterface
+}
+
+func NewRoleService(db *gorm.DB, config *config.Config) *RoleService {
+	return &RoleService{
+		config:   config,
+		roleRepo: repositories.NewRoleRepository(db),
+		userRepo: repositories.NewUserRepository(db),
+	}
+}
+
+// GetPermissions lists every permission that can be granted to a role
+func (s *RoleService) GetPermissions() []dto.PermissionResponse {
+	permissions := make([]dto.PermissionResponse, 0, len(models.PermissionDescriptions))
+	for name, description := range models.PermissionDescriptions {
+		permissions = append(permissions, dto.PermissionResponse{Name: name, Description: description})
+	}
+	sort.Slice(permissions, func(i, j int) bool {
+		return permissions[i].Name < permissions[j].Name
+	})
+	return permissions
+}
+
+func (s *RoleService) GetRoles() ([]dto.RoleResponse, error) {
+	roles, err := s.roleRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return toRoleResponses(roles), nil
+}
+
+func (s *RoleService) CreateRole(req *dto.CreateRoleRequest, grantor []string) (*dto.RoleResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if !roleNamePattern.MatchString(name) {
+		return nil, errors.New("role names use lowercase letters, digits and underscores, starting with a letter")
+	}
+
+	permissions, err := rolePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := models.Role{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+	}
+	if err := checkGrantor(&role, grantor); err != nil {
+		return nil, err
+	}
+
+	_, err = s.roleRepo.GetByName(name)
+	if err == nil {
+		return nil, ErrRoleExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if err := s.roleRepo.Create(&role); err != nil {
+		return nil, err
+	}
+
+	response := toRoleResponse(&role)
+	return &response, nil
+}
+
+// UpdateRole replaces the description and permissions of a role
+func (s *RoleService) UpdateRole(id uint, req *dto.UpdateRoleRequest, grantor []string) (*dto.RoleResponse, error) {
+	role, err := s.getRole(id)
+	if err != nil {
+		return nil, err
+	}
+	if role.Name == models.RoleAdmin {
+		return nil, ErrBuiltInRole
+	}
+	if err := checkGrantor(role, grantor); err != nil {
+		return nil, err
+	}
+
+	permissions, err := rolePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role.Description = strings.TrimSpace(req.Description)
+	role.Permissions = permissions
+	if err := checkGrantor(role, grantor); err != nil {
+		return nil, err
+	}
+	if err := s.roleRepo.Update(role); err != nil {
+		return nil, err
+	}
+
+	response := toRoleResponse(role)
+	return &response, nil
+}
+
+// DeleteRole deletes a role, its holders lose its permissions
+func (s *RoleService) DeleteRole(id uint, grantor []string) error {
+	role, err := s.getRole(id)
+	if err != nil {
+		return err
+	}
+	if role.Name == models.RoleAdmin {
+		return ErrBuiltInRole
+	}
+	if err := checkGrantor(role, grantor); err != nil {
+		return err
+	}
+
+	return s.roleRepo.Delete(role.ID)
+}
+
+func (s *RoleService) GetUserRoles(userID uint) ([]dto.RoleResponse, error) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	roles, err := s.roleRepo.GetUserRoles(userID)
+	if err != nil {
+		return nil, err
+	}
+	return toRoleResponses(roles), nil
+}
+
+// AssignRole gives a role to a user, giving it again changes nothing
+func (s *RoleService) AssignRole(userID, roleID uint, grantor []string) error {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return ErrUserNotFound
+	}
+	role, err := s.getRole(roleID)
+	if err != nil {
+		return err
+	}
+	if err := checkGrantor(role, grantor); err != nil {
+		return err
+	}
+
+	return s.roleRepo.AssignRole(userID, roleID)
+}
+
+// RemoveRole takes a role from a user. The admin role stays with its last
+// holder.
+func (s *RoleService) RemoveRole(userID, roleID uint, grantor []string) error {
+	role, err := s.getRole(roleID)
+	if err != nil {
+		return err
+	}
+	if err := checkGrantor(role, grantor); err != nil {
+		return err
+	}
+
+	removed, err := s.roleRepo.RemoveRole(userID, role.ID, role.Name == models.RoleAdmin)
+	if errors.Is(err, repositories.ErrLastRoleHolder) {
+		return ErrLastAdmin
+	}
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrRoleNotAssigned
+	}
+	return nil
+}
+
+func (s *RoleService) getRole(id uint) (*models.Role, error) {
+	role, err := s.roleRepo.GetByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRoleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// checkGrantor refuses privileged roles to callers that do not hold every
+// permission. Granting role management lets its holder give themselves any
+// permission, so it is as privileged as the admin role.
+func checkGrantor(role *models.Role, grantor []string) error {
+	if slices.Contains(grantor, models.PermissionAll) {
+		return nil
+	}
+
+	permissions := role.PermissionNames()
+	if role.Name == models.RoleAdmin ||
+		slices.Contains(permissions, models.PermissionAll) ||
+		slices.Contains(permissions, models.PermissionRolesManage) {
+		return ErrPrivilegedRole
+	}
+	return nil
+}
+
+// rolePermissions checks that every permission exists and drops duplicates
+func rolePermissions(names []string) ([]models.RolePermission, error) {
+	var permissions []models.RolePermission
+	var seen []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if !models.IsKnownPermission(name) {
+			return nil, fmt.Errorf("unknown permission %q", name)
+		}
+		if slices.Contains(seen, name) {
+			continue
+		}
+		seen = append(seen, name)
+		permissions = append(permissions, models.RolePermission{Permission: name})
+	}
+	return permissions, nil
+}
+
+func toRoleResponses(roles []models.Role) []dto.RoleResponse {
+	response := make([]dto.RoleResponse, len(roles))
+	for i := range roles {
+		response[i] = toRoleResponse(&roles[i])
+	}
+	return response
+}
+
+func toRoleResponse(role *models.Role) dto.RoleResponse {
+	return dto.RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.PermissionNames(),
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
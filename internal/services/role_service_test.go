@@ -0,0 +1,276 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/mocks"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// Permissions of the callers: a role manager and an admin
+var (
+	roleManagerPermissions = []string{models.PermissionRolesManage}
+	adminPermissions       = []string{models.PermissionAll}
+)
+
+func adminRole() *models.Role {
+	return &models.Role{ID: 1, Name: models.RoleAdmin, Permissions: []models.RolePermission{{RoleID: 1, Permission: models.PermissionAll}}}
+}
+
+func TestRoleService_CreateRole(t *testing.T) {
+	mockRoleRepo := new(mocks.MockRoleRepositoryInterface)
+
+	service := &RoleService{
+		roleRepo: mockRoleRepo,
+		userRepo: new(mocks.MockUserRepositoryInterface),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockRoleRepo.On("GetByName", "content_editor").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockRoleRepo.On("Create", mock.MatchedBy(func(role *models.Role) bool {
+			return role.Name == "content_editor" && len(role.Permissions) == 2
+		})).Return(nil).Once()
+
+		result, err := service.CreateRole(&dto.CreateRoleRequest{
+			Name:        "content_editor",
+			Description: " Catalog and Q&A ",
+			Permissions: []string{models.PermissionCatalogManage, models.PermissionQuestionsAnswer, models.PermissionCatalogManage},
+		}, roleManagerPermissions)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Catalog and Q&A", result.Description)
+		assert.Equal(t, []string{models.PermissionCatalogManage, models.PermissionQuestionsAnswer}, result.Permissions)
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		result, err := service.CreateRole(&dto.CreateRoleRequest{Name: "Content Editor", Permissions: []string{models.PermissionCatalogManage}}, roleManagerPermissions)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockRoleRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("unknown permission", func(t *testing.T) {
+		result, err := service.CreateRole(&dto.CreateRoleRequest{Name: "editor", Permissions: []string{"catalog:delete"}}, roleManagerPermissions)
+
+		assert.EqualError(t, err, `unknown permission "catalog:delete"`)
+		assert.Nil(t, result)
+		mockRoleRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("privileged permissions need an admin", func(t *testing.T) {
+		for _, permission := range []string{models.PermissionAll, models.PermissionRolesManage} {
+			result, err := service.CreateRole(&dto.CreateRoleRequest{Name: "deputy", Permissions: []string{permission}}, roleManagerPermissions)
+
+			assert.ErrorIs(t, err, ErrPrivilegedRole)
+			assert.Nil(t, result)
+			mockRoleRepo.AssertNumberOfCalls(t, "Create", 1)
+		}
+
+		mockRoleRepo.On("GetByName", "deputy").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockRoleRepo.On("Create", mock.Anything).Return(nil).Once()
+
+		_, err := service.CreateRole(&dto.CreateRoleRequest{Name: "deputy", Permissions: []string{models.PermissionRolesManage}}, adminPermissions)
+
+		assert.NoError(t, err)
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		mockRoleRepo.On("GetByName", "support").Return(&models.Role{ID: 4, Name: "support"}, nil).Once()
+
+		result, err := service.CreateRole(&dto.CreateRoleRequest{Name: "support", Permissions: []string{models.PermissionUsersUnlock}}, roleManagerPermissions)
+
+		assert.ErrorIs(t, err, ErrRoleExists)
+		assert.Nil(t, result)
+		mockRoleRepo.AssertNumberOfCalls(t, "Create", 2)
+	})
+}
+
+func TestRoleService_UpdateRole(t *testing.T) {
+	mockRoleRepo := new(mocks.MockRoleRepositoryInterface)
+
+	service := &RoleService{
+		roleRepo: mockRoleRepo,
+		userRepo: new(mocks.MockUserRepositoryInterface),
+	}
+
+	t.Run("replaces the permissions", func(t *testing.T) {
+		role := &models.Role{ID: 4, Name: "support", Permissions: []models.RolePermission{{RoleID: 4, Permission: models.PermissionUsersUnlock}}}
+
+		mockRoleRepo.On("GetByID", uint(4)).Return(role, nil).Once()
+		mockRoleRepo.On("Update", role).Return(nil).Once()
+
+		result, err := service.UpdateRole(4, &dto.UpdateRoleRequest{Permissions: []string{models.PermissionReviewsModerate}}, roleManagerPermissions)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{models.PermissionReviewsModerate}, result.Permissions)
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("admin role is built in", func(t *testing.T) {
+		mockRoleRepo.On("GetByID", uint(1)).Return(adminRole(), nil).Once()
+
+		result, err := service.UpdateRole(1, &dto.UpdateRoleRequest{Permissions: []string{models.PermissionCatalogManage}}, roleManagerPermissions)
+
+		assert.ErrorIs(t, err, ErrBuiltInRole)
+		assert.Nil(t, result)
+		mockRoleRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRoleRepo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := service.UpdateRole(9, &dto.UpdateRoleRequest{Permissions: []string{models.PermissionCatalogManage}}, roleManagerPermissions)
+
+		assert.ErrorIs(t, err, ErrRoleNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestRoleService_DeleteRole(t *testing.T) {
+	mockRoleRepo := new(mocks.MockRoleRepositoryInterface)
+
+	service := &RoleService{
+		roleRepo: mockRoleRepo,
+		userRepo: new(mocks.MockUserRepositoryInterface),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockRoleRepo.On("GetByID", uint(4)).Return(&models.Role{ID: 4, Name: "support"}, nil).Once()
+		mockRoleRepo.On("Delete", uint(4)).Return(nil).Once()
+
+		err := service.DeleteRole(4, roleManagerPermissions)
+
+		assert.NoError(t, err)
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("admin role is built in", func(t *testing.T) {
+		mockRoleRepo.On("GetByID", uint(1)).Return(adminRole(), nil).Once()
+
+		err := service.DeleteRole(1, roleManagerPermissions)
+
+		assert.ErrorIs(t, err, ErrBuiltInRole)
+		mockRoleRepo.AssertNotCalled(t, "Delete", uint(1))
+	})
+}
+
+func TestRoleService_AssignRole(t *testing.T) {
+	mockRoleRepo := new(mocks.MockRoleRepositoryInterface)
+	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+
+	service := &RoleService{
+		roleRepo: mockRoleRepo,
+		userRepo: mockUserRepo,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.On("GetByID", uint(7)).Return(&models.User{ID: 7}, nil).Once()
+		mockRoleRepo.On("GetByID", uint(4)).Return(&models.Role{ID: 4, Name: "support"}, nil).Once()
+		mockRoleRepo.On("AssignRole", uint(7), uint(4)).Return(nil).Once()
+
+		err := service.AssignRole(7, 4, roleManagerPermissions)
+
+		assert.NoError(t, err)
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockUserRepo.On("GetByID", uint(7)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		err := service.AssignRole(7, 4, roleManagerPermissions)
+
+		assert.ErrorIs(t, err, ErrUserNotFound)
+		mockRoleRepo.AssertNumberOfCalls(t, "AssignRole", 1)
+	})
+
+	t.Run("role managers cannot hand out the admin role", func(t *testing.T) {
+		mockUserRepo.On("GetByID", uint(7)).Return(&models.User{ID: 7}, nil).Once()
+		mockRoleRepo.On("GetByID", uint(1)).Return(adminRole(), nil).Once()
+
+		err := service.AssignRole(7, 1, roleManagerPermissions)
+
+		assert.ErrorIs(t, err, ErrPrivilegedRole)
+		mockRoleRepo.AssertNotCalled(t, "AssignRole", uint(7), uint(1))
+	})
+}
+
+func TestRoleService_RemoveRole(t *testing.T) {
+	mockRoleRepo := new(mocks.MockRoleRepositoryInterface)
+
+	service := &RoleService{
+		roleRepo: mockRoleRepo,
+		userRepo: new(mocks.MockUserRepositoryInterface),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockRoleRepo.On("GetByID", uint(4)).Return(&models.Role{ID: 4, Name: "support"}, nil).Once()
+		mockRoleRepo.On("RemoveRole", uint(7), uint(4), false).Return(true, nil).Once()
+
+		err := service.RemoveRole(7, 4, roleManagerPermissions)
+
+		assert.NoError(t, err)
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("user does not have the role", func(t *testing.T) {
+		mockRoleRepo.On("GetByID", uint(4)).Return(&models.Role{ID: 4, Name: "support"}, nil).Once()
+		mockRoleRepo.On("RemoveRole", uint(7), uint(4), false).Return(false, nil).Once()
+
+		err := service.RemoveRole(7, 4, roleManagerPermissions)
+
+		assert.ErrorIs(t, err, ErrRoleNotAssigned)
+	})
+
+	t.Run("last admin keeps the admin role", func(t *testing.T) {
+		mockRoleRepo.On("GetByID", uint(1)).Return(adminRole(), nil).Once()
+		mockRoleRepo.On("RemoveRole", uint(7), uint(1), true).Return(false, repositories.ErrLastRoleHolder).Once()
+
+		err := service.RemoveRole(7, 1, adminPermissions)
+
+		assert.ErrorIs(t, err, ErrLastAdmin)
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("admin role the user does not hold", func(t *testing.T) {
+		mockRoleRepo.On("GetByID", uint(1)).Return(adminRole(), nil).Once()
+		mockRoleRepo.On("RemoveRole", uint(7), uint(1), true).Return(false, nil).Once()
+
+		err := service.RemoveRole(7, 1, adminPermissions)
+
+		assert.ErrorIs(t, err, ErrRoleNotAssigned)
+	})
+
+	t.Run("admin role with other holders", func(t *testing.T) {
+		mockRoleRepo.On("GetByID", uint(1)).Return(adminRole(), nil).Once()
+		mockRoleRepo.On("RemoveRole", uint(7), uint(1), true).Return(true, nil).Once()
+
+		err := service.RemoveRole(7, 1, adminPermissions)
+
+		assert.NoError(t, err)
+		mockRoleRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserPermissions(t *testing.T) {
+	user := &models.User{Roles: []models.Role{
+		{Name: "support", Permissions: []models.RolePermission{{Permission: models.PermissionUsersUnlock}, {Permission: models.PermissionQuestionsAnswer}}},
+		{Name: "fulfilment", Permissions: []models.RolePermission{{Permission: models.PermissionOrdersUpdate}, {Permission: models.PermissionUsersUnlock}}},
+	}}
+
+	permissions := user.Permissions()
+
+	assert.Equal(t, []string{"support", "fulfilment"}, user.RoleNames())
+	assert.Equal(t, []string{models.PermissionUsersUnlock, models.PermissionQuestionsAnswer, models.PermissionOrdersUpdate}, permissions)
+	assert.True(t, models.HasPermission(permissions, models.PermissionOrdersUpdate))
+	assert.False(t, models.HasPermission(permissions, models.PermissionCatalogManage))
+	assert.True(t, models.HasPermission([]string{models.PermissionAll}, models.PermissionRolesManage))
+	assert.Empty(t, (&models.User{}).Permissions())
+}
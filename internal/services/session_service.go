@@ -0,0 +1,132 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxUserAgentLength = 512
+	// sessionTouchInterval limits how often authenticated requests write the
+	// last used time of their session
+	sessionTouchInterval = time.Minute
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrPermissionsChanged is returned for access tokens issued before the roles
+// of their user changed, the permissions they carry may be out of date
+var ErrPermissionsChanged = errors.New("permissions have changed")
+
+// SessionService manages the signed in devices of a user. A session is a
+// refresh token family; its ID is the sid claim of the tokens issued for it.
+type SessionService struct {
+	config   *config.Config
+	userRepo repositories.UserRepositoryInterface
+}
+
+func NewSessionService(db *gorm.DB, config *config.Config) *SessionService {
+	return &SessionService{
+		config:   config,
+		userRepo: repositories.NewUserRepository(db),
+	}
+}
+
+// GetSessions lists the sessions of the user that can still be refreshed,
+// most recently used first. currentID marks the session of the request.
+func (s *SessionService) GetSessions(userID uint, currentID string) ([]dto.SessionResponse, error) {
+	tokens, err := s.userRepo.GetSessions(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]dto.SessionResponse, len(tokens))
+	for i, token := range tokens {
+		sessions[i] = dto.SessionResponse{
+			ID:         token.FamilyID,
+			UserAgent:  token.UserAgent,
+			IPAddress:  token.IPAddress,
+			LastUsedAt: token.LastUsedAt,
+			ExpiresAt:  token.ExpiredAt,
+			Current:    token.FamilyID == currentID,
+		}
+	}
+	return sessions, nil
+}
+
+// RevokeSession signs the user out of one session. Its refresh token stops
+// working at once and so do its access tokens, through ValidateSession.
+func (s *SessionService) RevokeSession(userID uint, sessionID string) error {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return ErrSessionNotFound
+	}
+
+	token, err := s.userRepo.GetSession(sessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && token.UserID != userID) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.userRepo.RevokeRefreshTokenFamily(sessionID)
+}
+
+// RevokeAllSessions signs the user out everywhere, including the session of
+// the request
+func (s *SessionService) RevokeAllSessions(userID uint) error {
+	return s.userRepo.DeleteRefreshTokensByUserID(userID)
+}
+
+// ValidateSession reports whether the session of an access token is still
+// signed in, and records that it was used. Tokens issued before the roles of
+// the user changed get ErrPermissionsChanged.
+func (s *SessionService) ValidateSession(userID uint, sessionID string, issuedAt time.Time) (bool, error) {
+	if sessionID == "" {
+		return false, nil
+	}
+
+	token, err := s.userRepo.GetSession(sessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if token.UserID != userID {
+		return false, nil
+	}
+	// Tokens carry their issue time in whole seconds, so one issued in the
+	// second the roles changed may predate the change and is refused too
+	if changedAt := token.User.RolesChangedAt; changedAt != nil && !issuedAt.After(changedAt.Truncate(time.Second)) {
+		return false, ErrPermissionsChanged
+	}
+
+	now := time.Now()
+	if now.Sub(token.LastUsedAt) >= sessionTouchInterval {
+		if err := s.userRepo.TouchRefreshToken(token.ID, now); err != nil {
+			log.Println("Failed to update session last used time:", err)
+		}
+	}
+
+	return true, nil
+}
+
+// truncate cuts value to at most length bytes without splitting a character
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	for length > 0 && !utf8.RuneStart(value[length]) {
+		length--
+	}
+	return value[:length]
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/mocks"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestSessionService_GetSessions(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+
+	service := &SessionService{
+		config:   &config.Config{},
+		userRepo: mockUserRepo,
+	}
+
+	lastUsed := time.Now().Add(-time.Hour)
+	mockUserRepo.On("GetSessions", uint(1), mock.AnythingOfType("time.Time")).Return([]models.RefreshToken{
+		{ID: 1, UserID: 1, FamilyID: "9b2f0d3e-0000-4000-8000-000000000001", UserAgent: "Firefox", IPAddress: "203.0.113.7", LastUsedAt: lastUsed},
+		{ID: 2, UserID: 1, FamilyID: "9b2f0d3e-0000-4000-8000-000000000002", UserAgent: "Safari", IPAddress: "198.51.100.2", LastUsedAt: lastUsed},
+	}, nil).Once()
+
+	sessions, err := service.GetSessions(1, "9b2f0d3e-0000-4000-8000-000000000002")
+
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "Firefox", sessions[0].UserAgent)
+	assert.False(t, sessions[0].Current)
+	assert.True(t, sessions[1].Current)
+	assert.Equal(t, lastUsed, sessions[1].LastUsedAt)
+}
+
+func TestSessionService_RevokeSession(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+
+	service := &SessionService{
+		config:   &config.Config{},
+		userRepo: mockUserRepo,
+	}
+
+	sessionID := "9b2f0d3e-0000-4000-8000-000000000001"
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.On("GetSession", sessionID).Return(&models.RefreshToken{ID: 1, UserID: 1, FamilyID: sessionID}, nil).Once()
+		mockUserRepo.On("RevokeRefreshTokenFamily", sessionID).Return(nil).Once()
+
+		err := service.RevokeSession(1, sessionID)
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("session of another user", func(t *testing.T) {
+		mockUserRepo.On("GetSession", sessionID).Return(&models.RefreshToken{ID: 1, UserID: 2, FamilyID: sessionID}, nil).Once()
+
+		err := service.RevokeSession(1, sessionID)
+
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		mockUserRepo.AssertNumberOfCalls(t, "RevokeRefreshTokenFamily", 1)
+	})
+
+	t.Run("malformed id", func(t *testing.T) {
+		err := service.RevokeSession(1, "not-a-session")
+
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		mockUserRepo.AssertNotCalled(t, "GetSession", "not-a-session")
+	})
+}
+
+func TestSessionService_ValidateSession(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+
+	service := &SessionService{
+		config:   &config.Config{},
+		userRepo: mockUserRepo,
+	}
+
+	sessionID := "9b2f0d3e-0000-4000-8000-000000000001"
+
+	t.Run("active session is touched", func(t *testing.T) {
+		mockUserRepo.On("GetSession", sessionID).
+			Return(&models.RefreshToken{ID: 4, UserID: 1, FamilyID: sessionID, LastUsedAt: time.Now().Add(-time.Hour)}, nil).Once()
+		mockUserRepo.On("TouchRefreshToken", uint(4), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		active, err := service.ValidateSession(1, sessionID, time.Now())
+
+		assert.NoError(t, err)
+		assert.True(t, active)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("recently used session is not written", func(t *testing.T) {
+		mockUserRepo.On("GetSession", sessionID).
+			Return(&models.RefreshToken{ID: 4, UserID: 1, FamilyID: sessionID, LastUsedAt: time.Now()}, nil).Once()
+
+		active, err := service.ValidateSession(1, sessionID, time.Now())
+
+		assert.NoError(t, err)
+		assert.True(t, active)
+		mockUserRepo.AssertNumberOfCalls(t, "TouchRefreshToken", 1)
+	})
+
+	t.Run("token issued before the roles changed", func(t *testing.T) {
+		issuedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+		changedAt := issuedAt.Add(30 * time.Second)
+		mockUserRepo.On("GetSession", sessionID).
+			Return(&models.RefreshToken{ID: 4, UserID: 1, FamilyID: sessionID, LastUsedAt: time.Now(), User: models.User{ID: 1, RolesChangedAt: &changedAt}}, nil).Once()
+
+		active, err := service.ValidateSession(1, sessionID, issuedAt)
+
+		assert.ErrorIs(t, err, ErrPermissionsChanged)
+		assert.False(t, active)
+	})
+
+	t.Run("token issued in the second the roles changed", func(t *testing.T) {
+		issuedAt := time.Now().Truncate(time.Second)
+		changedAt := issuedAt.Add(400 * time.Millisecond)
+		mockUserRepo.On("GetSession", sessionID).
+			Return(&models.RefreshToken{ID: 4, UserID: 1, FamilyID: sessionID, LastUsedAt: time.Now(), User: models.User{ID: 1, RolesChangedAt: &changedAt}}, nil).Once()
+
+		active, err := service.ValidateSession(1, sessionID, issuedAt)
+
+		assert.ErrorIs(t, err, ErrPermissionsChanged)
+		assert.False(t, active)
+	})
+
+	t.Run("token issued after the roles changed", func(t *testing.T) {
+		issuedAt := time.Now().Truncate(time.Second)
+		changedAt := issuedAt.Add(-600 * time.Millisecond)
+		mockUserRepo.On("GetSession", sessionID).
+			Return(&models.RefreshToken{ID: 4, UserID: 1, FamilyID: sessionID, LastUsedAt: time.Now(), User: models.User{ID: 1, RolesChangedAt: &changedAt}}, nil).Once()
+
+		active, err := service.ValidateSession(1, sessionID, issuedAt)
+
+		assert.NoError(t, err)
+		assert.True(t, active)
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		mockUserRepo.On("GetSession", sessionID).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		active, err := service.ValidateSession(1, sessionID, time.Now())
+
+		assert.NoError(t, err)
+		assert.False(t, active)
+	})
+
+	t.Run("token without a session", func(t *testing.T) {
+		active, err := service.ValidateSession(1, "", time.Now())
+
+		assert.NoError(t, err)
+		assert.False(t, active)
+		mockUserRepo.AssertNotCalled(t, "GetSession", "")
+	})
+}
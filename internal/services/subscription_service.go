@@ -0,0 +1,336 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/events"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/notifications"
+	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// subscriptionOrderCreator places the order for a subscription delivery within
+// tx, rolling its own writes back when it fails. OrderService implements it
+// through the regular checkout pipeline.
+type subscriptionOrderCreator interface {
+	CreateSubscriptionOrder(tx *gorm.DB, subscription *models.Subscription) (*models.Order, error)
+}
+
+type SubscriptionService struct {
+	db               *gorm.DB
+	config           *config.Config
+	eventPublisher   events.Publisher
+	subscriptionRepo repositories.SubscriptionRepositoryInterface
+	productRepo      repositories.ProductRepositoryInterface
+	userRepo         repositories.UserRepositoryInterface
+	orderService     subscriptionOrderCreator
+}
+
+func NewSubscriptionService(db *gorm.DB, config *config.Config, eventPublisher events.Publisher, orderService *OrderService) *SubscriptionService {
+	return &SubscriptionService{
+		db:               db,
+		config:           config,
+		eventPublisher:   eventPublisher,
+		subscriptionRepo: repositories.NewSubscriptionRepository(db),
+		productRepo:      repositories.NewProductRepository(db),
+		userRepo:         repositories.NewUserRepository(db),
+		orderService:     orderService,
+	}
+}
+
+// CreateSubscription subscribes the user to the given products. The first
+// delivery is placed on the start date, or on the next scheduler run when none
+// is given. Like checkout, it needs a verified email address when the shop
+// requires one.
+func (s *SubscriptionService) CreateSubscription(userID uint, req *dto.CreateSubscriptionRequest) (*dto.SubscriptionResponse, error) {
+	if err := ensureCanCheckout(s.config, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	nextRunAt := now
+	if req.StartDate != nil {
+		if req.StartDate.Before(now) {
+			return nil, errors.New("start date must be in the future")
+		}
+		nextRunAt = *req.StartDate
+	}
+
+	subscription := models.Subscription{
+		UserID:    userID,
+		Status:    models.SubscriptionStatusActive,
+		Frequency: models.SubscriptionFrequency(req.Frequency),
+		NextRunAt: nextRunAt,
+		AnchorDay: nextRunAt.Day(),
+		Items:     make([]models.SubscriptionItem, len(req.Items)),
+	}
+
+	seen := make(map[uint]bool, len(req.Items))
+	for i, item := range req.Items {
+		if seen[item.ProductID] {
+			return nil, errors.New("each product can only be added once")
+		}
+		seen[item.ProductID] = true
+
+		product, err := s.productRepo.GetByID(item.ProductID)
+		if err != nil || !product.IsActive {
+			return nil, errors.New("product not found")
+		}
+
+		subscription.Items[i] = models.SubscriptionItem{
+			ProductID: product.ID,
+			Quantity:  item.Quantity,
+			Product:   *product,
+		}
+	}
+
+	if err := s.subscriptionRepo.Create(&subscription); err != nil {
+		return nil, err
+	}
+
+	return s.toSubscriptionResponse(&subscription), nil
+}
+
+func (s *SubscriptionService) GetSubscriptions(userID uint) ([]dto.SubscriptionResponse, error) {
+	subscriptions, err := s.subscriptionRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.SubscriptionResponse, len(subscriptions))
+	for i := range subscriptions {
+		response[i] = *s.toSubscriptionResponse(&subscriptions[i])
+	}
+	return response, nil
+}
+
+func (s *SubscriptionService) GetSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	subscription, err := s.getOwnedSubscription(userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toSubscriptionResponse(subscription), nil
+}
+
+// SkipNextDelivery moves the upcoming delivery one period ahead. A delivery
+// that is being retried is dropped as well.
+func (s *SubscriptionService) SkipNextDelivery(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	return s.changeOwnedSubscription(userID, subscriptionID, func(subscription *models.Subscription) ([]string, error) {
+		if subscription.Status == models.SubscriptionStatusCancelled {
+			return nil, errors.New("subscription is cancelled")
+		}
+
+		subscription.NextRunAt = subscription.Frequency.Next(subscription.NextRunAt, subscription.AnchorDay)
+		subscription.RetryAt = nil
+		subscription.FailedAttempts = 0
+		return []string{"next_run_at", "retry_at", "failed_attempts"}, nil
+	})
+}
+
+func (s *SubscriptionService) PauseSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	return s.changeOwnedSubscription(userID, subscriptionID, func(subscription *models.Subscription) ([]string, error) {
+		if subscription.Status != models.SubscriptionStatusActive {
+			return nil, errors.New("only active subscriptions can be paused")
+		}
+
+		subscription.Status = models.SubscriptionStatusPaused
+		subscription.RetryAt = nil
+		subscription.FailedAttempts = 0
+		return []string{"status", "retry_at", "failed_attempts"}, nil
+	})
+}
+
+// ResumeSubscription reactivates a paused subscription. Deliveries that fell
+// within the pause are not made up for; the schedule continues from the first
+// delivery date still ahead.
+func (s *SubscriptionService) ResumeSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	return s.changeOwnedSubscription(userID, subscriptionID, func(subscription *models.Subscription) ([]string, error) {
+		if subscription.Status != models.SubscriptionStatusPaused {
+			return nil, errors.New("only paused subscriptions can be resumed")
+		}
+
+		now := time.Now()
+		if subscription.NextRunAt.Before(now) {
+			subscription.NextRunAt = nextDeliveryAfter(subscription, now)
+		}
+		subscription.Status = models.SubscriptionStatusActive
+		return []string{"status", "next_run_at"}, nil
+	})
+}
+
+func (s *SubscriptionService) CancelSubscription(userID, subscriptionID uint) (*dto.SubscriptionResponse, error) {
+	return s.changeOwnedSubscription(userID, subscriptionID, func(subscription *models.Subscription) ([]string, error) {
+		if subscription.Status == models.SubscriptionStatusCancelled {
+			return nil, errors.New("subscription is already cancelled")
+		}
+
+		now := time.Now()
+		subscription.Status = models.SubscriptionStatusCancelled
+		subscription.CancelledAt = &now
+		subscription.RetryAt = nil
+		return []string{"status", "cancelled_at", "retry_at"}, nil
+	})
+}
+
+// ProcessDueSubscriptions places the orders of every subscription due at now.
+// A failed delivery is retried after the configured delay and skipped once the
+// attempts run out; the customer is notified of every failure. It returns the
+// number of orders placed.
+func (s *SubscriptionService) ProcessDueSubscriptions(now time.Time) (int, error) {
+	ids, err := s.subscriptionRepo.GetDueIDs(now, s.config.Subscription.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	placed := 0
+	for _, id := range ids {
+		ok, err := s.processSubscription(id, now)
+		if err != nil {
+			fmt.Println("Failed to process subscription:", err)
+			continue
+		}
+		if ok {
+			placed++
+		}
+	}
+
+	return placed, nil
+}
+
+// processSubscription places the order of a due subscription and moves its
+// schedule on in the same transaction, so a delivery is never placed twice
+func (s *SubscriptionService) processSubscription(id uint, now time.Time) (bool, error) {
+	placed := false
+	var failure *notifications.SubscriptionOrderFailedPayload
+
+	_, err := s.subscriptionRepo.ProcessDue(id, now, func(tx *gorm.DB, subscription *models.Subscription) error {
+		order, orderErr := s.orderService.CreateSubscriptionOrder(tx, subscription)
+		if orderErr == nil {
+			subscription.LastOrderID = &order.ID
+			subscription.LastError = ""
+			subscription.FailedAttempts = 0
+			subscription.RetryAt = nil
+			subscription.NextRunAt = nextDeliveryAfter(subscription, now)
+			placed = true
+			return nil
+		}
+
+		subscription.FailedAttempts++
+		subscription.LastError = orderErr.Error()
+		attempt := subscription.FailedAttempts
+
+		willRetry := attempt < s.config.Subscription.MaxAttempts
+		var nextAttemptAt time.Time
+		if willRetry {
+			nextAttemptAt = now.Add(s.config.Subscription.RetryDelay)
+			subscription.RetryAt = &nextAttemptAt
+		} else {
+			subscription.NextRunAt = nextDeliveryAfter(subscription, now)
+			subscription.RetryAt = nil
+			subscription.FailedAttempts = 0
+			nextAttemptAt = subscription.NextRunAt
+		}
+
+		failure = &notifications.SubscriptionOrderFailedPayload{
+			Email:          subscription.User.Email,
+			Name:           strings.TrimSpace(subscription.User.FirstName + " " + subscription.User.LastName),
+			SubscriptionID: subscription.ID,
+			Reason:         orderErr.Error(),
+			Attempt:        attempt,
+			WillRetry:      willRetry,
+			NextAttemptAt:  nextAttemptAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// The customer is told once the failure is recorded
+	if failure != nil {
+		if err := s.eventPublisher.Publish(notifications.SubscriptionOrderFailed, *failure, nil); err != nil {
+			fmt.Println("Failed to publish SUBSCRIPTION_ORDER_FAILED event:", err)
+		}
+	}
+
+	return placed, nil
+}
+
+func (s *SubscriptionService) getOwnedSubscription(userID, subscriptionID uint) (*models.Subscription, error) {
+	subscription, err := s.subscriptionRepo.GetByID(subscriptionID)
+	if err != nil || subscription.UserID != userID {
+		return nil, errors.New("subscription not found")
+	}
+	return subscription, nil
+}
+
+// changeOwnedSubscription applies change to a subscription of the user while
+// the subscription is locked, writing only the columns change returns
+func (s *SubscriptionService) changeOwnedSubscription(userID, subscriptionID uint, change func(subscription *models.Subscription) ([]string, error)) (*dto.SubscriptionResponse, error) {
+	subscription, err := s.subscriptionRepo.Change(subscriptionID, func(subscription *models.Subscription) ([]string, error) {
+		if subscription.UserID != userID {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return change(subscription)
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("subscription not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toSubscriptionResponse(subscription), nil
+}
+
+// nextDeliveryAfter advances the delivery date of the subscription by whole
+// periods until it lies after now
+func nextDeliveryAfter(subscription *models.Subscription, now time.Time) time.Time {
+	next := subscription.Frequency.Next(subscription.NextRunAt, subscription.AnchorDay)
+	for !next.After(now) {
+		next = subscription.Frequency.Next(next, subscription.AnchorDay)
+	}
+	return next
+}
+
+func (s *SubscriptionService) toSubscriptionResponse(subscription *models.Subscription) *dto.SubscriptionResponse {
+	items := make([]dto.SubscriptionItemResponse, len(subscription.Items))
+	for i, item := range subscription.Items {
+		items[i] = dto.SubscriptionItemResponse{
+			ID: item.ID,
+			Product: dto.ProductResponse{
+				ID:          item.Product.ID,
+				CategoryID:  item.Product.CategoryID,
+				Name:        item.Product.Name,
+				Slug:        item.Product.Slug,
+				Description: item.Product.Description,
+				Price:       item.Product.Price,
+				Stock:       item.Product.Stock,
+				IsActive:    item.Product.IsActive,
+			},
+			Quantity: item.Quantity,
+		}
+	}
+
+	return &dto.SubscriptionResponse{
+		ID:             subscription.ID,
+		Status:         string(subscription.Status),
+		Frequency:      string(subscription.Frequency),
+		NextRunAt:      subscription.NextRunAt,
+		RetryAt:        subscription.RetryAt,
+		FailedAttempts: subscription.FailedAttempts,
+		LastError:      subscription.LastError,
+		LastOrderID:    subscription.LastOrderID,
+		CancelledAt:    subscription.CancelledAt,
+		Items:          items,
+		CreatedAt:      subscription.CreatedAt,
+		UpdatedAt:      subscription.UpdatedAt,
+	}
+}
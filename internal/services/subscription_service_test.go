@@ -0,0 +1,350 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/mocks"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type fakeSubscriptionOrderCreator struct {
+	order *models.Order
+	err   error
+	calls int
+}
+
+func (f *fakeSubscriptionOrderCreator) CreateSubscriptionOrder(tx *gorm.DB, subscription *models.Subscription) (*models.Order, error) {
+	f.calls++
+	return f.order, f.err
+}
+
+func TestSubscriptionService_CreateSubscription(t *testing.T) {
+	mockSubscriptionRepo := new(mocks.MockSubscriptionRepositoryInterface)
+	mockProductRepo := new(mocks.MockProductRepositoryInterface)
+	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+	cfg := &config.Config{}
+
+	service := &SubscriptionService{
+		db:               &gorm.DB{},
+		config:           cfg,
+		eventPublisher:   new(mocks.MockPublisher),
+		subscriptionRepo: mockSubscriptionRepo,
+		productRepo:      mockProductRepo,
+		userRepo:         mockUserRepo,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockProductRepo.On("GetByID", uint(10)).Return(&models.Product{ID: 10, Name: "Coffee", IsActive: true}, nil).Once()
+		mockSubscriptionRepo.On("Create", mock.MatchedBy(func(s *models.Subscription) bool {
+			return s.UserID == 1 && s.Status == models.SubscriptionStatusActive &&
+				s.Frequency == models.SubscriptionFrequencyMonthly && s.AnchorDay == s.NextRunAt.Day() &&
+				len(s.Items) == 1 && s.Items[0].ProductID == 10 && s.Items[0].Quantity == 2
+		})).Return(nil).Once()
+
+		result, err := service.CreateSubscription(1, &dto.CreateSubscriptionRequest{
+			Frequency: "monthly",
+			Items:     []dto.SubscriptionItemRequest{{ProductID: 10, Quantity: 2}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "active", result.Status)
+		assert.Equal(t, "Coffee", result.Items[0].Product.Name)
+		mockSubscriptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("duplicate product", func(t *testing.T) {
+		mockProductRepo.On("GetByID", uint(10)).Return(&models.Product{ID: 10, IsActive: true}, nil).Once()
+
+		result, err := service.CreateSubscription(1, &dto.CreateSubscriptionRequest{
+			Frequency: "weekly",
+			Items:     []dto.SubscriptionItemRequest{{ProductID: 10, Quantity: 1}, {ProductID: 10, Quantity: 2}},
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockSubscriptionRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("start date in the past", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+
+		result, err := service.CreateSubscription(1, &dto.CreateSubscriptionRequest{
+			Frequency: "weekly",
+			StartDate: &past,
+			Items:     []dto.SubscriptionItemRequest{{ProductID: 10, Quantity: 1}},
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("unverified email when the shop requires verification", func(t *testing.T) {
+		cfg.Auth.RequireVerifiedEmail = config.RequireVerifiedEmailForCheckout
+		defer func() { cfg.Auth.RequireVerifiedEmail = "" }()
+
+		mockUserRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1}, nil).Once()
+
+		result, err := service.CreateSubscription(1, &dto.CreateSubscriptionRequest{
+			Frequency: "weekly",
+			Items:     []dto.SubscriptionItemRequest{{ProductID: 10, Quantity: 1}},
+		})
+
+		assert.ErrorIs(t, err, ErrEmailNotVerified)
+		assert.Nil(t, result)
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestSubscriptionService_ProcessDueSubscriptions(t *testing.T) {
+	orders := &fakeSubscriptionOrderCreator{}
+	mockSubscriptionRepo := new(mocks.MockSubscriptionRepositoryInterface)
+	mockPublisher := new(mocks.MockPublisher)
+
+	service := &SubscriptionService{
+		db: &gorm.DB{},
+		config: &config.Config{Subscription: config.SubscriptionConfig{
+			RetryDelay:  6 * time.Hour,
+			MaxAttempts: 2,
+			BatchSize:   10,
+		}},
+		eventPublisher:   mockPublisher,
+		subscriptionRepo: mockSubscriptionRepo,
+		productRepo:      new(mocks.MockProductRepositoryInterface),
+		orderService:     orders,
+	}
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	due := func() models.Subscription {
+		return models.Subscription{
+			ID:        4,
+			UserID:    1,
+			Status:    models.SubscriptionStatusActive,
+			Frequency: models.SubscriptionFrequencyWeekly,
+			NextRunAt: now.Add(-time.Hour),
+			User:      models.User{Email: "jane@example.com", FirstName: "Jane"},
+			Items:     []models.SubscriptionItem{{ProductID: 10, Quantity: 1}},
+		}
+	}
+
+	t.Run("places order and schedules the next delivery", func(t *testing.T) {
+		orders.order, orders.err = &models.Order{ID: 99}, nil
+		subscription := due()
+		mockSubscriptionRepo.On("GetDueIDs", now, 10).Return([]uint{4}, nil).Once()
+		mockSubscriptionRepo.On("ProcessDue", uint(4), now, mock.Anything).Run(func(args mock.Arguments) {
+			process := args.Get(2).(func(*gorm.DB, *models.Subscription) error)
+			assert.NoError(t, process(nil, &subscription))
+		}).Return(true, nil).Once()
+
+		placed, err := service.ProcessDueSubscriptions(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, placed)
+		assert.Equal(t, uint(99), *subscription.LastOrderID)
+		assert.Zero(t, subscription.FailedAttempts)
+		assert.Nil(t, subscription.RetryAt)
+		assert.Equal(t, now.Add(-time.Hour).AddDate(0, 0, 7), subscription.NextRunAt)
+		mockSubscriptionRepo.AssertExpectations(t)
+		mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failure is retried and notified", func(t *testing.T) {
+		orders.order, orders.err = nil, errors.New("insufficient stock for product: Coffee")
+		subscription := due()
+		mockSubscriptionRepo.On("GetDueIDs", now, 10).Return([]uint{4}, nil).Once()
+		mockSubscriptionRepo.On("ProcessDue", uint(4), now, mock.Anything).Run(func(args mock.Arguments) {
+			process := args.Get(2).(func(*gorm.DB, *models.Subscription) error)
+			assert.NoError(t, process(nil, &subscription))
+		}).Return(true, nil).Once()
+		mockPublisher.On("Publish", notifications.SubscriptionOrderFailed, mock.MatchedBy(func(p notifications.SubscriptionOrderFailedPayload) bool {
+			return p.Email == "jane@example.com" && p.Attempt == 1 && p.WillRetry && p.NextAttemptAt.Equal(now.Add(6*time.Hour))
+		}), mock.Anything).Return(nil).Once()
+
+		placed, err := service.ProcessDueSubscriptions(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, placed)
+		assert.Equal(t, 1, subscription.FailedAttempts)
+		assert.Equal(t, now.Add(6*time.Hour), *subscription.RetryAt)
+		assert.Equal(t, now.Add(-time.Hour), subscription.NextRunAt)
+		assert.NotEmpty(t, subscription.LastError)
+		mockSubscriptionRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("last failed attempt skips the delivery", func(t *testing.T) {
+		orders.order, orders.err = nil, errors.New("product 10 is no longer available")
+		subscription := due()
+		subscription.FailedAttempts = 1
+		retryAt := now.Add(-time.Minute)
+		subscription.RetryAt = &retryAt
+
+		mockSubscriptionRepo.On("GetDueIDs", now, 10).Return([]uint{4}, nil).Once()
+		mockSubscriptionRepo.On("ProcessDue", uint(4), now, mock.Anything).Run(func(args mock.Arguments) {
+			process := args.Get(2).(func(*gorm.DB, *models.Subscription) error)
+			assert.NoError(t, process(nil, &subscription))
+		}).Return(true, nil).Once()
+		mockPublisher.On("Publish", notifications.SubscriptionOrderFailed, mock.MatchedBy(func(p notifications.SubscriptionOrderFailedPayload) bool {
+			return p.Attempt == 2 && !p.WillRetry
+		}), mock.Anything).Return(nil).Once()
+
+		_, err := service.ProcessDueSubscriptions(now)
+
+		assert.NoError(t, err)
+		assert.Zero(t, subscription.FailedAttempts)
+		assert.Nil(t, subscription.RetryAt)
+		assert.Equal(t, now.Add(-time.Hour).AddDate(0, 0, 7), subscription.NextRunAt)
+		mockSubscriptionRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("subscription claimed by another worker is skipped", func(t *testing.T) {
+		calls := orders.calls
+		mockSubscriptionRepo.On("GetDueIDs", now, 10).Return([]uint{4}, nil).Once()
+		mockSubscriptionRepo.On("ProcessDue", uint(4), now, mock.Anything).Return(false, nil).Once()
+
+		placed, err := service.ProcessDueSubscriptions(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, placed)
+		assert.Equal(t, calls, orders.calls)
+		mockSubscriptionRepo.AssertExpectations(t)
+	})
+}
+
+func TestSubscriptionService_ChangeStatus(t *testing.T) {
+	mockSubscriptionRepo := new(mocks.MockSubscriptionRepositoryInterface)
+
+	service := &SubscriptionService{
+		db:               &gorm.DB{},
+		config:           &config.Config{},
+		eventPublisher:   new(mocks.MockPublisher),
+		subscriptionRepo: mockSubscriptionRepo,
+		productRepo:      new(mocks.MockProductRepositoryInterface),
+	}
+
+	// change runs the change of the service on the locked subscription the
+	// way the repository does, recording the columns it writes
+	var columns []string
+	change := func(subscription *models.Subscription) func(uint, func(*models.Subscription) ([]string, error)) (*models.Subscription, error) {
+		return func(_ uint, apply func(*models.Subscription) ([]string, error)) (*models.Subscription, error) {
+			var err error
+			if columns, err = apply(subscription); err != nil {
+				return nil, err
+			}
+			return subscription, nil
+		}
+	}
+
+	t.Run("pause active subscription", func(t *testing.T) {
+		subscription := &models.Subscription{ID: 4, UserID: 1, Status: models.SubscriptionStatusActive}
+		mockSubscriptionRepo.On("Change", uint(4), mock.Anything).Return(change(subscription), nil).Once()
+
+		result, err := service.PauseSubscription(1, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "paused", result.Status)
+		assert.ElementsMatch(t, []string{"status", "retry_at", "failed_attempts"}, columns)
+	})
+
+	t.Run("resume rolls the schedule forward", func(t *testing.T) {
+		lastRun := time.Now().AddDate(0, 0, -10)
+		subscription := &models.Subscription{
+			ID:        4,
+			UserID:    1,
+			Status:    models.SubscriptionStatusPaused,
+			Frequency: models.SubscriptionFrequencyWeekly,
+			NextRunAt: lastRun,
+		}
+		mockSubscriptionRepo.On("Change", uint(4), mock.Anything).Return(change(subscription), nil).Once()
+
+		_, err := service.ResumeSubscription(1, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.SubscriptionStatusActive, subscription.Status)
+		assert.Equal(t, lastRun.AddDate(0, 0, 14), subscription.NextRunAt)
+		assert.ElementsMatch(t, []string{"status", "next_run_at"}, columns)
+	})
+
+	t.Run("skip moves the next delivery", func(t *testing.T) {
+		subscription := &models.Subscription{
+			ID:        4,
+			UserID:    1,
+			Status:    models.SubscriptionStatusActive,
+			Frequency: models.SubscriptionFrequencyMonthly,
+			NextRunAt: time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC),
+		}
+		mockSubscriptionRepo.On("Change", uint(4), mock.Anything).Return(change(subscription), nil).Once()
+
+		_, err := service.SkipNextDelivery(1, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 4, 8, 9, 0, 0, 0, time.UTC), subscription.NextRunAt)
+		assert.ElementsMatch(t, []string{"next_run_at", "retry_at", "failed_attempts"}, columns)
+	})
+
+	t.Run("cancelled subscription cannot be skipped", func(t *testing.T) {
+		subscription := &models.Subscription{ID: 4, UserID: 1, Status: models.SubscriptionStatusCancelled}
+		mockSubscriptionRepo.On("Change", uint(4), mock.Anything).Return(change(subscription), nil).Once()
+
+		result, err := service.SkipNextDelivery(1, 4)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("subscription of another user", func(t *testing.T) {
+		subscription := &models.Subscription{ID: 4, UserID: 2, Status: models.SubscriptionStatusActive}
+		mockSubscriptionRepo.On("Change", uint(4), mock.Anything).Return(change(subscription), nil).Once()
+
+		result, err := service.CancelSubscription(1, 4)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "subscription not found", err.Error())
+		assert.Equal(t, models.SubscriptionStatusActive, subscription.Status)
+	})
+}
+
+func TestSubscriptionFrequency_Next(t *testing.T) {
+	t.Run("monthly keeps the anchor day past short months", func(t *testing.T) {
+		next := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+
+		var dates []time.Time
+		for range 3 {
+			next = models.SubscriptionFrequencyMonthly.Next(next, 31)
+			dates = append(dates, next)
+		}
+
+		assert.Equal(t, []time.Time{
+			time.Date(2026, 2, 28, 9, 0, 0, 0, time.UTC),
+			time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC),
+			time.Date(2026, 4, 30, 9, 0, 0, 0, time.UTC),
+		}, dates)
+	})
+
+	t.Run("monthly in a leap year", func(t *testing.T) {
+		next := models.SubscriptionFrequencyMonthly.Next(time.Date(2028, 1, 30, 9, 0, 0, 0, time.UTC), 30)
+
+		assert.Equal(t, time.Date(2028, 2, 29, 9, 0, 0, 0, time.UTC), next)
+	})
+
+	t.Run("monthly across the year end", func(t *testing.T) {
+		next := models.SubscriptionFrequencyMonthly.Next(time.Date(2026, 12, 15, 9, 0, 0, 0, time.UTC), 15)
+
+		assert.Equal(t, time.Date(2027, 1, 15, 9, 0, 0, 0, time.UTC), next)
+	})
+
+	t.Run("weekly ignores the anchor day", func(t *testing.T) {
+		next := models.SubscriptionFrequencyWeekly.Next(time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC), 31)
+
+		assert.Equal(t, time.Date(2026, 2, 7, 9, 0, 0, 0, time.UTC), next)
+	})
+}
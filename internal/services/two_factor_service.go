@@ -0,0 +1,210 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+
+// ErrTwoFactorRequired is returned when staff try to turn off two-factor
+// authentication while the shop requires it for staff
+var ErrTwoFactorRequired = errors.New("two-factor authentication is required for this account")
+
+// TwoFactorService manages TOTP sign in. Enrolling stores a secret that only
+// takes effect once a first code from the authenticator app confirms it; the
+// recovery codes are handed out at that point.
+type TwoFactorService struct {
+	config       *config.Config
+	userRepo     repositories.UserRepositoryInterface
+	recoveryRepo repositories.RecoveryCodeRepositoryInterface
+}
+
+func NewTwoFactorService(db *gorm.DB, config *config.Config) *TwoFactorService {
+	return &TwoFactorService{
+		config:       config,
+		userRepo:     repositories.NewUserRepository(db),
+		recoveryRepo: repositories.NewRecoveryCodeRepository(db),
+	}
+}
+
+// Enroll starts setting up an authenticator app. Enrolling again before
+// confirming replaces the secret.
+func (s *TwoFactorService) Enroll(userID uint, req *dto.EnrollTwoFactorRequest) (*dto.TwoFactorEnrollmentResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !utils.CheckPassword(req.CurrentPassword, user.Password) {
+		return nil, errors.New("current password is incorrect")
+	}
+
+	if user.TwoFactorEnabled() {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	user.TOTPSecret = secret
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorEnrollmentResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(s.config.Auth.TwoFactor.Issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm turns two-factor authentication on with the first code of the
+// authenticator app. Every other session is revoked, they were signed in
+// without the second factor.
+func (s *TwoFactorService) Confirm(userID uint, sessionID string, req *dto.ConfirmTwoFactorRequest) (*dto.RecoveryCodesResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled() {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == "" {
+		return nil, errors.New("two-factor enrollment has not been started")
+	}
+
+	now := time.Now()
+	step, ok := utils.ValidateTOTP(req.Code, user.TOTPSecret, now, user.TOTPLastStep)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	user.TOTPEnabledAt = &now
+	user.TOTPLastStep = step
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.replaceRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sessionID == "" {
+		err = s.userRepo.DeleteRefreshTokensByUserID(user.ID)
+	} else {
+		err = s.userRepo.DeleteOtherRefreshTokens(user.ID, sessionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Disable turns two-factor authentication off. It takes both the password and
+// a current code, a stolen session alone is not enough.
+func (s *TwoFactorService) Disable(userID uint, req *dto.DisableTwoFactorRequest) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.TwoFactorEnabled() {
+		return errors.New("two-factor authentication is not enabled")
+	}
+	if len(user.Roles) > 0 && s.config.Auth.TwoFactor.RequireForStaff {
+		return ErrTwoFactorRequired
+	}
+
+	if !utils.CheckPassword(req.CurrentPassword, user.Password) {
+		return errors.New("current password is incorrect")
+	}
+
+	ok, err := verifySecondFactor(s.userRepo, s.recoveryRepo, user, req.Code, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	user.TOTPSecret = ""
+	user.TOTPEnabledAt = nil
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	return s.recoveryRepo.DeleteByUserID(user.ID)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes, the previous ones stop
+// working
+func (s *TwoFactorService) RegenerateRecoveryCodes(userID uint, req *dto.RegenerateRecoveryCodesRequest) (*dto.RecoveryCodesResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TwoFactorEnabled() {
+		return nil, errors.New("two-factor authentication is not enabled")
+	}
+
+	ok, err := verifySecondFactor(s.userRepo, s.recoveryRepo, user, req.Code, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	return s.replaceRecoveryCodes(user.ID)
+}
+
+func (s *TwoFactorService) replaceRecoveryCodes(userID uint) (*dto.RecoveryCodesResponse, error) {
+	codes, err := utils.GenerateRecoveryCodes(s.config.Auth.TwoFactor.RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = utils.HashRecoveryCode(code)
+	}
+	if err := s.recoveryRepo.ReplaceAll(userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// verifySecondFactor checks an authenticator code, or else a recovery code,
+// and consumes it so it cannot be used again
+func verifySecondFactor(userRepo repositories.UserRepositoryInterface, recoveryRepo repositories.RecoveryCodeRepositoryInterface, user *models.User, code string, now time.Time) (bool, error) {
+	if !utils.IsTOTPCode(code) {
+		return recoveryRepo.Use(user.ID, utils.HashRecoveryCode(code), now)
+	}
+
+	step, ok := utils.ValidateTOTP(code, user.TOTPSecret, now, user.TOTPLastStep)
+	if !ok {
+		return false, nil
+	}
+
+	advanced, err := userRepo.AdvanceTOTPStep(user.ID, step)
+	if err != nil || !advanced {
+		return false, err
+	}
+
+	user.TOTPLastStep = step
+	return true, nil
+}
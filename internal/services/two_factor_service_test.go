@@ -0,0 +1,197 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/mocks"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTwoFactorService_Enroll(t *testing.T) {
+	cfg := &config.Config{Auth: config.AuthConfig{TwoFactor: config.TwoFactorConfig{Issuer: "Go Shop"}}}
+	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+
+	service := &TwoFactorService{
+		config:       cfg,
+		userRepo:     mockUserRepo,
+		recoveryRepo: new(mocks.MockRecoveryCodeRepositoryInterface),
+	}
+
+	hashedPassword, _ := utils.HashPassword("password123")
+
+	t.Run("success", func(t *testing.T) {
+		user := &models.User{ID: 1, Email: "user@example.com", Password: hashedPassword}
+
+		mockUserRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+		mockUserRepo.On("Update", mock.MatchedBy(func(u *models.User) bool {
+			return u.TOTPSecret != "" && u.TOTPEnabledAt == nil
+		})).Return(nil).Once()
+
+		result, err := service.Enroll(1, &dto.EnrollTwoFactorRequest{CurrentPassword: "password123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.TOTPSecret, result.Secret)
+		assert.True(t, strings.HasPrefix(result.OTPAuthURI, "otpauth://totp/Go%20Shop:user@example.com?"))
+		assert.Contains(t, result.OTPAuthURI, "secret="+result.Secret)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		mockUserRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1, Password: hashedPassword}, nil).Once()
+
+		result, err := service.Enroll(1, &dto.EnrollTwoFactorRequest{CurrentPassword: "wrong"})
+
+		assert.EqualError(t, err, "current password is incorrect")
+		assert.Nil(t, result)
+		mockUserRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		enabledAt := time.Now()
+
+		mockUserRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1, Password: hashedPassword, TOTPSecret: "SECRET", TOTPEnabledAt: &enabledAt}, nil).Once()
+
+		_, err := service.Enroll(1, &dto.EnrollTwoFactorRequest{CurrentPassword: "password123"})
+
+		assert.EqualError(t, err, "two-factor authentication is already enabled")
+		mockUserRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
+}
+
+func TestTwoFactorService_Confirm(t *testing.T) {
+	cfg := &config.Config{Auth: config.AuthConfig{TwoFactor: config.TwoFactorConfig{RecoveryCodeCount: 10}}}
+	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+	mockRecoveryRepo := new(mocks.MockRecoveryCodeRepositoryInterface)
+
+	service := &TwoFactorService{
+		config:       cfg,
+		userRepo:     mockUserRepo,
+		recoveryRepo: mockRecoveryRepo,
+	}
+
+	secret, _ := utils.GenerateTOTPSecret()
+
+	t.Run("success", func(t *testing.T) {
+		user := &models.User{ID: 1, TOTPSecret: secret}
+		code, _ := utils.TOTPCode(secret, time.Now())
+
+		mockUserRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+		mockUserRepo.On("Update", mock.MatchedBy(func(u *models.User) bool {
+			return u.TwoFactorEnabled() && u.TOTPLastStep > 0
+		})).Return(nil).Once()
+		mockRecoveryRepo.On("ReplaceAll", uint(1), mock.MatchedBy(func(hashes []string) bool {
+			return len(hashes) == 10
+		})).Return(nil).Once()
+		mockUserRepo.On("DeleteOtherRefreshTokens", uint(1), "family-1").Return(nil).Once()
+
+		result, err := service.Confirm(1, "family-1", &dto.ConfirmTwoFactorRequest{Code: code})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.RecoveryCodes, 10)
+		hashes := mockRecoveryRepo.Calls[0].Arguments.Get(1).([]string)
+		assert.Equal(t, utils.HashRecoveryCode(result.RecoveryCodes[0]), hashes[0])
+		mockUserRepo.AssertExpectations(t)
+		mockRecoveryRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		mockUserRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1, TOTPSecret: secret}, nil).Once()
+
+		result, err := service.Confirm(1, "family-1", &dto.ConfirmTwoFactorRequest{Code: "000000"})
+
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+		assert.Nil(t, result)
+		mockUserRepo.AssertNumberOfCalls(t, "Update", 1)
+		mockRecoveryRepo.AssertNumberOfCalls(t, "ReplaceAll", 1)
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		mockUserRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1}, nil).Once()
+
+		_, err := service.Confirm(1, "family-1", &dto.ConfirmTwoFactorRequest{Code: "123456"})
+
+		assert.EqualError(t, err, "two-factor enrollment has not been started")
+	})
+}
+
+func TestTwoFactorService_Disable(t *testing.T) {
+	cfg := &config.Config{Auth: config.AuthConfig{TwoFactor: config.TwoFactorConfig{RequireForStaff: true}}}
+	mockUserRepo := new(mocks.MockUserRepositoryInterface)
+	mockRecoveryRepo := new(mocks.MockRecoveryCodeRepositoryInterface)
+
+	service := &TwoFactorService{
+		config:       cfg,
+		userRepo:     mockUserRepo,
+		recoveryRepo: mockRecoveryRepo,
+	}
+
+	secret, _ := utils.GenerateTOTPSecret()
+	hashedPassword, _ := utils.HashPassword("password123")
+	newUser := func(roles ...models.Role) *models.User {
+		enabledAt := time.Now()
+		return &models.User{ID: 1, Roles: roles, Password: hashedPassword, TOTPSecret: secret, TOTPEnabledAt: &enabledAt}
+	}
+
+	t.Run("with a recovery code", func(t *testing.T) {
+		mockUserRepo.On("GetByID", uint(1)).Return(newUser(), nil).Once()
+		mockRecoveryRepo.On("Use", uint(1), utils.HashRecoveryCode("abcd-efgh"), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		mockUserRepo.On("Update", mock.MatchedBy(func(u *models.User) bool {
+			return !u.TwoFactorEnabled() && u.TOTPSecret == ""
+		})).Return(nil).Once()
+		mockRecoveryRepo.On("DeleteByUserID", uint(1)).Return(nil).Once()
+
+		err := service.Disable(1, &dto.DisableTwoFactorRequest{CurrentPassword: "password123", Code: "ABCD EFGH"})
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockRecoveryRepo.AssertExpectations(t)
+	})
+
+	t.Run("replayed code", func(t *testing.T) {
+		code, _ := utils.TOTPCode(secret, time.Now())
+
+		mockUserRepo.On("GetByID", uint(1)).Return(newUser(), nil).Once()
+		mockUserRepo.On("AdvanceTOTPStep", uint(1), mock.AnythingOfType("int64")).Return(false, nil).Once()
+
+		err := service.Disable(1, &dto.DisableTwoFactorRequest{CurrentPassword: "password123", Code: code})
+
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+		mockUserRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
+
+	t.Run("required for staff", func(t *testing.T) {
+		mockUserRepo.On("GetByID", uint(1)).Return(newUser(models.Role{Name: models.RoleAdmin}), nil).Once()
+
+		err := service.Disable(1, &dto.DisableTwoFactorRequest{CurrentPassword: "password123", Code: "123456"})
+
+		assert.ErrorIs(t, err, ErrTwoFactorRequired)
+		mockUserRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
+}
+
+func TestValidateTOTP(t *testing.T) {
+	// RFC 6238 test secret "12345678901234567890", truncated to six digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	now := time.Unix(59, 0)
+
+	step, ok := utils.ValidateTOTP("287082", secret, now, 0)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), step)
+
+	_, ok = utils.ValidateTOTP("287082", secret, now, step)
+	assert.False(t, ok, "a used step must not be accepted again")
+
+	_, ok = utils.ValidateTOTP("287082", secret, now.Add(2*time.Minute), 0)
+	assert.False(t, ok, "codes outside the allowed drift must be rejected")
+
+	code, err := utils.TOTPCode(secret, time.Unix(1111111109, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "081804", code)
+}
@@ -1,11 +1,13 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"path/filepath"
 	"strings"
 
+	"github.com/JihadRinaldi/go-shop/internal/dto"
 	"github.com/JihadRinaldi/go-shop/internal/interfaces"
 	"github.com/JihadRinaldi/go-shop/internal/models"
 	"github.com/JihadRinaldi/go-shop/internal/repositories"
@@ -14,14 +16,16 @@ import (
 )
 
 type UploadService struct {
-	provider   interfaces.UploadProvider
-	uploadRepo repositories.UploadRepositoryInterface
+	provider    interfaces.UploadProvider
+	uploadRepo  repositories.UploadRepositoryInterface
+	productRepo repositories.ProductRepositoryInterface
 }
 
 func NewUploadService(db *gorm.DB, provider interfaces.UploadProvider) *UploadService {
 	return &UploadService{
-		provider:   provider,
-		uploadRepo: repositories.NewUploadRepository(db),
+		provider:    provider,
+		uploadRepo:  repositories.NewUploadRepository(db),
+		productRepo: repositories.NewProductRepository(db),
 	}
 }
 
@@ -58,6 +62,65 @@ func (s *UploadService) UploadProductImage(productID uint, file *multipart.FileH
 	return url, nil
 }
 
+// UploadReviewImage stores a customer photo for a review and returns its URL
+func (s *UploadService) UploadReviewImage(reviewID uint, file *multipart.FileHeader) (string, error) {
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+
+	if !isValidImageExtension(ext) {
+		return "", fmt.Errorf("invalid image extension: %s", ext)
+	}
+
+	path := fmt.Sprintf("reviews/%d/%s%s", reviewID, uuid.New().String(), ext)
+
+	return s.provider.UploadFile(file, path)
+}
+
+// UploadProductFile stores a downloadable file of a digital product under
+// digital/, which the public /uploads/ location refuses to serve, so the file
+// is only handed out through signed download links.
+func (s *UploadService) UploadProductFile(productID uint, file *multipart.FileHeader) (*dto.ProductFileResponse, error) {
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, errors.New("product not found")
+	}
+	if !product.IsDigital {
+		return nil, errors.New("product is not digital")
+	}
+
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	path := fmt.Sprintf("digital/%d/%s%s", productID, uuid.New().String(), ext)
+
+	if _, err := s.provider.UploadFile(file, path); err != nil {
+		return nil, err
+	}
+
+	productFile := models.ProductFile{
+		ProductID:   productID,
+		Path:        path,
+		FileName:    filepath.Base(file.Filename),
+		ContentType: file.Header.Get("Content-Type"),
+		Size:        file.Size,
+	}
+
+	if err := s.uploadRepo.CreateProductFile(&productFile); err != nil {
+		return nil, err
+	}
+
+	response := toProductFileResponse(&productFile)
+	return &response, nil
+}
+
+func toProductFileResponse(file *models.ProductFile) dto.ProductFileResponse {
+	return dto.ProductFileResponse{
+		ID:          file.ID,
+		ProductID:   file.ProductID,
+		FileName:    file.FileName,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+		CreatedAt:   file.CreatedAt,
+	}
+}
+
 func isValidImageExtension(ext string) bool {
 	validExtensions := []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
 	for _, v := range validExtensions {
@@ -3,6 +3,7 @@ package services
 import (
 	"errors"
 	"mime/multipart"
+	"strings"
 	"testing"
 
 	"github.com/JihadRinaldi/go-shop/internal/mocks"
@@ -191,3 +192,90 @@ func TestUploadService_UploadProductImage(t *testing.T) {
 		}
 	})
 }
+
+func TestUploadService_UploadReviewImage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockProvider := new(mocks.MockUploadProvider)
+
+		service := &UploadService{
+			provider: mockProvider,
+		}
+
+		file := &multipart.FileHeader{
+			Filename: "photo.webp",
+			Size:     1024,
+		}
+
+		expectedURL := "http://example.com/reviews/3/photo.webp"
+
+		mockProvider.On("UploadFile", file, mock.MatchedBy(func(path string) bool {
+			return strings.HasPrefix(path, "reviews/3/") && strings.HasSuffix(path, ".webp")
+		})).Return(expectedURL, nil).Once()
+
+		result, err := service.UploadReviewImage(3, file)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedURL, result)
+		mockProvider.AssertExpectations(t)
+	})
+
+	t.Run("invalid extension", func(t *testing.T) {
+		service := &UploadService{
+			provider: new(mocks.MockUploadProvider),
+		}
+
+		result, err := service.UploadReviewImage(3, &multipart.FileHeader{Filename: "script.exe"})
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestUploadService_UploadProductFile(t *testing.T) {
+	t.Run("stores file of digital product", func(t *testing.T) {
+		mockProvider := new(mocks.MockUploadProvider)
+		mockUploadRepo := new(mocks.MockUploadRepositoryInterface)
+		mockProductRepo := new(mocks.MockProductRepositoryInterface)
+
+		service := &UploadService{
+			provider:    mockProvider,
+			uploadRepo:  mockUploadRepo,
+			productRepo: mockProductRepo,
+		}
+
+		file := &multipart.FileHeader{Filename: "handbook.pdf", Size: 4096}
+
+		mockProductRepo.On("GetByID", uint(5)).Return(&models.Product{ID: 5, IsDigital: true}, nil).Once()
+		mockProvider.On("UploadFile", file, mock.MatchedBy(func(path string) bool {
+			return strings.HasPrefix(path, "digital/5/") && strings.HasSuffix(path, ".pdf")
+		})).Return("/uploads/digital/5/file.pdf", nil).Once()
+		mockUploadRepo.On("CreateProductFile", mock.MatchedBy(func(f *models.ProductFile) bool {
+			return f.ProductID == 5 && f.FileName == "handbook.pdf" && f.Size == 4096 && strings.HasPrefix(f.Path, "digital/5/")
+		})).Return(nil).Once()
+
+		result, err := service.UploadProductFile(5, file)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "handbook.pdf", result.FileName)
+		mockProvider.AssertExpectations(t)
+		mockUploadRepo.AssertExpectations(t)
+	})
+
+	t.Run("product is not digital", func(t *testing.T) {
+		mockProvider := new(mocks.MockUploadProvider)
+		mockProductRepo := new(mocks.MockProductRepositoryInterface)
+
+		service := &UploadService{
+			provider:    mockProvider,
+			productRepo: mockProductRepo,
+		}
+
+		mockProductRepo.On("GetByID", uint(6)).Return(&models.Product{ID: 6}, nil).Once()
+
+		result, err := service.UploadProductFile(6, &multipart.FileHeader{Filename: "mug.pdf"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockProvider.AssertNotCalled(t, "UploadFile", mock.Anything, mock.Anything)
+	})
+}
@@ -1,21 +1,34 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
 	"github.com/JihadRinaldi/go-shop/internal/config"
 	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/events"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/notifications"
 	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
 	"gorm.io/gorm"
 )
 
 type UserService struct {
-	config   *config.Config
-	userRepo repositories.UserRepositoryInterface
+	config         *config.Config
+	eventPublisher events.Publisher
+	userRepo       repositories.UserRepositoryInterface
+	tokenRepo      repositories.UserTokenRepositoryInterface
 }
 
-func NewUserService(db *gorm.DB, config *config.Config) *UserService {
+func NewUserService(db *gorm.DB, config *config.Config, eventPublisher events.Publisher) *UserService {
 	return &UserService{
-		config:   config,
-		userRepo: repositories.NewUserRepository(db),
+		config:         config,
+		eventPublisher: eventPublisher,
+		userRepo:       repositories.NewUserRepository(db),
+		tokenRepo:      repositories.NewUserTokenRepository(db),
 	}
 }
 
@@ -25,17 +38,8 @@ func (s *UserService) GetProfile(userID uint) (*dto.UserResponse, error) {
 		return nil, err
 	}
 
-	return &dto.UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Phone:     user.Phone,
-		Role:      string(user.Role),
-		IsActive:  user.IsActive,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	response := toUserResponse(user)
+	return &response, nil
 }
 
 func (s *UserService) UpdateProfile(userID uint, req *dto.UpdateProfileRequest) (*dto.UserResponse, error) {
@@ -54,3 +58,165 @@ func (s *UserService) UpdateProfile(userID uint, req *dto.UpdateProfileRequest)
 
 	return s.GetProfile(userID)
 }
+
+// ChangePassword sets a new password after checking the current one. Reset
+// links that are still out stop working and every other session is revoked.
+func (s *UserService) ChangePassword(userID uint, sessionID string, req *dto.ChangePasswordRequest) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if !utils.CheckPassword(req.CurrentPassword, user.Password) {
+		return errors.New("current password is incorrect")
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	user.Password = hashedPassword
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.InvalidateAll(user.ID, models.UserTokenPasswordReset, time.Now()); err != nil {
+		log.Println("Failed to invalidate password reset tokens:", err)
+	}
+
+	return s.revokeOtherSessions(user.ID, sessionID)
+}
+
+// RequestEmailChange emails a confirmation link to the new address. The
+// account keeps its current address until the link is redeemed through
+// ConfirmEmailChange; only the latest link is valid.
+func (s *UserService) RequestEmailChange(userID uint, req *dto.ChangeEmailRequest) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if !utils.CheckPassword(req.CurrentPassword, user.Password) {
+		return errors.New("current password is incorrect")
+	}
+
+	if req.NewEmail == user.Email {
+		return errors.New("new email is the same as the current one")
+	}
+
+	if _, err := s.userRepo.GetByEmail(req.NewEmail); err == nil {
+		return errors.New("email already in use")
+	}
+
+	latest, err := s.tokenRepo.GetLatest(user.ID, models.UserTokenEmailChange)
+	if err == nil && time.Since(latest.CreatedAt) < s.config.Auth.VerificationResendInterval {
+		return errors.New("a confirmation email was sent recently, please wait before requesting another")
+	}
+
+	if err := s.tokenRepo.InvalidateAll(user.ID, models.UserTokenEmailChange, time.Now()); err != nil {
+		return err
+	}
+
+	token, userToken, err := issueUserToken(s.tokenRepo, s.config.JWT.SecretKey, user, req.NewEmail, models.UserTokenEmailChange, s.config.Auth.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	payload := notifications.EmailVerificationPayload{
+		Email:           req.NewEmail,
+		Name:            displayName(user),
+		VerificationURL: frontendLink(s.config.Server.FrontendURL, "/confirm-email-change", token),
+		ExpiresAt:       userToken.ExpiresAt,
+	}
+	if err := s.eventPublisher.Publish(notifications.EmailChangeRequested, payload, nil); err != nil {
+		fmt.Println("Failed to publish EMAIL_CHANGE_REQUESTED event:", err)
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange switches the account to the address the token was sent
+// to, which counts as verified. The previous address is told about the change
+// and every other session is revoked.
+func (s *UserService) ConfirmEmailChange(userID uint, sessionID string, req *dto.ConfirmEmailChangeRequest) (*dto.UserResponse, error) {
+	invalid := errors.New("invalid or expired email change token")
+
+	hash, err := utils.ParseUserToken(req.Token, string(models.UserTokenEmailChange), s.config.JWT.SecretKey)
+	if err != nil {
+		return nil, invalid
+	}
+
+	now := time.Now()
+	token, err := s.tokenRepo.GetUsable(models.UserTokenEmailChange, hash, now)
+	if err != nil || token.UserID != userID || token.User.ID == 0 {
+		return nil, invalid
+	}
+
+	if _, err := s.userRepo.GetByEmail(token.Email); err == nil {
+		return nil, errors.New("email already in use")
+	}
+
+	used, err := s.tokenRepo.MarkUsed(token.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, invalid
+	}
+
+	user := &token.User
+	previousEmail := user.Email
+	user.Email = token.Email
+	user.EmailVerifiedAt = &now
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	// Links sent to the previous address must not act on the account anymore
+	for _, purpose := range []models.UserTokenPurpose{models.UserTokenEmailVerification, models.UserTokenPasswordReset} {
+		if err := s.tokenRepo.InvalidateAll(user.ID, purpose, now); err != nil {
+			log.Println("Failed to invalidate "+string(purpose)+" tokens:", err)
+		}
+	}
+
+	if err := s.revokeOtherSessions(user.ID, sessionID); err != nil {
+		return nil, err
+	}
+
+	payload := notifications.EmailChangedPayload{
+		Email:    previousEmail,
+		Name:     displayName(user),
+		NewEmail: user.Email,
+	}
+	if err := s.eventPublisher.Publish(notifications.EmailChanged, payload, nil); err != nil {
+		fmt.Println("Failed to publish EMAIL_CHANGED event:", err)
+	}
+
+	response := toUserResponse(user)
+	return &response, nil
+}
+
+// revokeOtherSessions keeps only the session of the request signed in
+func (s *UserService) revokeOtherSessions(userID uint, currentSessionID string) error {
+	if currentSessionID == "" {
+		return s.userRepo.DeleteRefreshTokensByUserID(userID)
+	}
+	return s.userRepo.DeleteOtherRefreshTokens(userID, currentSessionID)
+}
+
+func toUserResponse(user *models.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:               user.ID,
+		Email:            user.Email,
+		FirstName:        user.FirstName,
+		LastName:         user.LastName,
+		Phone:            user.Phone,
+		Roles:            user.RoleNames(),
+		IsActive:         user.IsActive,
+		EmailVerified:    user.IsEmailVerified(),
+		TwoFactorEnabled: user.TwoFactorEnabled(),
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+	}
+}
@@ -9,8 +9,11 @@ import (
 	"github.com/JihadRinaldi/go-shop/internal/dto"
 	"github.com/JihadRinaldi/go-shop/internal/mocks"
 	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/notifications"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestUserService_GetProfile(t *testing.T) {
@@ -30,7 +33,6 @@ func TestUserService_GetProfile(t *testing.T) {
 			FirstName: "John",
 			LastName:  "Doe",
 			Phone:     "1234567890",
-			Role:      models.UserRoleCustomer,
 			IsActive:  true,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
@@ -84,7 +86,6 @@ func TestUserService_UpdateProfile(t *testing.T) {
 			FirstName: "John",
 			LastName:  "Doe",
 			Phone:     "1234567890",
-			Role:      models.UserRoleCustomer,
 			IsActive:  true,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
@@ -96,7 +97,6 @@ func TestUserService_UpdateProfile(t *testing.T) {
 			FirstName: updateReq.FirstName,
 			LastName:  updateReq.LastName,
 			Phone:     updateReq.Phone,
-			Role:      models.UserRoleCustomer,
 			IsActive:  true,
 			CreatedAt: existingUser.CreatedAt,
 			UpdatedAt: time.Now(),
@@ -147,7 +147,6 @@ func TestUserService_UpdateProfile(t *testing.T) {
 			FirstName: "John",
 			LastName:  "Doe",
 			Phone:     "1234567890",
-			Role:      models.UserRoleCustomer,
 			IsActive:  true,
 		}
 
@@ -161,3 +160,182 @@ func TestUserService_UpdateProfile(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 }
+
+func TestUserService_ChangePassword(t *testing.T) {
+	newService := func() (*UserService, *mocks.MockUserRepositoryInterface, *mocks.MockUserTokenRepositoryInterface) {
+		mockUserRepo := new(mocks.MockUserRepositoryInterface)
+		mockTokenRepo := new(mocks.MockUserTokenRepositoryInterface)
+		return &UserService{
+			config:    &config.Config{},
+			userRepo:  mockUserRepo,
+			tokenRepo: mockTokenRepo,
+		}, mockUserRepo, mockTokenRepo
+	}
+
+	hashedPassword, _ := utils.HashPassword("old-password")
+
+	t.Run("success keeps the current session", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo := newService()
+		user := &models.User{ID: 1, Email: "test@example.com", Password: hashedPassword}
+
+		mockUserRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+		mockUserRepo.On("Update", mock.MatchedBy(func(user *models.User) bool {
+			return utils.CheckPassword("new-password", user.Password)
+		})).Return(nil).Once()
+		mockTokenRepo.On("InvalidateAll", uint(1), models.UserTokenPasswordReset, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockUserRepo.On("DeleteOtherRefreshTokens", uint(1), "family-1").Return(nil).Once()
+
+		err := service.ChangePassword(1, "family-1", &dto.ChangePasswordRequest{
+			CurrentPassword: "old-password",
+			NewPassword:     "new-password",
+		})
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		service, mockUserRepo, _ := newService()
+		user := &models.User{ID: 1, Email: "test@example.com", Password: hashedPassword}
+
+		mockUserRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+
+		err := service.ChangePassword(1, "family-1", &dto.ChangePasswordRequest{CurrentPassword: "guess", NewPassword: "new-password"})
+
+		assert.Error(t, err)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "DeleteRefreshTokensByUserID", mock.Anything)
+	})
+}
+
+func TestUserService_RequestEmailChange(t *testing.T) {
+	cfg := &config.Config{
+		JWT:  config.JWTConfig{SecretKey: "test-secret-key"},
+		Auth: config.AuthConfig{EmailVerificationTTL: time.Hour, VerificationResendInterval: time.Minute},
+	}
+
+	newService := func() (*UserService, *mocks.MockUserRepositoryInterface, *mocks.MockUserTokenRepositoryInterface, *mocks.MockPublisher) {
+		mockUserRepo := new(mocks.MockUserRepositoryInterface)
+		mockTokenRepo := new(mocks.MockUserTokenRepositoryInterface)
+		mockPublisher := new(mocks.MockPublisher)
+		return &UserService{
+			config:         cfg,
+			eventPublisher: mockPublisher,
+			userRepo:       mockUserRepo,
+			tokenRepo:      mockTokenRepo,
+		}, mockUserRepo, mockTokenRepo, mockPublisher
+	}
+
+	hashedPassword, _ := utils.HashPassword("password123")
+
+	t.Run("sends a link to the new address", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, mockPublisher := newService()
+		user := &models.User{ID: 1, Email: "old@example.com", Password: hashedPassword}
+
+		mockUserRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+		mockUserRepo.On("GetByEmail", "new@example.com").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockTokenRepo.On("GetLatest", uint(1), models.UserTokenEmailChange).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockTokenRepo.On("InvalidateAll", uint(1), models.UserTokenEmailChange, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockTokenRepo.On("Create", mock.MatchedBy(func(token *models.UserToken) bool {
+			return token.Purpose == models.UserTokenEmailChange && token.Email == "new@example.com"
+		})).Return(nil).Once()
+		mockPublisher.On("Publish", "EMAIL_CHANGE_REQUESTED", mock.MatchedBy(func(payload notifications.EmailVerificationPayload) bool {
+			return payload.Email == "new@example.com"
+		}), mock.Anything).Return(nil).Once()
+
+		err := service.RequestEmailChange(1, &dto.ChangeEmailRequest{NewEmail: "new@example.com", CurrentPassword: "password123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "old@example.com", user.Email)
+		mockTokenRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("address already in use", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, _ := newService()
+		user := &models.User{ID: 1, Email: "old@example.com", Password: hashedPassword}
+
+		mockUserRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+		mockUserRepo.On("GetByEmail", "taken@example.com").Return(&models.User{ID: 2}, nil).Once()
+
+		err := service.RequestEmailChange(1, &dto.ChangeEmailRequest{NewEmail: "taken@example.com", CurrentPassword: "password123"})
+
+		assert.Error(t, err)
+		mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, _ := newService()
+		user := &models.User{ID: 1, Email: "old@example.com", Password: hashedPassword}
+
+		mockUserRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+
+		err := service.RequestEmailChange(1, &dto.ChangeEmailRequest{NewEmail: "new@example.com", CurrentPassword: "guess"})
+
+		assert.Error(t, err)
+		mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestUserService_ConfirmEmailChange(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{SecretKey: "test-secret-key"}}
+
+	newService := func() (*UserService, *mocks.MockUserRepositoryInterface, *mocks.MockUserTokenRepositoryInterface, *mocks.MockPublisher) {
+		mockUserRepo := new(mocks.MockUserRepositoryInterface)
+		mockTokenRepo := new(mocks.MockUserTokenRepositoryInterface)
+		mockPublisher := new(mocks.MockPublisher)
+		return &UserService{
+			config:         cfg,
+			eventPublisher: mockPublisher,
+			userRepo:       mockUserRepo,
+			tokenRepo:      mockTokenRepo,
+		}, mockUserRepo, mockTokenRepo, mockPublisher
+	}
+
+	purpose := string(models.UserTokenEmailChange)
+
+	t.Run("success notifies the previous address", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, mockPublisher := newService()
+		token, hash, _ := utils.GenerateUserToken(purpose, cfg.JWT.SecretKey)
+		stored := &models.UserToken{ID: 7, UserID: 1, Email: "new@example.com", User: models.User{ID: 1, Email: "old@example.com"}}
+
+		mockTokenRepo.On("GetUsable", models.UserTokenEmailChange, hash, mock.AnythingOfType("time.Time")).Return(stored, nil).Once()
+		mockUserRepo.On("GetByEmail", "new@example.com").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockTokenRepo.On("MarkUsed", uint(7), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		mockUserRepo.On("Update", mock.MatchedBy(func(user *models.User) bool {
+			return user.Email == "new@example.com" && user.IsEmailVerified()
+		})).Return(nil).Once()
+		mockTokenRepo.On("InvalidateAll", uint(1), models.UserTokenEmailVerification, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockTokenRepo.On("InvalidateAll", uint(1), models.UserTokenPasswordReset, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		mockUserRepo.On("DeleteRefreshTokensByUserID", uint(1)).Return(nil).Once()
+		mockPublisher.On("Publish", "EMAIL_CHANGED", notifications.EmailChangedPayload{
+			Email:    "old@example.com",
+			NewEmail: "new@example.com",
+		}, mock.Anything).Return(nil).Once()
+
+		result, err := service.ConfirmEmailChange(1, "", &dto.ConfirmEmailChangeRequest{Token: token})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new@example.com", result.Email)
+		assert.True(t, result.EmailVerified)
+		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("token of another user", func(t *testing.T) {
+		service, mockUserRepo, mockTokenRepo, _ := newService()
+		token, hash, _ := utils.GenerateUserToken(purpose, cfg.JWT.SecretKey)
+		stored := &models.UserToken{ID: 8, UserID: 2, Email: "new@example.com", User: models.User{ID: 2, Email: "other@example.com"}}
+
+		mockTokenRepo.On("GetUsable", models.UserTokenEmailChange, hash, mock.AnythingOfType("time.Time")).Return(stored, nil).Once()
+
+		result, err := service.ConfirmEmailChange(1, "", &dto.ConfirmEmailChangeRequest{Token: token})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockTokenRepo.AssertNotCalled(t, "MarkUsed", mock.Anything, mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+)
+
+// issueUserToken stores a new single-use token for the user, bound to the
+// address it is emailed to, and returns the token to put in the link
+func issueUserToken(repo repositories.UserTokenRepositoryInterface, secret string, user *models.User, email string, purpose models.UserTokenPurpose, ttl time.Duration) (string, *models.UserToken, error) {
+	token, hash, err := utils.GenerateUserToken(string(purpose), secret)
+	if err != nil {
+		return "", nil, err
+	}
+
+	userToken := models.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: hash,
+		Email:     email,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := repo.Create(&userToken); err != nil {
+		return "", nil, err
+	}
+
+	return token, &userToken, nil
+}
+
+// frontendLink builds the storefront URL that redeems an emailed token
+func frontendLink(frontendURL, path, token string) string {
+	return strings.TrimRight(frontendURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+func displayName(user *models.User) string {
+	return strings.TrimSpace(user.FirstName + " " + user.LastName)
+}
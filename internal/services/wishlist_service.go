@@ -0,0 +1,309 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/JihadRinaldi/go-shop/internal/repositories"
+	"github.com/JihadRinaldi/go-shop/internal/utils"
+	"gorm.io/gorm"
+)
+
+const shareTokenBytes = 24
+
+type WishlistService struct {
+	db           *gorm.DB
+	config       *config.Config
+	wishlistRepo repositories.WishlistRepositoryInterface
+	productRepo  repositories.ProductRepositoryInterface
+	cartService  *CartService
+}
+
+func NewWishlistService(db *gorm.DB, config *config.Config, cartService *CartService) *WishlistService {
+	return &WishlistService{
+		db:           db,
+		config:       config,
+		wishlistRepo: repositories.NewWishlistRepository(db),
+		productRepo:  repositories.NewProductRepository(db),
+		cartService:  cartService,
+	}
+}
+
+func (s *WishlistService) CreateWishlist(userID uint, req *dto.CreateWishlistRequest) (*dto.WishlistResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if err := s.ensureUniqueName(userID, name, 0); err != nil {
+		return nil, err
+	}
+
+	wishlist := models.Wishlist{
+		UserID: userID,
+		Name:   name,
+	}
+
+	if err := s.wishlistRepo.Create(&wishlist); err != nil {
+		return nil, err
+	}
+
+	return s.toWishlistResponse(&wishlist, true), nil
+}
+
+func (s *WishlistService) GetWishlists(userID uint) ([]dto.WishlistResponse, error) {
+	wishlists, err := s.wishlistRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.WishlistResponse, len(wishlists))
+	for i := range wishlists {
+		response[i] = *s.toWishlistResponse(&wishlists[i], true)
+	}
+	return response, nil
+}
+
+func (s *WishlistService) GetWishlist(userID, wishlistID uint) (*dto.WishlistResponse, error) {
+	wishlist, err := s.getOwnWishlist(userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toWishlistResponse(wishlist, true), nil
+}
+
+func (s *WishlistService) UpdateWishlist(userID, wishlistID uint, req *dto.UpdateWishlistRequest) (*dto.WishlistResponse, error) {
+	wishlist, err := s.getOwnWishlist(userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if err := s.ensureUniqueName(userID, name, wishlist.ID); err != nil {
+		return nil, err
+	}
+
+	wishlist.Name = name
+	if err := s.wishlistRepo.Update(wishlist); err != nil {
+		return nil, err
+	}
+
+	return s.toWishlistResponse(wishlist, true), nil
+}
+
+func (s *WishlistService) DeleteWishlist(userID, wishlistID uint) error {
+	wishlist, err := s.getOwnWishlist(userID, wishlistID)
+	if err != nil {
+		return err
+	}
+
+	return s.wishlistRepo.Delete(wishlist.ID)
+}
+
+func (s *WishlistService) AddItem(userID, wishlistID uint, req *dto.AddWishlistItemRequest) (*dto.WishlistResponse, error) {
+	wishlist, err := s.getOwnWishlist(userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetByID(req.ProductID)
+	if err != nil || !product.IsActive {
+		return nil, errors.New("product not found")
+	}
+
+	if _, err := s.wishlistRepo.GetItemByProduct(wishlist.ID, product.ID); err == nil {
+		return nil, errors.New("product already in wishlist")
+	}
+
+	item := models.WishlistItem{
+		WishlistID: wishlist.ID,
+		ProductID:  product.ID,
+	}
+	if err := s.wishlistRepo.AddItem(&item); err != nil {
+		return nil, err
+	}
+
+	return s.GetWishlist(userID, wishlistID)
+}
+
+func (s *WishlistService) RemoveItem(userID, wishlistID, itemID uint) error {
+	wishlist, err := s.getOwnWishlist(userID, wishlistID)
+	if err != nil {
+		return err
+	}
+
+	item, err := s.wishlistRepo.GetItem(wishlist.ID, itemID)
+	if err != nil {
+		return errors.New("wishlist item not found")
+	}
+
+	return s.wishlistRepo.RemoveItem(item.ID)
+}
+
+// MoveToCart adds the item to the user's cart with the same checks as a regular
+// add to cart, and only takes it off the wishlist once that succeeded
+func (s *WishlistService) MoveToCart(userID, wishlistID, itemID uint, req *dto.MoveToCartRequest) (*dto.CartResponse, error) {
+	wishlist, err := s.getOwnWishlist(userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.wishlistRepo.GetItem(wishlist.ID, itemID)
+	if err != nil {
+		return nil, errors.New("wishlist item not found")
+	}
+
+	quantity := req.Quantity
+	if quantity < 1 {
+		quantity = 1
+	}
+
+	cart, err := s.cartService.AddToCart(userID, dto.AddToCartRequest{
+		ProductID: item.ProductID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.wishlistRepo.RemoveItem(item.ID); err != nil {
+		return nil, err
+	}
+
+	return cart, nil
+}
+
+// ShareWishlist issues a share token for the wishlist, keeping the existing one
+// if the list is already shared
+func (s *WishlistService) ShareWishlist(userID, wishlistID uint) (*dto.WishlistResponse, error) {
+	wishlist, err := s.getOwnWishlist(userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wishlist.ShareToken == nil {
+		token, err := utils.GenerateRandomToken(shareTokenBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		wishlist.ShareToken = &token
+		if err := s.wishlistRepo.Update(wishlist); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.toWishlistResponse(wishlist, true), nil
+}
+
+// UnshareWishlist revokes the share token so existing links stop working
+func (s *WishlistService) UnshareWishlist(userID, wishlistID uint) (*dto.WishlistResponse, error) {
+	wishlist, err := s.getOwnWishlist(userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	wishlist.ShareToken = nil
+	if err := s.wishlistRepo.Update(wishlist); err != nil {
+		return nil, err
+	}
+
+	return s.toWishlistResponse(wishlist, true), nil
+}
+
+// GetSharedWishlist returns the read-only view of a shared wishlist. Inactive
+// products are left out.
+func (s *WishlistService) GetSharedWishlist(token string) (*dto.WishlistResponse, error) {
+	if token == "" {
+		return nil, errors.New("wishlist not found")
+	}
+
+	wishlist, err := s.wishlistRepo.GetByShareToken(token)
+	if err != nil {
+		return nil, errors.New("wishlist not found")
+	}
+
+	items := wishlist.Items[:0]
+	for _, item := range wishlist.Items {
+		if item.Product.IsActive {
+			items = append(items, item)
+		}
+	}
+	wishlist.Items = items
+
+	return s.toWishlistResponse(wishlist, false), nil
+}
+
+func (s *WishlistService) getOwnWishlist(userID, wishlistID uint) (*models.Wishlist, error) {
+	wishlist, err := s.wishlistRepo.GetByID(wishlistID)
+	if err != nil || wishlist.UserID != userID {
+		return nil, errors.New("wishlist not found")
+	}
+	return wishlist, nil
+}
+
+func (s *WishlistService) ensureUniqueName(userID uint, name string, excludeID uint) error {
+	if name == "" {
+		return errors.New("wishlist name is required")
+	}
+
+	exists, err := s.wishlistRepo.ExistsByName(userID, name, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("wishlist with this name already exists")
+	}
+	return nil
+}
+
+func (s *WishlistService) toWishlistResponse(wishlist *models.Wishlist, owner bool) *dto.WishlistResponse {
+	items := make([]dto.WishlistItemResponse, len(wishlist.Items))
+	for i, item := range wishlist.Items {
+		images := make([]dto.ProductImageResponse, len(item.Product.Images))
+		for j, img := range item.Product.Images {
+			images[j] = dto.ProductImageResponse{
+				ID:        img.ID,
+				URL:       img.URL,
+				AltText:   img.AltText,
+				IsPrimary: img.IsPrimary,
+				CreatedAt: img.CreatedAt,
+			}
+		}
+
+		items[i] = dto.WishlistItemResponse{
+			ID: item.ID,
+			Product: dto.ProductResponse{
+				ID:            item.Product.ID,
+				CategoryID:    item.Product.CategoryID,
+				Name:          item.Product.Name,
+				Slug:          item.Product.Slug,
+				Description:   item.Product.Description,
+				Price:         item.Product.Price,
+				Stock:         item.Product.Stock,
+				IsActive:      item.Product.IsActive,
+				RatingAverage: item.Product.RatingAverage,
+				RatingCount:   item.Product.RatingCount,
+				Category: dto.CategoryResponse{
+					ID:   item.Product.Category.ID,
+					Name: item.Product.Category.Name,
+				},
+				Images: images,
+			},
+			CreatedAt: item.CreatedAt,
+		}
+	}
+
+	response := &dto.WishlistResponse{
+		ID:        wishlist.ID,
+		Name:      wishlist.Name,
+		IsShared:  wishlist.ShareToken != nil,
+		Items:     items,
+		CreatedAt: wishlist.CreatedAt,
+		UpdatedAt: wishlist.UpdatedAt,
+	}
+	if owner && wishlist.ShareToken != nil {
+		response.ShareToken = *wishlist.ShareToken
+	}
+	return response
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+	"github.com/JihadRinaldi/go-shop/internal/dto"
+	"github.com/JihadRinaldi/go-shop/internal/mocks"
+	"github.com/JihadRinaldi/go-shop/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestWishlistService_CreateWishlist(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepositoryInterface)
+
+	service := &WishlistService{
+		db:           &gorm.DB{},
+		config:       &config.Config{},
+		wishlistRepo: mockWishlistRepo,
+		productRepo:  new(mocks.MockProductRepositoryInterface),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockWishlistRepo.On("ExistsByName", uint(1), "Birthday", uint(0)).Return(false, nil).Once()
+		mockWishlistRepo.On("Create", mock.MatchedBy(func(w *models.Wishlist) bool {
+			return w.UserID == 1 && w.Name == "Birthday" && w.ShareToken == nil
+		})).Return(nil).Once()
+
+		result, err := service.CreateWishlist(1, &dto.CreateWishlistRequest{Name: " Birthday "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Birthday", result.Name)
+		assert.False(t, result.IsShared)
+		mockWishlistRepo.AssertExpectations(t)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		mockWishlistRepo.On("ExistsByName", uint(1), "Birthday", uint(0)).Return(true, nil).Once()
+
+		result, err := service.CreateWishlist(1, &dto.CreateWishlistRequest{Name: "Birthday"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "already exists")
+	})
+}
+
+func TestWishlistService_AddItem(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepositoryInterface)
+	mockProductRepo := new(mocks.MockProductRepositoryInterface)
+
+	service := &WishlistService{
+		db:           &gorm.DB{},
+		config:       &config.Config{},
+		wishlistRepo: mockWishlistRepo,
+		productRepo:  mockProductRepo,
+		cartService: &CartService{
+			db:          &gorm.DB{},
+			config:      &config.Config{},
+			cartRepo:    new(mocks.MockCartRepositoryInterface),
+			productRepo: mockProductRepo,
+		},
+	}
+
+	wishlist := &models.Wishlist{ID: 3, UserID: 1, Name: "Later"}
+
+	t.Run("success", func(t *testing.T) {
+		mockWishlistRepo.On("GetByID", uint(3)).Return(wishlist, nil).Twice()
+		mockProductRepo.On("GetByID", uint(10)).Return(&models.Product{ID: 10, IsActive: true}, nil).Once()
+		mockWishlistRepo.On("GetItemByProduct", uint(3), uint(10)).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockWishlistRepo.On("AddItem", &models.WishlistItem{WishlistID: 3, ProductID: 10}).Return(nil).Once()
+
+		result, err := service.AddItem(1, 3, &dto.AddWishlistItemRequest{ProductID: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(3), result.ID)
+		mockWishlistRepo.AssertExpectations(t)
+		mockProductRepo.AssertExpectations(t)
+	})
+
+	t.Run("already in wishlist", func(t *testing.T) {
+		mockWishlistRepo.On("GetByID", uint(3)).Return(wishlist, nil).Once()
+		mockProductRepo.On("GetByID", uint(10)).Return(&models.Product{ID: 10, IsActive: true}, nil).Once()
+		mockWishlistRepo.On("GetItemByProduct", uint(3), uint(10)).Return(&models.WishlistItem{ID: 1}, nil).Once()
+
+		result, err := service.AddItem(1, 3, &dto.AddWishlistItemRequest{ProductID: 10})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "already in wishlist")
+	})
+
+	t.Run("someone else's wishlist", func(t *testing.T) {
+		mockWishlistRepo.On("GetByID", uint(3)).Return(wishlist, nil).Once()
+
+		result, err := service.AddItem(2, 3, &dto.AddWishlistItemRequest{ProductID: 10})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "wishlist not found")
+	})
+}
+
+func TestWishlistService_MoveToCart(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepositoryInterface)
+	mockProductRepo := new(mocks.MockProductRepositoryInterface)
+
+	service := &WishlistService{
+		db:           &gorm.DB{},
+		config:       &config.Config{},
+		wishlistRepo: mockWishlistRepo,
+		productRepo:  mockProductRepo,
+		cartService: &CartService{
+			db:          &gorm.DB{},
+			config:      &config.Config{},
+			cartRepo:    new(mocks.MockCartRepositoryInterface),
+			productRepo: mockProductRepo,
+		},
+	}
+
+	t.Run("insufficient stock keeps the item", func(t *testing.T) {
+		mockWishlistRepo.On("GetByID", uint(3)).Return(&models.Wishlist{ID: 3, UserID: 1}, nil).Once()
+		mockWishlistRepo.On("GetItem", uint(3), uint(7)).Return(&models.WishlistItem{ID: 7, WishlistID: 3, ProductID: 10}, nil).Once()
+		mockProductRepo.On("GetByID", uint(10)).Return(&models.Product{ID: 10, Stock: 1}, nil).Once()
+
+		result, err := service.MoveToCart(1, 3, 7, &dto.MoveToCartRequest{Quantity: 2})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "insufficient product stock")
+		mockWishlistRepo.AssertNotCalled(t, "RemoveItem", mock.Anything)
+	})
+
+	t.Run("item not found", func(t *testing.T) {
+		mockWishlistRepo.On("GetByID", uint(3)).Return(&models.Wishlist{ID: 3, UserID: 1}, nil).Once()
+		mockWishlistRepo.On("GetItem", uint(3), uint(8)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := service.MoveToCart(1, 3, 8, &dto.MoveToCartRequest{})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "wishlist item not found")
+	})
+}
+
+func TestWishlistService_ShareWishlist(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepositoryInterface)
+
+	service := &WishlistService{
+		db:           &gorm.DB{},
+		config:       &config.Config{},
+		wishlistRepo: mockWishlistRepo,
+		productRepo:  new(mocks.MockProductRepositoryInterface),
+	}
+
+	t.Run("issues a token once", func(t *testing.T) {
+		wishlist := &models.Wishlist{ID: 3, UserID: 1}
+		mockWishlistRepo.On("GetByID", uint(3)).Return(wishlist, nil).Twice()
+		mockWishlistRepo.On("Update", wishlist).Return(nil).Once()
+
+		first, err := service.ShareWishlist(1, 3)
+		assert.NoError(t, err)
+		assert.True(t, first.IsShared)
+		assert.GreaterOrEqual(t, len(first.ShareToken), 32)
+
+		second, err := service.ShareWishlist(1, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, first.ShareToken, second.ShareToken)
+		mockWishlistRepo.AssertExpectations(t)
+	})
+}
+
+func TestWishlistService_GetSharedWishlist(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepositoryInterface)
+
+	service := &WishlistService{
+		db:           &gorm.DB{},
+		config:       &config.Config{},
+		wishlistRepo: mockWishlistRepo,
+		productRepo:  new(mocks.MockProductRepositoryInterface),
+	}
+
+	t.Run("hides token and inactive products", func(t *testing.T) {
+		token := "share-token"
+		mockWishlistRepo.On("GetByShareToken", token).Return(&models.Wishlist{
+			ID:         3,
+			ShareToken: &token,
+			Items: []models.WishlistItem{
+				{ID: 1, Product: models.Product{ID: 10, IsActive: true}},
+				{ID: 2, Product: models.Product{ID: 11, IsActive: false}},
+			},
+		}, nil).Once()
+
+		result, err := service.GetSharedWishlist(token)
+
+		assert.NoError(t, err)
+		assert.True(t, result.IsShared)
+		assert.Empty(t, result.ShareToken)
+		assert.Len(t, result.Items, 1)
+		assert.Equal(t, uint(10), result.Items[0].Product.ID)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		mockWishlistRepo.On("GetByShareToken", "nope").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := service.GetSharedWishlist("nope")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// SignCartToken binds a guest cart ID to the server secret so clients cannot
+// forge tokens for carts they did not create
+func SignCartToken(guestID, secret string) string {
+	return guestID + "." + cartTokenSignature(guestID, secret)
+}
+
+// ParseCartToken verifies the signature of a guest cart token and returns the guest cart ID
+func ParseCartToken(token, secret string) (string, error) {
+	guestID, signature, ok := strings.Cut(token, ".")
+	if !ok || guestID == "" {
+		return "", errors.New("invalid cart token")
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(cartTokenSignature(guestID, secret))) {
+		return "", errors.New("invalid cart token")
+	}
+
+	return guestID, nil
+}
+
+func cartTokenSignature(guestID, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("cart:" + guestID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+
+	"github.com/JihadRinaldi/go-shop/internal/config"
+)
+
+// JWK is a public key as published in a JSON Web Key Set (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS lists the public keys tokens are verified with, so other services can
+// check our tokens. The shared secret is never part of it.
+func JWKS(cfg *config.JWTConfig) JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range verificationKeys(cfg) {
+		jwk, ok := toJWK(key)
+		if !ok {
+			continue
+		}
+		jwk.Kid = KeyID(key)
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// KeyID identifies a public key by its JWK thumbprint (RFC 7638), so the kid
+// stays the same on every instance without being configured
+func KeyID(key crypto.PublicKey) string {
+	jwk, ok := toJWK(key)
+	if !ok {
+		return ""
+	}
+
+	// The thumbprint covers the required members only, in lexicographic order
+	var members interface{}
+	switch jwk.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func toJWK(key crypto.PublicKey) (JWK, bool) {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}, true
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Use: "sig",
+			Alg: "EdDSA",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}, true
+	default:
+		return JWK{}, false
+	}
+}
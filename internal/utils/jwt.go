@@ -1,49 +1,82 @@
 package utils
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/JihadRinaldi/go-shop/internal/config"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
+// Token types, the typ claim. Both kinds are signed by the same keys, the
+// claim keeps a refresh token from being used as an access token and back.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// Claims of access and refresh tokens. SessionID is the refresh token family
+// the tokens were issued for, so revoking a session stops its access tokens.
+// TwoFactor tells that the account signs in with a second factor. Roles and
+// Permissions are only carried by access tokens, a refresh reads them afresh.
 type Claims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID      uint     `json:"user_id"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"perms,omitempty"`
+	TokenType   string   `json:"typ"`
+	SessionID   string   `json:"sid,omitempty"`
+	TwoFactor   bool     `json:"mfa,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(cfg *config.JWTConfig, userID uint, email, role string) (accessToken, refreshToken string, err error) {
-	accessClaims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.ExpireIn)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+// TokenSubject is the user tokens are issued to
+type TokenSubject struct {
+	UserID      uint
+	Email       string
+	Roles       []string
+	Permissions []string
+	SessionID   string
+	TwoFactor   bool
+}
+
+func GenerateToken(cfg *config.JWTConfig, subject TokenSubject) (accessToken, refreshToken string, err error) {
+	now := time.Now()
+	newClaims := func(tokenType string, ttl time.Duration) *Claims {
+		return &Claims{
+			UserID:    subject.UserID,
+			Email:     subject.Email,
+			TokenType: tokenType,
+			SessionID: subject.SessionID,
+			TwoFactor: subject.TwoFactor,
+			// Refresh tokens are looked up by hash, so each one needs to be
+			// unique even when two are issued within the same second
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        uuid.NewString(),
+				Issuer:    cfg.Issuer,
+				Subject:   strconv.FormatUint(uint64(subject.UserID), 10),
+				Audience:  jwt.ClaimStrings{cfg.Audience},
+				ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+				IssuedAt:  jwt.NewNumericDate(now),
+			},
+		}
 	}
 
-	at := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessToken, err = at.SignedString([]byte(cfg.SecretKey))
+	access := newClaims(TokenTypeAccess, cfg.ExpireIn)
+	access.Roles = subject.Roles
+	access.Permissions = subject.Permissions
+
+	accessToken, err = signToken(cfg, access)
 	if err != nil {
 		return "", "", err
 	}
 
-	refreshClaims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.RefreshTokenExpires)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-	}
-
-	rt := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	refreshToken, err = rt.SignedString([]byte(cfg.SecretKey))
+	refreshToken, err = signToken(cfg, newClaims(TokenTypeRefresh, cfg.RefreshTokenExpires))
 	if err != nil {
 		return "", "", err
 	}
@@ -51,19 +84,98 @@ func GenerateToken(cfg *config.JWTConfig, userID uint, email, role string) (acce
 	return accessToken, refreshToken, nil
 }
 
-func ValidateToken(tokenString, secret string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
+// ValidateToken verifies a token of the given type, issued by us for our
+// audience, and signed by any of the configured keys, found by the kid header.
+// Tokens without a kid are signed with the secret and are only accepted while
+// no signing key is configured, or during the switch to one with
+// AcceptLegacyTokens.
+func ValidateToken(tokenString string, cfg *config.JWTConfig, tokenType string) (*Claims, error) {
+	parser := jwt.NewParser(
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithAudience(cfg.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+
+	token, err := parser.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			if cfg.SigningKey != nil && !cfg.AcceptLegacyTokens {
+				return nil, errors.New("token has no key ID")
+			}
+			if token.Method != jwt.SigningMethodHS256 {
+				return nil, errors.New("unexpected signing method")
+			}
+			return []byte(cfg.SecretKey), nil
+		}
+
+		for _, key := range verificationKeys(cfg) {
+			if KeyID(key) != kid {
+				continue
+			}
+			// The algorithm comes from the key, never from the token
+			if method, _ := signingMethod(key); token.Method != method {
+				return nil, errors.New("unexpected signing method")
+			}
+			return key, nil
+		}
+		return nil, errors.New("unknown signing key")
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	if claims.TokenType != tokenType {
+		return nil, errors.New("wrong token type")
+	}
+	if claims.ID == "" || claims.UserID == 0 || claims.Subject != strconv.FormatUint(uint64(claims.UserID), 10) {
+		return nil, errors.New("invalid token")
 	}
 
-	return nil, errors.New("invalid token")
+	return claims, nil
 
 }
+
+// signToken signs with the configured key, naming it in the kid header, and
+// falls back to the secret
+func signToken(cfg *config.JWTConfig, claims *Claims) (string, error) {
+	if cfg.SigningKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.SecretKey))
+	}
+
+	method, err := signingMethod(cfg.SigningKey.Public())
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = KeyID(cfg.SigningKey.Public())
+	return token.SignedString(cfg.SigningKey)
+}
+
+// verificationKeys returns the public keys tokens are accepted from, the
+// signing key first
+func verificationKeys(cfg *config.JWTConfig) []crypto.PublicKey {
+	keys := make([]crypto.PublicKey, 0, len(cfg.VerificationKeys)+1)
+	if cfg.SigningKey != nil {
+		keys = append(keys, cfg.SigningKey.Public())
+	}
+	return append(keys, cfg.VerificationKeys...)
+}
+
+func signingMethod(key crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, errors.New("unsupported signing key")
+	}
+}
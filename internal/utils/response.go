@@ -70,6 +70,26 @@ func NotFoundResponse(c *gin.Context, message string) {
 	ErrorResponse(c, http.StatusNotFound, message, nil)
 }
 
+// ConflictResponse reports a request that clashes with the current state of a
+// resource, along with the details the client needs to resolve it
+func ConflictResponse(c *gin.Context, message string, err error, data interface{}) {
+	response := Response{
+		Success: false,
+		Message: message,
+		Data:    data,
+	}
+
+	if err != nil {
+		response.Error = err.Error()
+	}
+
+	c.JSON(http.StatusConflict, response)
+}
+
+func TooManyRequestsResponse(c *gin.Context, message string) {
+	ErrorResponse(c, http.StatusTooManyRequests, message, nil)
+}
+
 func InternalServerErrorResponse(c *gin.Context, message string, err error) {
 	ErrorResponse(c, http.StatusInternalServerError, message, err)
 }
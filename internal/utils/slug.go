@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+const maxSlugLength = 200
+
+var (
+	slugSeparator = regexp.MustCompile(`[^a-z0-9]+`)
+	slugPattern   = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+)
+
+// Slugify converts text into a lowercase, hyphen separated URL segment
+func Slugify(text string) string {
+	slug := strings.Trim(slugSeparator.ReplaceAllString(strings.ToLower(text), "-"), "-")
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	return slug
+}
+
+// IsValidSlug checks that slug is already in the form produced by Slugify
+func IsValidSlug(slug string) bool {
+	return len(slug) <= maxSlugLength && slugPattern.MatchString(slug)
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// GenerateUserToken returns a random token signed for purpose, to be emailed
+// to the user, and the hash under which it is stored
+func GenerateUserToken(purpose, secret string) (token, hash string, err error) {
+	random, err := GenerateRandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	return random + "." + userTokenSignature(purpose, random, secret), HashToken(random), nil
+}
+
+// ParseUserToken verifies that the token was signed for purpose and returns
+// the hash to look it up by
+func ParseUserToken(token, purpose, secret string) (string, error) {
+	random, signature, ok := strings.Cut(token, ".")
+	if !ok || random == "" {
+		return "", errors.New("invalid token")
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(userTokenSignature(purpose, random, secret))) {
+		return "", errors.New("invalid token")
+	}
+
+	return HashToken(random), nil
+}
+
+// HashToken returns the hex encoded SHA-256 of a token. Tokens are random, so
+// an unsalted hash is enough to keep a database dump from being replayed.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func userTokenSignature(purpose, random, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose + ":" + random))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}